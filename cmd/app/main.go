@@ -2,11 +2,15 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+	// база часовых поясов встраивается в приложение: местное время вылета и прилета выводится в часовых поясах аэропортов
+	_ "time/tzdata"
 
 	"github.com/go-chi/chi"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -51,6 +55,9 @@ func main() {
 	// инициализация сервисов
 	serviceRegistry := service.NewServiceRegistry(cfg, storageRegistry)
 
+	// запуск фоновой обработки листа ожидания
+	go startWaitlistJob(ctx, cfg, serviceRegistry)
+
 	// инициализация хэндлеров
 	apiServer := v1.NewAPIServer(serviceRegistry)
 
@@ -78,6 +85,10 @@ func startHTTPServer(
 
 	router := chi.NewRouter()
 	router.Use(commonMiddleware)
+	if cfg.Gateway.Token == "" {
+		log.Println("gateway token is not set: X-Role header is ignored")
+	}
+	router.Use(gatewayRoleMiddleware(cfg.Gateway.Token))
 	router.Handle("/*", handler)
 
 	httpServer := http.Server{
@@ -102,9 +113,47 @@ func startHTTPServer(
 	return group.Wait()
 }
 
+// фоновая обработка листа ожидания: отмена неоплаченных билетов, освобождение истекших удержаний мест,
+// оформление билетов по листу ожидания. выполняется с интервалом из конфигурации до завершения приложения
+func startWaitlistJob(ctx context.Context, cfg *config.Config, serviceRegistry *service.Services) {
+
+	ticker := time.NewTicker(cfg.Waitlist.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := serviceRegistry.Ticket.ProcessWaitlist(ctx, time.Now())
+			if err != nil {
+				log.Printf("failed to process waitlist: %v\n", err)
+			}
+		}
+	}
+}
+
 func commonMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Add("Content-Type", "application/json")
 		next.ServeHTTP(w, r)
 	})
 }
+
+// заголовок, которым шлюз подтверждает, что роль сотрудника X-Role установлена им после аутентификации
+const headerGatewayToken = "X-Gateway-Token"
+
+// роль сотрудника X-Role принимается только от шлюза: если токен шлюза не передан или не совпадает
+// с токеном из конфигурации, то заголовок роли удаляется, и запрос выполняется как запрос без роли
+func gatewayRoleMiddleware(gatewayToken string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.Header.Get(headerGatewayToken)
+			if gatewayToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(gatewayToken)) != 1 {
+				r.Header.Del("X-Role")
+			}
+			r.Header.Del(headerGatewayToken)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
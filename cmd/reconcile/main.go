@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"homework/internal/config"
+	"homework/internal/service"
+	"homework/internal/storage"
+)
+
+// пересчет остатков мест рейсов (flight_inventory) по билетам и удержаниям мест.
+// используется при расхождении остатков, конфигурация аналогична приложению (-c путь к config.yaml)
+func main() {
+	ctx, cancel := signal.NotifyContext(
+		context.Background(),
+		syscall.SIGHUP,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT,
+	)
+	defer cancel()
+
+	cfg, err := config.InitConfig(os.Args)
+	if err != nil {
+		log.Fatal("get config: ", err.Error())
+		return
+	}
+
+	// инициализация пакета/драйвера БД
+	db, err := pgxpool.Connect(ctx, cfg.DB.Postgresql)
+	if err != nil {
+		log.Fatalf("unable to connect to database: %v\n", err)
+	}
+	defer db.Close()
+
+	// инициализация хранилищ и сервисов
+	storageRegistry := storage.NewStorageRegistry(cfg, db)
+	serviceRegistry := service.NewServiceRegistry(cfg, storageRegistry)
+
+	countReconciled, err := serviceRegistry.Flight.ReconcileFlightInventory(ctx, time.Now())
+	if err != nil {
+		log.Fatalf("failed to reconcile flight inventory: %v\n", err)
+	}
+	log.Printf("flight inventory reconciled, corrected rows: %d\n", countReconciled)
+}
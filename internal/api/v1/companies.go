@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"homework/internal/util/terr"
+	"homework/specs"
+)
+
+func (a apiServer) GetCompanyById(w http.ResponseWriter, r *http.Request, companyIdSpecs specs.UUIDPathObjectID) {
+
+	companyId, err := convertStringToUuid(string(companyIdSpecs))
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_COMPANY_UUID", err.Error()))
+		return
+	}
+
+	ctx := r.Context()
+	company, err := a.serviceRegistry.Ticket.GetCompanyById(ctx, companyId)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	companySpecs := transformCompany(company)
+	_ = json.NewEncoder(w).Encode(companySpecs)
+}
+
+func (a apiServer) CreateCompanyInvoice(w http.ResponseWriter, r *http.Request, companyIdSpecs specs.UUIDPathObjectID) {
+
+	paramsCreateCompanyInvoiceSpecs := &specs.ParamsCreateCompanyInvoice{}
+	err := json.NewDecoder(r.Body).Decode(paramsCreateCompanyInvoiceSpecs)
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_BODY_REQUEST", err.Error()))
+		return
+	}
+
+	paramsCreateCompanyInvoice, err := transformParamsCreateCompanyInvoice(string(companyIdSpecs), paramsCreateCompanyInvoiceSpecs)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	ctx := r.Context()
+	invoice, err := a.serviceRegistry.Ticket.CreateCompanyInvoice(ctx, paramsCreateCompanyInvoice)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	invoiceSpecs := transformCompanyInvoice(invoice)
+	_ = json.NewEncoder(w).Encode(invoiceSpecs)
+}
+
+func (a apiServer) GetCompanyReport(w http.ResponseWriter, r *http.Request, companyIdSpecs specs.UUIDPathObjectID, paramsGetCompanyReportSpecs specs.GetCompanyReportParams) {
+
+	paramsGetCompanyReport, err := transformParamsGetCompanyReport(string(companyIdSpecs), &paramsGetCompanyReportSpecs)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	ctx := r.Context()
+	report, err := a.serviceRegistry.Ticket.GetCompanyReport(ctx, paramsGetCompanyReport)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	reportSpecs := transformCompanyReport(report)
+	_ = json.NewEncoder(w).Encode(reportSpecs)
+}
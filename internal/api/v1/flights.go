@@ -1,10 +1,14 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
 	"homework/internal/util/terr"
 	"net/http"
+	"time"
 
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
 	"homework/specs"
 )
 
@@ -23,14 +27,25 @@ func (a apiServer) GetFlights(w http.ResponseWriter, r *http.Request, paramsGetF
 		return
 	}
 
+	// рейсы могут продаваться в разных валютах, курс к валюте просмотра запрашивается один раз на валюту
+	displayRates := make(map[string]*money.ExchangeRate)
 	flightsSpecs := make([]specs.Flight, len(flights))
 	for i, flight := range flights {
-		flightsSpecs[i] = *transformFlight(&flight)
+		displayRate, ok := displayRates[flight.Currency]
+		if !ok {
+			displayRate, err = a.getDisplayExchangeRate(ctx, flight.Currency, paramsGetFlightsSpecs.Currency)
+			if err != nil {
+				terr.WriteError(w, err.(*terr.Error))
+				return
+			}
+			displayRates[flight.Currency] = displayRate
+		}
+		flightsSpecs[i] = *transformFlight(&flight, displayRate)
 	}
 	_ = json.NewEncoder(w).Encode(flightsSpecs)
 }
 
-func (a apiServer) GetFlightById(w http.ResponseWriter, r *http.Request, flightIdSpecs specs.UUIDPathObjectID) {
+func (a apiServer) GetFlightById(w http.ResponseWriter, r *http.Request, flightIdSpecs specs.UUIDPathObjectID, paramsGetFlightByIdSpecs specs.GetFlightByIdParams) {
 
 	flightId, err := convertStringToUuid(string(flightIdSpecs))
 	if err != nil {
@@ -45,10 +60,27 @@ func (a apiServer) GetFlightById(w http.ResponseWriter, r *http.Request, flightI
 		return
 	}
 
-	flightSpecs := transformFlight(flight)
+	displayRate, err := a.getDisplayExchangeRate(ctx, flight.Currency, paramsGetFlightByIdSpecs.Currency)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	flightSpecs := transformFlight(flight, displayRate)
 	_ = json.NewEncoder(w).Encode(flightSpecs)
 }
 
+// getDisplayExchangeRate возвращает текущий курс валюты цен рейса к валюте просмотра.
+// если валюта просмотра не задана, курс не нужен
+func (a apiServer) getDisplayExchangeRate(ctx context.Context, currencyFrom string, currencyTo *string) (*money.ExchangeRate, error) {
+
+	if currencyTo == nil {
+		return nil, nil
+	}
+
+	return a.serviceRegistry.Flight.GetExchangeRate(ctx, currencyFrom, *currencyTo, time.Now())
+}
+
 func (a apiServer) GetFlightVacantSeats(w http.ResponseWriter, r *http.Request, flightIdSpecs specs.UUIDPathObjectID) {
 	flightId, err := convertStringToUuid(string(flightIdSpecs))
 	if err != nil {
@@ -69,3 +101,185 @@ func (a apiServer) GetFlightVacantSeats(w http.ResponseWriter, r *http.Request,
 	}
 	_ = json.NewEncoder(w).Encode(arrVacantSeatsSpecs)
 }
+
+func (a apiServer) GetFlightAncillaries(w http.ResponseWriter, r *http.Request, flightIdSpecs specs.UUIDPathObjectID) {
+
+	flightId, err := convertStringToUuid(string(flightIdSpecs))
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_FLIGHT_UUID", err.Error()))
+		return
+	}
+
+	ctx := r.Context()
+	flightAncillaries, err := a.serviceRegistry.Flight.GetFlightAncillaries(ctx, flightId)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	flightAncillariesSpecs := make([]specs.FlightAncillary, len(flightAncillaries))
+	for i, flightAncillary := range flightAncillaries {
+		flightAncillariesSpecs[i] = *transformFlightAncillary(&flightAncillary)
+	}
+	_ = json.NewEncoder(w).Encode(flightAncillariesSpecs)
+}
+
+func (a apiServer) GetOversoldFlights(w http.ResponseWriter, r *http.Request, paramsGetOversoldFlightsSpecs specs.GetOversoldFlightsParams) {
+
+	paramsGetOversoldFlights := transformParamsGetOversoldFlights(&paramsGetOversoldFlightsSpecs)
+
+	ctx := r.Context()
+	arrOversoldClassSeats, err := a.serviceRegistry.Flight.GetOversoldFlights(ctx, paramsGetOversoldFlights)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	arrOversoldClassSeatsSpecs := make([]specs.OversoldClassSeats, len(arrOversoldClassSeats))
+	for i, oversoldClassSeats := range arrOversoldClassSeats {
+		arrOversoldClassSeatsSpecs[i] = *transformOversoldClassSeats(&oversoldClassSeats)
+	}
+	_ = json.NewEncoder(w).Encode(arrOversoldClassSeatsSpecs)
+}
+
+func (a apiServer) SearchLocations(w http.ResponseWriter, r *http.Request, paramsSearchLocationsSpecs specs.SearchLocationsParams) {
+
+	paramsSearchLocations := transformParamsSearchLocations(&paramsSearchLocationsSpecs)
+
+	ctx := r.Context()
+	locations, err := a.serviceRegistry.Flight.SearchLocations(ctx, paramsSearchLocations)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	locationsSpecs := make([]specs.Location, len(locations))
+	for i, location := range locations {
+		locationsSpecs[i] = *transformLocation(&location)
+	}
+	_ = json.NewEncoder(w).Encode(locationsSpecs)
+}
+
+func (a apiServer) CreateSeatHold(w http.ResponseWriter, r *http.Request, flightIdSpecs specs.UUIDPathObjectID) {
+
+	paramsCreateSeatHoldSpecs := &specs.ParamsCreateSeatHold{}
+	err := json.NewDecoder(r.Body).Decode(paramsCreateSeatHoldSpecs)
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_BODY_REQUEST", err.Error()))
+		return
+	}
+
+	paramsCreateSeatHold, err := transformParamsCreateSeatHold(string(flightIdSpecs), paramsCreateSeatHoldSpecs)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	ctx := r.Context()
+	seatHold, err := a.serviceRegistry.Flight.CreateSeatHold(ctx, paramsCreateSeatHold)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	seatHoldSpecs := transformSeatHold(seatHold)
+	_ = json.NewEncoder(w).Encode(seatHoldSpecs)
+}
+
+func (a apiServer) BoardTicket(w http.ResponseWriter, r *http.Request, flightIdSpecs specs.UUIDPathObjectID) {
+
+	paramsBoardTicketSpecs := &specs.ParamsBoardTicket{}
+	err := json.NewDecoder(r.Body).Decode(paramsBoardTicketSpecs)
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_BODY_REQUEST", err.Error()))
+		return
+	}
+
+	paramsBoardTicket, err := transformParamsBoardTicket(string(flightIdSpecs), paramsBoardTicketSpecs)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	ctx := r.Context()
+	ticketId, err := a.serviceRegistry.Ticket.BoardTicket(ctx, paramsBoardTicket)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	updatedItem := specs.UpdatedItem{Id: ticketId.String()}
+	_ = json.NewEncoder(w).Encode(updatedItem)
+}
+
+func (a apiServer) CloseFlight(w http.ResponseWriter, r *http.Request, flightIdSpecs specs.UUIDPathObjectID) {
+
+	flightId, err := convertStringToUuid(string(flightIdSpecs))
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_FLIGHT_UUID", err.Error()))
+		return
+	}
+
+	ctx := r.Context()
+	closedFlight, err := a.serviceRegistry.Ticket.CloseFlight(ctx, &ticketsDomain.ParamsCloseFlight{
+		Timestamp: time.Now(),
+		FlightId:  flightId,
+	})
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	closedFlightSpecs := transformClosedFlight(closedFlight)
+	_ = json.NewEncoder(w).Encode(closedFlightSpecs)
+}
+
+func (a apiServer) GetFlightStatus(w http.ResponseWriter, r *http.Request, flightIdSpecs specs.UUIDPathObjectID) {
+
+	flightId, err := convertStringToUuid(string(flightIdSpecs))
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_FLIGHT_UUID", err.Error()))
+		return
+	}
+
+	ctx := r.Context()
+	flight, err := a.serviceRegistry.Flight.GetFlightById(ctx, flightId)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	flightStatusSpecs := transformFlightStatus(flight)
+	_ = json.NewEncoder(w).Encode(flightStatusSpecs)
+}
+
+func (a apiServer) UpdateFlightStatus(w http.ResponseWriter, r *http.Request, flightIdSpecs specs.UUIDPathObjectID, paramsUpdateFlightStatusSpecs specs.UpdateFlightStatusParams) {
+
+	paramsUpdateFlightStatusBodySpecs := &specs.ParamsUpdateFlightStatus{}
+	err := json.NewDecoder(r.Body).Decode(paramsUpdateFlightStatusBodySpecs)
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_BODY_REQUEST", err.Error()))
+		return
+	}
+
+	var role string
+	if paramsUpdateFlightStatusSpecs.XRole != nil {
+		role = *paramsUpdateFlightStatusSpecs.XRole
+	}
+
+	paramsUpdateFlightStatus, err := transformParamsUpdateFlightStatus(string(flightIdSpecs), role, paramsUpdateFlightStatusBodySpecs)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	ctx := r.Context()
+	flight, err := a.serviceRegistry.Flight.UpdateFlightStatus(ctx, paramsUpdateFlightStatus)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	flightStatusSpecs := transformFlightStatus(flight)
+	_ = json.NewEncoder(w).Encode(flightStatusSpecs)
+}
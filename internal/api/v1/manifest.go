@@ -0,0 +1,186 @@
+package v1
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/pdf"
+	"homework/internal/util/terr"
+	"homework/specs"
+)
+
+// колонки манифеста в выгрузке CSV
+var manifestCsvHeader = []string{
+	"ticket_id", "name_passenger", "passenger_type", "class_seats", "seat_number", "status", "count_additional_baggage",
+	"document_type", "document_number", "document_issuing_country", "nationality", "birth_date", "document_expiry_date",
+	"destination_country", "destination_city", "destination_address",
+}
+
+// колонки манифеста в выгрузке PDF: заголовок и ширина колонки в символах
+var manifestPdfColumns = []struct {
+	title string
+	width int
+}{
+	{"No", 3}, {"Passenger", 28}, {"Type", 6}, {"Class", 10}, {"Seat", 4}, {"Status", 10}, {"Bag", 3},
+	{"Document", 30}, {"Iss", 3}, {"Nat", 3}, {"Birth date", 10}, {"Expiry", 10}, {"Destination", 30},
+}
+
+func (a apiServer) GetFlightManifest(w http.ResponseWriter, r *http.Request, flightIdSpecs specs.UUIDPathObjectID, paramsGetFlightManifestSpecs specs.GetFlightManifestParams) {
+
+	flightId, err := convertStringToUuid(string(flightIdSpecs))
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_FLIGHT_UUID", err.Error()))
+		return
+	}
+
+	format := specs.GetFlightManifestParamsFormatJson
+	if paramsGetFlightManifestSpecs.Format != nil {
+		format = *paramsGetFlightManifestSpecs.Format
+	}
+	switch format {
+	case specs.GetFlightManifestParamsFormatJson, specs.GetFlightManifestParamsFormatCsv, specs.GetFlightManifestParamsFormatPdf:
+	default:
+		terr.WriteError(w, terr.BadRequest("INVALID_FORMAT", fmt.Sprintf("unknown manifest format %s", format)))
+		return
+	}
+
+	paramsGetFlightManifest := &ticketsDomain.ParamsGetFlightManifest{
+		Timestamp: time.Now(),
+		FlightId:  flightId,
+	}
+	if paramsGetFlightManifestSpecs.XRole != nil {
+		paramsGetFlightManifest.Role = *paramsGetFlightManifestSpecs.XRole
+	}
+
+	ctx := r.Context()
+	flightManifest, err := a.serviceRegistry.Ticket.GetFlightManifest(ctx, paramsGetFlightManifest)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	fileName := fmt.Sprintf("manifest_%s_%s", flightManifest.Flight.Name, flightManifest.Flight.DepartureDate.Format("2006-01-02"))
+	switch format {
+	case specs.GetFlightManifestParamsFormatCsv:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName+".csv"))
+		_ = csv.NewWriter(w).WriteAll(formatManifestCsv(flightManifest))
+	case specs.GetFlightManifestParamsFormatPdf:
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName+".pdf"))
+		_ = pdf.Write(w, formatManifestPdf(flightManifest))
+	default:
+		flightManifestSpecs := transformFlightManifest(flightManifest)
+		_ = json.NewEncoder(w).Encode(flightManifestSpecs)
+	}
+}
+
+// formatManifestCsv формирует строки выгрузки манифеста в CSV: заголовок и по строке на пассажира
+func formatManifestCsv(flightManifest *ticketsDomain.FlightManifest) [][]string {
+
+	records := make([][]string, 0, len(flightManifest.Passengers)+1)
+	records = append(records, manifestCsvHeader)
+	for _, passenger := range flightManifest.Passengers {
+		records = append(records, []string{
+			passenger.TicketId.String(),
+			passenger.NamePassenger,
+			passenger.PassengerType,
+			passenger.ClassSeatsName,
+			passenger.SeatNumber,
+			passenger.StatusName,
+			strconv.Itoa(passenger.CountAdditionalBaggage),
+			passenger.DocumentType,
+			passenger.DocumentNumber,
+			passenger.DocumentIssuingCountry,
+			passenger.Nationality,
+			formatManifestDate(passenger.BirthDate),
+			formatManifestDate(passenger.DocumentExpiryDate),
+			passenger.DestinationCountry,
+			passenger.DestinationCity,
+			passenger.DestinationAddress,
+		})
+	}
+	return records
+}
+
+// formatManifestPdf формирует документ PDF манифеста: таблица пассажиров колонками фиксированной ширины
+func formatManifestPdf(flightManifest *ticketsDomain.FlightManifest) *pdf.Document {
+
+	document := &pdf.Document{
+		Title: fmt.Sprintf("Flight manifest %s, departure %s UTC", flightManifest.Flight.Name,
+			flightManifest.Flight.DepartureDate.UTC().Format("2006-01-02 15:04")),
+		Landscape: true,
+		FontSize:  8,
+	}
+
+	masked := "no"
+	if flightManifest.IsMasked {
+		masked = "yes"
+	}
+	document.Lines = append(document.Lines,
+		fmt.Sprintf("Generated %s UTC. Passengers: %d. Personal data masked: %s.",
+			flightManifest.Timestamp.UTC().Format("2006-01-02 15:04"), len(flightManifest.Passengers), masked),
+		"")
+
+	header := make([]string, len(manifestPdfColumns))
+	for i, column := range manifestPdfColumns {
+		header[i] = column.title
+	}
+	document.Lines = append(document.Lines, formatManifestPdfLine(header), "")
+
+	for i, passenger := range flightManifest.Passengers {
+		destination := make([]string, 0, 3)
+		for _, value := range []string{passenger.DestinationCountry, passenger.DestinationCity, passenger.DestinationAddress} {
+			if value != "" {
+				destination = append(destination, value)
+			}
+		}
+		document.Lines = append(document.Lines, formatManifestPdfLine([]string{
+			strconv.Itoa(i + 1),
+			passenger.NamePassenger,
+			passenger.PassengerType,
+			passenger.ClassSeatsName,
+			passenger.SeatNumber,
+			passenger.StatusName,
+			strconv.Itoa(passenger.CountAdditionalBaggage),
+			strings.TrimSpace(passenger.DocumentType + " " + passenger.DocumentNumber),
+			passenger.DocumentIssuingCountry,
+			passenger.Nationality,
+			formatManifestDate(passenger.BirthDate),
+			formatManifestDate(passenger.DocumentExpiryDate),
+			strings.Join(destination, ", "),
+		}))
+	}
+	return document
+}
+
+// formatManifestPdfLine выравнивает значения по ширине колонок манифеста.
+// значения транслитерируются до выравнивания, т.к. транслитерация меняет длину строки
+func formatManifestPdfLine(values []string) string {
+
+	var line strings.Builder
+	for i, column := range manifestPdfColumns {
+		value := pdf.Transliterate(values[i])
+		if len(value) > column.width {
+			value = value[:column.width]
+		}
+		if i < len(manifestPdfColumns)-1 {
+			value += strings.Repeat(" ", column.width-len(value)+1)
+		}
+		line.WriteString(value)
+	}
+	return line.String()
+}
+
+func formatManifestDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	ticketsDomain "homework/internal/domain/tickets"
+)
+
+func Test_FormatManifestCsv(t *testing.T) {
+
+	// Arrange
+	ticketId := uuid.New()
+	birthDate := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	flightManifest := &ticketsDomain.FlightManifest{
+		Passengers: []ticketsDomain.ManifestPassenger{
+			{
+				TicketId:               ticketId,
+				NamePassenger:          "Иванов Иван Иванович",
+				PassengerType:          ticketsDomain.PassengerTypeAdult,
+				ClassSeatsName:         "Economy",
+				SeatNumber:             "12A",
+				StatusName:             "Registered",
+				CountAdditionalBaggage: 1,
+				DocumentType:           "passport",
+				DocumentNumber:         "751234567",
+				DocumentIssuingCountry: "RU",
+				Nationality:            "RU",
+				BirthDate:              &birthDate,
+			},
+		},
+	}
+
+	// Act
+	got := formatManifestCsv(flightManifest)
+
+	// Assert
+	assert.Len(t, got, 2)
+	assert.Equal(t, manifestCsvHeader, got[0])
+	assert.Equal(t, []string{
+		ticketId.String(), "Иванов Иван Иванович", "adult", "Economy", "12A", "Registered", "1",
+		"passport", "751234567", "RU", "RU", "1990-05-17", "", "", "", "",
+	}, got[1])
+}
+
+func Test_FormatManifestPdfLine(t *testing.T) {
+
+	// Arrange
+	values := []string{"1", "Щукин Ж. П.", "adult", "Business class", "1A", "Boarded", "0",
+		"passport ******4567", "RU", "RU", "", "", "TR, Antalya"}
+
+	// Act
+	got := formatManifestPdfLine(values)
+
+	// Assert
+	// кириллица транслитерируется до выравнивания, длинные значения обрезаются по ширине колонки
+	assert.Equal(t, "1   Shchukin Zh. P.              adult  Business c 1A   Boarded    0   "+
+		"passport ******4567            RU  RU                        TR, Antalya", got)
+}
@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+	"homework/specs"
+)
+
+func (a apiServer) GetUserPassengers(w http.ResponseWriter, r *http.Request, userIdSpecs specs.UUIDPathObjectID) {
+
+	userId, err := convertStringToUuid(string(userIdSpecs))
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_USER_UUID", err.Error()))
+		return
+	}
+
+	ctx := r.Context()
+	passengers, err := a.serviceRegistry.Ticket.GetUserPassengers(ctx, userId)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	passengersSpecs := make([]specs.Passenger, len(passengers))
+	for i, passenger := range passengers {
+		passengersSpecs[i] = *transformPassenger(&passenger)
+	}
+	_ = json.NewEncoder(w).Encode(passengersSpecs)
+}
+
+func (a apiServer) CreatePassenger(w http.ResponseWriter, r *http.Request, userIdSpecs specs.UUIDPathObjectID) {
+
+	paramsSavePassengerSpecs := &specs.ParamsSavePassenger{}
+	err := json.NewDecoder(r.Body).Decode(paramsSavePassengerSpecs)
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_BODY_REQUEST", err.Error()))
+		return
+	}
+
+	paramsCreatePassenger, err := transformParamsCreatePassenger(string(userIdSpecs), paramsSavePassengerSpecs)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	ctx := r.Context()
+	passengerId, err := a.serviceRegistry.Ticket.CreatePassenger(ctx, paramsCreatePassenger)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	createdItem := specs.CreatedItem{Id: passengerId.String()}
+	_ = json.NewEncoder(w).Encode(createdItem)
+}
+
+func (a apiServer) UpdatePassenger(w http.ResponseWriter, r *http.Request, userIdSpecs specs.UUIDPathObjectID, passengerIdSpecs string) {
+
+	paramsSavePassengerSpecs := &specs.ParamsSavePassenger{}
+	err := json.NewDecoder(r.Body).Decode(paramsSavePassengerSpecs)
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_BODY_REQUEST", err.Error()))
+		return
+	}
+
+	paramsUpdatePassenger, err := transformParamsUpdatePassenger(string(userIdSpecs), passengerIdSpecs, paramsSavePassengerSpecs)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	ctx := r.Context()
+	passengerId, err := a.serviceRegistry.Ticket.UpdatePassenger(ctx, paramsUpdatePassenger)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	updatedItem := specs.UpdatedItem{Id: passengerId.String()}
+	_ = json.NewEncoder(w).Encode(updatedItem)
+}
+
+func (a apiServer) DeletePassenger(w http.ResponseWriter, r *http.Request, userIdSpecs specs.UUIDPathObjectID, passengerIdSpecs string) {
+
+	userId, err := convertStringToUuid(string(userIdSpecs))
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_USER_UUID", err.Error()))
+		return
+	}
+
+	passengerId, err := convertStringToUuid(passengerIdSpecs)
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_PASSENGER_UUID", err.Error()))
+		return
+	}
+
+	paramsDeletePassenger := &ticketsDomain.ParamsDeletePassenger{
+		Timestamp:   time.Now(),
+		PassengerId: passengerId,
+		UserId:      userId,
+	}
+
+	ctx := r.Context()
+	passengerId, err = a.serviceRegistry.Ticket.DeletePassenger(ctx, paramsDeletePassenger)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	deletedItem := specs.DeletedItem{Id: passengerId.String()}
+	_ = json.NewEncoder(w).Encode(deletedItem)
+}
@@ -0,0 +1,181 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/pdf"
+	"homework/internal/util/terr"
+	"homework/specs"
+)
+
+// ширина строки документа билета и колонки сумм в символах
+const (
+	ticketDocumentPdfWidth       = 72
+	ticketDocumentPdfAmountWidth = 18
+)
+
+// префиксы номеров документов билета
+var ticketDocumentNumberPrefixes = map[string]string{
+	ticketsDomain.TicketDocumentTypeReceipt:    "R",
+	ticketsDomain.TicketDocumentTypeCreditNote: "CN",
+}
+
+// наименования способов оплаты в документах билета
+var ticketDocumentPaymentMethods = map[string]string{
+	ticketsDomain.PaymentMethodBonuses:         "Bonuses",
+	ticketsDomain.PaymentMethodGiftCertificate: "Gift certificate",
+	ticketsDomain.PaymentMethodTravelCredit:    "Travel credit",
+	ticketsDomain.PaymentMethodCard:            "Card",
+	ticketsDomain.PaymentMethodInvoice:         "Company invoice",
+}
+
+func (a apiServer) GetTicketReceipt(w http.ResponseWriter, r *http.Request, ticketIdSpecs specs.UUIDPathObjectID) {
+	a.writeTicketDocument(w, r, ticketIdSpecs, ticketsDomain.TicketDocumentTypeReceipt)
+}
+
+func (a apiServer) GetTicketCreditNote(w http.ResponseWriter, r *http.Request, ticketIdSpecs specs.UUIDPathObjectID) {
+	a.writeTicketDocument(w, r, ticketIdSpecs, ticketsDomain.TicketDocumentTypeCreditNote)
+}
+
+// writeTicketDocument выводит документ билета в формате PDF
+func (a apiServer) writeTicketDocument(w http.ResponseWriter, r *http.Request, ticketIdSpecs specs.UUIDPathObjectID, documentType string) {
+
+	ticketId, err := convertStringToUuid(string(ticketIdSpecs))
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_TICKET_UUID", err.Error()))
+		return
+	}
+
+	ctx := r.Context()
+	document, err := a.serviceRegistry.Ticket.GetTicketDocument(ctx, &ticketsDomain.ParamsGetTicketDocument{
+		TicketId: ticketId,
+		Type:     documentType,
+	})
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	fileName := fmt.Sprintf("%s_%s.pdf", documentType, formatTicketDocumentNumber(documentType, document.Number))
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	_ = pdf.Write(w, formatTicketDocumentPdf(document))
+}
+
+// formatTicketDocumentNumber выводит номер документа билета с префиксом типа документа, например, R-000042
+func formatTicketDocumentNumber(documentType string, number int64) string {
+	return fmt.Sprintf("%s-%06d", ticketDocumentNumberPrefixes[documentType], number)
+}
+
+// formatTicketDocumentPdf формирует документ PDF чека оплаты или кредит-ноты возврата билета:
+// реквизиты продавца, данные билета, состав стоимости в валюте билета и суммы оплаты (возврата) в валюте учета
+func formatTicketDocumentPdf(document *ticketsDomain.TicketDocument) *pdf.Document {
+
+	ticket := &document.Ticket
+	isCreditNote := document.Type == ticketsDomain.TicketDocumentTypeCreditNote
+
+	title, paymentsTitle, paidWith := "Receipt", "Payments", "Paid"
+	if isCreditNote {
+		title, paymentsTitle, paidWith = "Credit note", "Refunds", "Refunded"
+	}
+	number := formatTicketDocumentNumber(document.Type, document.Number)
+
+	pdfDocument := &pdf.Document{Title: fmt.Sprintf("%s %s", title, number)}
+	lines := []string{
+		document.Seller.Name,
+		"Tax ID: " + document.Seller.TaxId,
+		document.Seller.Address,
+		document.Seller.Email,
+		"",
+		formatTicketDocumentField(title+" No", number),
+		formatTicketDocumentField("Date", document.Timestamp.UTC().Format("2006-01-02 15:04")+" UTC"),
+	}
+	if isCreditNote && document.ReceiptNumber > 0 {
+		lines = append(lines, formatTicketDocumentField("Receipt No", formatTicketDocumentNumber(ticketsDomain.TicketDocumentTypeReceipt, document.ReceiptNumber)))
+	}
+
+	seat := ticket.ClassSeats.Name
+	if ticket.Seat != nil {
+		seat += ", seat " + ticket.Seat.Number
+	}
+	lines = append(lines,
+		"",
+		formatTicketDocumentField("Ticket", ticket.Id.String()),
+		formatTicketDocumentField("Flight", fmt.Sprintf("%s %s - %s", ticket.Flight.Name,
+			ticket.Flight.DepartureAirport.City.Name, ticket.Flight.ArrivalAirport.City.Name)),
+		formatTicketDocumentField("Departure", ticket.Flight.DepartureDate.UTC().Format("2006-01-02 15:04")+" UTC"),
+		formatTicketDocumentField("Passenger", fmt.Sprintf("%s (%s)", ticket.Passenger.NamePassenger, ticket.PassengerType)),
+		formatTicketDocumentField("Class", seat),
+		formatTicketDocumentField("Customer", ticket.User.Name),
+	)
+	if ticket.Company != nil {
+		lines = append(lines, formatTicketDocumentField("Company", ticket.Company.CompanyName))
+	}
+
+	lines = append(lines, "", "Price breakdown:")
+	for _, item := range ticket.Items {
+		name := item.Name
+		if item.Quantity > 1 {
+			name = fmt.Sprintf("%s x%d", item.Name, item.Quantity)
+		}
+		lines = append(lines, formatTicketDocumentAmount(name, item.Price))
+	}
+	lines = append(lines, formatTicketDocumentAmount("Total", ticket.Price))
+	if ticket.ExchangeRate.From != ticket.ExchangeRate.To {
+		lines = append(lines,
+			fmt.Sprintf("Exchange rate: 1 %s = %.4f %s", ticket.ExchangeRate.From, ticket.ExchangeRate.Rate, ticket.ExchangeRate.To),
+			formatTicketDocumentAmount("Total", ticket.Price.Convert(ticket.ExchangeRate)))
+	}
+
+	lines = append(lines, "", paymentsTitle+":")
+	for _, payment := range document.Payments {
+		lines = append(lines, formatTicketDocumentAmount(formatTicketDocumentPayment(document, &payment), payment.Amount))
+	}
+	lines = append(lines,
+		formatTicketDocumentAmount(paidWith+" with bonuses", document.PaidWithBonuses),
+		formatTicketDocumentAmount(paidWith+" with money", document.PaidWithMoney),
+	)
+
+	pdfDocument.Lines = lines
+	return pdfDocument
+}
+
+// formatTicketDocumentPayment выводит способ оплаты. для кредит-ноты указывается, куда возвращена оплата картой
+func formatTicketDocumentPayment(document *ticketsDomain.TicketDocument, payment *ticketsDomain.TicketPayment) string {
+
+	method := ticketDocumentPaymentMethods[payment.Method]
+	if method == "" {
+		method = payment.Method
+	}
+	if payment.VoucherCode != "" {
+		method += " " + payment.VoucherCode
+	}
+
+	if document.Type == ticketsDomain.TicketDocumentTypeCreditNote && payment.Method == ticketsDomain.PaymentMethodCard {
+		if document.TravelCreditCode != "" {
+			return method + " (to travel credit " + document.TravelCreditCode + ")"
+		}
+		return method + " (to bonuses)"
+	}
+	return method
+}
+
+func formatTicketDocumentField(name string, value string) string {
+	return fmt.Sprintf("%-14s%s", name+":", value)
+}
+
+// formatTicketDocumentAmount выравнивает сумму по правому краю строки документа.
+// наименование транслитерируется до выравнивания, т.к. транслитерация меняет длину строки
+func formatTicketDocumentAmount(name string, amount money.Money) string {
+
+	nameWidth := ticketDocumentPdfWidth - ticketDocumentPdfAmountWidth - 1
+	name = pdf.Transliterate(name)
+	if len(name) > nameWidth {
+		name = name[:nameWidth]
+	}
+	return name + strings.Repeat(" ", nameWidth-len(name)+1) + fmt.Sprintf("%*s", ticketDocumentPdfAmountWidth, amount.String())
+}
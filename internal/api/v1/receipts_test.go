@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	usersDomain "homework/internal/domain/users"
+)
+
+func Test_FormatTicketDocumentNumber(t *testing.T) {
+
+	// Act
+	receipt := formatTicketDocumentNumber(ticketsDomain.TicketDocumentTypeReceipt, 42)
+	creditNote := formatTicketDocumentNumber(ticketsDomain.TicketDocumentTypeCreditNote, 1234567)
+
+	// Assert
+	assert.Equal(t, "R-000042", receipt)
+	assert.Equal(t, "CN-1234567", creditNote)
+}
+
+func Test_FormatTicketDocumentAmount(t *testing.T) {
+
+	// Act
+	got := formatTicketDocumentAmount("Тариф", money.New(1234550, money.BaseCurrency))
+
+	// Assert
+	// наименование транслитерируется до выравнивания, сумма выравнивается по правому краю строки
+	assert.Equal(t, "Tarif"+strings.Repeat(" ", 55)+"12345.50 RUB", got)
+	assert.Len(t, got, ticketDocumentPdfWidth)
+}
+
+func Test_FormatTicketDocumentPdf(t *testing.T) {
+
+	// Arrange
+	document := &ticketsDomain.TicketDocument{
+		Type:             ticketsDomain.TicketDocumentTypeCreditNote,
+		Number:           7,
+		Timestamp:        time.Date(2022, 12, 3, 10, 0, 0, 0, time.UTC),
+		ReceiptNumber:    42,
+		TravelCreditCode: "TC-1A2B3C4D5E6F",
+		Seller: ticketsDomain.Seller{
+			Name:    "Booking Air Tickets LLC",
+			TaxId:   "7700000000",
+			Address: "Moscow, Tverskaya st. 1",
+			Email:   "billing@booking-air-tickets.example",
+		},
+		Ticket: ticketsDomain.Ticket{
+			Id: uuid.MustParse("6382589b-ab8e-4519-8c00-d0fe095179b3"),
+			Flight: flightsDomain.Flight{
+				Name:             "SU1234",
+				DepartureAirport: flightsDomain.Airport{City: flightsDomain.City{Name: "Moscow"}},
+				ArrivalAirport:   flightsDomain.Airport{City: flightsDomain.City{Name: "Antalya"}},
+				DepartureDate:    time.Date(2022, 12, 20, 17, 30, 0, 0, time.UTC),
+			},
+			User:          usersDomain.User{Name: "Ivan Ivanov"},
+			Passenger:     ticketsDomain.Passenger{NamePassenger: "Ivan Ivanov"},
+			PassengerType: ticketsDomain.PassengerTypeAdult,
+			ClassSeats:    flightsDomain.ClassSeats{Name: "Economy"},
+			Price:         money.New(10000, money.CurrencyUSD),
+			ExchangeRate:  money.ExchangeRate{From: money.CurrencyUSD, To: money.BaseCurrency, Rate: 90},
+			Items: []ticketsDomain.TicketItem{
+				{Name: "Fare", Quantity: 1, Price: money.New(8000, money.CurrencyUSD)},
+				{Name: "Extra baggage", Quantity: 2, Price: money.New(2000, money.CurrencyUSD)},
+			},
+		},
+		PaidWithBonuses: money.New(200000, money.BaseCurrency),
+		PaidWithMoney:   money.New(700000, money.BaseCurrency),
+		Payments: []ticketsDomain.TicketPayment{
+			{Method: ticketsDomain.PaymentMethodBonuses, Amount: money.New(200000, money.BaseCurrency)},
+			{Method: ticketsDomain.PaymentMethodCard, Amount: money.New(700000, money.BaseCurrency)},
+		},
+	}
+
+	// Act
+	got := formatTicketDocumentPdf(document)
+
+	// Assert
+	assert.Equal(t, "Credit note CN-000007", got.Title)
+	assert.Contains(t, got.Lines, "Receipt No:   R-000042")
+	assert.Contains(t, got.Lines, "Class:        Economy")
+	assert.Contains(t, got.Lines, formatTicketDocumentAmount("Extra baggage x2", money.New(2000, money.CurrencyUSD)))
+	assert.Contains(t, got.Lines, formatTicketDocumentAmount("Total", money.New(900000, money.BaseCurrency)))
+	assert.Contains(t, got.Lines, formatTicketDocumentAmount("Card (to travel credit TC-1A2B3C4D5E6F)", money.New(700000, money.BaseCurrency)))
+	assert.Contains(t, got.Lines, formatTicketDocumentAmount("Refunded with bonuses", money.New(200000, money.BaseCurrency)))
+	assert.Contains(t, got.Lines, formatTicketDocumentAmount("Refunded with money", money.New(700000, money.BaseCurrency)))
+}
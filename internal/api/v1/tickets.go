@@ -29,6 +29,20 @@ func (a apiServer) GetTicketById(w http.ResponseWriter, r *http.Request, ticketI
 
 }
 
+func (a apiServer) GetVoucherByCode(w http.ResponseWriter, r *http.Request, code string) {
+
+	ctx := r.Context()
+	voucher, err := a.serviceRegistry.Ticket.GetVoucherByCode(ctx, code)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	voucherSpecs := transformVoucher(voucher)
+	_ = json.NewEncoder(w).Encode(voucherSpecs)
+
+}
+
 func (a apiServer) CreateTicket(w http.ResponseWriter, r *http.Request) {
 
 	paramsCreateTicketSpecs := &specs.ParamsCreateTicket{}
@@ -83,6 +97,60 @@ func (a apiServer) PayForTicket(w http.ResponseWriter, r *http.Request) {
 
 }
 
+func (a apiServer) ApproveTicket(w http.ResponseWriter, r *http.Request) {
+
+	paramsApproveTicketSpecs := &specs.ParamsApproveTicket{}
+	err := json.NewDecoder(r.Body).Decode(paramsApproveTicketSpecs)
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_BODY_REQUEST", err.Error()))
+		return
+	}
+
+	paramsApproveTicket, err := transformParamsApproveTicket(paramsApproveTicketSpecs)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	ctx := r.Context()
+	ticketId, err := a.serviceRegistry.Ticket.ApproveTicket(ctx, paramsApproveTicket)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	updatedItem := specs.UpdatedItem{Id: uuid.UUID(ticketId).String()}
+	_ = json.NewEncoder(w).Encode(updatedItem)
+
+}
+
+func (a apiServer) CreatePriceHold(w http.ResponseWriter, r *http.Request) {
+
+	paramsCreatePriceHoldSpecs := &specs.ParamsCreatePriceHold{}
+	err := json.NewDecoder(r.Body).Decode(paramsCreatePriceHoldSpecs)
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_BODY_REQUEST", err.Error()))
+		return
+	}
+
+	paramsCreatePriceHold, err := transformParamsCreatePriceHold(paramsCreatePriceHoldSpecs)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	ctx := r.Context()
+	priceHold, err := a.serviceRegistry.Ticket.CreatePriceHold(ctx, paramsCreatePriceHold)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	priceHoldSpecs := transformPriceHold(priceHold)
+	_ = json.NewEncoder(w).Encode(priceHoldSpecs)
+
+}
+
 func (a apiServer) RefundTicket(w http.ResponseWriter, r *http.Request) {
 
 	paramsRefundTicketSpecs := &specs.ParamsRefundTicket{}
@@ -135,3 +203,105 @@ func (a apiServer) RegisterTicket(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(updatedItem)
 
 }
+
+func (a apiServer) RegisterFlightTickets(w http.ResponseWriter, r *http.Request) {
+
+	paramsRegisterFlightTicketsSpecs := &specs.ParamsRegisterFlightTickets{}
+	err := json.NewDecoder(r.Body).Decode(paramsRegisterFlightTicketsSpecs)
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_BODY_REQUEST", err.Error()))
+		return
+	}
+
+	paramsRegisterFlightTickets, err := transformParamsRegisterFlightTickets(paramsRegisterFlightTicketsSpecs)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	ctx := r.Context()
+	ticketsIds, err := a.serviceRegistry.Ticket.RegisterFlightTickets(ctx, paramsRegisterFlightTickets)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	updatedItems := make([]specs.UpdatedItem, len(ticketsIds))
+	for i, ticketId := range ticketsIds {
+		updatedItems[i] = specs.UpdatedItem{Id: ticketId.String()}
+	}
+	_ = json.NewEncoder(w).Encode(updatedItems)
+
+}
+
+func (a apiServer) AddTicketAncillary(w http.ResponseWriter, r *http.Request) {
+
+	paramsAddTicketAncillarySpecs := &specs.ParamsAddTicketAncillary{}
+	err := json.NewDecoder(r.Body).Decode(paramsAddTicketAncillarySpecs)
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_BODY_REQUEST", err.Error()))
+		return
+	}
+
+	paramsAddTicketAncillary, err := transformParamsAddTicketAncillary(paramsAddTicketAncillarySpecs)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	ctx := r.Context()
+	itemId, err := a.serviceRegistry.Ticket.AddTicketAncillary(ctx, paramsAddTicketAncillary)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	createdItem := specs.CreatedItem{Id: uuid.UUID(itemId).String()}
+	_ = json.NewEncoder(w).Encode(createdItem)
+}
+
+func (a apiServer) JoinWaitlist(w http.ResponseWriter, r *http.Request) {
+
+	paramsJoinWaitlistSpecs := &specs.ParamsJoinWaitlist{}
+	err := json.NewDecoder(r.Body).Decode(paramsJoinWaitlistSpecs)
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_BODY_REQUEST", err.Error()))
+		return
+	}
+
+	paramsJoinWaitlist, err := transformParamsJoinWaitlist(paramsJoinWaitlistSpecs)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	ctx := r.Context()
+	waitlistEntryId, err := a.serviceRegistry.Ticket.JoinWaitlist(ctx, paramsJoinWaitlist)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	createdItem := specs.CreatedItem{Id: uuid.UUID(waitlistEntryId).String()}
+	_ = json.NewEncoder(w).Encode(createdItem)
+}
+
+func (a apiServer) GetUserTickets(w http.ResponseWriter, r *http.Request, userIdSpecs specs.UUIDPathObjectID, paramsGetUserTicketsSpecs specs.GetUserTicketsParams) {
+
+	paramsGetUserTickets, err := transformParamsGetUserTickets(string(userIdSpecs), &paramsGetUserTicketsSpecs)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	ctx := r.Context()
+	ticketsPage, err := a.serviceRegistry.Ticket.GetUserTickets(ctx, paramsGetUserTickets)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	isSummaryView := paramsGetUserTicketsSpecs.View != nil && *paramsGetUserTicketsSpecs.View == specs.GetUserTicketsParamsViewSummary
+	ticketsPageSpecs := transformTicketsPage(ticketsPage, isSummaryView)
+	_ = json.NewEncoder(w).Encode(ticketsPageSpecs)
+}
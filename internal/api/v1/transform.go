@@ -1,11 +1,15 @@
 package v1
 
 import (
+	"encoding/base64"
 	"errors"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
 	uuid "github.com/google/uuid"
+	"strings"
 	"time"
 
 	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
 	ticketsDomain "homework/internal/domain/tickets"
 	usersDomain "homework/internal/domain/users"
 	terr "homework/internal/util/terr"
@@ -28,26 +32,243 @@ func convertStringToUuid(idString string) (uuid.UUID, error) {
 	return id, nil
 }
 
+// convertStringToLocation разбирает значение отбора рейсов: id города или код города или аэропорта
+// (IATA - 3 латинские буквы, ICAO - 4 латинские буквы). код возвращается заглавными буквами
+func convertStringToLocation(value string) (uuid.UUID, string, error) {
+
+	if len(value) == 3 || len(value) == 4 {
+		isCode := true
+		for _, r := range value {
+			if !(r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z') {
+				isCode = false
+				break
+			}
+		}
+		if isCode {
+			return uuid.UUID{}, strings.ToUpper(value), nil
+		}
+	}
+
+	id, err := convertStringToUuid(value)
+	return id, "", err
+}
+
+// convertToLocalTime переводит время в часовой пояс аэропорта (IANA).
+// если часовой пояс не задан или неизвестен, время выводится в UTC
+func convertToLocalTime(t time.Time, timeZone string) time.Time {
+
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return t.UTC()
+	}
+	return t.In(location)
+}
+
 func transformParamsGetFlights(paramsFlightsSpecs *specs.GetFlightsParams) (*flightsDomain.ParamsGetFlights, error) {
 
-	departureCityId, err := convertStringToUuid(paramsFlightsSpecs.DepartureCityId)
+	departureCityId, departureCode, err := convertStringToLocation(paramsFlightsSpecs.DepartureCityId)
 	if err != nil {
 		return nil, terr.BadRequest("INVALID_DEPARTURE_CITY_UUID", err.Error())
 	}
 
-	arrivalCityId, err := convertStringToUuid(paramsFlightsSpecs.ArrivalCityId)
+	arrivalCityId, arrivalCode, err := convertStringToLocation(paramsFlightsSpecs.ArrivalCityId)
 	if err != nil {
 		return nil, terr.BadRequest("INVALID_ARRIVAL_CITY_UUID", err.Error())
 	}
 
 	var paramsGetFlights flightsDomain.ParamsGetFlights
 	paramsGetFlights.DepartureCityId = departureCityId
+	paramsGetFlights.DepartureCode = departureCode
 	paramsGetFlights.ArrivalCityId = arrivalCityId
+	paramsGetFlights.ArrivalCode = arrivalCode
 	paramsGetFlights.DepartureDate = paramsFlightsSpecs.DepartureDate.Time
 
 	return &paramsGetFlights, nil
 }
 
+func transformParamsGetOversoldFlights(paramsGetOversoldFlightsSpecs *specs.GetOversoldFlightsParams) *flightsDomain.ParamsGetOversoldFlights {
+
+	var paramsGetOversoldFlights flightsDomain.ParamsGetOversoldFlights
+	paramsGetOversoldFlights.Timestamp = time.Now()
+
+	if paramsGetOversoldFlightsSpecs.DepartureDateFrom != nil {
+		departureDateFrom := paramsGetOversoldFlightsSpecs.DepartureDateFrom.Time
+		paramsGetOversoldFlights.DepartureDateFrom = &departureDateFrom
+	}
+	if paramsGetOversoldFlightsSpecs.DepartureDateTo != nil {
+		departureDateTo := paramsGetOversoldFlightsSpecs.DepartureDateTo.Time
+		paramsGetOversoldFlights.DepartureDateTo = &departureDateTo
+	}
+
+	return &paramsGetOversoldFlights
+}
+
+func transformParamsSearchLocations(paramsSearchLocationsSpecs *specs.SearchLocationsParams) *flightsDomain.ParamsSearchLocations {
+
+	var paramsSearchLocations flightsDomain.ParamsSearchLocations
+	paramsSearchLocations.Query = paramsSearchLocationsSpecs.Q
+	if paramsSearchLocationsSpecs.Limit != nil {
+		paramsSearchLocations.Limit = *paramsSearchLocationsSpecs.Limit
+	}
+
+	return &paramsSearchLocations
+}
+
+// количество билетов на странице списка билетов пользователя
+const (
+	defaultLimitUserTickets = 20
+	maxLimitUserTickets     = 100
+)
+
+func transformParamsGetUserTickets(userIdString string, paramsGetUserTicketsSpecs *specs.GetUserTicketsParams) (*ticketsDomain.ParamsGetUserTickets, error) {
+
+	userId, err := convertStringToUuid(userIdString)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_USER_UUID", err.Error())
+	}
+
+	var paramsGetUserTickets ticketsDomain.ParamsGetUserTickets
+	paramsGetUserTickets.Timestamp = time.Now()
+	paramsGetUserTickets.UserId = userId
+
+	if paramsGetUserTicketsSpecs.Status != nil {
+		paramsGetUserTickets.StatusesNames = *paramsGetUserTicketsSpecs.Status
+	}
+
+	if paramsGetUserTicketsSpecs.Period != nil {
+		switch *paramsGetUserTicketsSpecs.Period {
+		case specs.GetUserTicketsParamsPeriodUpcoming:
+			paramsGetUserTickets.Period = ticketsDomain.TicketsPeriodUpcoming
+		case specs.GetUserTicketsParamsPeriodPast:
+			paramsGetUserTickets.Period = ticketsDomain.TicketsPeriodPast
+		default:
+			return nil, terr.BadRequest("INVALID_PERIOD", "period must be upcoming or past")
+		}
+	}
+
+	if paramsGetUserTicketsSpecs.DepartureDateFrom != nil {
+		departureDateFrom := paramsGetUserTicketsSpecs.DepartureDateFrom.Time
+		paramsGetUserTickets.DepartureDateFrom = &departureDateFrom
+	}
+	if paramsGetUserTicketsSpecs.DepartureDateTo != nil {
+		departureDateTo := paramsGetUserTicketsSpecs.DepartureDateTo.Time
+		paramsGetUserTickets.DepartureDateTo = &departureDateTo
+	}
+
+	if paramsGetUserTicketsSpecs.Cursor != nil {
+		cursor, err := decodeTicketsCursor(*paramsGetUserTicketsSpecs.Cursor)
+		if err != nil {
+			return nil, terr.BadRequest("INVALID_CURSOR", err.Error())
+		}
+		paramsGetUserTickets.Cursor = cursor
+	}
+
+	paramsGetUserTickets.Limit = defaultLimitUserTickets
+	if paramsGetUserTicketsSpecs.Limit != nil {
+		if *paramsGetUserTicketsSpecs.Limit <= 0 || *paramsGetUserTicketsSpecs.Limit > maxLimitUserTickets {
+			return nil, terr.BadRequest("INVALID_LIMIT", "limit must be between 1 and 100")
+		}
+		paramsGetUserTickets.Limit = *paramsGetUserTicketsSpecs.Limit
+	}
+
+	return &paramsGetUserTickets, nil
+}
+
+// курсор передается клиенту в виде строки base64 от "дата вылета|id билета"
+func encodeTicketsCursor(cursor *ticketsDomain.TicketsCursor) string {
+	cursorString := cursor.DepartureDate.Format(time.RFC3339Nano) + "|" + cursor.TicketId.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorString))
+}
+
+func decodeTicketsCursor(cursorString string) (*ticketsDomain.TicketsCursor, error) {
+
+	cursorBytes, err := base64.RawURLEncoding.DecodeString(cursorString)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(string(cursorBytes), "|")
+	if len(parts) != 2 {
+		return nil, errors.New("invalid cursor format")
+	}
+
+	departureDate, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	ticketId, err := convertStringToUuid(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return &ticketsDomain.TicketsCursor{
+		DepartureDate: departureDate,
+		TicketId:      ticketId,
+	}, nil
+}
+
+func transformParamsCreatePassenger(userIdString string, paramsSavePassengerSpecs *specs.ParamsSavePassenger) (*ticketsDomain.ParamsCreatePassenger, error) {
+
+	userId, err := convertStringToUuid(userIdString)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_USER_UUID", err.Error())
+	}
+
+	if paramsSavePassengerSpecs.NamePassenger == "" {
+		return nil, terr.BadRequest("INVALID_NAME_PASSENGER", "empty name passenger")
+	}
+
+	var paramsCreatePassenger ticketsDomain.ParamsCreatePassenger
+	paramsCreatePassenger.Timestamp = time.Now()
+	paramsCreatePassenger.UserId = userId
+	paramsCreatePassenger.NamePassenger = paramsSavePassengerSpecs.NamePassenger
+	paramsCreatePassenger.Document = transformParamsIdentityDocument(&paramsSavePassengerSpecs.DocumentPassenger)
+
+	return &paramsCreatePassenger, nil
+}
+
+func transformParamsUpdatePassenger(userIdString string, passengerIdString string, paramsSavePassengerSpecs *specs.ParamsSavePassenger) (*ticketsDomain.ParamsUpdatePassenger, error) {
+
+	passengerId, err := convertStringToUuid(passengerIdString)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_PASSENGER_UUID", err.Error())
+	}
+
+	paramsCreatePassenger, err := transformParamsCreatePassenger(userIdString, paramsSavePassengerSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	var paramsUpdatePassenger ticketsDomain.ParamsUpdatePassenger
+	paramsUpdatePassenger.Timestamp = paramsCreatePassenger.Timestamp
+	paramsUpdatePassenger.PassengerId = passengerId
+	paramsUpdatePassenger.UserId = paramsCreatePassenger.UserId
+	paramsUpdatePassenger.NamePassenger = paramsCreatePassenger.NamePassenger
+	paramsUpdatePassenger.Document = paramsCreatePassenger.Document
+
+	return &paramsUpdatePassenger, nil
+}
+
+// документ пассажира приводится к единому виду: номер и коды стран в верхнем регистре без пробелов по краям.
+// проверка документа выполняется в сервисе
+func transformParamsIdentityDocument(documentSpecs *specs.IdentityDocument) ticketsDomain.IdentityDocument {
+
+	var document ticketsDomain.IdentityDocument
+	document.Type = string(documentSpecs.Type)
+	document.Number = strings.ToUpper(strings.TrimSpace(documentSpecs.Number))
+	document.IssuingCountry = strings.ToUpper(strings.TrimSpace(documentSpecs.IssuingCountry))
+	document.Nationality = strings.ToUpper(strings.TrimSpace(documentSpecs.Nationality))
+	document.BirthDate = documentSpecs.BirthDate.Time
+
+	if documentSpecs.ExpiryDate != nil {
+		expiryDate := documentSpecs.ExpiryDate.Time
+		document.ExpiryDate = &expiryDate
+	}
+
+	return document
+}
+
 func transformParamsCreateTicket(paramsCreateTicketSpecs *specs.ParamsCreateTicket) (*ticketsDomain.ParamsCreateTicket, error) {
 
 	flightId, err := convertStringToUuid(paramsCreateTicketSpecs.FlightId)
@@ -72,8 +293,18 @@ func transformParamsCreateTicket(paramsCreateTicketSpecs *specs.ParamsCreateTick
 		if paramsCreateTicketSpecs.NamePassenger == nil || *paramsCreateTicketSpecs.NamePassenger == "" {
 			return nil, terr.BadRequest("INVALID_NAME_PASSENGER", "empty name passenger")
 		}
-		if paramsCreateTicketSpecs.IdentityDataPassenger == nil || *paramsCreateTicketSpecs.IdentityDataPassenger == "" {
-			return nil, terr.BadRequest("INVALID_IDENTITY_DATA_PASSENGER", "empty identity data passenger")
+		if paramsCreateTicketSpecs.DocumentPassenger == nil {
+			return nil, terr.BadRequest("INVALID_DOCUMENT_PASSENGER", "empty document passenger")
+		}
+	}
+
+	// если передается AccompanyingTicketId, значит билет оформляется для ребенка или младенца с сопровождением взрослого
+	isAccompanied := paramsCreateTicketSpecs.AccompanyingTicketId != nil
+	var accompanyingTicketId uuid.UUID
+	if isAccompanied {
+		accompanyingTicketId, err = convertStringToUuid(*paramsCreateTicketSpecs.AccompanyingTicketId)
+		if err != nil {
+			return nil, terr.BadRequest("INVALID_ACCOMPANYING_TICKET_UUID", err.Error())
 		}
 	}
 
@@ -92,29 +323,149 @@ func transformParamsCreateTicket(paramsCreateTicketSpecs *specs.ParamsCreateTick
 		}
 	}
 
+	// если передается SeatHoldId, значит билет оформляется по ранее удержанному месту
+	isSeatHeld := paramsCreateTicketSpecs.SeatHoldId != nil
+	var seatHoldId uuid.UUID
+	if isSeatHeld {
+		seatHoldId, err = convertStringToUuid(*paramsCreateTicketSpecs.SeatHoldId)
+		if err != nil {
+			return nil, terr.BadRequest("INVALID_SEAT_HOLD_UUID", err.Error())
+		}
+	}
+
+	// если передается CompanyId, значит билет оформляется на компанию, BookerId - пользователем компании за путешественника
+	isCompanyTicket := paramsCreateTicketSpecs.CompanyId != nil
+	var companyId uuid.UUID
+	if isCompanyTicket {
+		companyId, err = convertStringToUuid(*paramsCreateTicketSpecs.CompanyId)
+		if err != nil {
+			return nil, terr.BadRequest("INVALID_COMPANY_UUID", err.Error())
+		}
+	}
+
+	isBooked := paramsCreateTicketSpecs.BookerId != nil
+	var bookerId uuid.UUID
+	if isBooked {
+		bookerId, err = convertStringToUuid(*paramsCreateTicketSpecs.BookerId)
+		if err != nil {
+			return nil, terr.BadRequest("INVALID_BOOKER_UUID", err.Error())
+		}
+	}
+
 	var paramsCreateTicket ticketsDomain.ParamsCreateTicket
 	paramsCreateTicket.StatusTimestamp = time.Now()
 	paramsCreateTicket.FlightId = flightId
 	paramsCreateTicket.UserId = userId
 	paramsCreateTicket.ClassSeatsId = classSeatsId
 	paramsCreateTicket.CountAdditionalBaggage = paramsCreateTicketSpecs.CountAdditionalBaggage
+	if paramsCreateTicketSpecs.PromoCode != nil {
+		paramsCreateTicket.PromoCode = strings.TrimSpace(*paramsCreateTicketSpecs.PromoCode)
+	}
 
 	if passengerExists {
 		paramsCreateTicket.PassengerId = &passengerId
 	} else {
 		paramsCreateTicket.ParamsCreatePassenger = &ticketsDomain.ParamsCreatePassenger{
-			NamePassenger:         *paramsCreateTicketSpecs.NamePassenger,
-			IdentityDataPassenger: *paramsCreateTicketSpecs.IdentityDataPassenger,
+			Timestamp:     paramsCreateTicket.StatusTimestamp,
+			UserId:        userId,
+			NamePassenger: *paramsCreateTicketSpecs.NamePassenger,
+			Document:      transformParamsIdentityDocument(paramsCreateTicketSpecs.DocumentPassenger),
 		}
 	}
 
+	if isAccompanied {
+		paramsCreateTicket.AccompanyingTicketId = &accompanyingTicketId
+	}
+
 	if isSeatAssigned {
 		paramsCreateTicket.SeatId = &seatId
 	}
 
+	if isSeatHeld {
+		paramsCreateTicket.SeatHoldId = &seatHoldId
+	}
+
+	if isCompanyTicket {
+		paramsCreateTicket.CompanyId = &companyId
+	}
+
+	if isBooked {
+		paramsCreateTicket.BookerId = &bookerId
+	}
+
 	return &paramsCreateTicket, nil
 }
 
+func transformParamsJoinWaitlist(paramsJoinWaitlistSpecs *specs.ParamsJoinWaitlist) (*ticketsDomain.ParamsJoinWaitlist, error) {
+
+	flightId, err := convertStringToUuid(paramsJoinWaitlistSpecs.FlightId)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_FLIGHT_UUID", err.Error())
+	}
+
+	userId, err := convertStringToUuid(paramsJoinWaitlistSpecs.UserId)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_USER_UUID", err.Error())
+	}
+
+	passengerId, err := convertStringToUuid(paramsJoinWaitlistSpecs.PassengerId)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_PASSENGER_UUID", err.Error())
+	}
+
+	classSeatsId, err := convertStringToUuid(paramsJoinWaitlistSpecs.ClassSeatsId)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_CLASS_SEAT_UUID", err.Error())
+	}
+
+	var paramsJoinWaitlist ticketsDomain.ParamsJoinWaitlist
+	paramsJoinWaitlist.Timestamp = time.Now()
+	paramsJoinWaitlist.FlightId = flightId
+	paramsJoinWaitlist.UserId = userId
+	paramsJoinWaitlist.PassengerId = passengerId
+	paramsJoinWaitlist.ClassSeatsId = classSeatsId
+	if paramsJoinWaitlistSpecs.CountAdditionalBaggage != nil {
+		paramsJoinWaitlist.CountAdditionalBaggage = *paramsJoinWaitlistSpecs.CountAdditionalBaggage
+	}
+
+	return &paramsJoinWaitlist, nil
+}
+
+func transformParamsCreateSeatHold(flightIdString string, paramsCreateSeatHoldSpecs *specs.ParamsCreateSeatHold) (*flightsDomain.ParamsCreateSeatHold, error) {
+
+	flightId, err := convertStringToUuid(flightIdString)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_FLIGHT_UUID", err.Error())
+	}
+
+	userId, err := convertStringToUuid(paramsCreateSeatHoldSpecs.UserId)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_USER_UUID", err.Error())
+	}
+
+	classSeatsId, err := convertStringToUuid(paramsCreateSeatHoldSpecs.ClassSeatsId)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_CLASS_SEAT_UUID", err.Error())
+	}
+
+	var paramsCreateSeatHold flightsDomain.ParamsCreateSeatHold
+	paramsCreateSeatHold.Timestamp = time.Now()
+	paramsCreateSeatHold.FlightId = flightId
+	paramsCreateSeatHold.UserId = userId
+	paramsCreateSeatHold.ClassSeatsId = classSeatsId
+
+	// если SeatId не передается, то удерживается одно место класса без выбора конкретного места
+	if paramsCreateSeatHoldSpecs.SeatId != nil {
+		seatId, err := convertStringToUuid(*paramsCreateSeatHoldSpecs.SeatId)
+		if err != nil {
+			return nil, terr.BadRequest("INVALID_SEAT_UUID", err.Error())
+		}
+		paramsCreateSeatHold.SeatId = &seatId
+	}
+
+	return &paramsCreateSeatHold, nil
+}
+
 func transformParamsPayForTicket(paramsPayForTicketSpecs *specs.ParamsPayForTicket) (*ticketsDomain.ParamsPayForTicket, error) {
 
 	ticketId, err := convertStringToUuid(paramsPayForTicketSpecs.TicketId)
@@ -135,7 +486,19 @@ func transformParamsPayForTicket(paramsPayForTicketSpecs *specs.ParamsPayForTick
 	paramsPayForTicket.StatusTimestamp = time.Now()
 	paramsPayForTicket.TicketId = ticketId
 	paramsPayForTicket.UserId = userId
-	paramsPayForTicket.PaidWithBonuses = paramsPayForTicketSpecs.PaidWithBonuses
+	paramsPayForTicket.PaidWithBonuses = money.New(paramsPayForTicketSpecs.PaidWithBonuses, money.BaseCurrency)
+
+	if paramsPayForTicketSpecs.Vouchers != nil {
+		for _, voucherSpecs := range *paramsPayForTicketSpecs.Vouchers {
+			if voucherSpecs.Amount <= 0 {
+				return nil, terr.BadRequest("INVALID_VOUCHER_AMOUNT", "voucher amount is a positive number")
+			}
+			paramsPayForTicket.Vouchers = append(paramsPayForTicket.Vouchers, ticketsDomain.ParamsVoucherPayment{
+				Code:   strings.TrimSpace(voucherSpecs.Code),
+				Amount: money.New(voucherSpecs.Amount, money.BaseCurrency),
+			})
+		}
+	}
 
 	return &paramsPayForTicket, nil
 }
@@ -156,6 +519,9 @@ func transformParamsRefundTicket(paramsRefundTicketSpecs *specs.ParamsRefundTick
 	paramsRefundTicket.StatusTimestamp = time.Now()
 	paramsRefundTicket.TicketId = ticketId
 	paramsRefundTicket.UserId = userId
+	if paramsRefundTicketSpecs.RefundAsCredit != nil {
+		paramsRefundTicket.RefundAsCredit = *paramsRefundTicketSpecs.RefundAsCredit
+	}
 
 	return &paramsRefundTicket, nil
 }
@@ -190,10 +556,276 @@ func transformParamsRegisterTicket(paramsRegisterTicketSpecs *specs.ParamsRegist
 	if isSeatAssigned {
 		paramsRegisterTicket.SeatId = &seatId
 	}
+	paramsRegisterTicket.Apis = transformParamsApisData(paramsRegisterTicketSpecs.Apis)
 	return &paramsRegisterTicket, nil
 }
 
-func transformFlight(flight *flightsDomain.Flight) *specs.Flight {
+func transformParamsRegisterFlightTickets(paramsRegisterFlightTicketsSpecs *specs.ParamsRegisterFlightTickets) (*ticketsDomain.ParamsRegisterFlightTickets, error) {
+
+	userId, err := convertStringToUuid(paramsRegisterFlightTicketsSpecs.UserId)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_USER_UUID", err.Error())
+	}
+
+	flightId, err := convertStringToUuid(paramsRegisterFlightTicketsSpecs.FlightId)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_FLIGHT_UUID", err.Error())
+	}
+
+	var paramsRegisterFlightTickets ticketsDomain.ParamsRegisterFlightTickets
+	paramsRegisterFlightTickets.StatusTimestamp = time.Now()
+	paramsRegisterFlightTickets.UserId = userId
+	paramsRegisterFlightTickets.FlightId = flightId
+
+	if paramsRegisterFlightTicketsSpecs.Tickets != nil {
+		for _, paramsTicketSpecs := range *paramsRegisterFlightTicketsSpecs.Tickets {
+
+			ticketId, err := convertStringToUuid(paramsTicketSpecs.TicketId)
+			if err != nil {
+				return nil, terr.BadRequest("INVALID_TICKET_UUID", err.Error())
+			}
+
+			var paramsRegisterTicket ticketsDomain.ParamsRegisterTicket
+			paramsRegisterTicket.TicketId = ticketId
+			if paramsTicketSpecs.SeatId != nil {
+				seatId, err := convertStringToUuid(*paramsTicketSpecs.SeatId)
+				if err != nil {
+					return nil, terr.BadRequest("INVALID_SEAT_UUID", err.Error())
+				}
+				paramsRegisterTicket.SeatId = &seatId
+			}
+			paramsRegisterTicket.Apis = transformParamsApisData(paramsTicketSpecs.Apis)
+
+			paramsRegisterFlightTickets.Tickets = append(paramsRegisterFlightTickets.Tickets, paramsRegisterTicket)
+		}
+	}
+	return &paramsRegisterFlightTickets, nil
+}
+
+// данные APIS: документ приводится к единому виду, код страны назначения - в верхний регистр.
+// проверка данных выполняется в сервисе
+func transformParamsApisData(apisSpecs *specs.ApisData) *ticketsDomain.ApisData {
+
+	if apisSpecs == nil {
+		return nil
+	}
+
+	var apis ticketsDomain.ApisData
+	if apisSpecs.Document != nil {
+		document := transformParamsIdentityDocument(apisSpecs.Document)
+		apis.Document = &document
+	}
+	apis.DestinationAddress.Country = strings.ToUpper(strings.TrimSpace(apisSpecs.DestinationAddress.Country))
+	apis.DestinationAddress.City = apisSpecs.DestinationAddress.City
+	apis.DestinationAddress.Address = apisSpecs.DestinationAddress.Address
+	if apisSpecs.DestinationAddress.PostalCode != nil {
+		apis.DestinationAddress.PostalCode = *apisSpecs.DestinationAddress.PostalCode
+	}
+
+	return &apis
+}
+
+func transformParamsBoardTicket(flightIdString string, paramsBoardTicketSpecs *specs.ParamsBoardTicket) (*ticketsDomain.ParamsBoardTicket, error) {
+
+	flightId, err := convertStringToUuid(flightIdString)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_FLIGHT_UUID", err.Error())
+	}
+
+	var paramsBoardTicket ticketsDomain.ParamsBoardTicket
+	paramsBoardTicket.StatusTimestamp = time.Now()
+	paramsBoardTicket.FlightId = flightId
+	paramsBoardTicket.Code = paramsBoardTicketSpecs.Code
+
+	return &paramsBoardTicket, nil
+}
+
+func transformParamsUpdateFlightStatus(flightIdString string, role string, paramsUpdateFlightStatusSpecs *specs.ParamsUpdateFlightStatus) (*flightsDomain.ParamsUpdateFlightStatus, error) {
+
+	flightId, err := convertStringToUuid(flightIdString)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_FLIGHT_UUID", err.Error())
+	}
+
+	paramsUpdateFlightStatus := &flightsDomain.ParamsUpdateFlightStatus{
+		Timestamp:          time.Now(),
+		FlightId:           flightId,
+		Role:               role,
+		State:              paramsUpdateFlightStatusSpecs.State,
+		EstimatedDeparture: paramsUpdateFlightStatusSpecs.EstimatedDeparture,
+		ActualDeparture:    paramsUpdateFlightStatusSpecs.ActualDeparture,
+		EstimatedArrival:   paramsUpdateFlightStatusSpecs.EstimatedArrival,
+		ActualArrival:      paramsUpdateFlightStatusSpecs.ActualArrival,
+		BoardingTime:       paramsUpdateFlightStatusSpecs.BoardingTime,
+	}
+	if paramsUpdateFlightStatusSpecs.Terminal != nil {
+		paramsUpdateFlightStatus.Terminal = *paramsUpdateFlightStatusSpecs.Terminal
+	}
+	if paramsUpdateFlightStatusSpecs.Gate != nil {
+		paramsUpdateFlightStatus.Gate = *paramsUpdateFlightStatusSpecs.Gate
+	}
+
+	return paramsUpdateFlightStatus, nil
+}
+
+func transformParamsAddTicketAncillary(paramsAddTicketAncillarySpecs *specs.ParamsAddTicketAncillary) (*ticketsDomain.ParamsAddTicketAncillary, error) {
+
+	ticketId, err := convertStringToUuid(paramsAddTicketAncillarySpecs.TicketId)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_TICKET_UUID", err.Error())
+	}
+
+	userId, err := convertStringToUuid(paramsAddTicketAncillarySpecs.UserId)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_USER_UUID", err.Error())
+	}
+
+	var paramsAddTicketAncillary ticketsDomain.ParamsAddTicketAncillary
+	paramsAddTicketAncillary.Timestamp = time.Now()
+	paramsAddTicketAncillary.TicketId = ticketId
+	paramsAddTicketAncillary.UserId = userId
+	paramsAddTicketAncillary.Type = strings.TrimSpace(paramsAddTicketAncillarySpecs.Type)
+
+	// по умолчанию покупается одна услуга
+	paramsAddTicketAncillary.Quantity = 1
+	if paramsAddTicketAncillarySpecs.Quantity != nil {
+		paramsAddTicketAncillary.Quantity = *paramsAddTicketAncillarySpecs.Quantity
+	}
+
+	// FlightAncillaryId передается для услуг из каталога рейса
+	if paramsAddTicketAncillarySpecs.FlightAncillaryId != nil {
+		flightAncillaryId, err := convertStringToUuid(*paramsAddTicketAncillarySpecs.FlightAncillaryId)
+		if err != nil {
+			return nil, terr.BadRequest("INVALID_FLIGHT_ANCILLARY_UUID", err.Error())
+		}
+		paramsAddTicketAncillary.FlightAncillaryId = &flightAncillaryId
+	}
+
+	// SeatId передается для услуги выбора места
+	if paramsAddTicketAncillarySpecs.SeatId != nil {
+		seatId, err := convertStringToUuid(*paramsAddTicketAncillarySpecs.SeatId)
+		if err != nil {
+			return nil, terr.BadRequest("INVALID_SEAT_UUID", err.Error())
+		}
+		paramsAddTicketAncillary.SeatId = &seatId
+	}
+
+	return &paramsAddTicketAncillary, nil
+}
+
+func transformParamsApproveTicket(paramsApproveTicketSpecs *specs.ParamsApproveTicket) (*ticketsDomain.ParamsApproveTicket, error) {
+
+	ticketId, err := convertStringToUuid(paramsApproveTicketSpecs.TicketId)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_TICKET_UUID", err.Error())
+	}
+
+	userId, err := convertStringToUuid(paramsApproveTicketSpecs.UserId)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_USER_UUID", err.Error())
+	}
+
+	var paramsApproveTicket ticketsDomain.ParamsApproveTicket
+	paramsApproveTicket.StatusTimestamp = time.Now()
+	paramsApproveTicket.TicketId = ticketId
+	paramsApproveTicket.UserId = userId
+	paramsApproveTicket.Approved = paramsApproveTicketSpecs.Approved
+
+	return &paramsApproveTicket, nil
+}
+
+func transformParamsCreatePriceHold(paramsCreatePriceHoldSpecs *specs.ParamsCreatePriceHold) (*ticketsDomain.ParamsCreatePriceHold, error) {
+
+	ticketId, err := convertStringToUuid(paramsCreatePriceHoldSpecs.TicketId)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_TICKET_UUID", err.Error())
+	}
+
+	userId, err := convertStringToUuid(paramsCreatePriceHoldSpecs.UserId)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_USER_UUID", err.Error())
+	}
+
+	var paramsCreatePriceHold ticketsDomain.ParamsCreatePriceHold
+	paramsCreatePriceHold.Timestamp = time.Now()
+	paramsCreatePriceHold.TicketId = ticketId
+	paramsCreatePriceHold.UserId = userId
+
+	return &paramsCreatePriceHold, nil
+}
+
+// месяц счета передается в виде ГГГГ-ММ (UTC)
+func transformParamsCreateCompanyInvoice(companyIdString string, paramsCreateCompanyInvoiceSpecs *specs.ParamsCreateCompanyInvoice) (*ticketsDomain.ParamsCreateCompanyInvoice, error) {
+
+	companyId, err := convertStringToUuid(companyIdString)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_COMPANY_UUID", err.Error())
+	}
+
+	periodFrom, err := time.Parse("2006-01", strings.TrimSpace(paramsCreateCompanyInvoiceSpecs.Period))
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_INVOICE_PERIOD", "period must be in format YYYY-MM")
+	}
+
+	var paramsCreateCompanyInvoice ticketsDomain.ParamsCreateCompanyInvoice
+	paramsCreateCompanyInvoice.Timestamp = time.Now()
+	paramsCreateCompanyInvoice.CompanyId = companyId
+	paramsCreateCompanyInvoice.PeriodFrom = periodFrom
+
+	return &paramsCreateCompanyInvoice, nil
+}
+
+func transformParamsGetCompanyReport(companyIdString string, paramsGetCompanyReportSpecs *specs.GetCompanyReportParams) (*ticketsDomain.ParamsGetCompanyReport, error) {
+
+	companyId, err := convertStringToUuid(companyIdString)
+	if err != nil {
+		return nil, terr.BadRequest("INVALID_COMPANY_UUID", err.Error())
+	}
+
+	var paramsGetCompanyReport ticketsDomain.ParamsGetCompanyReport
+	paramsGetCompanyReport.CompanyId = companyId
+
+	if paramsGetCompanyReportSpecs.DepartureDateFrom != nil {
+		departureDateFrom := paramsGetCompanyReportSpecs.DepartureDateFrom.Time
+		paramsGetCompanyReport.DepartureDateFrom = &departureDateFrom
+	}
+	if paramsGetCompanyReportSpecs.DepartureDateTo != nil {
+		departureDateTo := paramsGetCompanyReportSpecs.DepartureDateTo.Time
+		paramsGetCompanyReport.DepartureDateTo = &departureDateTo
+	}
+
+	return &paramsGetCompanyReport, nil
+}
+
+// transformMoney преобразует сумму. если задан курс валюты суммы к другой валюте,
+// дополнительно выводится сумма в валюте курса
+func transformMoney(m money.Money, displayRate *money.ExchangeRate) specs.Money {
+
+	var moneySpecs specs.Money
+	moneySpecs.Amount = m.Amount
+	moneySpecs.Currency = m.Currency
+	if displayRate != nil && displayRate.From == m.Currency && displayRate.To != m.Currency {
+		displayMoney := m.Convert(*displayRate)
+		moneySpecs.DisplayAmount = &displayMoney.Amount
+		moneySpecs.DisplayCurrency = &displayMoney.Currency
+	}
+
+	return moneySpecs
+}
+
+func transformExchangeRate(rate *money.ExchangeRate) *specs.ExchangeRate {
+
+	var rateSpecs specs.ExchangeRate
+	rateSpecs.From = rate.From
+	rateSpecs.To = rate.To
+	rateSpecs.Rate = rate.Rate
+	rateSpecs.Timestamp = rate.Timestamp.UTC()
+
+	return &rateSpecs
+}
+
+// transformFlight преобразует рейс. displayRate - курс валюты цен рейса к валюте просмотра, может быть не задан
+func transformFlight(flight *flightsDomain.Flight, displayRate *money.ExchangeRate) *specs.Flight {
 
 	var flightSpec specs.Flight
 
@@ -209,14 +841,18 @@ func transformFlight(flight *flightsDomain.Flight) *specs.Flight {
 	flightSpec.Departure.CityName = flight.DepartureAirport.City.Name
 	flightSpec.Departure.AirportId = flight.DepartureAirport.Id.String()
 	flightSpec.Departure.AirportName = flight.DepartureAirport.Name
+	flightSpec.Departure.TimeZone = flight.DepartureAirport.TimeZone
 
 	flightSpec.Arrival.CityId = flight.ArrivalAirport.City.Id.String()
 	flightSpec.Arrival.CityName = flight.ArrivalAirport.City.Name
 	flightSpec.Arrival.AirportId = flight.ArrivalAirport.Id.String()
 	flightSpec.Arrival.AirportName = flight.ArrivalAirport.Name
+	flightSpec.Arrival.TimeZone = flight.ArrivalAirport.TimeZone
 
-	flightSpec.Date.Departure = flight.DepartureDate
-	flightSpec.Date.Arrival = flight.DepartureDate.Add(flight.Duration)
+	flightSpec.Date.Departure = flight.DepartureDate.UTC()
+	flightSpec.Date.Arrival = flight.DepartureDate.Add(flight.Duration).UTC()
+	flightSpec.Date.DepartureLocal = convertToLocalTime(flight.DepartureDate, flight.DepartureAirport.TimeZone)
+	flightSpec.Date.ArrivalLocal = convertToLocalTime(flight.DepartureDate.Add(flight.Duration), flight.ArrivalAirport.TimeZone)
 	flightSpec.Date.Duration = int(flight.Duration / time.Minute)
 
 	PricesTickets := make([]specs.FlightPrice, len(flight.PricesTickets))
@@ -224,20 +860,95 @@ func transformFlight(flight *flightsDomain.Flight) *specs.Flight {
 		PricesTickets[i].ClassSeatsId = flightPrice.ClassSeats.Id.String()
 		PricesTickets[i].ClassSeatsName = flightPrice.ClassSeats.Name
 		PricesTickets[i].CountVacantSeats = flightPrice.CountVacantSeats
-		PricesTickets[i].PriceTicket = flightPrice.PriceTicket
+		PricesTickets[i].PriceTicket = transformMoney(flightPrice.PriceTicket, displayRate)
+		PricesTickets[i].PriceTotal = transformMoney(flightPrice.PriceTotal, displayRate)
+		PricesTickets[i].ChildDiscountPercent = flightPrice.ChildDiscountPercent
+		PricesTickets[i].InfantDiscountPercent = flightPrice.InfantDiscountPercent
+		PricesTickets[i].OverbookingPercent = flightPrice.OverbookingPercent
+		PricesTickets[i].PriceHoldMaxHours = flightPrice.PriceHoldMaxHours
+		PricesTickets[i].PriceHoldFee = transformMoney(flightPrice.PriceHoldFee, displayRate)
 	}
 	flightSpec.PricesTickets = PricesTickets
 
-	flightSpec.PriceAdditionalBaggage = flight.PriceAdditionalBaggage
-	flightSpec.PriceSeatSelection = flight.PriceSeatSelection
+	flightSpec.PriceAdditionalBaggage = transformMoney(flight.PriceAdditionalBaggage, displayRate)
+	flightSpec.PriceSeatSelection = transformMoney(flight.PriceSeatSelection, displayRate)
+	flightSpec.TaxesFees = make([]specs.FlightTaxFee, len(flight.TaxesFees))
+	for i, taxFee := range flight.TaxesFees {
+		flightSpec.TaxesFees[i].Id = taxFee.Id.String()
+		flightSpec.TaxesFees[i].Type = taxFee.Type
+		flightSpec.TaxesFees[i].Name = taxFee.Name
+		flightSpec.TaxesFees[i].Price = transformMoney(taxFee.Price, displayRate)
+	}
+	flightSpec.Currency = flight.Currency
+	if displayRate != nil {
+		flightSpec.DisplayExchangeRate = transformExchangeRate(displayRate)
+	}
 
 	flightSpec.IsInternational = flight.IsInternational
 	flightSpec.BaggageIncluded = flight.BaggageIncluded
 	flightSpec.PetAllowed = flight.PetAllowed
+	flightSpec.MaxInfants = flight.MaxInfants
+	flightSpec.ClosedTimestamp = flight.ClosedTimestamp
+	flightSpec.Status = transformFlightStatus(flight)
 
 	return &flightSpec
 }
 
+func transformFlightStatus(flight *flightsDomain.Flight) *specs.FlightStatus {
+
+	var flightStatusSpecs specs.FlightStatus
+	flightStatusSpecs.FlightId = flight.Id.String()
+	flightStatusSpecs.FlightName = flight.Name
+	flightStatusSpecs.State = flight.Status.State
+
+	flightStatusSpecs.ScheduledDeparture = flight.DepartureDate
+	flightStatusSpecs.EstimatedDeparture = flight.Status.EstimatedDeparture
+	flightStatusSpecs.ActualDeparture = flight.Status.ActualDeparture
+	flightStatusSpecs.ScheduledArrival = flight.DepartureDate.Add(flight.Duration)
+	flightStatusSpecs.EstimatedArrival = flight.Status.EstimatedArrival
+	flightStatusSpecs.ActualArrival = flight.Status.ActualArrival
+
+	if flight.Status.Terminal != "" {
+		terminal := flight.Status.Terminal
+		flightStatusSpecs.Terminal = &terminal
+	}
+	if flight.Status.Gate != "" {
+		gate := flight.Status.Gate
+		flightStatusSpecs.Gate = &gate
+	}
+	flightStatusSpecs.BoardingTime = flight.Status.BoardingTime
+	flightStatusSpecs.UpdatedTimestamp = flight.Status.UpdatedTimestamp
+
+	return &flightStatusSpecs
+}
+
+func transformLocation(location *flightsDomain.Location) *specs.Location {
+
+	var locationSpecs specs.Location
+	locationSpecs.Type = location.Type
+	locationSpecs.Id = location.Id.String()
+	locationSpecs.Name = location.Name
+	locationSpecs.CityId = location.City.Id.String()
+	locationSpecs.CityName = location.City.Name
+
+	if location.IataCode != "" {
+		iataCode := location.IataCode
+		locationSpecs.IataCode = &iataCode
+	}
+	if location.IcaoCode != "" {
+		icaoCode := location.IcaoCode
+		locationSpecs.IcaoCode = &icaoCode
+	}
+	if location.CountryCode != "" {
+		countryCode := location.CountryCode
+		locationSpecs.CountryCode = &countryCode
+	}
+	locationSpecs.Latitude = location.Latitude
+	locationSpecs.Longitude = location.Longitude
+
+	return &locationSpecs
+}
+
 func transformVacantSeats(vacantSeats *flightsDomain.VacantSeats) *specs.VacantSeats {
 
 	var vacantSeatsSpec specs.VacantSeats
@@ -250,11 +961,123 @@ func transformVacantSeats(vacantSeats *flightsDomain.VacantSeats) *specs.VacantS
 	for i, seat := range vacantSeats.Seats {
 		Seats[i].Id = seat.Id.String()
 		Seats[i].Number = seat.Number
+		Seats[i].IsExitRow = seat.IsExitRow
 	}
 	vacantSeatsSpec.Seats = Seats
 	return &vacantSeatsSpec
 }
 
+func transformFlightAncillary(flightAncillary *flightsDomain.FlightAncillary) *specs.FlightAncillary {
+
+	var flightAncillarySpecs specs.FlightAncillary
+	flightAncillarySpecs.Id = flightAncillary.Id.String()
+	flightAncillarySpecs.Type = flightAncillary.Type
+	flightAncillarySpecs.Name = flightAncillary.Name
+	flightAncillarySpecs.Price = transformMoney(flightAncillary.Price, nil)
+	flightAncillarySpecs.MaxCount = flightAncillary.MaxCount
+	flightAncillarySpecs.CountSold = flightAncillary.CountSold
+
+	return &flightAncillarySpecs
+}
+
+func transformOversoldClassSeats(oversoldClassSeats *flightsDomain.OversoldClassSeats) *specs.OversoldClassSeats {
+
+	var oversoldClassSeatsSpecs specs.OversoldClassSeats
+	oversoldClassSeatsSpecs.FlightId = oversoldClassSeats.FlightId.String()
+	oversoldClassSeatsSpecs.FlightName = oversoldClassSeats.FlightName
+	oversoldClassSeatsSpecs.DepartureDate = oversoldClassSeats.DepartureDate
+	oversoldClassSeatsSpecs.ClassSeatsId = oversoldClassSeats.ClassSeatsId.String()
+	oversoldClassSeatsSpecs.ClassSeatsName = oversoldClassSeats.ClassSeatsName
+	oversoldClassSeatsSpecs.CountSeats = oversoldClassSeats.CountSeats
+	oversoldClassSeatsSpecs.OverbookingPercent = oversoldClassSeats.OverbookingPercent
+	oversoldClassSeatsSpecs.CountTickets = oversoldClassSeats.CountTickets
+	oversoldClassSeatsSpecs.CountOversold = oversoldClassSeats.CountOversold
+
+	return &oversoldClassSeatsSpecs
+}
+
+func transformClosedFlight(closedFlight *ticketsDomain.ClosedFlight) *specs.ClosedFlight {
+
+	var closedFlightSpecs specs.ClosedFlight
+	closedFlightSpecs.FlightId = closedFlight.FlightId.String()
+	closedFlightSpecs.ClosedTimestamp = closedFlight.ClosedTimestamp
+	closedFlightSpecs.CountBoarded = closedFlight.CountBoarded
+	closedFlightSpecs.CountNoShow = closedFlight.CountNoShow
+	closedFlightSpecs.CountClosed = closedFlight.CountClosed
+
+	return &closedFlightSpecs
+}
+
+func transformFlightManifest(flightManifest *ticketsDomain.FlightManifest) *specs.FlightManifest {
+
+	var flightManifestSpecs specs.FlightManifest
+	flightManifestSpecs.FlightId = flightManifest.Flight.Id.String()
+	flightManifestSpecs.FlightName = flightManifest.Flight.Name
+	flightManifestSpecs.DepartureDate = flightManifest.Flight.DepartureDate
+	flightManifestSpecs.Timestamp = flightManifest.Timestamp
+	flightManifestSpecs.IsMasked = flightManifest.IsMasked
+
+	flightManifestSpecs.Passengers = make([]specs.ManifestPassenger, len(flightManifest.Passengers))
+	for i, passenger := range flightManifest.Passengers {
+		flightManifestSpecs.Passengers[i] = *transformManifestPassenger(&passenger)
+	}
+
+	return &flightManifestSpecs
+}
+
+func transformManifestPassenger(passenger *ticketsDomain.ManifestPassenger) *specs.ManifestPassenger {
+
+	optionalString := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+
+	var passengerSpecs specs.ManifestPassenger
+	passengerSpecs.TicketId = passenger.TicketId.String()
+	passengerSpecs.NamePassenger = passenger.NamePassenger
+	passengerSpecs.PassengerType = passenger.PassengerType
+	passengerSpecs.ClassSeatsName = passenger.ClassSeatsName
+	passengerSpecs.SeatNumber = optionalString(passenger.SeatNumber)
+	passengerSpecs.Status = passenger.StatusName
+	passengerSpecs.CountAdditionalBaggage = passenger.CountAdditionalBaggage
+
+	passengerSpecs.DocumentType = optionalString(passenger.DocumentType)
+	passengerSpecs.DocumentNumber = optionalString(passenger.DocumentNumber)
+	passengerSpecs.DocumentIssuingCountry = optionalString(passenger.DocumentIssuingCountry)
+	passengerSpecs.Nationality = optionalString(passenger.Nationality)
+	if passenger.BirthDate != nil {
+		passengerSpecs.BirthDate = &openapi_types.Date{Time: *passenger.BirthDate}
+	}
+	if passenger.DocumentExpiryDate != nil {
+		passengerSpecs.DocumentExpiryDate = &openapi_types.Date{Time: *passenger.DocumentExpiryDate}
+	}
+
+	passengerSpecs.DestinationCountry = optionalString(passenger.DestinationCountry)
+	passengerSpecs.DestinationCity = optionalString(passenger.DestinationCity)
+	passengerSpecs.DestinationAddress = optionalString(passenger.DestinationAddress)
+
+	return &passengerSpecs
+}
+
+func transformSeatHold(seatHold *flightsDomain.SeatHold) *specs.SeatHold {
+
+	var seatHoldSpecs specs.SeatHold
+	seatHoldSpecs.Id = seatHold.Id.String()
+	seatHoldSpecs.FlightId = seatHold.FlightId.String()
+	seatHoldSpecs.ClassSeatsId = seatHold.ClassSeatsId.String()
+	if seatHold.SeatId != nil {
+		seatId := seatHold.SeatId.String()
+		seatHoldSpecs.SeatId = &seatId
+	}
+	seatHoldSpecs.UserId = seatHold.UserId.String()
+	seatHoldSpecs.Timestamp = seatHold.Timestamp
+	seatHoldSpecs.ExpiresAt = seatHold.ExpiresAt
+
+	return &seatHoldSpecs
+}
+
 func transformTicket(ticket *ticketsDomain.Ticket) *specs.Ticket {
 
 	var ticketSpecs specs.Ticket
@@ -270,10 +1093,12 @@ func transformTicket(ticket *ticketsDomain.Ticket) *specs.Ticket {
 	ticketSpecs.Flight.Aircraft = ticket.Flight.Aircraft.Name
 	ticketSpecs.Flight.DepartureCity = ticket.Flight.DepartureAirport.City.Name
 	ticketSpecs.Flight.DepartureAirport = ticket.Flight.DepartureAirport.Name
-	ticketSpecs.Flight.DepartureDate = ticket.Flight.DepartureDate
+	ticketSpecs.Flight.DepartureDate = ticket.Flight.DepartureDate.UTC()
+	ticketSpecs.Flight.DepartureDateLocal = convertToLocalTime(ticket.Flight.DepartureDate, ticket.Flight.DepartureAirport.TimeZone)
 	ticketSpecs.Flight.ArrivalCity = ticket.Flight.ArrivalAirport.City.Name
 	ticketSpecs.Flight.ArrivalAirport = ticket.Flight.ArrivalAirport.Name
-	ticketSpecs.Flight.ArrivalDate = ticket.Flight.DepartureDate.Add(ticket.Flight.Duration)
+	ticketSpecs.Flight.ArrivalDate = ticket.Flight.DepartureDate.Add(ticket.Flight.Duration).UTC()
+	ticketSpecs.Flight.ArrivalDateLocal = convertToLocalTime(ticket.Flight.DepartureDate.Add(ticket.Flight.Duration), ticket.Flight.ArrivalAirport.TimeZone)
 	ticketSpecs.Flight.Duration = int(ticket.Flight.Duration / time.Minute)
 
 	ticketSpecs.User.Id = ticket.User.Id.String()
@@ -282,6 +1107,14 @@ func transformTicket(ticket *ticketsDomain.Ticket) *specs.Ticket {
 	ticketSpecs.Passenger.Id = ticket.Passenger.Id.String()
 	ticketSpecs.Passenger.Name = ticket.Passenger.NamePassenger
 	ticketSpecs.Passenger.IdentityData = ticket.Passenger.IdentityDataPassenger
+	if ticket.Passenger.Document != nil {
+		ticketSpecs.Passenger.Document = transformIdentityDocument(ticket.Passenger.Document)
+	}
+	ticketSpecs.PassengerType = ticket.PassengerType
+	if ticket.AccompanyingTicketId != nil {
+		accompanyingTicketId := ticket.AccompanyingTicketId.String()
+		ticketSpecs.AccompanyingTicketId = &accompanyingTicketId
+	}
 
 	ticketSpecs.Seat.ClassSeatsId = ticket.ClassSeats.Id.String()
 	ticketSpecs.Seat.ClassSeatsName = ticket.ClassSeats.Name
@@ -292,26 +1125,374 @@ func transformTicket(ticket *ticketsDomain.Ticket) *specs.Ticket {
 	}
 
 	ticketSpecs.СountAdditionalBaggage = ticket.CountAdditionalBaggage
-	ticketSpecs.Price = ticket.Price
-	ticketSpecs.PaidWithBonuses = ticket.PaidWithBonuses
-	ticketSpecs.AccruedBonuses = ticket.AccruedBonuses
+	ticketSpecs.Price = transformMoney(ticket.Price, &ticket.ExchangeRate)
+	ticketSpecs.PaidWithBonuses = transformMoney(ticket.PaidWithBonuses, nil)
+	ticketSpecs.AccruedBonuses = transformMoney(ticket.AccruedBonuses, nil)
+	ticketSpecs.ExchangeRate = *transformExchangeRate(&ticket.ExchangeRate)
+
+	if len(ticket.Payments) > 0 {
+		payments := make([]specs.TicketPayment, len(ticket.Payments))
+		for i, payment := range ticket.Payments {
+			payments[i] = *transformTicketPayment(&payment)
+		}
+		ticketSpecs.Payments = &payments
+	}
+
+	if ticket.PromoCode != "" {
+		promoCode := ticket.PromoCode
+		ticketSpecs.PromoCode = &promoCode
+	}
+
+	if ticket.BoardingPassCode != "" {
+		boardingPassCode := ticket.BoardingPassCode
+		ticketSpecs.BoardingPassCode = &boardingPassCode
+	}
+
+	if ticket.Company != nil {
+		ticketSpecs.Company = transformTicketCompany(ticket.Company)
+	}
+
+	if ticket.PriceHold != nil {
+		ticketSpecs.PriceHold = transformPriceHold(ticket.PriceHold)
+	}
+
+	ticketSpecs.Items = make([]specs.TicketItem, len(ticket.Items))
+	for i, item := range ticket.Items {
+		ticketSpecs.Items[i] = *transformTicketItem(&item, &ticket.ExchangeRate)
+	}
+	ticketSpecs.PriceBreakdown = *transformPriceBreakdown(ticket)
 
 	return &ticketSpecs
 }
 
+func transformTicketItem(item *ticketsDomain.TicketItem, displayRate *money.ExchangeRate) *specs.TicketItem {
+
+	var itemSpecs specs.TicketItem
+	itemSpecs.Id = item.Id.String()
+	itemSpecs.Type = item.Type
+	itemSpecs.Name = item.Name
+	if item.TaxFeeId != nil {
+		taxFeeId := item.TaxFeeId.String()
+		itemSpecs.TaxFeeId = &taxFeeId
+	}
+	if item.FlightAncillaryId != nil {
+		flightAncillaryId := item.FlightAncillaryId.String()
+		itemSpecs.FlightAncillaryId = &flightAncillaryId
+	}
+	itemSpecs.Quantity = item.Quantity
+	itemSpecs.Price = transformMoney(item.Price, displayRate)
+	itemSpecs.Timestamp = item.Timestamp
+
+	return &itemSpecs
+}
+
+func transformTicketCompany(ticketCompany *ticketsDomain.TicketCompany) *specs.TicketCompany {
+
+	var ticketCompanySpecs specs.TicketCompany
+	ticketCompanySpecs.CompanyId = ticketCompany.CompanyId.String()
+	ticketCompanySpecs.CompanyName = ticketCompany.CompanyName
+	if ticketCompany.BookerId != nil {
+		bookerId := ticketCompany.BookerId.String()
+		ticketCompanySpecs.BookerId = &bookerId
+	}
+	ticketCompanySpecs.ApprovalStatus = ticketCompany.ApprovalStatus
+	if len(ticketCompany.PolicyViolations) > 0 {
+		policyViolations := ticketCompany.PolicyViolations
+		ticketCompanySpecs.PolicyViolations = &policyViolations
+	}
+	if ticketCompany.ApproverId != nil {
+		approverId := ticketCompany.ApproverId.String()
+		ticketCompanySpecs.ApproverId = &approverId
+	}
+	ticketCompanySpecs.ApprovalTimestamp = ticketCompany.ApprovalTimestamp
+
+	return &ticketCompanySpecs
+}
+
+// плата за удержание - в валюте цен рейса, поэтому выводится без пересчета
+func transformPriceHold(priceHold *ticketsDomain.PriceHold) *specs.PriceHold {
+
+	var priceHoldSpecs specs.PriceHold
+	priceHoldSpecs.Id = priceHold.Id.String()
+	priceHoldSpecs.TicketId = priceHold.TicketId.String()
+	priceHoldSpecs.UserId = priceHold.UserId.String()
+	priceHoldSpecs.Fee = transformMoney(priceHold.Fee, nil)
+	priceHoldSpecs.Status = priceHold.Status
+	priceHoldSpecs.HoldTimestamp = priceHold.HoldTimestamp
+	priceHoldSpecs.ExpiryTimestamp = priceHold.ExpiryTimestamp
+	priceHoldSpecs.ReminderTimestamp = priceHold.ReminderTimestamp
+
+	return &priceHoldSpecs
+}
+
+// суммы оплат - в валюте учета, поэтому выводятся без пересчета
+func transformTicketPayment(payment *ticketsDomain.TicketPayment) *specs.TicketPayment {
+
+	var paymentSpecs specs.TicketPayment
+	paymentSpecs.Id = payment.Id.String()
+	paymentSpecs.Method = payment.Method
+	if payment.VoucherCode != "" {
+		voucherCode := payment.VoucherCode
+		paymentSpecs.VoucherCode = &voucherCode
+	}
+	if payment.InvoiceId != nil {
+		invoiceId := payment.InvoiceId.String()
+		paymentSpecs.InvoiceId = &invoiceId
+	}
+	paymentSpecs.Amount = transformMoney(payment.Amount, nil)
+	paymentSpecs.Timestamp = payment.Timestamp
+	paymentSpecs.RefundTimestamp = payment.RefundTimestamp
+
+	return &paymentSpecs
+}
+
+func transformVoucher(voucher *ticketsDomain.Voucher) *specs.Voucher {
+
+	var voucherSpecs specs.Voucher
+	voucherSpecs.Type = voucher.Type
+	voucherSpecs.Code = voucher.Code
+	voucherSpecs.Amount = transformMoney(voucher.Amount, nil)
+	voucherSpecs.Balance = transformMoney(voucher.Balance, nil)
+	voucherSpecs.IssueTimestamp = voucher.IssueTimestamp
+	voucherSpecs.ExpiryTimestamp = voucher.ExpiryTimestamp
+
+	return &voucherSpecs
+}
+
+// transformPriceBreakdown подводит итоги состава стоимости билета: тариф, таксы (аэропортовые и государственные сборы),
+// сборы (топливный и сервисные), дополнительные услуги и скидки по промокоду
+func transformPriceBreakdown(ticket *ticketsDomain.Ticket) *specs.PriceBreakdown {
+
+	fare := money.New(0, ticket.Price.Currency)
+	taxes := money.New(0, ticket.Price.Currency)
+	fees := money.New(0, ticket.Price.Currency)
+	ancillaries := money.New(0, ticket.Price.Currency)
+	discounts := money.New(0, ticket.Price.Currency)
+	for _, item := range ticket.Items {
+		switch item.Type {
+		case ticketsDomain.TicketItemTypeFare:
+			fare = fare.Add(item.Price)
+		case flightsDomain.TaxFeeTypeAirportTax, flightsDomain.TaxFeeTypeCountryTax:
+			taxes = taxes.Add(item.Price)
+		case flightsDomain.TaxFeeTypeFuelSurcharge, flightsDomain.TaxFeeTypeServiceFee:
+			fees = fees.Add(item.Price)
+		case ticketsDomain.TicketItemTypePromoDiscount:
+			discounts = discounts.Add(item.Price)
+		default:
+			ancillaries = ancillaries.Add(item.Price)
+		}
+	}
+
+	var priceBreakdownSpecs specs.PriceBreakdown
+	priceBreakdownSpecs.Fare = transformMoney(fare, &ticket.ExchangeRate)
+	priceBreakdownSpecs.Taxes = transformMoney(taxes, &ticket.ExchangeRate)
+	priceBreakdownSpecs.Fees = transformMoney(fees, &ticket.ExchangeRate)
+	priceBreakdownSpecs.Ancillaries = transformMoney(ancillaries, &ticket.ExchangeRate)
+	priceBreakdownSpecs.Discounts = transformMoney(discounts, &ticket.ExchangeRate)
+	priceBreakdownSpecs.Total = transformMoney(fare.Add(taxes).Add(fees).Add(ancillaries).Add(discounts), &ticket.ExchangeRate)
+
+	return &priceBreakdownSpecs
+}
+
+func transformPassenger(passenger *ticketsDomain.Passenger) *specs.Passenger {
+
+	var passengerSpecs specs.Passenger
+	passengerSpecs.Id = passenger.Id.String()
+	passengerSpecs.Name = passenger.NamePassenger
+	passengerSpecs.IdentityData = passenger.IdentityDataPassenger
+	if passenger.Document != nil {
+		passengerSpecs.Document = transformIdentityDocument(passenger.Document)
+	}
+
+	return &passengerSpecs
+}
+
+func transformIdentityDocument(document *ticketsDomain.IdentityDocument) *specs.IdentityDocument {
+
+	var documentSpecs specs.IdentityDocument
+	documentSpecs.Type = specs.IdentityDocumentType(document.Type)
+	documentSpecs.Number = document.Number
+	documentSpecs.IssuingCountry = document.IssuingCountry
+	documentSpecs.Nationality = document.Nationality
+	documentSpecs.BirthDate = openapi_types.Date{Time: document.BirthDate}
+
+	if document.ExpiryDate != nil {
+		documentSpecs.ExpiryDate = &openapi_types.Date{Time: *document.ExpiryDate}
+	}
+
+	return &documentSpecs
+}
+
+func transformParamsCreateUser(paramsCreateUserSpecs *specs.ParamsCreateUser) *usersDomain.ParamsCreateUser {
+
+	paramsCreateUser := usersDomain.ParamsCreateUser{
+		Name:     paramsCreateUserSpecs.Name,
+		Email:    paramsCreateUserSpecs.Email,
+		Password: paramsCreateUserSpecs.Password,
+	}
+	if paramsCreateUserSpecs.ReferralCode != nil {
+		paramsCreateUser.ReferralCode = strings.TrimSpace(*paramsCreateUserSpecs.ReferralCode)
+	}
+	return &paramsCreateUser
+}
+
 func transformUser(user *usersDomain.User) *specs.User {
 
 	var userSpecs specs.User
 	userSpecs.Id = user.Id.String()
 	userSpecs.Name = user.Name
 	userSpecs.Email = user.Email
+	userSpecs.ReferralCode = user.ReferralCode
 
 	if user.Balance != nil {
-		userSpecs.Balance.SumPurchases = user.Balance.SumPurchases
-		userSpecs.Balance.SumBonuses = user.Balance.SumBonuses
+		userSpecs.Balance.SumPurchases = transformMoney(user.Balance.SumPurchases, nil)
+		userSpecs.Balance.SumBonuses = transformMoney(user.Balance.SumBonuses, nil)
 	} else {
-		userSpecs.Balance.SumPurchases = 0
-		userSpecs.Balance.SumBonuses = 0
+		userSpecs.Balance.SumPurchases = transformMoney(money.New(0, money.BaseCurrency), nil)
+		userSpecs.Balance.SumBonuses = transformMoney(money.New(0, money.BaseCurrency), nil)
 	}
 	return &userSpecs
 }
+
+func transformNotification(notification *usersDomain.Notification) *specs.Notification {
+
+	var notificationSpecs specs.Notification
+	notificationSpecs.Id = notification.Id.String()
+	notificationSpecs.Type = notification.Type
+	notificationSpecs.Message = notification.Message
+	if notification.TicketId != nil {
+		ticketId := notification.TicketId.String()
+		notificationSpecs.TicketId = &ticketId
+	}
+	notificationSpecs.Timestamp = notification.Timestamp
+
+	return &notificationSpecs
+}
+
+func transformTicketSummary(ticket *ticketsDomain.Ticket) *specs.TicketSummary {
+
+	var ticketSummarySpecs specs.TicketSummary
+	ticketSummarySpecs.Id = ticket.Id.String()
+	ticketSummarySpecs.Status = ticket.Status.Name
+	ticketSummarySpecs.FlightName = ticket.Flight.Name
+	ticketSummarySpecs.DepartureCity = ticket.Flight.DepartureAirport.City.Name
+	ticketSummarySpecs.ArrivalCity = ticket.Flight.ArrivalAirport.City.Name
+	ticketSummarySpecs.DepartureDate = ticket.Flight.DepartureDate
+	ticketSummarySpecs.PassengerName = ticket.Passenger.NamePassenger
+	ticketSummarySpecs.ClassSeatsName = ticket.ClassSeats.Name
+	if ticket.Seat != nil {
+		ticketSummarySpecs.SeatNumber = &ticket.Seat.Number
+	}
+	ticketSummarySpecs.Price = transformMoney(ticket.Price, &ticket.ExchangeRate)
+
+	return &ticketSummarySpecs
+}
+
+func transformTicketsPage(ticketsPage *ticketsDomain.TicketsPage, isSummaryView bool) *specs.TicketsPage {
+
+	var ticketsPageSpecs specs.TicketsPage
+
+	if isSummaryView {
+		ticketsSummary := make([]specs.TicketSummary, len(ticketsPage.Tickets))
+		for i, ticket := range ticketsPage.Tickets {
+			ticketsSummary[i] = *transformTicketSummary(&ticket)
+		}
+		ticketsPageSpecs.TicketsSummary = &ticketsSummary
+	} else {
+		tickets := make([]specs.Ticket, len(ticketsPage.Tickets))
+		for i, ticket := range ticketsPage.Tickets {
+			tickets[i] = *transformTicket(&ticket)
+		}
+		ticketsPageSpecs.Tickets = &tickets
+	}
+
+	if ticketsPage.NextCursor != nil {
+		nextCursor := encodeTicketsCursor(ticketsPage.NextCursor)
+		ticketsPageSpecs.NextCursor = &nextCursor
+	}
+
+	return &ticketsPageSpecs
+}
+
+func transformCompany(company *ticketsDomain.Company) *specs.Company {
+
+	var companySpecs specs.Company
+	companySpecs.Id = company.Id.String()
+	companySpecs.Name = company.Name
+
+	companySpecs.Members = make([]specs.CompanyMember, len(company.Members))
+	for i, member := range company.Members {
+		companySpecs.Members[i] = specs.CompanyMember{
+			UserId: member.User.Id.String(),
+			Name:   member.User.Name,
+			Email:  member.User.Email,
+			Roles:  member.Roles,
+		}
+	}
+
+	if company.TravelPolicy != nil {
+		var travelPolicySpecs specs.TravelPolicy
+		travelPolicySpecs.MaxClassSeatsName = company.TravelPolicy.MaxClassSeatsName
+		if company.TravelPolicy.MaxPrice != nil {
+			maxPrice := transformMoney(*company.TravelPolicy.MaxPrice, nil)
+			travelPolicySpecs.MaxPrice = &maxPrice
+		}
+		travelPolicySpecs.ApprovalRequired = company.TravelPolicy.ApprovalRequired
+		companySpecs.TravelPolicy = &travelPolicySpecs
+	}
+
+	return &companySpecs
+}
+
+func transformCompanyInvoice(invoice *ticketsDomain.CompanyInvoice) *specs.CompanyInvoice {
+
+	var invoiceSpecs specs.CompanyInvoice
+	invoiceSpecs.Id = invoice.Id.String()
+	invoiceSpecs.CompanyId = invoice.CompanyId.String()
+	invoiceSpecs.Period = invoice.PeriodFrom.Format("2006-01")
+	invoiceSpecs.Amount = transformMoney(invoice.Amount, nil)
+	invoiceSpecs.CountPayments = invoice.CountPayments
+	invoiceSpecs.CountRefunds = invoice.CountRefunds
+	invoiceSpecs.IssueTimestamp = invoice.IssueTimestamp
+
+	return &invoiceSpecs
+}
+
+// суммы отчета - в валюте учета, поэтому выводятся без пересчета
+func transformCompanyReport(report *ticketsDomain.CompanyReport) *specs.CompanyReport {
+
+	var reportSpecs specs.CompanyReport
+	reportSpecs.CompanyId = report.CompanyId.String()
+	reportSpecs.Total = *transformCompanyReportRow(&report.Total)
+	reportSpecs.OutOfPolicy = *transformCompanyReportRow(&report.OutOfPolicy)
+	reportSpecs.PendingApproval = *transformCompanyReportRow(&report.PendingApproval)
+
+	reportSpecs.Statuses = make([]specs.CompanyReportStatus, len(report.Statuses))
+	for i, status := range report.Statuses {
+		reportSpecs.Statuses[i] = specs.CompanyReportStatus{
+			StatusName:   status.StatusName,
+			CountTickets: status.CountTickets,
+			Amount:       transformMoney(status.Amount, nil),
+		}
+	}
+
+	reportSpecs.Travelers = make([]specs.CompanyReportTraveler, len(report.Travelers))
+	for i, traveler := range report.Travelers {
+		reportSpecs.Travelers[i] = specs.CompanyReportTraveler{
+			UserId:       traveler.User.Id.String(),
+			Name:         traveler.User.Name,
+			CountTickets: traveler.CountTickets,
+			Amount:       transformMoney(traveler.Amount, nil),
+		}
+	}
+
+	return &reportSpecs
+}
+
+func transformCompanyReportRow(row *ticketsDomain.CompanyReportRow) *specs.CompanyReportRow {
+
+	return &specs.CompanyReportRow{
+		CountTickets: row.CountTickets,
+		Amount:       transformMoney(row.Amount, nil),
+	}
+}
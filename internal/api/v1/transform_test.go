@@ -1,12 +1,16 @@
 package v1
 
 import (
+	"encoding/base64"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
 	ticketsDomain "homework/internal/domain/tickets"
 	"homework/internal/util/terr"
 	"homework/specs"
@@ -64,6 +68,191 @@ func Test_ConvertStringToUuid(t *testing.T) {
 
 }
 
+func Test_ConvertStringToLocation(t *testing.T) {
+
+	// Arrange
+	cityId := uuid.MustParse("6ac8fa15-a3d7-4b5f-a6e5-5bce49da4647")
+
+	var tests = []struct {
+		name     string
+		args     string
+		wantId   uuid.UUID
+		wantCode string
+		isErr    bool
+	}{
+		{
+			name:   "success/city id",
+			args:   cityId.String(),
+			wantId: cityId,
+		},
+		{
+			name:     "success/iata code",
+			args:     "mow",
+			wantCode: "MOW",
+		},
+		{
+			name:     "success/icao code",
+			args:     "UUEE",
+			wantCode: "UUEE",
+		},
+		{
+			name:  "fail/code with digits",
+			args:  "SV0",
+			isErr: true,
+		},
+		{
+			name:  "fail/too long code",
+			args:  "MOSCOW",
+			isErr: true,
+		},
+		{
+			name:  "fail/empty value",
+			args:  "",
+			isErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Act
+			gotId, gotCode, err := convertStringToLocation(tt.args)
+
+			// Assert
+			assert.Equal(t, tt.isErr, err != nil)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.wantId, gotId)
+			assert.Equal(t, tt.wantCode, gotCode)
+		})
+	}
+}
+
+func Test_TransformFlightLocalDates(t *testing.T) {
+
+	// Arrange
+	departureDate := time.Date(2022, 12, 2, 17, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name               string
+		departureTimeZone  string
+		arrivalTimeZone    string
+		wantDepartureLocal string
+		wantArrivalLocal   string
+	}{
+		{
+			name:               "success/airports in different time zones",
+			departureTimeZone:  "Europe/Moscow",
+			arrivalTimeZone:    "Asia/Novosibirsk",
+			wantDepartureLocal: "2022-12-02T20:00:00+03:00",
+			wantArrivalLocal:   "2022-12-03T05:00:00+07:00",
+		},
+		{
+			name:               "success/unknown time zone in UTC",
+			departureTimeZone:  "",
+			arrivalTimeZone:    "Mars/Olympus",
+			wantDepartureLocal: "2022-12-02T17:00:00Z",
+			wantArrivalLocal:   "2022-12-02T22:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			flight := &flightsDomain.Flight{
+				DepartureAirport: flightsDomain.Airport{TimeZone: tt.departureTimeZone},
+				ArrivalAirport:   flightsDomain.Airport{TimeZone: tt.arrivalTimeZone},
+				DepartureDate:    departureDate,
+				Duration:         5 * time.Hour,
+			}
+
+			// Act
+			got := transformFlight(flight, nil)
+
+			// Assert
+			assert.Equal(t, departureDate, got.Date.Departure)
+			assert.Equal(t, departureDate.Add(5*time.Hour), got.Date.Arrival)
+			assert.Equal(t, tt.wantDepartureLocal, got.Date.DepartureLocal.Format(time.RFC3339))
+			assert.Equal(t, tt.wantArrivalLocal, got.Date.ArrivalLocal.Format(time.RFC3339))
+		})
+	}
+}
+
+func Test_TransformMoney(t *testing.T) {
+
+	// Arrange
+	rateRubUsd := &money.ExchangeRate{From: money.CurrencyRUB, To: money.CurrencyUSD, Rate: 0.0125}
+	rateUsdRub := &money.ExchangeRate{From: money.CurrencyUSD, To: money.CurrencyRUB, Rate: 80}
+	displayAmount := int64(7500)
+	displayCurrency := money.CurrencyUSD
+
+	var tests = []struct {
+		name        string
+		args        money.Money
+		displayRate *money.ExchangeRate
+		want        specs.Money
+	}{
+		{
+			name:        "without display rate",
+			args:        money.New(600000, money.CurrencyRUB),
+			displayRate: nil,
+			want:        specs.Money{Amount: 600000, Currency: money.CurrencyRUB},
+		},
+		{
+			name:        "with display rate",
+			args:        money.New(600000, money.CurrencyRUB),
+			displayRate: rateRubUsd,
+			want:        specs.Money{Amount: 600000, Currency: money.CurrencyRUB, DisplayAmount: &displayAmount, DisplayCurrency: &displayCurrency},
+		},
+		{
+			name:        "display rate of other currency",
+			args:        money.New(600000, money.CurrencyRUB),
+			displayRate: rateUsdRub,
+			want:        specs.Money{Amount: 600000, Currency: money.CurrencyRUB},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := transformMoney(tt.args, tt.displayRate)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_TransformPriceBreakdown(t *testing.T) {
+
+	// Arrange
+	rate := money.ExchangeRate{From: money.CurrencyRUB, To: money.CurrencyRUB, Rate: 1}
+	ticket := &ticketsDomain.Ticket{
+		Price:        money.New(805000, money.CurrencyRUB),
+		ExchangeRate: rate,
+		Items: []ticketsDomain.TicketItem{
+			{Type: ticketsDomain.TicketItemTypeFare, Price: money.New(600000, money.CurrencyRUB)},
+			{Type: flightsDomain.TaxFeeTypeAirportTax, Price: money.New(45000, money.CurrencyRUB)},
+			{Type: flightsDomain.TaxFeeTypeCountryTax, Price: money.New(20000, money.CurrencyRUB)},
+			{Type: flightsDomain.TaxFeeTypeFuelSurcharge, Price: money.New(120000, money.CurrencyRUB)},
+			{Type: flightsDomain.AncillaryTypeExtraBaggage, Price: money.New(80000, money.CurrencyRUB)},
+			{Type: ticketsDomain.TicketItemTypePromoDiscount, Price: money.New(-60000, money.CurrencyRUB)},
+		},
+	}
+
+	// Act
+	got := transformPriceBreakdown(ticket)
+
+	// Assert
+	assert.Equal(t, specs.Money{Amount: 600000, Currency: money.CurrencyRUB}, got.Fare)
+	assert.Equal(t, specs.Money{Amount: 65000, Currency: money.CurrencyRUB}, got.Taxes)
+	assert.Equal(t, specs.Money{Amount: 120000, Currency: money.CurrencyRUB}, got.Fees)
+	assert.Equal(t, specs.Money{Amount: 80000, Currency: money.CurrencyRUB}, got.Ancillaries)
+	assert.Equal(t, specs.Money{Amount: -60000, Currency: money.CurrencyRUB}, got.Discounts)
+	assert.Equal(t, specs.Money{Amount: ticket.Price.Amount, Currency: money.CurrencyRUB}, got.Total)
+}
+
 func Test_TransformParamsPayForTicket(t *testing.T) {
 
 	// Arrange
@@ -75,7 +264,7 @@ func Test_TransformParamsPayForTicket(t *testing.T) {
 	validParamsPayForTicket := &ticketsDomain.ParamsPayForTicket{
 		TicketId:        uuid.MustParse("6ac8fa15-a3d7-4b5f-a6e5-5bce49da4647"),
 		UserId:          uuid.MustParse("fdef87aa-7694-47c6-a5cd-50984326a071"),
-		PaidWithBonuses: 100,
+		PaidWithBonuses: money.New(100, money.BaseCurrency),
 	}
 
 	invalidTicketIdString := "123"
@@ -119,3 +308,216 @@ func Test_TransformParamsPayForTicket(t *testing.T) {
 	}
 
 }
+
+func Test_TransformParamsCreateCompanyInvoice(t *testing.T) {
+
+	// Arrange
+	companyIdString := "1b7e5c3a-0d2f-4e8b-9a61-3c5d7e9f1a2b"
+	companyId := uuid.MustParse(companyIdString)
+
+	var tests = []struct {
+		name   string
+		period string
+		want   *ticketsDomain.ParamsCreateCompanyInvoice
+		err    error
+	}{
+		{
+			name:   "success",
+			period: "2022-12",
+			want: &ticketsDomain.ParamsCreateCompanyInvoice{
+				CompanyId:  companyId,
+				PeriodFrom: time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC),
+			},
+			err: nil,
+		},
+		{
+			name:   "fail/invalid period",
+			period: "2022-12-01",
+			want:   nil,
+			err:    terr.BadRequest("INVALID_INVOICE_PERIOD", "period must be in format YYYY-MM"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Act
+			got, err := transformParamsCreateCompanyInvoice(companyIdString, &specs.ParamsCreateCompanyInvoice{Period: tt.period})
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			tt.want.Timestamp = got.Timestamp
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_TransformPriceHold(t *testing.T) {
+
+	// Arrange
+	reminderTimestamp := time.Date(2022, 12, 5, 16, 0, 0, 0, time.UTC)
+	priceHold := &ticketsDomain.PriceHold{
+		Id:                uuid.MustParse("9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"),
+		TicketId:          uuid.MustParse("0e7c1d5a-3b2f-4f7e-8a9c-6d1e2f3a4b5c"),
+		UserId:            uuid.MustParse("07d87607-1f06-4599-8af5-07229525c106"),
+		Fee:               money.New(1500, money.CurrencyUSD),
+		Status:            ticketsDomain.PriceHoldStatusActive,
+		HoldTimestamp:     time.Date(2022, 12, 2, 22, 0, 0, 0, time.UTC),
+		ExpiryTimestamp:   time.Date(2022, 12, 5, 22, 0, 0, 0, time.UTC),
+		ReminderTimestamp: &reminderTimestamp,
+	}
+
+	// Act
+	got := transformPriceHold(priceHold)
+
+	// Assert
+	assert.Equal(t, &specs.PriceHold{
+		Id:                "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d",
+		TicketId:          "0e7c1d5a-3b2f-4f7e-8a9c-6d1e2f3a4b5c",
+		UserId:            "07d87607-1f06-4599-8af5-07229525c106",
+		Fee:               transformMoney(money.New(1500, money.CurrencyUSD), nil),
+		Status:            "active",
+		HoldTimestamp:     priceHold.HoldTimestamp,
+		ExpiryTimestamp:   priceHold.ExpiryTimestamp,
+		ReminderTimestamp: &reminderTimestamp,
+	}, got)
+}
+
+func Test_DecodeTicketsCursor(t *testing.T) {
+
+	// Arrange
+	validCursor := &ticketsDomain.TicketsCursor{
+		DepartureDate: time.Date(2022, 12, 20, 17, 30, 0, 0, time.UTC),
+		TicketId:      uuid.MustParse("6ac8fa15-a3d7-4b5f-a6e5-5bce49da4647"),
+	}
+	validCursorString := encodeTicketsCursor(validCursor)
+	invalidCursorString := base64.RawURLEncoding.EncodeToString([]byte("2022-12-20"))
+
+	var tests = []struct {
+		name string
+		args string
+		want *ticketsDomain.TicketsCursor
+		err  error
+	}{
+		{
+			name: "success",
+			args: validCursorString,
+			want: validCursor,
+			err:  nil,
+		},
+		{
+			name: "fail/invalid cursor format",
+			args: invalidCursorString,
+			want: nil,
+			err:  errors.New("invalid cursor format"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := decodeTicketsCursor(tt.args)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.True(t, tt.want.DepartureDate.Equal(got.DepartureDate))
+			assert.Equal(t, tt.want.TicketId, got.TicketId)
+		})
+	}
+}
+
+func Test_TransformParamsGetUserTickets(t *testing.T) {
+
+	// Arrange
+	userIdString := "07d87607-1f06-4599-8af5-07229525c106"
+	userId := uuid.MustParse(userIdString)
+	cursor := &ticketsDomain.TicketsCursor{
+		DepartureDate: time.Date(2022, 12, 20, 17, 30, 0, 0, time.UTC),
+		TicketId:      uuid.MustParse("6ac8fa15-a3d7-4b5f-a6e5-5bce49da4647"),
+	}
+	cursorString := encodeTicketsCursor(cursor)
+	invalidCursorString := "not a cursor"
+	statuses := []string{"Paid", "Registered"}
+	periodUpcoming := specs.GetUserTicketsParamsPeriodUpcoming
+	periodPast := specs.GetUserTicketsParamsPeriodPast
+	periodInvalid := specs.GetUserTicketsParamsPeriod("tomorrow")
+	limit := 50
+	limitZero := 0
+	limitTooLarge := 101
+
+	var tests = []struct {
+		name string
+		args specs.GetUserTicketsParams
+		want *ticketsDomain.ParamsGetUserTickets
+		err  error
+	}{
+		{
+			name: "success/default limit",
+			args: specs.GetUserTicketsParams{},
+			want: &ticketsDomain.ParamsGetUserTickets{UserId: userId, Limit: defaultLimitUserTickets},
+			err:  nil,
+		},
+		{
+			name: "success/upcoming statuses with cursor and limit",
+			args: specs.GetUserTicketsParams{Status: &statuses, Period: &periodUpcoming, Cursor: &cursorString, Limit: &limit},
+			want: &ticketsDomain.ParamsGetUserTickets{UserId: userId, StatusesNames: statuses, Period: ticketsDomain.TicketsPeriodUpcoming, Cursor: cursor, Limit: limit},
+			err:  nil,
+		},
+		{
+			name: "success/past",
+			args: specs.GetUserTicketsParams{Period: &periodPast},
+			want: &ticketsDomain.ParamsGetUserTickets{UserId: userId, Period: ticketsDomain.TicketsPeriodPast, Limit: defaultLimitUserTickets},
+			err:  nil,
+		},
+		{
+			name: "fail/invalid period",
+			args: specs.GetUserTicketsParams{Period: &periodInvalid},
+			want: nil,
+			err:  terr.BadRequest("INVALID_PERIOD", "period must be upcoming or past"),
+		},
+		{
+			name: "fail/invalid cursor",
+			args: specs.GetUserTicketsParams{Cursor: &invalidCursorString},
+			want: nil,
+			err:  terr.BadRequest("INVALID_CURSOR", "illegal base64 data at input byte 3"),
+		},
+		{
+			name: "fail/zero limit",
+			args: specs.GetUserTicketsParams{Limit: &limitZero},
+			want: nil,
+			err:  terr.BadRequest("INVALID_LIMIT", "limit must be between 1 and 100"),
+		},
+		{
+			name: "fail/limit too large",
+			args: specs.GetUserTicketsParams{Limit: &limitTooLarge},
+			want: nil,
+			err:  terr.BadRequest("INVALID_LIMIT", "limit must be between 1 and 100"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := transformParamsGetUserTickets(userIdString, &tt.args)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.False(t, got.Timestamp.IsZero())
+			got.Timestamp = time.Time{}
+			if tt.want.Cursor != nil {
+				assert.True(t, tt.want.Cursor.DepartureDate.Equal(got.Cursor.DepartureDate))
+				got.Cursor.DepartureDate = tt.want.Cursor.DepartureDate
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -8,6 +8,29 @@ import (
 	"homework/specs"
 )
 
+func (a apiServer) CreateUser(w http.ResponseWriter, r *http.Request) {
+
+	paramsCreateUserSpecs := &specs.ParamsCreateUser{}
+	err := json.NewDecoder(r.Body).Decode(paramsCreateUserSpecs)
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_BODY_REQUEST", err.Error()))
+		return
+	}
+
+	paramsCreateUser := transformParamsCreateUser(paramsCreateUserSpecs)
+
+	ctx := r.Context()
+	userId, err := a.serviceRegistry.User.CreateUser(ctx, paramsCreateUser)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	createdItem := specs.CreatedItem{Id: userId.String()}
+	_ = json.NewEncoder(w).Encode(createdItem)
+
+}
+
 func (a apiServer) GetUserById(w http.ResponseWriter, r *http.Request, userIdSpecs specs.UUIDPathObjectID) {
 
 	userId, err := convertStringToUuid(string(userIdSpecs))
@@ -28,3 +51,25 @@ func (a apiServer) GetUserById(w http.ResponseWriter, r *http.Request, userIdSpe
 	_ = json.NewEncoder(w).Encode(userSpecs)
 
 }
+
+func (a apiServer) GetUserNotifications(w http.ResponseWriter, r *http.Request, userIdSpecs specs.UUIDPathObjectID) {
+
+	userId, err := convertStringToUuid(string(userIdSpecs))
+	if err != nil {
+		terr.WriteError(w, terr.BadRequest("INVALID_USER_UUID", err.Error()))
+		return
+	}
+
+	ctx := r.Context()
+	notifications, err := a.serviceRegistry.User.GetUserNotifications(ctx, userId)
+	if err != nil {
+		terr.WriteError(w, err.(*terr.Error))
+		return
+	}
+
+	notificationsSpecs := make([]specs.Notification, len(notifications))
+	for i, notification := range notifications {
+		notificationsSpecs[i] = *transformNotification(&notification)
+	}
+	_ = json.NewEncoder(w).Encode(notificationsSpecs)
+}
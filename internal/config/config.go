@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
@@ -17,8 +18,32 @@ type Config struct {
 	DB       struct {
 		Postgresql string `yaml:"postgresql"`
 	} `yaml:"db"`
+	SeatHold struct {
+		TTL time.Duration `yaml:"ttl"`
+	} `yaml:"seat_hold"`
+	Waitlist struct {
+		Interval time.Duration `yaml:"interval"`
+	} `yaml:"waitlist"`
+	Gateway struct {
+		Token string `yaml:"token"`
+	} `yaml:"gateway"`
+	Seller struct {
+		Name    string `yaml:"name"`
+		TaxId   string `yaml:"tax_id"`
+		Address string `yaml:"address"`
+		Email   string `yaml:"email"`
+	} `yaml:"seller"`
 }
 
+// время удержания места по умолчанию, если не задано в конфигурации
+const defaultSeatHoldTTL = 5 * time.Minute
+
+// интервал обработки листа ожидания по умолчанию, если не задан в конфигурации
+const defaultWaitlistInterval = time.Minute
+
+// переменная окружения с токеном шлюза: токен не хранится в файле конфигурации в репозитории
+const envGatewayToken = "GATEWAY_TOKEN"
+
 func InitConfig(args []string) (*Config, error) {
 	var configPath string
 
@@ -41,5 +66,15 @@ func InitConfig(args []string) (*Config, error) {
 		return nil, fmt.Errorf("fail to parse config %w", err)
 	}
 
+	if cfg.SeatHold.TTL <= 0 {
+		cfg.SeatHold.TTL = defaultSeatHoldTTL
+	}
+	if cfg.Waitlist.Interval <= 0 {
+		cfg.Waitlist.Interval = defaultWaitlistInterval
+	}
+	if token := os.Getenv(envGatewayToken); token != "" {
+		cfg.Gateway.Token = token
+	}
+
 	return &cfg, nil
 }
@@ -3,6 +3,8 @@ package flights
 import (
 	"github.com/google/uuid"
 	"time"
+
+	"homework/internal/domain/money"
 )
 
 type Airline struct {
@@ -21,10 +23,12 @@ type City struct {
 	Name string
 }
 
+// аэропорт. TimeZone - часовой пояс аэропорта (IANA), в котором выводится местное время вылета и прилета
 type Airport struct {
-	Id   uuid.UUID
-	City City
-	Name string
+	Id       uuid.UUID
+	City     City
+	Name     string
+	TimeZone string
 }
 
 type ClassSeats struct {
@@ -37,18 +41,32 @@ type ClassSeats struct {
 	CountInRow int
 }
 
+// место в самолете. места ряда у аварийного выхода (IsExitRow) не назначаются детям и взрослым с младенцами
 type Seat struct {
 	Id         uuid.UUID
 	ClassSeats ClassSeats
 	Number     string
+	IsExitRow  bool
 }
 
+// цена класса мест рейса.
+// OverbookingPercent - допустимая продажа билетов без места сверх количества мест класса, %.
+// CountVacantSeats учитывает места, доступные для продажи с учетом овербукинга.
+// PriceTotal - стоимость билета взрослого с учетом сборов рейса.
+// PriceHoldMaxHours - максимальный срок удержания цены билета в часах (0 - удержание недоступно), PriceHoldFee - плата за удержание
 type FlightPrice struct {
-	ClassSeats       ClassSeats
-	CountVacantSeats int
-	PriceTicket      int
+	ClassSeats            ClassSeats
+	CountVacantSeats      int
+	PriceTicket           money.Money
+	PriceTotal            money.Money
+	ChildDiscountPercent  int
+	InfantDiscountPercent int
+	OverbookingPercent    int
+	PriceHoldMaxHours     int
+	PriceHoldFee          money.Money
 }
 
+// рейс. Currency - валюта цен рейса: цен билетов, дополнительного багажа, выбора места и дополнительных услуг
 type Flight struct {
 	Id                     uuid.UUID
 	Name                   string
@@ -57,23 +75,181 @@ type Flight struct {
 	ArrivalAirport         Airport
 	DepartureDate          time.Time
 	Duration               time.Duration
+	Currency               string
 	PricesTickets          []FlightPrice
-	PriceAdditionalBaggage int
-	PriceSeatSelection     int
+	PriceAdditionalBaggage money.Money
+	PriceSeatSelection     money.Money
+	TaxesFees              []FlightTaxFee
 	IsInternational        bool
 	BaggageIncluded        bool
 	PetAllowed             bool
+	MaxInfants             int
+	ClosedTimestamp        *time.Time
+	Status                 FlightStatus
+}
+
+// типы сборов рейса
+const (
+	TaxFeeTypeAirportTax    = "airport_tax"
+	TaxFeeTypeCountryTax    = "country_tax"
+	TaxFeeTypeFuelSurcharge = "fuel_surcharge"
+	TaxFeeTypeServiceFee    = "service_fee"
+)
+
+// сбор, включаемый в стоимость каждого билета рейса отдельной позицией (таблица taxes_fees).
+// сумма сбора задается в валюте сбора и пересчитывается в валюту цен рейса
+type FlightTaxFee struct {
+	Id    uuid.UUID
+	Type  string
+	Name  string
+	Price money.Money
+}
+
+// операционные состояния рейса
+const (
+	FlightStateOnTime    = "on_time"
+	FlightStateDelayed   = "delayed"
+	FlightStateBoarding  = "boarding"
+	FlightStateDeparted  = "departed"
+	FlightStateCancelled = "cancelled"
+)
+
+// операционный статус рейса. время вылета и прилета по расписанию - DepartureDate и DepartureDate + Duration рейса.
+// пока статус не обновлялся, рейс в состоянии on_time и UpdatedTimestamp не заполнено
+type FlightStatus struct {
+	State              string
+	EstimatedDeparture *time.Time
+	ActualDeparture    *time.Time
+	EstimatedArrival   *time.Time
+	ActualArrival      *time.Time
+	Terminal           string
+	Gate               string
+	BoardingTime       *time.Time
+	UpdatedTimestamp   *time.Time
+}
+
+// структура, содержащая параметры метода UpdateFlightStatus
+type ParamsUpdateFlightStatus struct {
+	Timestamp          time.Time
+	FlightId           uuid.UUID
+	Role               string
+	State              string
+	EstimatedDeparture *time.Time
+	ActualDeparture    *time.Time
+	EstimatedArrival   *time.Time
+	ActualArrival      *time.Time
+	Terminal           string
+	Gate               string
+	BoardingTime       *time.Time
+}
+
+// типы дополнительных услуг рейса
+const (
+	AncillaryTypeExtraBaggage     = "extra_baggage"
+	AncillaryTypeSeatSelection    = "seat_selection"
+	AncillaryTypePetInCabin       = "pet_in_cabin"
+	AncillaryTypePriorityBoarding = "priority_boarding"
+	AncillaryTypeMeal             = "meal"
+)
+
+// дополнительная услуга из каталога рейса.
+// дополнительный багаж и выбор места продаются по ценам рейса PriceAdditionalBaggage и PriceSeatSelection и в каталог не входят
+type FlightAncillary struct {
+	Id        uuid.UUID
+	FlightId  uuid.UUID
+	Type      string
+	Name      string
+	Price     money.Money
+	MaxCount  *int
+	CountSold int
+}
+
+// временное удержание места или квоты класса мест рейса на время оформления билета.
+// если место не задано, то удерживается одно место класса без выбора конкретного места
+type SeatHold struct {
+	Id           uuid.UUID
+	FlightId     uuid.UUID
+	ClassSeatsId uuid.UUID
+	SeatId       *uuid.UUID
+	UserId       uuid.UUID
+	Timestamp    time.Time
+	ExpiresAt    time.Time
+}
+
+// структура, содержащая параметры метода CreateSeatHold
+type ParamsCreateSeatHold struct {
+	Timestamp    time.Time
+	FlightId     uuid.UUID
+	UserId       uuid.UUID
+	ClassSeatsId uuid.UUID
+	SeatId       *uuid.UUID
+	ExpiresAt    time.Time
+}
+
+// класс мест рейса, на который продано билетов больше, чем мест в классе (овербукинг).
+// CountOversold - количество пассажиров, которым может быть отказано в посадке
+type OversoldClassSeats struct {
+	FlightId           uuid.UUID
+	FlightName         string
+	DepartureDate      time.Time
+	ClassSeatsId       uuid.UUID
+	ClassSeatsName     string
+	CountSeats         int
+	OverbookingPercent int
+	CountTickets       int
+	CountOversold      int
 }
 
-// структура, содержащая параметры метода GetFlights
+// структура, содержащая параметры метода GetOversoldFlights
+type ParamsGetOversoldFlights struct {
+	Timestamp         time.Time
+	DepartureDateFrom *time.Time
+	DepartureDateTo   *time.Time
+}
+
+// структура, содержащая параметры метода GetFlights.
+// вместо id города может передаваться код IATA/ICAO города или аэропорта (DepartureCode, ArrivalCode).
+// для кода аэропорта рейсы отбираются по аэропорту (DepartureAirportId, ArrivalAirportId)
 type ParamsGetFlights struct {
-	DepartureCityId uuid.UUID
-	ArrivalCityId   uuid.UUID
-	DepartureDate   time.Time
+	DepartureCityId    uuid.UUID
+	ArrivalCityId      uuid.UUID
+	DepartureCode      string
+	ArrivalCode        string
+	DepartureAirportId *uuid.UUID
+	ArrivalAirportId   *uuid.UUID
+	DepartureDate      time.Time
+}
+
+// типы мест поиска
+const (
+	LocationTypeCity    = "city"
+	LocationTypeAirport = "airport"
+)
+
+// место поиска рейсов: город или аэропорт.
+// для города City совпадает с самим городом, код ICAO не заполняется
+type Location struct {
+	Type        string
+	Id          uuid.UUID
+	Name        string
+	IataCode    string
+	IcaoCode    string
+	City        City
+	CountryCode string
+	Latitude    *float64
+	Longitude   *float64
+}
+
+// структура, содержащая параметры метода SearchLocations
+type ParamsSearchLocations struct {
+	Query string
+	Limit int
 }
 
 // структура, используемая как вывода результата метода GetFlightVacantSeats,
-// а также для проверок при создании и регистрации билета
+// а также для проверок при создании и регистрации билета.
+// CountVacantSeats - количество мест, доступных для продажи с учетом овербукинга,
+// Seats - свободные физические места, поэтому их может быть меньше, чем CountVacantSeats
 type VacantSeats struct {
 	ClassSeatsId     uuid.UUID
 	ClassSeatsName   string
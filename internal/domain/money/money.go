@@ -0,0 +1,136 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// коды валют ISO 4217
+const (
+	CurrencyRUB = "RUB"
+	CurrencyUSD = "USD"
+	CurrencyEUR = "EUR"
+	CurrencyCNY = "CNY"
+	CurrencyKZT = "KZT"
+	CurrencyTRY = "TRY"
+	CurrencyAED = "AED"
+	CurrencyJPY = "JPY"
+)
+
+// BaseCurrency - валюта учета: баланс пользователя, бонусы и шкала начисления бонусов
+const BaseCurrency = CurrencyRUB
+
+// количество знаков минимальных единиц поддерживаемых валют (копейки, центы; у иены минимальных единиц нет)
+var currenciesMinorUnits = map[string]int{
+	CurrencyRUB: 2,
+	CurrencyUSD: 2,
+	CurrencyEUR: 2,
+	CurrencyCNY: 2,
+	CurrencyKZT: 2,
+	CurrencyTRY: 2,
+	CurrencyAED: 2,
+	CurrencyJPY: 0,
+}
+
+// IsValidCurrency проверяет, что валюта поддерживается
+func IsValidCurrency(currency string) bool {
+	_, ok := currenciesMinorUnits[currency]
+	return ok
+}
+
+// Money - денежная сумма в минимальных единицах валюты (копейках, центах) с кодом валюты ISO 4217.
+// арифметические операции выполняются только над суммами одной валюты.
+// нулевое значение Money{} - нулевая сумма без валюты, при сложении принимает валюту второго слагаемого
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// currencyWith возвращает общую валюту двух сумм. суммы разных валют складывать нельзя:
+// это ошибка программы, а не данных, поэтому вызывается паника
+func (m Money) currencyWith(other Money) string {
+	switch {
+	case m.Currency == "":
+		return other.Currency
+	case other.Currency == "" || other.Currency == m.Currency:
+		return m.Currency
+	}
+	panic(fmt.Sprintf("money: currency mismatch %s and %s", m.Currency, other.Currency))
+}
+
+func (m Money) Add(other Money) Money {
+	return Money{Amount: m.Amount + other.Amount, Currency: m.currencyWith(other)}
+}
+
+func (m Money) Sub(other Money) Money {
+	return Money{Amount: m.Amount - other.Amount, Currency: m.currencyWith(other)}
+}
+
+// Mul умножает сумму на количество, например, цену услуги на количество услуг
+func (m Money) Mul(quantity int) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+// Percent возвращает процент от суммы. дробная часть минимальной единицы отбрасывается
+func (m Money) Percent(percent int) Money {
+	return Money{Amount: m.Amount * int64(percent) / 100, Currency: m.Currency}
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Convert переводит сумму в валюту курса. результат округляется до минимальной единицы валюты курса.
+// сумма в валюте курса возвращается без изменений
+func (m Money) Convert(rate ExchangeRate) Money {
+	if m.Currency == rate.To {
+		return m
+	}
+	if m.Currency != rate.From {
+		panic(fmt.Sprintf("money: currency %s doesn't match exchange rate %s/%s", m.Currency, rate.From, rate.To))
+	}
+	amount := float64(m.Amount) * rate.Rate *
+		math.Pow10(currenciesMinorUnits[rate.To]-currenciesMinorUnits[rate.From])
+	return Money{Amount: int64(math.Round(amount)), Currency: rate.To}
+}
+
+// String выводит сумму в основных единицах валюты, например, "1234.50 RUB"
+func (m Money) String() string {
+	minorUnits := currenciesMinorUnits[m.Currency]
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	if minorUnits == 0 {
+		return strings.TrimSpace(fmt.Sprintf("%s%d %s", sign, amount, m.Currency))
+	}
+	divider := int64(math.Pow10(minorUnits))
+	return strings.TrimSpace(fmt.Sprintf("%s%d.%0*d %s", sign, amount/divider, minorUnits, amount%divider, m.Currency))
+}
+
+// ExchangeRate - курс валюты From к валюте To: количество основных единиц To за одну основную единицу From.
+// Timestamp - время установки курса
+type ExchangeRate struct {
+	From      string
+	To        string
+	Rate      float64
+	Timestamp time.Time
+}
+
+// NewIdentityRate возвращает курс валюты к самой себе
+func NewIdentityRate(currency string, timestamp time.Time) ExchangeRate {
+	return ExchangeRate{From: currency, To: currency, Rate: 1, Timestamp: timestamp}
+}
+
+// Invert возвращает обратный курс
+func (r ExchangeRate) Invert() ExchangeRate {
+	return ExchangeRate{From: r.To, To: r.From, Rate: 1 / r.Rate, Timestamp: r.Timestamp}
+}
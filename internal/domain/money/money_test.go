@@ -0,0 +1,74 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Convert(t *testing.T) {
+
+	// Arrange
+	var tests = []struct {
+		name string
+		args Money
+		rate ExchangeRate
+		want Money
+	}{
+		{
+			name: "same currency",
+			args: New(600000, CurrencyRUB),
+			rate: ExchangeRate{From: CurrencyUSD, To: CurrencyRUB, Rate: 80},
+			want: New(600000, CurrencyRUB),
+		},
+		{
+			name: "rub to usd",
+			args: New(600000, CurrencyRUB),
+			rate: ExchangeRate{From: CurrencyRUB, To: CurrencyUSD, Rate: 0.0125},
+			want: New(7500, CurrencyUSD),
+		},
+		{
+			name: "rub to jpy without minor units",
+			args: New(600050, CurrencyRUB),
+			rate: ExchangeRate{From: CurrencyRUB, To: CurrencyJPY, Rate: 1.5},
+			want: New(9001, CurrencyJPY),
+		},
+		{
+			name: "jpy to rub by inverted rate",
+			args: New(9000, CurrencyJPY),
+			rate: ExchangeRate{From: CurrencyRUB, To: CurrencyJPY, Rate: 1.5}.Invert(),
+			want: New(600000, CurrencyRUB),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := tt.args.Convert(tt.rate)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Arithmetic(t *testing.T) {
+
+	// Arrange
+	price := New(600000, CurrencyRUB)
+
+	// Act, Assert
+	assert.Equal(t, price, Money{}.Add(price))
+	assert.Equal(t, New(450000, CurrencyRUB), price.Sub(price.Percent(25)))
+	assert.Equal(t, New(1800000, CurrencyRUB), price.Mul(3))
+	assert.Equal(t, New(33, CurrencyRUB), New(333, CurrencyRUB).Percent(10))
+	assert.Panics(t, func() { price.Add(New(100, CurrencyUSD)) })
+}
+
+func Test_String(t *testing.T) {
+
+	// Act, Assert
+	assert.Equal(t, "1234.50 RUB", New(123450, CurrencyRUB).String())
+	assert.Equal(t, "-0.05 USD", New(-5, CurrencyUSD).String())
+	assert.Equal(t, "9001 JPY", New(9001, CurrencyJPY).String())
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 
 	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
 	usersDomain "homework/internal/domain/users"
 )
 
@@ -15,61 +16,236 @@ type Status struct {
 	Timestamp time.Time
 }
 
+// типы документов, удостоверяющих личность пассажира
+const (
+	DocumentTypePassport         = "passport"
+	DocumentTypeNationalId       = "national_id"
+	DocumentTypeBirthCertificate = "birth_certificate"
+)
+
+// документ, удостоверяющий личность пассажира.
+// страны (выдачи документа и гражданства) - коды ISO 3166-1 alpha-2
+type IdentityDocument struct {
+	Type           string
+	Number         string
+	IssuingCountry string
+	Nationality    string
+	BirthDate      time.Time
+	ExpiryDate     *time.Time
+}
+
+// адрес пребывания пассажира в стране назначения
+type DestinationAddress struct {
+	Country    string
+	City       string
+	Address    string
+	PostalCode string
+}
+
+// предварительная информация о пассажире (APIS - Advance Passenger Information) для международного рейса.
+// если документ не передан, используется документ пассажира, указанный в билете
+type ApisData struct {
+	Document           *IdentityDocument
+	DestinationAddress DestinationAddress
+}
+
+// типы пассажиров по возрасту на дату вылета
+const (
+	PassengerTypeAdult  = "adult"
+	PassengerTypeChild  = "child"
+	PassengerTypeInfant = "infant"
+)
+
 type Passenger struct {
 	Id                    uuid.UUID
 	User                  usersDomain.User
 	NamePassenger         string
 	IdentityDataPassenger string
+	Document              *IdentityDocument
+}
+
+// типы позиций состава стоимости билета: тариф и скидка по промокоду (отрицательная сумма).
+// остальные позиции - сборы рейса (flightsDomain.TaxFeeType...) и дополнительные услуги рейса (flightsDomain.AncillaryType...)
+const (
+	TicketItemTypeFare          = "fare"
+	TicketItemTypePromoDiscount = "promo_discount"
+)
+
+// позиция состава стоимости билета. TaxFeeId заполняется для сбора, FlightAncillaryId - для услуги каталога рейса
+type TicketItem struct {
+	Id                uuid.UUID
+	Type              string
+	Name              string
+	TaxFeeId          *uuid.UUID
+	FlightAncillaryId *uuid.UUID
+	Quantity          int
+	Price             money.Money
+	Timestamp         time.Time
+}
+
+// способы оплаты билета
+const (
+	PaymentMethodBonuses         = "bonuses"
+	PaymentMethodGiftCertificate = VoucherTypeGiftCertificate
+	PaymentMethodTravelCredit    = VoucherTypeTravelCredit
+	PaymentMethodCard            = "card"
+	PaymentMethodInvoice         = "invoice"
+)
+
+// оплата билета одним способом оплаты в валюте учета. VoucherId и VoucherCode заполняются для оплаты сертификатом или кредитом,
+// InvoiceId - для оплаты по счету компании, включенной в счет
+type TicketPayment struct {
+	Id              uuid.UUID
+	Method          string
+	VoucherId       *uuid.UUID
+	VoucherCode     string
+	InvoiceId       *uuid.UUID
+	Amount          money.Money
+	Timestamp       time.Time
+	RefundTimestamp *time.Time
 }
 
+// билет. стоимость Price и позиции состава стоимости - в валюте цен рейса,
+// бонусы PaidWithBonuses и AccruedBonuses - в валюте учета.
+// ExchangeRate - курс валюты билета к валюте учета, зафиксированный при оформлении билета
 type Ticket struct {
 	Id                     uuid.UUID
 	Status                 Status
 	Flight                 flightsDomain.Flight
 	User                   usersDomain.User
 	Passenger              Passenger
+	PassengerType          string
+	AccompanyingTicketId   *uuid.UUID
 	ClassSeats             flightsDomain.ClassSeats
 	Seat                   *flightsDomain.Seat
 	CountAdditionalBaggage int
-	Price                  int
-	PaidWithBonuses        int
-	AccruedBonuses         int
+	Price                  money.Money
+	PaidWithBonuses        money.Money
+	AccruedBonuses         money.Money
+	ExchangeRate           money.ExchangeRate
+	Items                  []TicketItem
+	Payments               []TicketPayment
+	PromoCodeId            *uuid.UUID
+	PromoCode              string
+	BoardingPassCode       string
+	Company                *TicketCompany
+	PriceHold              *PriceHold
+}
+
+// страница списка билетов пользователя
+type TicketsPage struct {
+	Tickets    []Ticket
+	NextCursor *TicketsCursor
+}
+
+// курсор постраничного вывода билетов: дата вылета и id последнего билета страницы
+type TicketsCursor struct {
+	DepartureDate time.Time
+	TicketId      uuid.UUID
 }
 
+// период вылета рейсов в отборе билетов пользователя
+const (
+	TicketsPeriodUpcoming = "upcoming"
+	TicketsPeriodPast     = "past"
+)
+
 // структуры, содержащие параметры методов:
 
+type ParamsGetUserTickets struct {
+	Timestamp         time.Time
+	UserId            uuid.UUID
+	StatusesNames     []string
+	Period            string
+	DepartureDateFrom *time.Time
+	DepartureDateTo   *time.Time
+	Cursor            *TicketsCursor
+	Limit             int
+}
+
 type ParamsCreateTicket struct {
 	StatusTimestamp        time.Time
 	FlightId               uuid.UUID
 	UserId                 uuid.UUID
 	PassengerId            *uuid.UUID
 	ParamsCreatePassenger  *ParamsCreatePassenger
+	PassengerType          string
+	AccompanyingTicketId   *uuid.UUID
 	ClassSeatsId           uuid.UUID
 	SeatId                 *uuid.UUID
+	SeatHoldId             *uuid.UUID
 	CountAdditionalBaggage int
-	Price                  int
+	PromoCode              string
+	PromoCodeId            *uuid.UUID
+	CompanyId              *uuid.UUID
+	BookerId               *uuid.UUID
+	ApprovalStatus         string
+	PolicyViolations       []string
+	Price                  money.Money
+	ExchangeRate           money.ExchangeRate
+	Items                  []TicketItem
 }
 
 type ParamsCreatePassenger struct {
+	Timestamp             time.Time
+	UserId                uuid.UUID
 	NamePassenger         string
 	IdentityDataPassenger string
+	Document              IdentityDocument
 }
 
+type ParamsUpdatePassenger struct {
+	Timestamp             time.Time
+	PassengerId           uuid.UUID
+	UserId                uuid.UUID
+	NamePassenger         string
+	IdentityDataPassenger string
+	Document              IdentityDocument
+}
+
+type ParamsDeletePassenger struct {
+	Timestamp   time.Time
+	PassengerId uuid.UUID
+	UserId      uuid.UUID
+}
+
+// оплата билета. суммы в валюте учета: стоимость билета Price пересчитывается по курсу, зафиксированному в билете.
+// PromoCode и PromoDiscount (скидка в валюте билета) заполняются, если при оформлении билета был применен промокод:
+// использование промокода фиксируется вместе с оплатой
 type ParamsPayForTicket struct {
 	StatusTimestamp time.Time
 	TicketId        uuid.UUID
 	UserId          uuid.UUID
 	UserBalanceInit bool
-	Price           int
-	PaidWithBonuses int
-	AccruedBonuses  int
+	Price           money.Money
+	PaidWithBonuses money.Money
+	AccruedBonuses  money.Money
+	PromoCode       *PromoCode
+	PromoDiscount   money.Money
+	Vouchers        []ParamsVoucherPayment
+	Payments        []TicketPayment
+	PriceHoldId     *uuid.UUID
+}
+
+// оплата билета сертификатом или кредитом: сумма Amount в валюте учета списывается с остатка
+type ParamsVoucherPayment struct {
+	Code    string
+	Amount  money.Money
+	Voucher *Voucher
 }
 
+// возврат билета. стоимость билета Price в валюте учета по курсу, зафиксированному в билете
+// оплаты билета Payments возвращаются: сертификатами и кредитами - на их остаток, бонусами и картой - в сумму бонусов
+// пользователя RefundToBonuses. при возврате кредитом RefundAsCredit оплаченное картой возвращается кредитом TravelCredit
 type ParamsRefundTicket struct {
 	StatusTimestamp time.Time
 	TicketId        uuid.UUID
 	UserId          uuid.UUID
-	Price           int
+	RefundAsCredit  bool
+	Price           money.Money
+	Payments        []TicketPayment
+	RefundToBonuses money.Money
+	TravelCredit    *Voucher
 }
 
 type ParamsRegisterTicket struct {
@@ -77,5 +253,368 @@ type ParamsRegisterTicket struct {
 	TicketId        uuid.UUID
 	UserId          uuid.UUID
 	SeatId          *uuid.UUID
-	AccruedBonuses  int
+	Apis            *ApisData
+}
+
+// регистрация на рейс всех билетов пользователя.
+// в Tickets передаются параметры отдельных билетов (место, данные APIS), билеты без параметров регистрируются с параметрами по умолчанию
+type ParamsRegisterFlightTickets struct {
+	StatusTimestamp time.Time
+	UserId          uuid.UUID
+	FlightId        uuid.UUID
+	Tickets         []ParamsRegisterTicket
+}
+
+// посадка пассажира на рейс по коду посадочного талона или id билета
+type ParamsBoardTicket struct {
+	StatusTimestamp time.Time
+	FlightId        uuid.UUID
+	Code            string
+	TicketId        uuid.UUID
+}
+
+// закрытие рейса: окончание посадки
+type ParamsCloseFlight struct {
+	Timestamp time.Time
+	FlightId  uuid.UUID
+}
+
+// результат закрытия рейса: количество билетов по итоговым статусам
+type ClosedFlight struct {
+	FlightId        uuid.UUID
+	ClosedTimestamp time.Time
+	CountBoarded    int
+	CountNoShow     int
+	CountClosed     int
+}
+
+// пассажир в манифесте рейса: данные билета, документ (данные APIS, если собраны при регистрации, иначе документ билета)
+// и адрес пребывания в стране назначения
+type ManifestPassenger struct {
+	TicketId               uuid.UUID
+	NamePassenger          string
+	PassengerType          string
+	ClassSeatsName         string
+	SeatNumber             string
+	StatusName             string
+	CountAdditionalBaggage int
+	DocumentType           string
+	DocumentNumber         string
+	DocumentIssuingCountry string
+	Nationality            string
+	BirthDate              *time.Time
+	DocumentExpiryDate     *time.Time
+	DestinationCountry     string
+	DestinationCity        string
+	DestinationAddress     string
+}
+
+// манифест рейса: пассажиры действующих билетов рейса.
+// IsMasked - персональные данные пассажиров маскированы по роли вызывающего
+type FlightManifest struct {
+	Flight     flightsDomain.Flight
+	Timestamp  time.Time
+	IsMasked   bool
+	Passengers []ManifestPassenger
+}
+
+type ParamsGetFlightManifest struct {
+	Timestamp time.Time
+	FlightId  uuid.UUID
+	Role      string
+}
+
+type ParamsAddTicketAncillary struct {
+	Timestamp         time.Time
+	TicketId          uuid.UUID
+	UserId            uuid.UUID
+	Type              string
+	FlightAncillaryId *uuid.UUID
+	Quantity          int
+	SeatId            *uuid.UUID
+	Item              TicketItem
+	BasePrice         money.Money
+	AccruedBonuses    money.Money
+	Payment           TicketPayment
+}
+
+// статусы записи в листе ожидания
+const (
+	WaitlistStatusWaiting   = "waiting"
+	WaitlistStatusFulfilled = "fulfilled"
+	WaitlistStatusExpired   = "expired"
+	WaitlistStatusCanceled  = "canceled"
+)
+
+// запись в листе ожидания рейса по классу мест.
+// при освобождении места по записи автоматически оформляется билет со статусом 1(Created)
+type WaitlistEntry struct {
+	Id                     uuid.UUID
+	FlightId               uuid.UUID
+	ClassSeatsId           uuid.UUID
+	UserId                 uuid.UUID
+	PassengerId            uuid.UUID
+	CountAdditionalBaggage int
+	Status                 string
+	Timestamp              time.Time
+	StatusTimestamp        time.Time
+	TicketId               *uuid.UUID
+}
+
+// класс мест рейса, по которому есть ожидающие записи в листе ожидания
+type WaitlistClassSeats struct {
+	FlightId     uuid.UUID
+	ClassSeatsId uuid.UUID
+}
+
+type ParamsJoinWaitlist struct {
+	Timestamp              time.Time
+	FlightId               uuid.UUID
+	UserId                 uuid.UUID
+	PassengerId            uuid.UUID
+	ClassSeatsId           uuid.UUID
+	CountAdditionalBaggage int
+}
+
+// параметры закрытия записи листа ожидания: оформление билета (для статуса fulfilled) и уведомление пользователя
+type ParamsCloseWaitlistEntry struct {
+	StatusTimestamp    time.Time
+	WaitlistEntryId    uuid.UUID
+	Status             string
+	ParamsCreateTicket *ParamsCreateTicket
+	Notification       usersDomain.Notification
+}
+
+// типы скидки промокода
+const (
+	PromoDiscountTypePercent = "percent"
+	PromoDiscountTypeFixed   = "fixed"
+)
+
+// промокод. скидка - процент от тарифа DiscountPercent или фиксированная сумма DiscountAmount, но не больше тарифа.
+// незаполненные условия применения не проверяются. MinSpend - минимальная стоимость билета в валюте промокода
+type PromoCode struct {
+	Id                 uuid.UUID
+	Code               string
+	DiscountType       string
+	DiscountPercent    int
+	DiscountAmount     money.Money
+	MinSpend           money.Money
+	DepartureAirportId *uuid.UUID
+	ArrivalAirportId   *uuid.UUID
+	ClassSeatsName     *string
+	ValidFrom          *time.Time
+	ValidTo            *time.Time
+	MaxUses            *int
+	MaxUsesPerUser     *int
+}
+
+// количество неотмененных использований промокода: всего и пользователем
+type PromoCodeUsage struct {
+	CountUses     int
+	CountUserUses int
+}
+
+// типы сертификатов и кредитов
+const (
+	VoucherTypeGiftCertificate = "gift_certificate"
+	VoucherTypeTravelCredit    = "travel_credit"
+)
+
+// сертификат или кредит на перелет - средство оплаты билетов с остатком Balance в валюте учета.
+// кредит принадлежит пользователю UserId и выпускается при возврате билета TicketId, сертификат - на предъявителя
+type Voucher struct {
+	Id              uuid.UUID
+	Type            string
+	Code            string
+	UserId          *uuid.UUID
+	TicketId        *uuid.UUID
+	Amount          money.Money
+	Balance         money.Money
+	IssueTimestamp  time.Time
+	ExpiryTimestamp time.Time
+}
+
+// роли пользователя компании
+const (
+	CompanyRoleTraveler = "traveler"
+	CompanyRoleBooker   = "booker"
+	CompanyRoleApprover = "approver"
+)
+
+// статусы согласования билета компании
+const (
+	ApprovalStatusPending  = "pending"
+	ApprovalStatusApproved = "approved"
+	ApprovalStatusRejected = "rejected"
+)
+
+// правила поездок компании: незаполненные правила не проверяются.
+// MaxPrice - максимальная стоимость билета в валюте учета, ApprovalRequired - согласование всех билетов
+type TravelPolicy struct {
+	MaxClassSeatsName *string
+	MaxPrice          *money.Money
+	ApprovalRequired  bool
+}
+
+// пользователь компании и его роли
+type CompanyMember struct {
+	User  usersDomain.User
+	Roles []string
+}
+
+type Company struct {
+	Id           uuid.UUID
+	Name         string
+	Members      []CompanyMember
+	TravelPolicy *TravelPolicy
+}
+
+// билет компании: кто оформил билет, статус согласования и нарушения правил поездок
+type TicketCompany struct {
+	CompanyId         uuid.UUID
+	CompanyName       string
+	BookerId          *uuid.UUID
+	ApprovalStatus    string
+	PolicyViolations  []string
+	ApproverId        *uuid.UUID
+	ApprovalTimestamp *time.Time
+}
+
+// согласование (Approved) или отклонение билета компании пользователем UserId с ролью approver
+type ParamsApproveTicket struct {
+	StatusTimestamp time.Time
+	TicketId        uuid.UUID
+	UserId          uuid.UUID
+	Approved        bool
+}
+
+// счет компании за месяц: оплаты билетов по счету за период и возвраты оплат, включенных в предыдущие счета.
+// сумма Amount в валюте учета
+type CompanyInvoice struct {
+	Id             uuid.UUID
+	CompanyId      uuid.UUID
+	PeriodFrom     time.Time
+	PeriodTo       time.Time
+	Amount         money.Money
+	CountPayments  int
+	CountRefunds   int
+	IssueTimestamp time.Time
+}
+
+// выставление счета компании за месяц Period (первый день месяца)
+type ParamsCreateCompanyInvoice struct {
+	Timestamp  time.Time
+	CompanyId  uuid.UUID
+	PeriodFrom time.Time
+	PeriodTo   time.Time
+}
+
+// отчет по билетам компании с датой вылета в периоде. незаполненные границы периода не проверяются
+type ParamsGetCompanyReport struct {
+	CompanyId         uuid.UUID
+	DepartureDateFrom *time.Time
+	DepartureDateTo   *time.Time
+}
+
+// строка отчета по билетам компании: количество билетов и их стоимость в валюте учета
+type CompanyReportRow struct {
+	CountTickets int
+	Amount       money.Money
+}
+
+// строка отчета по статусу билета
+type CompanyReportStatus struct {
+	StatusName string
+	CompanyReportRow
+}
+
+// строка отчета по путешественнику
+type CompanyReportTraveler struct {
+	User usersDomain.User
+	CompanyReportRow
+}
+
+// отчет по билетам компании. Total, Travelers - по билетам, кроме отмененных и возвращенных.
+// OutOfPolicy - билеты с нарушением правил поездок, PendingApproval - билеты, ожидающие согласования
+type CompanyReport struct {
+	CompanyId       uuid.UUID
+	Total           CompanyReportRow
+	OutOfPolicy     CompanyReportRow
+	PendingApproval CompanyReportRow
+	Statuses        []CompanyReportStatus
+	Travelers       []CompanyReportTraveler
+}
+
+// статусы удержания цены билета
+const (
+	PriceHoldStatusActive  = "active"
+	PriceHoldStatusPaid    = "paid"
+	PriceHoldStatusExpired = "expired"
+)
+
+// удержание цены неоплаченного билета: билет со статусом 1(Created) можно оплатить до ExpiryTimestamp.
+// Fee - плата за удержание в валюте цен рейса, ReminderTimestamp - время напоминания об окончании удержания
+type PriceHold struct {
+	Id                uuid.UUID
+	TicketId          uuid.UUID
+	UserId            uuid.UUID
+	Fee               money.Money
+	Status            string
+	HoldTimestamp     time.Time
+	ExpiryTimestamp   time.Time
+	ReminderTimestamp *time.Time
+}
+
+type ParamsCreatePriceHold struct {
+	Timestamp       time.Time
+	TicketId        uuid.UUID
+	UserId          uuid.UUID
+	Fee             money.Money
+	ExpiryTimestamp time.Time
+}
+
+// параметры обработки удержания цены фоновым заданием: напоминание или истечение удержания с уведомлением пользователя
+type ParamsProcessPriceHold struct {
+	Timestamp    time.Time
+	PriceHoldId  uuid.UUID
+	TicketId     uuid.UUID
+	Notification usersDomain.Notification
+}
+
+// типы документов билета
+const (
+	TicketDocumentTypeReceipt    = "receipt"
+	TicketDocumentTypeCreditNote = "credit_note"
+)
+
+// реквизиты продавца билетов для документов билета (из конфигурации)
+type Seller struct {
+	Name    string
+	TaxId   string
+	Address string
+	Email   string
+}
+
+// документ билета: чек оплаты (receipt) или кредит-нота возврата (credit_note). номер Number - сквозной по типу документа,
+// выдается при оплате (возврате) билета. суммы оплаты (возврата) в валюте учета: PaidWithBonuses - бонусами,
+// PaidWithMoney - деньгами (картой, сертификатами, кредитами, по счету компании), Payments - по способам оплаты.
+// для кредит-ноты ReceiptNumber - номер чека оплаты, TravelCreditCode - код кредита, выпущенного вместо возврата денег
+type TicketDocument struct {
+	Id               uuid.UUID
+	Type             string
+	Number           int64
+	Timestamp        time.Time
+	Seller           Seller
+	Ticket           Ticket
+	ReceiptNumber    int64
+	TravelCreditCode string
+	PaidWithBonuses  money.Money
+	PaidWithMoney    money.Money
+	Payments         []TicketPayment
+}
+
+type ParamsGetTicketDocument struct {
+	TicketId uuid.UUID
+	Type     string
 }
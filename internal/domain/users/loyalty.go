@@ -0,0 +1,72 @@
+package users
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"homework/internal/domain/money"
+)
+
+// максимальная доля стоимости билета в процентах, оплачиваемая бонусами, если правилами не задано иное
+const DefaultMaxRedemptionPercent = 50
+
+// правило программы лояльности, применимое к билету. незаполненные действия правила не меняют расчет:
+// MaxRedemptionPercent - максимальная доля стоимости билета, оплачиваемая бонусами,
+// AccrualPercent - процент начисления бонусов от начисления по шкале (200 - двойные бонусы),
+// AncillariesAccrual - начисляются ли бонусы за дополнительные услуги
+type LoyaltyRule struct {
+	Id                   uuid.UUID
+	Name                 string
+	Priority             int
+	MaxRedemptionPercent *int
+	AccrualPercent       *int
+	AncillariesAccrual   *bool
+}
+
+// параметры расчета бонусов по билету. стоимость билета Price и стоимость дополнительных услуг PriceAncillaries - в валюте учета
+type ParamsLoyalty struct {
+	Timestamp          time.Time
+	UserId             uuid.UUID
+	DepartureAirportId uuid.UUID
+	ArrivalAirportId   uuid.UUID
+	ClassSeatsName     string
+	Price              money.Money
+	PriceAncillaries   money.Money
+}
+
+// GetMaxRedemptionPercent возвращает максимальную долю оплаты бонусами по правилу с наибольшим приоритетом.
+// правила передаются в порядке убывания приоритета
+func GetMaxRedemptionPercent(rules []LoyaltyRule) int {
+
+	for _, rule := range rules {
+		if rule.MaxRedemptionPercent != nil {
+			return *rule.MaxRedemptionPercent
+		}
+	}
+	return DefaultMaxRedemptionPercent
+}
+
+// CalcAccruedBonuses рассчитывает бонусы за билет: процент шкалы начисления scalePercent от стоимости билета
+// (без дополнительных услуг, если правилом начисление за них отключено), умноженный на проценты начисления всех правил.
+// правила передаются в порядке убывания приоритета
+func CalcAccruedBonuses(paramsLoyalty *ParamsLoyalty, scalePercent int, rules []LoyaltyRule) money.Money {
+
+	base := paramsLoyalty.Price
+	for _, rule := range rules {
+		if rule.AncillariesAccrual != nil {
+			if !*rule.AncillariesAccrual {
+				base = base.Sub(paramsLoyalty.PriceAncillaries)
+			}
+			break
+		}
+	}
+
+	accruedBonuses := base.Percent(scalePercent)
+	for _, rule := range rules {
+		if rule.AccrualPercent != nil {
+			accruedBonuses = accruedBonuses.Percent(*rule.AccrualPercent)
+		}
+	}
+	return accruedBonuses
+}
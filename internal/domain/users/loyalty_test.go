@@ -0,0 +1,115 @@
+package users
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"homework/internal/domain/money"
+)
+
+func Test_GetMaxRedemptionPercent(t *testing.T) {
+
+	// Arrange
+	percent30 := 30
+	percent70 := 70
+
+	var tests = []struct {
+		name  string
+		rules []LoyaltyRule
+		want  int
+	}{
+		{
+			name:  "default without rules",
+			rules: []LoyaltyRule{},
+			want:  DefaultMaxRedemptionPercent,
+		},
+		{
+			name: "rule with the highest priority",
+			rules: []LoyaltyRule{
+				{Name: "route", Priority: 10, MaxRedemptionPercent: &percent70},
+				{Name: "base", Priority: 0, MaxRedemptionPercent: &percent30},
+			},
+			want: 70,
+		},
+		{
+			name: "rules without redemption share are skipped",
+			rules: []LoyaltyRule{
+				{Name: "double points", Priority: 10},
+				{Name: "base", Priority: 0, MaxRedemptionPercent: &percent30},
+			},
+			want: 30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := GetMaxRedemptionPercent(tt.rules)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_CalcAccruedBonuses(t *testing.T) {
+
+	// Arrange
+	paramsLoyalty := &ParamsLoyalty{
+		Price:            money.New(1000000, money.BaseCurrency),
+		PriceAncillaries: money.New(200000, money.BaseCurrency),
+	}
+	doublePoints := 200
+	businessBonus := 150
+	noAncillaries := false
+	withAncillaries := true
+
+	var tests = []struct {
+		name  string
+		rules []LoyaltyRule
+		want  money.Money
+	}{
+		{
+			name:  "scale percent without rules",
+			rules: []LoyaltyRule{},
+			want:  money.New(50000, money.BaseCurrency),
+		},
+		{
+			name:  "double points",
+			rules: []LoyaltyRule{{Name: "double points", AccrualPercent: &doublePoints}},
+			want:  money.New(100000, money.BaseCurrency),
+		},
+		{
+			name: "accrual percents are multiplied",
+			rules: []LoyaltyRule{
+				{Name: "double points", Priority: 10, AccrualPercent: &doublePoints},
+				{Name: "business", Priority: 5, AccrualPercent: &businessBonus},
+			},
+			want: money.New(150000, money.BaseCurrency),
+		},
+		{
+			name:  "ancillaries don't earn points",
+			rules: []LoyaltyRule{{Name: "no ancillaries", AncillariesAccrual: &noAncillaries}},
+			want:  money.New(40000, money.BaseCurrency),
+		},
+		{
+			name: "ancillaries accrual by the rule with the highest priority",
+			rules: []LoyaltyRule{
+				{Name: "route", Priority: 10, AncillariesAccrual: &withAncillaries},
+				{Name: "base", Priority: 0, AncillariesAccrual: &noAncillaries},
+			},
+			want: money.New(50000, money.BaseCurrency),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := CalcAccruedBonuses(paramsLoyalty, 5, tt.rules)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
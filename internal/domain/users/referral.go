@@ -0,0 +1,55 @@
+package users
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+
+	"homework/internal/domain/money"
+)
+
+// бонусы реферальной программы в валюте учета: пригласившему пользователю (referrer)
+// и приглашенному пользователю (referee). начисляются после регистрации на рейс первого билета приглашенного пользователя
+var (
+	ReferrerBonus = money.New(50000, money.BaseCurrency)
+	RefereeBonus  = money.New(30000, money.BaseCurrency)
+)
+
+// минимальная длина пароля пользователя
+const MinPasswordLength = 8
+
+// параметры регистрации пользователя. ReferralCode - реферальный код пригласившего пользователя (необязательный).
+// EmailNormalized, PasswordHash, UserReferralCode и ReferrerId заполняются сервисом
+type ParamsCreateUser struct {
+	Name             string
+	Email            string
+	Password         string
+	ReferralCode     string
+	EmailNormalized  string
+	PasswordHash     string
+	UserReferralCode string
+	ReferrerId       *uuid.UUID
+}
+
+// NormalizeEmail приводит адрес электронной почты к виду, по которому проверяются повторные аккаунты:
+// нижний регистр, без подадреса (+tag), для gmail.com - без точек в имени ящика
+func NormalizeEmail(email string) string {
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+
+	local, domain := email[:at], email[at+1:]
+	if plus := strings.Index(local, "+"); plus >= 0 {
+		local = local[:plus]
+	}
+	if domain == "googlemail.com" {
+		domain = "gmail.com"
+	}
+	if domain == "gmail.com" {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+	return local + "@" + domain
+}
@@ -0,0 +1,58 @@
+package users
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NormalizeEmail(t *testing.T) {
+
+	// Arrange
+	var tests = []struct {
+		name  string
+		email string
+		want  string
+	}{
+		{
+			name:  "lower case and spaces",
+			email: " User@Example.COM ",
+			want:  "user@example.com",
+		},
+		{
+			name:  "subaddress",
+			email: "user+travel@example.com",
+			want:  "user@example.com",
+		},
+		{
+			name:  "dots kept outside gmail",
+			email: "first.last@example.com",
+			want:  "first.last@example.com",
+		},
+		{
+			name:  "gmail dots and subaddress",
+			email: "First.Last+promo@gmail.com",
+			want:  "firstlast@gmail.com",
+		},
+		{
+			name:  "googlemail",
+			email: "first.last@googlemail.com",
+			want:  "firstlast@gmail.com",
+		},
+		{
+			name:  "without domain",
+			email: "user",
+			want:  "user",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := NormalizeEmail(tt.email)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
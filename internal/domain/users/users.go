@@ -1,15 +1,50 @@
 package users
 
-import "github.com/google/uuid"
+import (
+	"time"
 
+	"github.com/google/uuid"
+
+	"homework/internal/domain/money"
+)
+
+// баланс пользователя в валюте учета (money.BaseCurrency): общая сумма покупок и сумма бонусов
 type UserBalance struct {
-	SumPurchases int
-	SumBonuses   int
+	SumPurchases money.Money
+	SumBonuses   money.Money
 }
 
+// ReferralCode - реферальный код пользователя для приглашения новых пользователей
 type User struct {
-	Id      uuid.UUID
-	Name    string
-	Email   string
-	Balance *UserBalance
+	Id           uuid.UUID
+	Name         string
+	Email        string
+	ReferralCode string
+	Balance      *UserBalance
+}
+
+// роли сотрудников, вызывающих административные методы. роль передается шлюзом в заголовке X-Role.
+// admin - полные персональные данные пассажиров, agent - персональные данные маскируются
+const (
+	RoleAdmin = "admin"
+	RoleAgent = "agent"
+)
+
+// типы уведомлений пользователя
+const (
+	NotificationTypeWaitlistTicketCreated = "waitlist_ticket_created"
+	NotificationTypeWaitlistExpired       = "waitlist_expired"
+	NotificationTypeWaitlistCanceled      = "waitlist_canceled"
+	NotificationTypePriceHoldReminder     = "price_hold_reminder"
+	NotificationTypePriceHoldExpired      = "price_hold_expired"
+)
+
+// уведомление пользователя
+type Notification struct {
+	Id        uuid.UUID
+	UserId    uuid.UUID
+	Type      string
+	Message   string
+	TicketId  *uuid.UUID
+	Timestamp time.Time
 }
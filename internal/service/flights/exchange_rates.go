@@ -0,0 +1,21 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"homework/internal/domain/money"
+	"homework/internal/util/terr"
+)
+
+// GetExchangeRate возвращает курс валюты цен рейса к валюте, в которой пользователь просматривает цены.
+// курс используется только для вывода: билеты оформляются в валюте цен рейса
+func (s service) GetExchangeRate(ctx context.Context, currencyFrom string, currencyTo string, timestamp time.Time) (*money.ExchangeRate, error) {
+
+	if !money.IsValidCurrency(currencyTo) {
+		return nil, terr.BadRequest("INVALID_CURRENCY", fmt.Sprintf("currency %s is not supported", currencyTo))
+	}
+
+	return s.exchangeRatesProvider.GetExchangeRate(ctx, currencyFrom, currencyTo, timestamp)
+}
@@ -0,0 +1,79 @@
+package flights
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"homework/internal/domain/money"
+	mockFlightsService "homework/internal/service/flights/mock"
+	"homework/internal/util/terr"
+)
+
+func Test_GetExchangeRate(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name         string
+		currencyFrom string
+		currencyTo   string
+		rateErr      error
+		want         *money.ExchangeRate
+		err          error
+	}{
+		{
+			name:         "success",
+			currencyFrom: money.CurrencyRUB,
+			currencyTo:   money.CurrencyUSD,
+			want:         &money.ExchangeRate{From: money.CurrencyRUB, To: money.CurrencyUSD, Rate: 0.0125, Timestamp: timestamp},
+			err:          nil,
+		},
+		{
+			name:         "fail/invalid currency",
+			currencyFrom: money.CurrencyRUB,
+			currencyTo:   "XXX",
+			want:         nil,
+			err:          terr.BadRequest("INVALID_CURRENCY", "currency XXX is not supported"),
+		},
+		{
+			name:         "fail/exchange rate not found",
+			currencyFrom: money.CurrencyRUB,
+			currencyTo:   money.CurrencyJPY,
+			rateErr:      terr.NotFound("not found exchange rate RUB/JPY"),
+			want:         nil,
+			err:          terr.NotFound("not found exchange rate RUB/JPY"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			exchangeRatesProvider := mockFlightsService.NewMockExchangeRatesProvider(ctrl)
+			if tt.want != nil || tt.rateErr != nil {
+				exchangeRatesProvider.EXPECT().
+					GetExchangeRate(ctx, tt.currencyFrom, tt.currencyTo, timestamp).
+					Return(tt.want, tt.rateErr)
+			}
+			flightsService := service{exchangeRatesProvider: exchangeRatesProvider}
+
+			// Act
+			got, err := flightsService.GetExchangeRate(ctx, tt.currencyFrom, tt.currencyTo, timestamp)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	flightsDomain "homework/internal/domain/flights"
+	usersDomain "homework/internal/domain/users"
+	"homework/internal/util/terr"
+)
+
+// максимальная длина терминала и выхода на посадку
+const maxLenTerminalGate = 10
+
+// UpdateFlightStatus обновляет операционный статус рейса: состояние, расчетное и фактическое время вылета и прилета,
+// терминал, выход и время начала посадки. статус заменяется целиком.
+// обновлять статус могут сотрудники с ролями admin и agent
+func (s service) UpdateFlightStatus(ctx context.Context, paramsUpdateFlightStatus *flightsDomain.ParamsUpdateFlightStatus) (*flightsDomain.Flight, error) {
+
+	// роль вызывающего: без роли доступа нет, неизвестная роль - доступ запрещен
+	switch paramsUpdateFlightStatus.Role {
+	case usersDomain.RoleAdmin, usersDomain.RoleAgent:
+	case "":
+		return nil, terr.Unauthorized()
+	default:
+		return nil, terr.Forbidden()
+	}
+
+	// проверяем, что по переданному FlightId существует рейс
+	flight, err := s.flightsStorage.GetFlightById(ctx, paramsUpdateFlightStatus.FlightId)
+	if err != nil {
+		return nil, err
+	}
+
+	// расчетное время прилета по умолчанию - расчетное время вылета + длительность полета
+	if paramsUpdateFlightStatus.EstimatedDeparture != nil && paramsUpdateFlightStatus.EstimatedArrival == nil {
+		estimatedArrival := paramsUpdateFlightStatus.EstimatedDeparture.Add(flight.Duration)
+		paramsUpdateFlightStatus.EstimatedArrival = &estimatedArrival
+	}
+
+	err = validateFlightStatus(flight, paramsUpdateFlightStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.flightsStorage.UpdateFlightStatus(ctx, paramsUpdateFlightStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.flightsStorage.GetFlightById(ctx, flight.Id)
+}
+
+// validateFlightStatus проверяет новый статус рейса:
+// - статус отмененного рейса не меняется;
+// - для задержанного рейса расчетное время вылета позже времени вылета по расписанию;
+// - для вылетевшего рейса заполнено фактическое время вылета, фактическое время не может быть в будущем;
+// - время прилета позже времени вылета, посадка начинается до вылета
+func validateFlightStatus(flight *flightsDomain.Flight, paramsUpdateFlightStatus *flightsDomain.ParamsUpdateFlightStatus) error {
+
+	switch paramsUpdateFlightStatus.State {
+	case flightsDomain.FlightStateOnTime, flightsDomain.FlightStateDelayed, flightsDomain.FlightStateBoarding,
+		flightsDomain.FlightStateDeparted, flightsDomain.FlightStateCancelled:
+	default:
+		return terr.BadRequest("INVALID_FLIGHT_STATE", fmt.Sprintf("unknown flight state %s", paramsUpdateFlightStatus.State))
+	}
+
+	if flight.Status.State == flightsDomain.FlightStateCancelled && paramsUpdateFlightStatus.State != flightsDomain.FlightStateCancelled {
+		return terr.Conflict("FLIGHT_ALREADY_CANCELLED", fmt.Sprintf("flight (id %s) is cancelled", flight.Id))
+	}
+
+	// ожидаемое время вылета: расчетное, если задано, иначе по расписанию
+	departureDate := flight.DepartureDate
+	if paramsUpdateFlightStatus.EstimatedDeparture != nil {
+		departureDate = *paramsUpdateFlightStatus.EstimatedDeparture
+	}
+
+	if paramsUpdateFlightStatus.State == flightsDomain.FlightStateDelayed &&
+		(paramsUpdateFlightStatus.EstimatedDeparture == nil || !paramsUpdateFlightStatus.EstimatedDeparture.After(flight.DepartureDate)) {
+		return terr.BadRequest("INVALID_ESTIMATED_DEPARTURE", "estimated departure of the delayed flight must be after the scheduled departure")
+	}
+	if paramsUpdateFlightStatus.EstimatedArrival != nil && !paramsUpdateFlightStatus.EstimatedArrival.After(departureDate) {
+		return terr.BadRequest("INVALID_ESTIMATED_ARRIVAL", "estimated arrival must be after departure")
+	}
+
+	actualDeparture := paramsUpdateFlightStatus.ActualDeparture
+	if paramsUpdateFlightStatus.State == flightsDomain.FlightStateDeparted && actualDeparture == nil {
+		return terr.BadRequest("ACTUAL_DEPARTURE_REQUIRED", "actual departure is required for the departed flight")
+	}
+	if actualDeparture != nil && actualDeparture.After(paramsUpdateFlightStatus.Timestamp) {
+		return terr.BadRequest("INVALID_ACTUAL_DEPARTURE", "actual departure can't be in the future")
+	}
+
+	actualArrival := paramsUpdateFlightStatus.ActualArrival
+	if actualArrival != nil && (actualDeparture == nil || !actualArrival.After(*actualDeparture) ||
+		actualArrival.After(paramsUpdateFlightStatus.Timestamp)) {
+		return terr.BadRequest("INVALID_ACTUAL_ARRIVAL", "actual arrival must be after actual departure and can't be in the future")
+	}
+
+	if paramsUpdateFlightStatus.BoardingTime != nil && !paramsUpdateFlightStatus.BoardingTime.Before(departureDate) {
+		return terr.BadRequest("INVALID_BOARDING_TIME", "boarding must start before departure")
+	}
+
+	if utf8.RuneCountInString(paramsUpdateFlightStatus.Terminal) > maxLenTerminalGate {
+		return terr.BadRequest("INVALID_TERMINAL", fmt.Sprintf("terminal must be at most %d characters", maxLenTerminalGate))
+	}
+	if utf8.RuneCountInString(paramsUpdateFlightStatus.Gate) > maxLenTerminalGate {
+		return terr.BadRequest("INVALID_GATE", fmt.Sprintf("gate must be at most %d characters", maxLenTerminalGate))
+	}
+
+	return nil
+}
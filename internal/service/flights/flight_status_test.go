@@ -0,0 +1,139 @@
+package flights
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/util/terr"
+)
+
+func Test_ValidateFlightStatus(t *testing.T) {
+
+	// Arrange
+	departureDate := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	timestamp := departureDate.Add(30 * time.Minute)
+	estimatedDeparture := departureDate.Add(2 * time.Hour)
+	earlyDeparture := departureDate.Add(-10 * time.Minute)
+	actualDeparture := departureDate.Add(20 * time.Minute)
+	futureArrival := departureDate.Add(3 * time.Hour)
+	boardingTime := departureDate.Add(-40 * time.Minute)
+
+	var tests = []struct {
+		name        string
+		flightState string
+		args        *flightsDomain.ParamsUpdateFlightStatus
+		err         error
+	}{
+		{
+			name:        "success/delayed flight",
+			flightState: flightsDomain.FlightStateOnTime,
+			args: &flightsDomain.ParamsUpdateFlightStatus{
+				Timestamp:          timestamp,
+				State:              flightsDomain.FlightStateDelayed,
+				EstimatedDeparture: &estimatedDeparture,
+				Terminal:           "B",
+				Gate:               "B12",
+			},
+			err: nil,
+		},
+		{
+			name:        "success/departed flight",
+			flightState: flightsDomain.FlightStateBoarding,
+			args: &flightsDomain.ParamsUpdateFlightStatus{
+				Timestamp:       timestamp,
+				State:           flightsDomain.FlightStateDeparted,
+				ActualDeparture: &actualDeparture,
+				BoardingTime:    &boardingTime,
+			},
+			err: nil,
+		},
+		{
+			name:        "fail/unknown state",
+			flightState: flightsDomain.FlightStateOnTime,
+			args: &flightsDomain.ParamsUpdateFlightStatus{
+				Timestamp: timestamp,
+				State:     "landed",
+			},
+			err: terr.BadRequest("INVALID_FLIGHT_STATE", "unknown flight state landed"),
+		},
+		{
+			name:        "fail/flight is cancelled",
+			flightState: flightsDomain.FlightStateCancelled,
+			args: &flightsDomain.ParamsUpdateFlightStatus{
+				Timestamp: timestamp,
+				State:     flightsDomain.FlightStateOnTime,
+			},
+			err: terr.Conflict("FLIGHT_ALREADY_CANCELLED", "flight (id 00000000-0000-0000-0000-000000000000) is cancelled"),
+		},
+		{
+			name:        "fail/delayed flight departs before the scheduled departure",
+			flightState: flightsDomain.FlightStateOnTime,
+			args: &flightsDomain.ParamsUpdateFlightStatus{
+				Timestamp:          timestamp,
+				State:              flightsDomain.FlightStateDelayed,
+				EstimatedDeparture: &earlyDeparture,
+			},
+			err: terr.BadRequest("INVALID_ESTIMATED_DEPARTURE", "estimated departure of the delayed flight must be after the scheduled departure"),
+		},
+		{
+			name:        "fail/departed flight without actual departure",
+			flightState: flightsDomain.FlightStateBoarding,
+			args: &flightsDomain.ParamsUpdateFlightStatus{
+				Timestamp: timestamp,
+				State:     flightsDomain.FlightStateDeparted,
+			},
+			err: terr.BadRequest("ACTUAL_DEPARTURE_REQUIRED", "actual departure is required for the departed flight"),
+		},
+		{
+			name:        "fail/actual arrival in the future",
+			flightState: flightsDomain.FlightStateBoarding,
+			args: &flightsDomain.ParamsUpdateFlightStatus{
+				Timestamp:       timestamp,
+				State:           flightsDomain.FlightStateDeparted,
+				ActualDeparture: &actualDeparture,
+				ActualArrival:   &futureArrival,
+			},
+			err: terr.BadRequest("INVALID_ACTUAL_ARRIVAL", "actual arrival must be after actual departure and can't be in the future"),
+		},
+		{
+			name:        "fail/boarding after departure",
+			flightState: flightsDomain.FlightStateOnTime,
+			args: &flightsDomain.ParamsUpdateFlightStatus{
+				Timestamp:    timestamp,
+				State:        flightsDomain.FlightStateBoarding,
+				BoardingTime: &futureArrival,
+			},
+			err: terr.BadRequest("INVALID_BOARDING_TIME", "boarding must start before departure"),
+		},
+		{
+			name:        "fail/too long gate",
+			flightState: flightsDomain.FlightStateOnTime,
+			args: &flightsDomain.ParamsUpdateFlightStatus{
+				Timestamp: timestamp,
+				State:     flightsDomain.FlightStateOnTime,
+				Gate:      "GATE-B-12-NORTH",
+			},
+			err: terr.BadRequest("INVALID_GATE", "gate must be at most 10 characters"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			flight := &flightsDomain.Flight{
+				DepartureDate: departureDate,
+				Duration:      2 * time.Hour,
+				Status:        flightsDomain.FlightStatus{State: tt.flightState},
+			}
+
+			// Act
+			err := validateFlightStatus(flight, tt.args)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
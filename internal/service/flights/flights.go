@@ -2,19 +2,33 @@ package flights
 
 import (
 	"context"
+	"time"
+
 	"github.com/google/uuid"
 
 	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
+	usersDomain "homework/internal/domain/users"
 )
 
 type service struct {
-	flightsStorage FlightsStorage
+	flightsStorage        FlightsStorage
+	usersStorage          UsersStorage
+	exchangeRatesProvider ExchangeRatesProvider
+	seatHoldTTL           time.Duration
 }
 
 type FlightsService interface {
 	GetFlights(ctx context.Context, paramsGetFlights *flightsDomain.ParamsGetFlights) ([]flightsDomain.Flight, error)
 	GetFlightById(ctx context.Context, flightId uuid.UUID) (*flightsDomain.Flight, error)
 	GetFlightVacantSeats(ctx context.Context, flightId uuid.UUID) ([]flightsDomain.VacantSeats, error)
+	GetFlightAncillaries(ctx context.Context, flightId uuid.UUID) ([]flightsDomain.FlightAncillary, error)
+	GetOversoldFlights(ctx context.Context, paramsGetOversoldFlights *flightsDomain.ParamsGetOversoldFlights) ([]flightsDomain.OversoldClassSeats, error)
+	CreateSeatHold(ctx context.Context, paramsCreateSeatHold *flightsDomain.ParamsCreateSeatHold) (*flightsDomain.SeatHold, error)
+	ReconcileFlightInventory(ctx context.Context, timestamp time.Time) (int64, error)
+	UpdateFlightStatus(ctx context.Context, paramsUpdateFlightStatus *flightsDomain.ParamsUpdateFlightStatus) (*flightsDomain.Flight, error)
+	SearchLocations(ctx context.Context, paramsSearchLocations *flightsDomain.ParamsSearchLocations) ([]flightsDomain.Location, error)
+	GetExchangeRate(ctx context.Context, currencyFrom string, currencyTo string, timestamp time.Time) (*money.ExchangeRate, error)
 }
 
 type FlightsStorage interface {
@@ -22,33 +36,100 @@ type FlightsStorage interface {
 	GetFlights(ctx context.Context, paramsGetFlights *flightsDomain.ParamsGetFlights) ([]flightsDomain.Flight, error)
 	GetFlightById(ctx context.Context, flightId uuid.UUID) (*flightsDomain.Flight, error)
 	GetFlightVacantSeats(ctx context.Context, flightId uuid.UUID) ([]flightsDomain.VacantSeats, error)
+	GetFlightAncillaries(ctx context.Context, flightId uuid.UUID) ([]flightsDomain.FlightAncillary, error)
+	GetFlightVacantSeatsByClassId(ctx context.Context, flightId uuid.UUID, classSeatsId uuid.UUID) (*flightsDomain.VacantSeats, error)
+	GetOversoldFlights(ctx context.Context, paramsGetOversoldFlights *flightsDomain.ParamsGetOversoldFlights) ([]flightsDomain.OversoldClassSeats, error)
+	GetCountUserSeatHolds(ctx context.Context, flightId uuid.UUID, userId uuid.UUID, timestamp time.Time) (int, error)
+	CreateSeatHold(ctx context.Context, paramsCreateSeatHold *flightsDomain.ParamsCreateSeatHold) (*flightsDomain.SeatHold, error)
+	DeleteExpiredSeatHolds(ctx context.Context, timestamp time.Time) (int64, error)
+	ReconcileFlightInventory(ctx context.Context, timestamp time.Time) (int64, error)
+	UpdateFlightStatus(ctx context.Context, paramsUpdateFlightStatus *flightsDomain.ParamsUpdateFlightStatus) error
+	SearchLocations(ctx context.Context, paramsSearchLocations *flightsDomain.ParamsSearchLocations) ([]flightsDomain.Location, error)
+	GetLocationByCode(ctx context.Context, code string) (*flightsDomain.Location, error)
+}
+
+type UsersStorage interface {
+	GetUserById(ctx context.Context, userId uuid.UUID) (*usersDomain.User, error)
+}
+
+// источник курсов валют: курс на момент timestamp
+type ExchangeRatesProvider interface {
+	GetExchangeRate(ctx context.Context, currencyFrom string, currencyTo string, timestamp time.Time) (*money.ExchangeRate, error)
 }
 
 func (s service) GetFlights(ctx context.Context, paramsGetFlights *flightsDomain.ParamsGetFlights) ([]flightsDomain.Flight, error) {
 
-	// проверяем, что по переданному DepartureCityId существует город
-	_, err := s.flightsStorage.GetCityById(ctx, paramsGetFlights.DepartureCityId)
+	// проверяем, что по переданному DepartureCityId существует город, или определяем город и аэропорт по коду DepartureCode
+	cityId, airportId, err := s.resolveLocation(ctx, paramsGetFlights.DepartureCityId, paramsGetFlights.DepartureCode)
+	if err != nil {
+		return nil, err
+	}
+	paramsGetFlights.DepartureCityId, paramsGetFlights.DepartureAirportId = cityId, airportId
+
+	// проверяем, что по переданному ArrivalCityId существует город, или определяем город и аэропорт по коду ArrivalCode
+	cityId, airportId, err = s.resolveLocation(ctx, paramsGetFlights.ArrivalCityId, paramsGetFlights.ArrivalCode)
 	if err != nil {
 		return nil, err
 	}
+	paramsGetFlights.ArrivalCityId, paramsGetFlights.ArrivalAirportId = cityId, airportId
 
-	// проверяем, что по переданному ArrivalCityId существует город
-	_, err = s.flightsStorage.GetCityById(ctx, paramsGetFlights.ArrivalCityId)
+	flights, err := s.flightsStorage.GetFlights(ctx, paramsGetFlights)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.flightsStorage.GetFlights(ctx, paramsGetFlights)
+	// сборы рейсов выводятся в валюте цен рейса по текущему курсу
+	timestamp := time.Now()
+	exchangeRates := make(map[[2]string]*money.ExchangeRate)
+	for i := range flights {
+		err = s.setFlightTaxesFees(ctx, &flights[i], exchangeRates, timestamp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return flights, nil
 }
 
 func (s service) GetFlightById(ctx context.Context, flightId uuid.UUID) (*flightsDomain.Flight, error) {
-	return s.flightsStorage.GetFlightById(ctx, flightId)
+
+	flight, err := s.flightsStorage.GetFlightById(ctx, flightId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.setFlightTaxesFees(ctx, flight, make(map[[2]string]*money.ExchangeRate), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return flight, nil
 }
 
 func (s service) GetFlightVacantSeats(ctx context.Context, flightId uuid.UUID) ([]flightsDomain.VacantSeats, error) {
 	return s.flightsStorage.GetFlightVacantSeats(ctx, flightId)
 }
 
-func NewFlightsService(flightsStorage FlightsStorage) FlightsService {
-	return &service{flightsStorage: flightsStorage}
+func (s service) GetFlightAncillaries(ctx context.Context, flightId uuid.UUID) ([]flightsDomain.FlightAncillary, error) {
+
+	// проверяем, что по переданному FlightId существует рейс
+	_, err := s.flightsStorage.GetFlightById(ctx, flightId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.flightsStorage.GetFlightAncillaries(ctx, flightId)
+}
+
+// пересчет остатков мест рейсов по билетам и удержаниям мест.
+// выполняется командой cmd/reconcile при расхождении остатков
+func (s service) ReconcileFlightInventory(ctx context.Context, timestamp time.Time) (int64, error) {
+	return s.flightsStorage.ReconcileFlightInventory(ctx, timestamp)
+}
+
+func NewFlightsService(flightsStorage FlightsStorage, usersStorage UsersStorage, exchangeRatesProvider ExchangeRatesProvider, seatHoldTTL time.Duration) FlightsService {
+	return &service{
+		flightsStorage:        flightsStorage,
+		usersStorage:          usersStorage,
+		exchangeRatesProvider: exchangeRatesProvider,
+		seatHoldTTL:           seatHoldTTL,
+	}
 }
@@ -2,7 +2,9 @@ package flights
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -14,6 +16,7 @@ import (
 )
 
 //go:generate mockgen -destination ./mock/flights_service_mock.go homework/internal/service/flights FlightsService
+//go:generate mockgen -destination ./mock/flights_storage_mock.go homework/internal/service/flights FlightsStorage,UsersStorage,ExchangeRatesProvider
 
 func Test_GetFlightById(t *testing.T) {
 
@@ -66,3 +69,60 @@ func Test_GetFlightById(t *testing.T) {
 		})
 	}
 }
+
+func Test_ReconcileFlightInventory(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name string
+		args time.Time
+		want int64
+		err  error
+	}{
+		{
+			name: "success",
+			args: timestamp,
+			want: 2,
+			err:  nil,
+		},
+		{
+			name: "success/nothing to reconcile",
+			args: timestamp,
+			want: 0,
+			err:  nil,
+		},
+		{
+			name: "fail/database error",
+			args: timestamp,
+			want: 0,
+			err:  terr.SQLDatabaseError(errors.New("connection refused")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			flightsStorage := mockFlightsService.NewMockFlightsStorage(ctrl)
+			flightsStorage.EXPECT().
+				ReconcileFlightInventory(ctx, tt.args).
+				Return(tt.want, tt.err)
+			flightsService := service{flightsStorage: flightsStorage}
+
+			// Act
+			got, err := flightsService.ReconcileFlightInventory(ctx, tt.args)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
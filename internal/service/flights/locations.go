@@ -0,0 +1,65 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/util/terr"
+)
+
+// ограничения строки поиска и количества мест в результате поиска
+const (
+	minLenLocationQuery   = 2
+	maxLenLocationQuery   = 100
+	defaultLimitLocations = 10
+	maxLimitLocations     = 50
+)
+
+// SearchLocations ищет города и аэропорты для выбора места вылета и прилета:
+// по совпадению кода IATA/ICAO, началу наименования и сходству наименования
+func (s service) SearchLocations(ctx context.Context, paramsSearchLocations *flightsDomain.ParamsSearchLocations) ([]flightsDomain.Location, error) {
+
+	paramsSearchLocations.Query = strings.TrimSpace(paramsSearchLocations.Query)
+	lenQuery := utf8.RuneCountInString(paramsSearchLocations.Query)
+	if lenQuery < minLenLocationQuery || lenQuery > maxLenLocationQuery {
+		return nil, terr.BadRequest("INVALID_QUERY", fmt.Sprintf("search query must be from %d to %d characters", minLenLocationQuery, maxLenLocationQuery))
+	}
+
+	if paramsSearchLocations.Limit == 0 {
+		paramsSearchLocations.Limit = defaultLimitLocations
+	}
+	if paramsSearchLocations.Limit < 0 || paramsSearchLocations.Limit > maxLimitLocations {
+		return nil, terr.BadRequest("INVALID_LIMIT", fmt.Sprintf("limit must be from 1 to %d", maxLimitLocations))
+	}
+
+	return s.flightsStorage.SearchLocations(ctx, paramsSearchLocations)
+}
+
+// resolveLocation определяет город и аэропорт отбора рейсов:
+// по коду IATA/ICAO, если он передан, иначе проверяет, что существует город cityId.
+// аэропорт возвращается только для кода аэропорта
+func (s service) resolveLocation(ctx context.Context, cityId uuid.UUID, code string) (uuid.UUID, *uuid.UUID, error) {
+
+	if code == "" {
+		_, err := s.flightsStorage.GetCityById(ctx, cityId)
+		if err != nil {
+			return uuid.UUID{}, nil, err
+		}
+		return cityId, nil, nil
+	}
+
+	location, err := s.flightsStorage.GetLocationByCode(ctx, code)
+	if err != nil {
+		return uuid.UUID{}, nil, err
+	}
+	if location.Type == flightsDomain.LocationTypeAirport {
+		airportId := location.Id
+		return location.City.Id, &airportId, nil
+	}
+	return location.City.Id, nil, nil
+}
@@ -7,8 +7,9 @@ package mock_flights
 import (
 	context "context"
 	flights "homework/internal/domain/flights"
-	specs "homework/specs"
+	money "homework/internal/domain/money"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -37,6 +38,51 @@ func (m *MockFlightsService) EXPECT() *MockFlightsServiceMockRecorder {
 	return m.recorder
 }
 
+// CreateSeatHold mocks base method.
+func (m *MockFlightsService) CreateSeatHold(arg0 context.Context, arg1 *flights.ParamsCreateSeatHold) (*flights.SeatHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeatHold", arg0, arg1)
+	ret0, _ := ret[0].(*flights.SeatHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSeatHold indicates an expected call of CreateSeatHold.
+func (mr *MockFlightsServiceMockRecorder) CreateSeatHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeatHold", reflect.TypeOf((*MockFlightsService)(nil).CreateSeatHold), arg0, arg1)
+}
+
+// GetExchangeRate mocks base method.
+func (m *MockFlightsService) GetExchangeRate(arg0 context.Context, arg1, arg2 string, arg3 time.Time) (*money.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*money.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRate indicates an expected call of GetExchangeRate.
+func (mr *MockFlightsServiceMockRecorder) GetExchangeRate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRate", reflect.TypeOf((*MockFlightsService)(nil).GetExchangeRate), arg0, arg1, arg2, arg3)
+}
+
+// GetFlightAncillaries mocks base method.
+func (m *MockFlightsService) GetFlightAncillaries(arg0 context.Context, arg1 uuid.UUID) ([]flights.FlightAncillary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlightAncillaries", arg0, arg1)
+	ret0, _ := ret[0].([]flights.FlightAncillary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlightAncillaries indicates an expected call of GetFlightAncillaries.
+func (mr *MockFlightsServiceMockRecorder) GetFlightAncillaries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlightAncillaries", reflect.TypeOf((*MockFlightsService)(nil).GetFlightAncillaries), arg0, arg1)
+}
+
 // GetFlightById mocks base method.
 func (m *MockFlightsService) GetFlightById(arg0 context.Context, arg1 uuid.UUID) (*flights.Flight, error) {
 	m.ctrl.T.Helper()
@@ -52,8 +98,23 @@ func (mr *MockFlightsServiceMockRecorder) GetFlightById(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlightById", reflect.TypeOf((*MockFlightsService)(nil).GetFlightById), arg0, arg1)
 }
 
+// GetFlightVacantSeats mocks base method.
+func (m *MockFlightsService) GetFlightVacantSeats(arg0 context.Context, arg1 uuid.UUID) ([]flights.VacantSeats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlightVacantSeats", arg0, arg1)
+	ret0, _ := ret[0].([]flights.VacantSeats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlightVacantSeats indicates an expected call of GetFlightVacantSeats.
+func (mr *MockFlightsServiceMockRecorder) GetFlightVacantSeats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlightVacantSeats", reflect.TypeOf((*MockFlightsService)(nil).GetFlightVacantSeats), arg0, arg1)
+}
+
 // GetFlights mocks base method.
-func (m *MockFlightsService) GetFlights(arg0 context.Context, arg1 specs.GetFlightsParams) ([]flights.Flight, error) {
+func (m *MockFlightsService) GetFlights(arg0 context.Context, arg1 *flights.ParamsGetFlights) ([]flights.Flight, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetFlights", arg0, arg1)
 	ret0, _ := ret[0].([]flights.Flight)
@@ -66,3 +127,63 @@ func (mr *MockFlightsServiceMockRecorder) GetFlights(arg0, arg1 interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlights", reflect.TypeOf((*MockFlightsService)(nil).GetFlights), arg0, arg1)
 }
+
+// GetOversoldFlights mocks base method.
+func (m *MockFlightsService) GetOversoldFlights(arg0 context.Context, arg1 *flights.ParamsGetOversoldFlights) ([]flights.OversoldClassSeats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOversoldFlights", arg0, arg1)
+	ret0, _ := ret[0].([]flights.OversoldClassSeats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOversoldFlights indicates an expected call of GetOversoldFlights.
+func (mr *MockFlightsServiceMockRecorder) GetOversoldFlights(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOversoldFlights", reflect.TypeOf((*MockFlightsService)(nil).GetOversoldFlights), arg0, arg1)
+}
+
+// ReconcileFlightInventory mocks base method.
+func (m *MockFlightsService) ReconcileFlightInventory(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileFlightInventory", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileFlightInventory indicates an expected call of ReconcileFlightInventory.
+func (mr *MockFlightsServiceMockRecorder) ReconcileFlightInventory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileFlightInventory", reflect.TypeOf((*MockFlightsService)(nil).ReconcileFlightInventory), arg0, arg1)
+}
+
+// SearchLocations mocks base method.
+func (m *MockFlightsService) SearchLocations(arg0 context.Context, arg1 *flights.ParamsSearchLocations) ([]flights.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchLocations", arg0, arg1)
+	ret0, _ := ret[0].([]flights.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchLocations indicates an expected call of SearchLocations.
+func (mr *MockFlightsServiceMockRecorder) SearchLocations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchLocations", reflect.TypeOf((*MockFlightsService)(nil).SearchLocations), arg0, arg1)
+}
+
+// UpdateFlightStatus mocks base method.
+func (m *MockFlightsService) UpdateFlightStatus(arg0 context.Context, arg1 *flights.ParamsUpdateFlightStatus) (*flights.Flight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFlightStatus", arg0, arg1)
+	ret0, _ := ret[0].(*flights.Flight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFlightStatus indicates an expected call of UpdateFlightStatus.
+func (mr *MockFlightsServiceMockRecorder) UpdateFlightStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFlightStatus", reflect.TypeOf((*MockFlightsService)(nil).UpdateFlightStatus), arg0, arg1)
+}
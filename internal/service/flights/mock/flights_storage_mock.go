@@ -0,0 +1,325 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: homework/internal/service/flights (interfaces: FlightsStorage,UsersStorage,ExchangeRatesProvider)
+
+// Package mock_flights is a generated GoMock package.
+package mock_flights
+
+import (
+	context "context"
+	flights "homework/internal/domain/flights"
+	money "homework/internal/domain/money"
+	users "homework/internal/domain/users"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockFlightsStorage is a mock of FlightsStorage interface.
+type MockFlightsStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockFlightsStorageMockRecorder
+}
+
+// MockFlightsStorageMockRecorder is the mock recorder for MockFlightsStorage.
+type MockFlightsStorageMockRecorder struct {
+	mock *MockFlightsStorage
+}
+
+// NewMockFlightsStorage creates a new mock instance.
+func NewMockFlightsStorage(ctrl *gomock.Controller) *MockFlightsStorage {
+	mock := &MockFlightsStorage{ctrl: ctrl}
+	mock.recorder = &MockFlightsStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFlightsStorage) EXPECT() *MockFlightsStorageMockRecorder {
+	return m.recorder
+}
+
+// CreateSeatHold mocks base method.
+func (m *MockFlightsStorage) CreateSeatHold(arg0 context.Context, arg1 *flights.ParamsCreateSeatHold) (*flights.SeatHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeatHold", arg0, arg1)
+	ret0, _ := ret[0].(*flights.SeatHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSeatHold indicates an expected call of CreateSeatHold.
+func (mr *MockFlightsStorageMockRecorder) CreateSeatHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeatHold", reflect.TypeOf((*MockFlightsStorage)(nil).CreateSeatHold), arg0, arg1)
+}
+
+// DeleteExpiredSeatHolds mocks base method.
+func (m *MockFlightsStorage) DeleteExpiredSeatHolds(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredSeatHolds", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredSeatHolds indicates an expected call of DeleteExpiredSeatHolds.
+func (mr *MockFlightsStorageMockRecorder) DeleteExpiredSeatHolds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredSeatHolds", reflect.TypeOf((*MockFlightsStorage)(nil).DeleteExpiredSeatHolds), arg0, arg1)
+}
+
+// GetCityById mocks base method.
+func (m *MockFlightsStorage) GetCityById(arg0 context.Context, arg1 uuid.UUID) (*flights.City, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCityById", arg0, arg1)
+	ret0, _ := ret[0].(*flights.City)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCityById indicates an expected call of GetCityById.
+func (mr *MockFlightsStorageMockRecorder) GetCityById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCityById", reflect.TypeOf((*MockFlightsStorage)(nil).GetCityById), arg0, arg1)
+}
+
+// GetCountUserSeatHolds mocks base method.
+func (m *MockFlightsStorage) GetCountUserSeatHolds(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountUserSeatHolds", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountUserSeatHolds indicates an expected call of GetCountUserSeatHolds.
+func (mr *MockFlightsStorageMockRecorder) GetCountUserSeatHolds(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountUserSeatHolds", reflect.TypeOf((*MockFlightsStorage)(nil).GetCountUserSeatHolds), arg0, arg1, arg2, arg3)
+}
+
+// GetFlightAncillaries mocks base method.
+func (m *MockFlightsStorage) GetFlightAncillaries(arg0 context.Context, arg1 uuid.UUID) ([]flights.FlightAncillary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlightAncillaries", arg0, arg1)
+	ret0, _ := ret[0].([]flights.FlightAncillary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlightAncillaries indicates an expected call of GetFlightAncillaries.
+func (mr *MockFlightsStorageMockRecorder) GetFlightAncillaries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlightAncillaries", reflect.TypeOf((*MockFlightsStorage)(nil).GetFlightAncillaries), arg0, arg1)
+}
+
+// GetFlightById mocks base method.
+func (m *MockFlightsStorage) GetFlightById(arg0 context.Context, arg1 uuid.UUID) (*flights.Flight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlightById", arg0, arg1)
+	ret0, _ := ret[0].(*flights.Flight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlightById indicates an expected call of GetFlightById.
+func (mr *MockFlightsStorageMockRecorder) GetFlightById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlightById", reflect.TypeOf((*MockFlightsStorage)(nil).GetFlightById), arg0, arg1)
+}
+
+// GetFlightVacantSeats mocks base method.
+func (m *MockFlightsStorage) GetFlightVacantSeats(arg0 context.Context, arg1 uuid.UUID) ([]flights.VacantSeats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlightVacantSeats", arg0, arg1)
+	ret0, _ := ret[0].([]flights.VacantSeats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlightVacantSeats indicates an expected call of GetFlightVacantSeats.
+func (mr *MockFlightsStorageMockRecorder) GetFlightVacantSeats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlightVacantSeats", reflect.TypeOf((*MockFlightsStorage)(nil).GetFlightVacantSeats), arg0, arg1)
+}
+
+// GetFlightVacantSeatsByClassId mocks base method.
+func (m *MockFlightsStorage) GetFlightVacantSeatsByClassId(arg0 context.Context, arg1, arg2 uuid.UUID) (*flights.VacantSeats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlightVacantSeatsByClassId", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*flights.VacantSeats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlightVacantSeatsByClassId indicates an expected call of GetFlightVacantSeatsByClassId.
+func (mr *MockFlightsStorageMockRecorder) GetFlightVacantSeatsByClassId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlightVacantSeatsByClassId", reflect.TypeOf((*MockFlightsStorage)(nil).GetFlightVacantSeatsByClassId), arg0, arg1, arg2)
+}
+
+// GetFlights mocks base method.
+func (m *MockFlightsStorage) GetFlights(arg0 context.Context, arg1 *flights.ParamsGetFlights) ([]flights.Flight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlights", arg0, arg1)
+	ret0, _ := ret[0].([]flights.Flight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlights indicates an expected call of GetFlights.
+func (mr *MockFlightsStorageMockRecorder) GetFlights(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlights", reflect.TypeOf((*MockFlightsStorage)(nil).GetFlights), arg0, arg1)
+}
+
+// GetLocationByCode mocks base method.
+func (m *MockFlightsStorage) GetLocationByCode(arg0 context.Context, arg1 string) (*flights.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocationByCode", arg0, arg1)
+	ret0, _ := ret[0].(*flights.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocationByCode indicates an expected call of GetLocationByCode.
+func (mr *MockFlightsStorageMockRecorder) GetLocationByCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocationByCode", reflect.TypeOf((*MockFlightsStorage)(nil).GetLocationByCode), arg0, arg1)
+}
+
+// GetOversoldFlights mocks base method.
+func (m *MockFlightsStorage) GetOversoldFlights(arg0 context.Context, arg1 *flights.ParamsGetOversoldFlights) ([]flights.OversoldClassSeats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOversoldFlights", arg0, arg1)
+	ret0, _ := ret[0].([]flights.OversoldClassSeats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOversoldFlights indicates an expected call of GetOversoldFlights.
+func (mr *MockFlightsStorageMockRecorder) GetOversoldFlights(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOversoldFlights", reflect.TypeOf((*MockFlightsStorage)(nil).GetOversoldFlights), arg0, arg1)
+}
+
+// ReconcileFlightInventory mocks base method.
+func (m *MockFlightsStorage) ReconcileFlightInventory(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileFlightInventory", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileFlightInventory indicates an expected call of ReconcileFlightInventory.
+func (mr *MockFlightsStorageMockRecorder) ReconcileFlightInventory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileFlightInventory", reflect.TypeOf((*MockFlightsStorage)(nil).ReconcileFlightInventory), arg0, arg1)
+}
+
+// SearchLocations mocks base method.
+func (m *MockFlightsStorage) SearchLocations(arg0 context.Context, arg1 *flights.ParamsSearchLocations) ([]flights.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchLocations", arg0, arg1)
+	ret0, _ := ret[0].([]flights.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchLocations indicates an expected call of SearchLocations.
+func (mr *MockFlightsStorageMockRecorder) SearchLocations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchLocations", reflect.TypeOf((*MockFlightsStorage)(nil).SearchLocations), arg0, arg1)
+}
+
+// UpdateFlightStatus mocks base method.
+func (m *MockFlightsStorage) UpdateFlightStatus(arg0 context.Context, arg1 *flights.ParamsUpdateFlightStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFlightStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFlightStatus indicates an expected call of UpdateFlightStatus.
+func (mr *MockFlightsStorageMockRecorder) UpdateFlightStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFlightStatus", reflect.TypeOf((*MockFlightsStorage)(nil).UpdateFlightStatus), arg0, arg1)
+}
+
+// MockUsersStorage is a mock of UsersStorage interface.
+type MockUsersStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsersStorageMockRecorder
+}
+
+// MockUsersStorageMockRecorder is the mock recorder for MockUsersStorage.
+type MockUsersStorageMockRecorder struct {
+	mock *MockUsersStorage
+}
+
+// NewMockUsersStorage creates a new mock instance.
+func NewMockUsersStorage(ctrl *gomock.Controller) *MockUsersStorage {
+	mock := &MockUsersStorage{ctrl: ctrl}
+	mock.recorder = &MockUsersStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsersStorage) EXPECT() *MockUsersStorageMockRecorder {
+	return m.recorder
+}
+
+// GetUserById mocks base method.
+func (m *MockUsersStorage) GetUserById(arg0 context.Context, arg1 uuid.UUID) (*users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", arg0, arg1)
+	ret0, _ := ret[0].(*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockUsersStorageMockRecorder) GetUserById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockUsersStorage)(nil).GetUserById), arg0, arg1)
+}
+
+// MockExchangeRatesProvider is a mock of ExchangeRatesProvider interface.
+type MockExchangeRatesProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRatesProviderMockRecorder
+}
+
+// MockExchangeRatesProviderMockRecorder is the mock recorder for MockExchangeRatesProvider.
+type MockExchangeRatesProviderMockRecorder struct {
+	mock *MockExchangeRatesProvider
+}
+
+// NewMockExchangeRatesProvider creates a new mock instance.
+func NewMockExchangeRatesProvider(ctrl *gomock.Controller) *MockExchangeRatesProvider {
+	mock := &MockExchangeRatesProvider{ctrl: ctrl}
+	mock.recorder = &MockExchangeRatesProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRatesProvider) EXPECT() *MockExchangeRatesProviderMockRecorder {
+	return m.recorder
+}
+
+// GetExchangeRate mocks base method.
+func (m *MockExchangeRatesProvider) GetExchangeRate(arg0 context.Context, arg1, arg2 string, arg3 time.Time) (*money.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*money.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRate indicates an expected call of GetExchangeRate.
+func (mr *MockExchangeRatesProviderMockRecorder) GetExchangeRate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRate", reflect.TypeOf((*MockExchangeRatesProvider)(nil).GetExchangeRate), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,20 @@
+package flights
+
+import (
+	"context"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/util/terr"
+)
+
+func (s service) GetOversoldFlights(ctx context.Context, paramsGetOversoldFlights *flightsDomain.ParamsGetOversoldFlights) ([]flightsDomain.OversoldClassSeats, error) {
+
+	// проверки отбора:
+	// начало периода дат вылета не позже его окончания
+	if paramsGetOversoldFlights.DepartureDateFrom != nil && paramsGetOversoldFlights.DepartureDateTo != nil &&
+		paramsGetOversoldFlights.DepartureDateFrom.After(*paramsGetOversoldFlights.DepartureDateTo) {
+		return nil, terr.BadRequest("INVALID_DEPARTURE_DATE_RANGE", "departure date from is after departure date to")
+	}
+
+	return s.flightsStorage.GetOversoldFlights(ctx, paramsGetOversoldFlights)
+}
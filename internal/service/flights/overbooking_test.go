@@ -0,0 +1,88 @@
+package flights
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	mockFlightsService "homework/internal/service/flights/mock"
+	"homework/internal/util/terr"
+)
+
+func Test_GetOversoldFlights(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	departureDateFrom := time.Date(2023, 6, 10, 0, 0, 0, 0, time.UTC)
+	departureDateTo := time.Date(2023, 6, 5, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name string
+		args *flightsDomain.ParamsGetOversoldFlights
+		want []flightsDomain.OversoldClassSeats
+		err  error
+	}{
+		{
+			name: "success",
+			args: &flightsDomain.ParamsGetOversoldFlights{
+				Timestamp: timestamp,
+			},
+			want: []flightsDomain.OversoldClassSeats{
+				{
+					FlightId:           uuid.MustParse("7d5925a6-2016-4c72-9298-517fc40d936c"),
+					FlightName:         "SU 1234",
+					DepartureDate:      timestamp.Add(48 * time.Hour),
+					ClassSeatsId:       uuid.MustParse("2c4b1c4e-0d7a-4a7c-9a57-5f5c2f1a9b10"),
+					ClassSeatsName:     "Economy",
+					CountSeats:         100,
+					OverbookingPercent: 5,
+					CountTickets:       103,
+					CountOversold:      3,
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "fail/invalid departure date range",
+			args: &flightsDomain.ParamsGetOversoldFlights{
+				Timestamp:         timestamp,
+				DepartureDateFrom: &departureDateFrom,
+				DepartureDateTo:   &departureDateTo,
+			},
+			want: nil,
+			err:  terr.BadRequest("INVALID_DEPARTURE_DATE_RANGE", "departure date from is after departure date to"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			flightsStorage := mockFlightsService.NewMockFlightsStorage(ctrl)
+			if tt.err == nil {
+				flightsStorage.EXPECT().
+					GetOversoldFlights(ctx, tt.args).
+					Return(tt.want, nil)
+			}
+			flightsService := NewFlightsService(flightsStorage, nil, nil, 0)
+
+			// Act
+			got, err := flightsService.GetOversoldFlights(ctx, tt.args)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
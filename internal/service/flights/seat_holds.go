@@ -0,0 +1,82 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/util/terr"
+)
+
+// максимальное количество действующих удержаний мест пользователя на одном рейсе
+const maxUserSeatHolds = 9
+
+func (s service) CreateSeatHold(ctx context.Context, paramsCreateSeatHold *flightsDomain.ParamsCreateSeatHold) (*flightsDomain.SeatHold, error) {
+
+	// проверяем, что по переданному FlightId существует рейс
+	flight, err := s.flightsStorage.GetFlightById(ctx, paramsCreateSeatHold.FlightId)
+	if err != nil {
+		return nil, err
+	}
+
+	// проверки рейса:
+	// до вылета осталось больше 2 часов (аналогично продаже билетов)
+	if flight.DepartureDate.Sub(paramsCreateSeatHold.Timestamp).Hours() < 2 {
+		return nil, terr.BadRequest("FLIGHT_ALREADY_CLOSED", "sale of tickets for the flight is closed")
+	}
+
+	// проверяем, что по переданному UserId существует пользователь
+	_, err = s.usersStorage.GetUserById(ctx, paramsCreateSeatHold.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// количество удержаний пользователя на рейсе ограничено
+	countSeatHolds, err := s.flightsStorage.GetCountUserSeatHolds(ctx, paramsCreateSeatHold.FlightId, paramsCreateSeatHold.UserId, paramsCreateSeatHold.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+	if countSeatHolds >= maxUserSeatHolds {
+		return nil, terr.BadRequest("SEAT_HOLD_LIMIT_EXCEEDED", fmt.Sprintf("user (id %s) has reached the limit of seat holds (%d) on the flight (id %s)", paramsCreateSeatHold.UserId, maxUserSeatHolds, flight.Id))
+	}
+
+	// удаляем истекшие удержания мест, чтобы они не уменьшали количество свободных мест
+	_, err = s.flightsStorage.DeleteExpiredSeatHolds(ctx, paramsCreateSeatHold.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	// проверяем, что на данном рейсе существуют места с заданным классом ClassSeatsId
+	vacantSeats, err := s.flightsStorage.GetFlightVacantSeatsByClassId(ctx, paramsCreateSeatHold.FlightId, paramsCreateSeatHold.ClassSeatsId)
+	if err != nil {
+		return nil, err
+	}
+
+	// проверки класса места:
+	// есть свободные места данного класса
+	if vacantSeats.CountVacantSeats <= 0 {
+		return nil, terr.BadRequest("NO_VACANT_SEAT", fmt.Sprintf("no vacant seats with class seat (id %s) ", paramsCreateSeatHold.ClassSeatsId))
+	}
+
+	// если место было указано, то проверяем, что место есть в списке свободных мест
+	if paramsCreateSeatHold.SeatId != nil {
+		isSeatVacant := false
+		seatId := *paramsCreateSeatHold.SeatId
+		for _, seat := range vacantSeats.Seats {
+			if seat.Id == seatId {
+				isSeatVacant = true
+				break
+			}
+		}
+
+		// место занято
+		if !isSeatVacant {
+			return nil, terr.BadRequest("SEAT_DOESNT_VACANT", fmt.Sprintf("seat (id %s) isn't in the list of vacant seats", seatId))
+		}
+	}
+
+	// удержание действует заданное в конфигурации время
+	paramsCreateSeatHold.ExpiresAt = paramsCreateSeatHold.Timestamp.Add(s.seatHoldTTL)
+
+	return s.flightsStorage.CreateSeatHold(ctx, paramsCreateSeatHold)
+}
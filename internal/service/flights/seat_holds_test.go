@@ -0,0 +1,135 @@
+package flights
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	usersDomain "homework/internal/domain/users"
+	mockFlightsService "homework/internal/service/flights/mock"
+	"homework/internal/util/terr"
+)
+
+func Test_CreateSeatHold(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	seatHoldTTL := 5 * time.Minute
+	flightId := uuid.MustParse("7d5925a6-2016-4c72-9298-517fc40d936c")
+	userId := uuid.MustParse("07d87607-1f06-4599-8af5-07229525c106")
+	classSeatsId := uuid.MustParse("2c4b1c4e-0d7a-4a7c-9a57-5f5c2f1a9b10")
+	seatId := uuid.MustParse("b1f5bb9c-4a38-4d3e-a2a6-a8c3de43e3a7")
+	otherSeatId := uuid.MustParse("c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c")
+
+	flight := &flightsDomain.Flight{Id: flightId, DepartureDate: timestamp.AddDate(0, 0, 7)}
+	vacantSeats := &flightsDomain.VacantSeats{
+		ClassSeatsId:     classSeatsId,
+		CountVacantSeats: 2,
+		Seats:            []flightsDomain.Seat{{Id: seatId}, {Id: otherSeatId}},
+	}
+	seatHold := &flightsDomain.SeatHold{
+		Id:           uuid.MustParse("5e0b0a6f-8f0a-4b67-9c55-0a4f1d3e2b11"),
+		FlightId:     flightId,
+		ClassSeatsId: classSeatsId,
+		SeatId:       &seatId,
+		UserId:       userId,
+		Timestamp:    timestamp,
+		ExpiresAt:    timestamp.Add(seatHoldTTL),
+	}
+
+	var tests = []struct {
+		name           string
+		flight         *flightsDomain.Flight
+		countSeatHolds int
+		vacantSeats    *flightsDomain.VacantSeats
+		want           *flightsDomain.SeatHold
+		err            error
+	}{
+		{
+			name:        "success",
+			flight:      flight,
+			vacantSeats: vacantSeats,
+			want:        seatHold,
+			err:         nil,
+		},
+		{
+			name:   "fail/flight already closed",
+			flight: &flightsDomain.Flight{Id: flightId, DepartureDate: timestamp.Add(time.Hour)},
+			want:   nil,
+			err:    terr.BadRequest("FLIGHT_ALREADY_CLOSED", "sale of tickets for the flight is closed"),
+		},
+		{
+			name:           "fail/seat hold limit exceeded",
+			flight:         flight,
+			countSeatHolds: maxUserSeatHolds,
+			want:           nil,
+			err:            terr.BadRequest("SEAT_HOLD_LIMIT_EXCEEDED", "user (id 07d87607-1f06-4599-8af5-07229525c106) has reached the limit of seat holds (9) on the flight (id 7d5925a6-2016-4c72-9298-517fc40d936c)"),
+		},
+		{
+			name:        "fail/no vacant seats",
+			flight:      flight,
+			vacantSeats: &flightsDomain.VacantSeats{ClassSeatsId: classSeatsId, CountVacantSeats: 0},
+			want:        nil,
+			err:         terr.BadRequest("NO_VACANT_SEAT", "no vacant seats with class seat (id 2c4b1c4e-0d7a-4a7c-9a57-5f5c2f1a9b10) "),
+		},
+		{
+			name:        "fail/class oversold",
+			flight:      flight,
+			vacantSeats: &flightsDomain.VacantSeats{ClassSeatsId: classSeatsId, CountVacantSeats: -2, Seats: []flightsDomain.Seat{{Id: seatId}}},
+			want:        nil,
+			err:         terr.BadRequest("NO_VACANT_SEAT", "no vacant seats with class seat (id 2c4b1c4e-0d7a-4a7c-9a57-5f5c2f1a9b10) "),
+		},
+		{
+			name:        "fail/seat isn't vacant",
+			flight:      flight,
+			vacantSeats: &flightsDomain.VacantSeats{ClassSeatsId: classSeatsId, CountVacantSeats: 1, Seats: []flightsDomain.Seat{{Id: otherSeatId}}},
+			want:        nil,
+			err:         terr.BadRequest("SEAT_DOESNT_VACANT", "seat (id b1f5bb9c-4a38-4d3e-a2a6-a8c3de43e3a7) isn't in the list of vacant seats"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			paramsCreateSeatHold := &flightsDomain.ParamsCreateSeatHold{
+				Timestamp:    timestamp,
+				FlightId:     flightId,
+				UserId:       userId,
+				ClassSeatsId: classSeatsId,
+				SeatId:       &seatId,
+			}
+
+			flightsStorage := mockFlightsService.NewMockFlightsStorage(ctrl)
+			usersStorage := mockFlightsService.NewMockUsersStorage(ctrl)
+			flightsStorage.EXPECT().GetFlightById(ctx, flightId).Return(tt.flight, nil)
+			usersStorage.EXPECT().GetUserById(ctx, userId).Return(&usersDomain.User{Id: userId}, nil).AnyTimes()
+			flightsStorage.EXPECT().GetCountUserSeatHolds(ctx, flightId, userId, timestamp).Return(tt.countSeatHolds, nil).AnyTimes()
+			flightsStorage.EXPECT().DeleteExpiredSeatHolds(ctx, timestamp).Return(int64(0), nil).AnyTimes()
+			flightsStorage.EXPECT().GetFlightVacantSeatsByClassId(ctx, flightId, classSeatsId).Return(tt.vacantSeats, nil).AnyTimes()
+			if tt.want != nil {
+				flightsStorage.EXPECT().CreateSeatHold(ctx, paramsCreateSeatHold).Return(tt.want, nil)
+			}
+			flightsService := NewFlightsService(flightsStorage, usersStorage, nil, seatHoldTTL)
+
+			// Act
+			got, err := flightsService.CreateSeatHold(ctx, paramsCreateSeatHold)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, timestamp.Add(seatHoldTTL), paramsCreateSeatHold.ExpiresAt)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package flights
+
+import (
+	"context"
+	"time"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
+)
+
+// setFlightTaxesFees пересчитывает сборы рейса в валюту цен рейса по курсу на момент timestamp
+// и рассчитывает стоимость билета взрослого с учетом сборов по каждому классу мест.
+// exchangeRates - курсы, уже полученные при выводе списка рейсов, в разрезе валюты сбора и валюты рейса
+func (s service) setFlightTaxesFees(ctx context.Context, flight *flightsDomain.Flight, exchangeRates map[[2]string]*money.ExchangeRate, timestamp time.Time) error {
+
+	sumTaxesFees := money.New(0, flight.Currency)
+	for i, taxFee := range flight.TaxesFees {
+		if taxFee.Price.Currency != flight.Currency {
+			currencies := [2]string{taxFee.Price.Currency, flight.Currency}
+			exchangeRate, ok := exchangeRates[currencies]
+			if !ok {
+				var err error
+				exchangeRate, err = s.exchangeRatesProvider.GetExchangeRate(ctx, taxFee.Price.Currency, flight.Currency, timestamp)
+				if err != nil {
+					return err
+				}
+				exchangeRates[currencies] = exchangeRate
+			}
+			flight.TaxesFees[i].Price = taxFee.Price.Convert(*exchangeRate)
+		}
+		sumTaxesFees = sumTaxesFees.Add(flight.TaxesFees[i].Price)
+	}
+
+	for i, flightPrice := range flight.PricesTickets {
+		flight.PricesTickets[i].PriceTotal = flightPrice.PriceTicket.Add(sumTaxesFees)
+	}
+	return nil
+}
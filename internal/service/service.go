@@ -2,6 +2,7 @@ package service
 
 import (
 	"homework/internal/config"
+	ticketsDomain "homework/internal/domain/tickets"
 	flightsService "homework/internal/service/flights"
 	ticketsService "homework/internal/service/tickets"
 	usersService "homework/internal/service/users"
@@ -17,11 +18,22 @@ type Services struct {
 func NewServiceRegistry(cfg *config.Config, Storages *storage.Storages) *Services {
 
 	flight := flightsService.NewFlightsService(
-		Storages.Flight)
+		Storages.Flight,
+		Storages.User,
+		Storages.Rate,
+		cfg.SeatHold.TTL,
+	)
 	ticket := ticketsService.NewTicketsService(
 		Storages.Ticket,
 		Storages.Flight,
 		Storages.User,
+		Storages.Rate,
+		ticketsDomain.Seller{
+			Name:    cfg.Seller.Name,
+			TaxId:   cfg.Seller.TaxId,
+			Address: cfg.Seller.Address,
+			Email:   cfg.Seller.Email,
+		},
 	)
 	user := usersService.NewUsersService(
 		Storages.User)
@@ -0,0 +1,274 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// наименования позиций состава стоимости билета, цены которых заданы в рейсе
+const (
+	ticketItemNameFare          = "Fare"
+	ticketItemNameExtraBaggage  = "Extra baggage"
+	ticketItemNameSeatSelection = "Seat selection"
+)
+
+// состав стоимости билета при оформлении: тариф, сборы рейса, дополнительный багаж, выбор места
+func getTicketItemsCreateTicket(paramsCreateTicket *ticketsDomain.ParamsCreateTicket, flight *flightsDomain.Flight, priceTicket money.Money) []ticketsDomain.TicketItem {
+
+	ticketItems := []ticketsDomain.TicketItem{
+		{
+			Type:      ticketsDomain.TicketItemTypeFare,
+			Name:      ticketItemNameFare,
+			Quantity:  1,
+			Price:     priceTicket,
+			Timestamp: paramsCreateTicket.StatusTimestamp,
+		},
+	}
+	ticketItems = append(ticketItems, getTicketItemsTaxesFees(paramsCreateTicket, flight)...)
+
+	if paramsCreateTicket.CountAdditionalBaggage > 0 {
+		ticketItems = append(ticketItems, ticketsDomain.TicketItem{
+			Type:      flightsDomain.AncillaryTypeExtraBaggage,
+			Name:      ticketItemNameExtraBaggage,
+			Quantity:  paramsCreateTicket.CountAdditionalBaggage,
+			Price:     flight.PriceAdditionalBaggage.Mul(paramsCreateTicket.CountAdditionalBaggage),
+			Timestamp: paramsCreateTicket.StatusTimestamp,
+		})
+	}
+
+	if paramsCreateTicket.SeatId != nil {
+		ticketItems = append(ticketItems, ticketsDomain.TicketItem{
+			Type:      flightsDomain.AncillaryTypeSeatSelection,
+			Name:      ticketItemNameSeatSelection,
+			Quantity:  1,
+			Price:     flight.PriceSeatSelection,
+			Timestamp: paramsCreateTicket.StatusTimestamp,
+		})
+	}
+
+	return ticketItems
+}
+
+// сумма позиций состава стоимости билета
+func getTicketItemsPrice(ticketItems []ticketsDomain.TicketItem) money.Money {
+
+	var price money.Money
+	for _, ticketItem := range ticketItems {
+		price = price.Add(ticketItem.Price)
+	}
+	return price
+}
+
+// проверка того, что услуга данного каталога рейса уже куплена по билету
+func isTicketAncillaryPurchased(ticket *ticketsDomain.Ticket, flightAncillaryId uuid.UUID) bool {
+
+	for _, ticketItem := range ticket.Items {
+		if ticketItem.FlightAncillaryId != nil && *ticketItem.FlightAncillaryId == flightAncillaryId {
+			return true
+		}
+	}
+	return false
+}
+
+func (s service) AddTicketAncillary(ctx context.Context, paramsAddTicketAncillary *ticketsDomain.ParamsAddTicketAncillary) (uuid.UUID, error) {
+
+	// по id получаем билет, к которому добавляется услуга
+	ticket, err := s.ticketsStorage.GetTicketById(ctx, paramsAddTicketAncillary.TicketId)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// проверки билета:
+	// услуги добавляются только к оплаченному билету со статусом 2 (Paid)
+	if ticket.Status.Id != 2 {
+		return uuid.UUID{}, terr.BadRequest("INVALID_STATUS_TICKET", fmt.Sprintf("ticket (id %s) has wrong status (%s)", paramsAddTicketAncillary.TicketId, ticket.Status.Name))
+	}
+
+	// продажа услуг закрывается одновременно с продажей билетов: за 2 часа до вылета
+	if ticket.Flight.DepartureDate.Sub(paramsAddTicketAncillary.Timestamp).Hours() < 2 {
+		return uuid.UUID{}, terr.BadRequest("ANCILLARY_SALE_CLOSED", "sale of ancillaries for the flight is closed")
+	}
+
+	// проверяем, что по переданному UserId существует пользователь
+	user, err := s.usersStorage.GetUserById(ctx, paramsAddTicketAncillary.UserId)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// проверки пользователя:
+	// переданный пользователь соответствует пользователю билета
+	if paramsAddTicketAncillary.UserId != ticket.User.Id {
+		return uuid.UUID{}, terr.BadRequest("INVALID_USER", fmt.Sprintf("the user (id %s) doesn't match the user of the ticket (id %s)", paramsAddTicketAncillary.UserId, ticket.User.Id))
+	}
+
+	// баланс пользователя должен быть заполнен, т.к. данный билет уже был куплен и это должно быть отражено в балансе пользователя
+	if user.Balance == nil {
+		return uuid.UUID{}, terr.BadRequest("INVALID_USER", "no information about the user's balance")
+	}
+
+	// количество услуг
+	if paramsAddTicketAncillary.Quantity < 1 {
+		return uuid.UUID{}, terr.BadRequest("INVALID_QUANTITY", "quantity must be positive")
+	}
+
+	// позиция состава стоимости билета формируется в зависимости от типа услуги
+	var ticketItem ticketsDomain.TicketItem
+	switch paramsAddTicketAncillary.Type {
+	case flightsDomain.AncillaryTypeExtraBaggage:
+		ticketItem, err = s.getTicketItemExtraBaggage(ticket, paramsAddTicketAncillary)
+	case flightsDomain.AncillaryTypeSeatSelection:
+		ticketItem, err = s.getTicketItemSeatSelection(ctx, ticket, paramsAddTicketAncillary)
+	case flightsDomain.AncillaryTypePetInCabin, flightsDomain.AncillaryTypePriorityBoarding, flightsDomain.AncillaryTypeMeal:
+		ticketItem, err = s.getTicketItemFlightAncillary(ctx, ticket, paramsAddTicketAncillary)
+	default:
+		return uuid.UUID{}, terr.BadRequest("INVALID_ANCILLARY_TYPE", fmt.Sprintf("ancillary type (%s) is not supported", paramsAddTicketAncillary.Type))
+	}
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// Все проверки пройдены
+
+	// Здесь по логике бизнес-процесса выполняется обращение к платежной системе
+	// и производится оплата услуги на сумму ticketItem.Price отдельно от оплаты билета
+	ticketItem.Timestamp = paramsAddTicketAncillary.Timestamp
+	paramsAddTicketAncillary.Item = ticketItem
+
+	// сумма покупок пользователя увеличивается на стоимость услуги в валюте учета по курсу, зафиксированному в билете
+	paramsAddTicketAncillary.BasePrice = ticketItem.Price.Convert(ticket.ExchangeRate)
+
+	// оплата услуги картой сохраняется в оплатах билета
+	paramsAddTicketAncillary.Payment = ticketsDomain.TicketPayment{
+		Method:    ticketsDomain.PaymentMethodCard,
+		Amount:    paramsAddTicketAncillary.BasePrice,
+		Timestamp: paramsAddTicketAncillary.Timestamp,
+	}
+
+	// Бонусы за услугу добавляются к бонусам билета и, как и они, поступят на счет пользователя после регистрации на рейс.
+	// Расчет бонусов - по тем же правилам программы лояльности, что и при оплате билета
+	accruedBonuses, err := s.usersStorage.GetAccruedBonuses(ctx, getParamsLoyaltyAncillary(ticket, paramsAddTicketAncillary.BasePrice, paramsAddTicketAncillary.Timestamp))
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	paramsAddTicketAncillary.AccruedBonuses = accruedBonuses
+
+	// Выполняем добавление услуги к билету, в т.ч. начисление бонусов за услугу, и изменение баланса пользователя
+	return s.ticketsStorage.AddTicketAncillary(ctx, paramsAddTicketAncillary)
+}
+
+// дополнительный багаж по цене рейса
+func (s service) getTicketItemExtraBaggage(ticket *ticketsDomain.Ticket, paramsAddTicketAncillary *ticketsDomain.ParamsAddTicketAncillary) (ticketsDomain.TicketItem, error) {
+
+	return ticketsDomain.TicketItem{
+		Type:     flightsDomain.AncillaryTypeExtraBaggage,
+		Name:     ticketItemNameExtraBaggage,
+		Quantity: paramsAddTicketAncillary.Quantity,
+		Price:    ticket.Flight.PriceAdditionalBaggage.Mul(paramsAddTicketAncillary.Quantity),
+	}, nil
+}
+
+// выбор места по цене рейса, если место еще не назначено
+func (s service) getTicketItemSeatSelection(ctx context.Context, ticket *ticketsDomain.Ticket, paramsAddTicketAncillary *ticketsDomain.ParamsAddTicketAncillary) (ticketsDomain.TicketItem, error) {
+
+	// младенцу на руках у взрослого место не назначается
+	if ticket.PassengerType == ticketsDomain.PassengerTypeInfant {
+		return ticketsDomain.TicketItem{}, terr.BadRequest("INFANT_SEAT_NOT_ALLOWED", "infant on lap can't have a seat")
+	}
+
+	// место уже назначено
+	if ticket.Seat != nil {
+		return ticketsDomain.TicketItem{}, terr.BadRequest("SEAT_ALREADY_ASSIGNED", fmt.Sprintf("ticket (id %s) already has a seat", ticket.Id))
+	}
+
+	if paramsAddTicketAncillary.SeatId == nil {
+		return ticketsDomain.TicketItem{}, terr.BadRequest("SEAT_DOESNT_ASSIGNED", "seat has to be assigned")
+	}
+	if paramsAddTicketAncillary.Quantity != 1 {
+		return ticketsDomain.TicketItem{}, terr.BadRequest("INVALID_QUANTITY", "only one seat can be selected")
+	}
+
+	// проверяем, что место есть в списке вакантных мест рейса по классу мест билета
+	vacantSeats, err := s.flightsStorage.GetFlightVacantSeatsByClassId(ctx, ticket.Flight.Id, ticket.ClassSeats.Id)
+	if err != nil {
+		return ticketsDomain.TicketItem{}, err
+	}
+
+	isSeatVacant := false
+	seatId := *paramsAddTicketAncillary.SeatId
+	for _, seat := range vacantSeats.Seats {
+		if seat.Id == seatId {
+			isSeatVacant = true
+			break
+		}
+	}
+	// место занято
+	if !isSeatVacant {
+		return ticketsDomain.TicketItem{}, terr.BadRequest("SEAT_DOESNT_VACANT", fmt.Sprintf("seat (id %s) isn't in the list of vacant seats", seatId))
+	}
+
+	return ticketsDomain.TicketItem{
+		Type:     flightsDomain.AncillaryTypeSeatSelection,
+		Name:     ticketItemNameSeatSelection,
+		Quantity: 1,
+		Price:    ticket.Flight.PriceSeatSelection,
+	}, nil
+}
+
+// услуга из каталога рейса: животное в салоне, приоритетная посадка, питание
+func (s service) getTicketItemFlightAncillary(ctx context.Context, ticket *ticketsDomain.Ticket, paramsAddTicketAncillary *ticketsDomain.ParamsAddTicketAncillary) (ticketsDomain.TicketItem, error) {
+
+	if paramsAddTicketAncillary.FlightAncillaryId == nil {
+		return ticketsDomain.TicketItem{}, terr.BadRequest("INVALID_FLIGHT_ANCILLARY", "flight ancillary has to be set")
+	}
+
+	// проверяем, что по переданному FlightAncillaryId существует услуга
+	flightAncillary, err := s.flightsStorage.GetFlightAncillaryById(ctx, *paramsAddTicketAncillary.FlightAncillaryId)
+	if err != nil {
+		return ticketsDomain.TicketItem{}, err
+	}
+
+	// проверки услуги:
+	// услуга из каталога рейса билета и соответствует переданному типу
+	if flightAncillary.FlightId != ticket.Flight.Id {
+		return ticketsDomain.TicketItem{}, terr.BadRequest("INVALID_FLIGHT_ANCILLARY", fmt.Sprintf("flight ancillary (id %s) isn't available on the flight (id %s)", flightAncillary.Id, ticket.Flight.Id))
+	}
+	if flightAncillary.Type != paramsAddTicketAncillary.Type {
+		return ticketsDomain.TicketItem{}, terr.BadRequest("INVALID_FLIGHT_ANCILLARY", fmt.Sprintf("flight ancillary (id %s) has type %s", flightAncillary.Id, flightAncillary.Type))
+	}
+
+	// животное в салоне и приоритетная посадка покупаются по билету один раз
+	if flightAncillary.Type == flightsDomain.AncillaryTypePetInCabin || flightAncillary.Type == flightsDomain.AncillaryTypePriorityBoarding {
+		if paramsAddTicketAncillary.Quantity != 1 {
+			return ticketsDomain.TicketItem{}, terr.BadRequest("INVALID_QUANTITY", fmt.Sprintf("%s can be purchased only once per ticket", flightAncillary.Type))
+		}
+		if isTicketAncillaryPurchased(ticket, flightAncillary.Id) {
+			return ticketsDomain.TicketItem{}, terr.Conflict("ANCILLARY_ALREADY_PURCHASED", fmt.Sprintf("flight ancillary (id %s) is already purchased for the ticket (id %s)", flightAncillary.Id, ticket.Id))
+		}
+	}
+
+	// перевозка животных разрешена на рейсе
+	if flightAncillary.Type == flightsDomain.AncillaryTypePetInCabin && !ticket.Flight.PetAllowed {
+		return ticketsDomain.TicketItem{}, terr.BadRequest("PET_NOT_ALLOWED", fmt.Sprintf("pets aren't allowed on the flight (id %s)", ticket.Flight.Id))
+	}
+
+	// количество услуг на рейсе ограничено
+	if flightAncillary.MaxCount != nil && flightAncillary.CountSold+paramsAddTicketAncillary.Quantity > *flightAncillary.MaxCount {
+		return ticketsDomain.TicketItem{}, terr.BadRequest("ANCILLARY_SOLD_OUT", fmt.Sprintf("flight ancillary (id %s) is sold out", flightAncillary.Id))
+	}
+
+	flightAncillaryId := flightAncillary.Id
+	return ticketsDomain.TicketItem{
+		Type:              flightAncillary.Type,
+		Name:              flightAncillary.Name,
+		FlightAncillaryId: &flightAncillaryId,
+		Quantity:          paramsAddTicketAncillary.Quantity,
+		Price:             flightAncillary.Price.Mul(paramsAddTicketAncillary.Quantity),
+	}, nil
+}
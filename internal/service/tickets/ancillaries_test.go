@@ -0,0 +1,284 @@
+package tickets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	usersDomain "homework/internal/domain/users"
+	mockTicketsService "homework/internal/service/tickets/mock"
+	"homework/internal/util/terr"
+)
+
+func Test_GetTicketItemsCreateTicket(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	seatId := uuid.MustParse("b1f5bb9c-4a38-4d3e-a2a6-a8c3de43e3a7")
+	flight := &flightsDomain.Flight{PriceAdditionalBaggage: money.New(150000, money.CurrencyRUB), PriceSeatSelection: money.New(50000, money.CurrencyRUB)}
+
+	fare := ticketsDomain.TicketItem{Type: ticketsDomain.TicketItemTypeFare, Name: ticketItemNameFare, Quantity: 1, Price: money.New(600000, money.CurrencyRUB), Timestamp: timestamp}
+	extraBaggage := ticketsDomain.TicketItem{Type: flightsDomain.AncillaryTypeExtraBaggage, Name: ticketItemNameExtraBaggage, Quantity: 2, Price: money.New(300000, money.CurrencyRUB), Timestamp: timestamp}
+	seatSelection := ticketsDomain.TicketItem{Type: flightsDomain.AncillaryTypeSeatSelection, Name: ticketItemNameSeatSelection, Quantity: 1, Price: money.New(50000, money.CurrencyRUB), Timestamp: timestamp}
+
+	var tests = []struct {
+		name      string
+		args      *ticketsDomain.ParamsCreateTicket
+		want      []ticketsDomain.TicketItem
+		wantPrice money.Money
+	}{
+		{
+			name:      "fare only",
+			args:      &ticketsDomain.ParamsCreateTicket{StatusTimestamp: timestamp},
+			want:      []ticketsDomain.TicketItem{fare},
+			wantPrice: money.New(600000, money.CurrencyRUB),
+		},
+		{
+			name:      "fare with extra baggage and seat selection",
+			args:      &ticketsDomain.ParamsCreateTicket{StatusTimestamp: timestamp, CountAdditionalBaggage: 2, SeatId: &seatId},
+			want:      []ticketsDomain.TicketItem{fare, extraBaggage, seatSelection},
+			wantPrice: money.New(950000, money.CurrencyRUB),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := getTicketItemsCreateTicket(tt.args, flight, money.New(600000, money.CurrencyRUB))
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPrice, getTicketItemsPrice(got))
+		})
+	}
+}
+
+func Test_IsTicketAncillaryPurchased(t *testing.T) {
+
+	// Arrange
+	flightAncillaryId := uuid.MustParse("2c4b1c4e-0d7a-4a7c-9a57-5f5c2f1a9b10")
+	otherFlightAncillaryId := uuid.MustParse("f0e1a2b3-c4d5-4e6f-8a9b-0c1d2e3f4a5b")
+	ticket := &ticketsDomain.Ticket{
+		Items: []ticketsDomain.TicketItem{
+			{Type: ticketsDomain.TicketItemTypeFare},
+			{Type: flightsDomain.AncillaryTypePriorityBoarding, FlightAncillaryId: &flightAncillaryId},
+		},
+	}
+
+	// Act, Assert
+	assert.True(t, isTicketAncillaryPurchased(ticket, flightAncillaryId))
+	assert.False(t, isTicketAncillaryPurchased(ticket, otherFlightAncillaryId))
+}
+
+func Test_AddTicketAncillary(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	ticketId := uuid.MustParse("6382589b-ab8e-4519-8c00-d0fe095179b3")
+	userId := uuid.MustParse("07d87607-1f06-4599-8af5-07229525c106")
+	otherUserId := uuid.MustParse("c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c")
+	flightId := uuid.MustParse("7d5925a6-2016-4c72-9298-517fc40d936c")
+	mealId := uuid.MustParse("5e0b0a6f-8f0a-4b67-9c55-0a4f1d3e2b11")
+	priorityBoardingId := uuid.MustParse("2c4b1c4e-0d7a-4a7c-9a57-5f5c2f1a9b10")
+	itemId := uuid.MustParse("b1f5bb9c-4a38-4d3e-a2a6-a8c3de43e3a7")
+	seatId := uuid.MustParse("c6eff2bf-525d-4b81-b995-d812874bbba8")
+	maxCount := 10
+	user := &usersDomain.User{Id: userId, Balance: &usersDomain.UserBalance{}}
+
+	newTicket := func(update func(ticket *ticketsDomain.Ticket)) *ticketsDomain.Ticket {
+		ticket := &ticketsDomain.Ticket{
+			Id:     ticketId,
+			Status: ticketsDomain.Status{Id: 2, Name: "Paid"},
+			Flight: flightsDomain.Flight{
+				Id:                     flightId,
+				DepartureDate:          timestamp.AddDate(0, 0, 2),
+				PriceAdditionalBaggage: money.New(150000, money.CurrencyRUB),
+			},
+			User:         usersDomain.User{Id: userId},
+			Price:        money.New(1000000, money.CurrencyRUB),
+			ExchangeRate: money.NewIdentityRate(money.CurrencyRUB, timestamp),
+		}
+		if update != nil {
+			update(ticket)
+		}
+		return ticket
+	}
+	meal := &flightsDomain.FlightAncillary{
+		Id:       mealId,
+		FlightId: flightId,
+		Type:     flightsDomain.AncillaryTypeMeal,
+		Name:     "Meal",
+		Price:    money.New(50000, money.CurrencyRUB),
+		MaxCount: &maxCount,
+	}
+
+	var tests = []struct {
+		name            string
+		ticket          *ticketsDomain.Ticket
+		user            *usersDomain.User
+		args            ticketsDomain.ParamsAddTicketAncillary
+		flightAncillary *flightsDomain.FlightAncillary
+		accruedBonuses  money.Money
+		wantItem        ticketsDomain.TicketItem
+		err             error
+	}{
+		{
+			name:           "success/extra baggage",
+			ticket:         newTicket(nil),
+			user:           user,
+			args:           ticketsDomain.ParamsAddTicketAncillary{Type: flightsDomain.AncillaryTypeExtraBaggage, Quantity: 2},
+			accruedBonuses: money.New(15000, money.CurrencyRUB),
+			wantItem: ticketsDomain.TicketItem{
+				Type:      flightsDomain.AncillaryTypeExtraBaggage,
+				Name:      ticketItemNameExtraBaggage,
+				Quantity:  2,
+				Price:     money.New(300000, money.CurrencyRUB),
+				Timestamp: timestamp,
+			},
+			err: nil,
+		},
+		{
+			name:            "success/meal from catalog",
+			ticket:          newTicket(nil),
+			user:            user,
+			args:            ticketsDomain.ParamsAddTicketAncillary{Type: flightsDomain.AncillaryTypeMeal, FlightAncillaryId: &mealId, Quantity: 2},
+			flightAncillary: meal,
+			accruedBonuses:  money.New(0, money.CurrencyRUB),
+			wantItem: ticketsDomain.TicketItem{
+				Type:              flightsDomain.AncillaryTypeMeal,
+				Name:              "Meal",
+				FlightAncillaryId: &mealId,
+				Quantity:          2,
+				Price:             money.New(100000, money.CurrencyRUB),
+				Timestamp:         timestamp,
+			},
+			err: nil,
+		},
+		{
+			name:   "fail/ticket isn't paid",
+			ticket: newTicket(func(ticket *ticketsDomain.Ticket) { ticket.Status = ticketsDomain.Status{Id: 4, Name: "Refunded"} }),
+			args:   ticketsDomain.ParamsAddTicketAncillary{Type: flightsDomain.AncillaryTypeExtraBaggage, Quantity: 1},
+			err:    terr.BadRequest("INVALID_STATUS_TICKET", "ticket (id 6382589b-ab8e-4519-8c00-d0fe095179b3) has wrong status (Refunded)"),
+		},
+		{
+			name:   "fail/ancillary sale closed",
+			ticket: newTicket(func(ticket *ticketsDomain.Ticket) { ticket.Flight.DepartureDate = timestamp.Add(time.Hour) }),
+			args:   ticketsDomain.ParamsAddTicketAncillary{Type: flightsDomain.AncillaryTypeExtraBaggage, Quantity: 1},
+			err:    terr.BadRequest("ANCILLARY_SALE_CLOSED", "sale of ancillaries for the flight is closed"),
+		},
+		{
+			name:   "fail/another user",
+			ticket: newTicket(func(ticket *ticketsDomain.Ticket) { ticket.User.Id = otherUserId }),
+			user:   user,
+			args:   ticketsDomain.ParamsAddTicketAncillary{Type: flightsDomain.AncillaryTypeExtraBaggage, Quantity: 1},
+			err:    terr.BadRequest("INVALID_USER", "the user (id 07d87607-1f06-4599-8af5-07229525c106) doesn't match the user of the ticket (id c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c)"),
+		},
+		{
+			name:   "fail/no user balance",
+			ticket: newTicket(nil),
+			user:   &usersDomain.User{Id: userId},
+			args:   ticketsDomain.ParamsAddTicketAncillary{Type: flightsDomain.AncillaryTypeExtraBaggage, Quantity: 1},
+			err:    terr.BadRequest("INVALID_USER", "no information about the user's balance"),
+		},
+		{
+			name:   "fail/invalid quantity",
+			ticket: newTicket(nil),
+			user:   user,
+			args:   ticketsDomain.ParamsAddTicketAncillary{Type: flightsDomain.AncillaryTypeExtraBaggage, Quantity: 0},
+			err:    terr.BadRequest("INVALID_QUANTITY", "quantity must be positive"),
+		},
+		{
+			name:   "fail/unsupported ancillary type",
+			ticket: newTicket(nil),
+			user:   user,
+			args:   ticketsDomain.ParamsAddTicketAncillary{Type: "lounge", Quantity: 1},
+			err:    terr.BadRequest("INVALID_ANCILLARY_TYPE", "ancillary type (lounge) is not supported"),
+		},
+		{
+			name:            "fail/ancillary sold out",
+			ticket:          newTicket(nil),
+			user:            user,
+			args:            ticketsDomain.ParamsAddTicketAncillary{Type: flightsDomain.AncillaryTypeMeal, FlightAncillaryId: &mealId, Quantity: 2},
+			flightAncillary: &flightsDomain.FlightAncillary{Id: mealId, FlightId: flightId, Type: flightsDomain.AncillaryTypeMeal, Price: meal.Price, MaxCount: &maxCount, CountSold: 9},
+			err:             terr.BadRequest("ANCILLARY_SOLD_OUT", "flight ancillary (id 5e0b0a6f-8f0a-4b67-9c55-0a4f1d3e2b11) is sold out"),
+		},
+		{
+			name: "fail/priority boarding already purchased",
+			ticket: newTicket(func(ticket *ticketsDomain.Ticket) {
+				ticket.Items = []ticketsDomain.TicketItem{{Type: flightsDomain.AncillaryTypePriorityBoarding, FlightAncillaryId: &priorityBoardingId}}
+			}),
+			user:            user,
+			args:            ticketsDomain.ParamsAddTicketAncillary{Type: flightsDomain.AncillaryTypePriorityBoarding, FlightAncillaryId: &priorityBoardingId, Quantity: 1},
+			flightAncillary: &flightsDomain.FlightAncillary{Id: priorityBoardingId, FlightId: flightId, Type: flightsDomain.AncillaryTypePriorityBoarding},
+			err:             terr.Conflict("ANCILLARY_ALREADY_PURCHASED", "flight ancillary (id 2c4b1c4e-0d7a-4a7c-9a57-5f5c2f1a9b10) is already purchased for the ticket (id 6382589b-ab8e-4519-8c00-d0fe095179b3)"),
+		},
+		{
+			name:   "fail/seat already assigned",
+			ticket: newTicket(func(ticket *ticketsDomain.Ticket) { ticket.Seat = &flightsDomain.Seat{Id: seatId} }),
+			user:   user,
+			args:   ticketsDomain.ParamsAddTicketAncillary{Type: flightsDomain.AncillaryTypeSeatSelection, SeatId: &seatId, Quantity: 1},
+			err:    terr.BadRequest("SEAT_ALREADY_ASSIGNED", "ticket (id 6382589b-ab8e-4519-8c00-d0fe095179b3) already has a seat"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			paramsAddTicketAncillary := tt.args
+			paramsAddTicketAncillary.Timestamp = timestamp
+			paramsAddTicketAncillary.TicketId = ticketId
+			paramsAddTicketAncillary.UserId = userId
+
+			ticketsStorage := mockTicketsService.NewMockTicketsStorage(ctrl)
+			flightsStorage := mockTicketsService.NewMockFlightsStorage(ctrl)
+			usersStorage := mockTicketsService.NewMockUsersStorage(ctrl)
+			ticketsStorage.EXPECT().GetTicketById(ctx, ticketId).Return(tt.ticket, nil)
+			if tt.user != nil {
+				usersStorage.EXPECT().GetUserById(ctx, userId).Return(tt.user, nil)
+			}
+			if tt.flightAncillary != nil {
+				flightsStorage.EXPECT().GetFlightAncillaryById(ctx, tt.flightAncillary.Id).Return(tt.flightAncillary, nil)
+			}
+			var gotParams ticketsDomain.ParamsAddTicketAncillary
+			if tt.err == nil {
+				usersStorage.EXPECT().GetAccruedBonuses(ctx, gomock.Any()).Return(tt.accruedBonuses, nil)
+				ticketsStorage.EXPECT().
+					AddTicketAncillary(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, paramsAddTicketAncillary *ticketsDomain.ParamsAddTicketAncillary) (uuid.UUID, error) {
+						gotParams = *paramsAddTicketAncillary
+						return itemId, nil
+					})
+			}
+			s := service{ticketsStorage: ticketsStorage, flightsStorage: flightsStorage, usersStorage: usersStorage}
+
+			// Act
+			got, err := s.AddTicketAncillary(ctx, &paramsAddTicketAncillary)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, itemId, got)
+			assert.Equal(t, tt.wantItem, gotParams.Item)
+			assert.Equal(t, tt.wantItem.Price, gotParams.BasePrice)
+			assert.Equal(t, tt.accruedBonuses, gotParams.AccruedBonuses)
+			assert.Equal(t, ticketsDomain.TicketPayment{
+				Method:    ticketsDomain.PaymentMethodCard,
+				Amount:    tt.wantItem.Price,
+				Timestamp: timestamp,
+			}, gotParams.Payment)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package tickets
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// максимальная длина полей адреса пребывания в стране назначения
+const (
+	maxLenDestinationCity       = 100
+	maxLenDestinationAddress    = 200
+	maxLenDestinationPostalCode = 20
+)
+
+// validateApisData проверяет данные APIS пассажира билета при регистрации на рейс на момент timestamp.
+// для внутреннего рейса данные APIS не собираются - возвращается nil.
+// для международного рейса данные обязательны: документ (если не передан - документ пассажира, указанный в билете)
+// и адрес пребывания в стране назначения. возвращаются данные APIS с заполненным документом для сохранения
+func validateApisData(apis *ticketsDomain.ApisData, ticket *ticketsDomain.Ticket, timestamp time.Time) (*ticketsDomain.ApisData, error) {
+
+	if !ticket.Flight.IsInternational {
+		return nil, nil
+	}
+
+	// документ пассажира
+	document := ticket.Passenger.Document
+	if apis != nil && apis.Document != nil {
+		document = apis.Document
+
+		err := validateIdentityDocument(document, timestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		// документ принадлежит пассажиру билета: дата рождения совпадает с документом, указанным в билете
+		if ticket.Passenger.Document != nil && !document.BirthDate.Equal(ticket.Passenger.Document.BirthDate) {
+			return nil, terr.BadRequest("APIS_BIRTH_DATE_MISMATCH", fmt.Sprintf("birth date in the document doesn't match the passenger of the ticket (id %s)", ticket.Id))
+		}
+	}
+
+	err := validateFlightDocument(document, &ticket.Flight)
+	if err != nil {
+		return nil, err
+	}
+
+	if apis == nil {
+		return nil, terr.BadRequest("APIS_REQUIRED", fmt.Sprintf("advance passenger information is required for the ticket (id %s) of the international flight", ticket.Id))
+	}
+
+	// адрес пребывания в стране назначения
+	destinationAddress := ticketsDomain.DestinationAddress{
+		Country:    apis.DestinationAddress.Country,
+		City:       strings.TrimSpace(apis.DestinationAddress.City),
+		Address:    strings.TrimSpace(apis.DestinationAddress.Address),
+		PostalCode: strings.TrimSpace(apis.DestinationAddress.PostalCode),
+	}
+	if !regexpCountryCode.MatchString(destinationAddress.Country) {
+		return nil, terr.BadRequest("INVALID_DESTINATION_COUNTRY", fmt.Sprintf("destination country (%s) must be ISO 3166-1 alpha-2 code", destinationAddress.Country))
+	}
+	if destinationAddress.City == "" || len([]rune(destinationAddress.City)) > maxLenDestinationCity {
+		return nil, terr.BadRequest("INVALID_DESTINATION_CITY", fmt.Sprintf("destination city must contain from 1 to %d characters", maxLenDestinationCity))
+	}
+	if destinationAddress.Address == "" || len([]rune(destinationAddress.Address)) > maxLenDestinationAddress {
+		return nil, terr.BadRequest("INVALID_DESTINATION_ADDRESS", fmt.Sprintf("destination address must contain from 1 to %d characters", maxLenDestinationAddress))
+	}
+	if len([]rune(destinationAddress.PostalCode)) > maxLenDestinationPostalCode {
+		return nil, terr.BadRequest("INVALID_DESTINATION_POSTAL_CODE", fmt.Sprintf("destination postal code must contain no more than %d characters", maxLenDestinationPostalCode))
+	}
+
+	return &ticketsDomain.ApisData{
+		Document:           document,
+		DestinationAddress: destinationAddress,
+	}, nil
+}
@@ -0,0 +1,109 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+func Test_ValidateApisData(t *testing.T) {
+
+	// Arrange
+	ticketId := uuid.MustParse("6382589b-ab8e-4519-8c00-d0fe095179b3")
+	flightId := uuid.MustParse("7d5925a6-2016-4c72-9298-517fc40d936c")
+	timestamp := time.Date(2023, 6, 1, 8, 0, 0, 0, time.UTC)
+	departureDate := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	birthDate := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	otherBirthDate := time.Date(1991, 5, 17, 0, 0, 0, 0, time.UTC)
+	expiryDate := time.Date(2030, 5, 17, 0, 0, 0, 0, time.UTC)
+
+	passport := &ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypePassport, Number: "4011123456", IssuingCountry: "RU", Nationality: "RU", BirthDate: birthDate, ExpiryDate: &expiryDate}
+	newPassport := &ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypePassport, Number: "7522654321", IssuingCountry: "RU", Nationality: "RU", BirthDate: birthDate, ExpiryDate: &expiryDate}
+	otherPassport := &ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypePassport, Number: "7522654321", IssuingCountry: "RU", Nationality: "RU", BirthDate: otherBirthDate, ExpiryDate: &expiryDate}
+	destinationAddress := ticketsDomain.DestinationAddress{Country: "TR", City: "Antalya", Address: " Lara Cd. 15 ", PostalCode: "07230"}
+
+	getTicket := func(isInternational bool, document *ticketsDomain.IdentityDocument) *ticketsDomain.Ticket {
+		return &ticketsDomain.Ticket{
+			Id:        ticketId,
+			Flight:    flightsDomain.Flight{Id: flightId, DepartureDate: departureDate, Duration: 3 * time.Hour, IsInternational: isInternational},
+			Passenger: ticketsDomain.Passenger{Document: document},
+		}
+	}
+
+	type args struct {
+		apis   *ticketsDomain.ApisData
+		ticket *ticketsDomain.Ticket
+	}
+	var tests = []struct {
+		name string
+		args args
+		want *ticketsDomain.ApisData
+		err  error
+	}{
+		{
+			name: "success/domestic flight",
+			args: args{apis: &ticketsDomain.ApisData{DestinationAddress: destinationAddress}, ticket: getTicket(false, nil)},
+			want: nil,
+			err:  nil,
+		},
+		{
+			name: "success/document of the ticket",
+			args: args{apis: &ticketsDomain.ApisData{DestinationAddress: destinationAddress}, ticket: getTicket(true, passport)},
+			want: &ticketsDomain.ApisData{Document: passport, DestinationAddress: ticketsDomain.DestinationAddress{Country: "TR", City: "Antalya", Address: "Lara Cd. 15", PostalCode: "07230"}},
+			err:  nil,
+		},
+		{
+			name: "success/new document",
+			args: args{apis: &ticketsDomain.ApisData{Document: newPassport, DestinationAddress: destinationAddress}, ticket: getTicket(true, passport)},
+			want: &ticketsDomain.ApisData{Document: newPassport, DestinationAddress: ticketsDomain.DestinationAddress{Country: "TR", City: "Antalya", Address: "Lara Cd. 15", PostalCode: "07230"}},
+			err:  nil,
+		},
+		{
+			name: "fail/no document",
+			args: args{apis: &ticketsDomain.ApisData{DestinationAddress: destinationAddress}, ticket: getTicket(true, nil)},
+			want: nil,
+			err:  terr.BadRequest("PASSPORT_REQUIRED", "passport is required for the international flight (id 7d5925a6-2016-4c72-9298-517fc40d936c)"),
+		},
+		{
+			name: "fail/no apis data",
+			args: args{apis: nil, ticket: getTicket(true, passport)},
+			want: nil,
+			err:  terr.BadRequest("APIS_REQUIRED", "advance passenger information is required for the ticket (id 6382589b-ab8e-4519-8c00-d0fe095179b3) of the international flight"),
+		},
+		{
+			name: "fail/document of another passenger",
+			args: args{apis: &ticketsDomain.ApisData{Document: otherPassport, DestinationAddress: destinationAddress}, ticket: getTicket(true, passport)},
+			want: nil,
+			err:  terr.BadRequest("APIS_BIRTH_DATE_MISMATCH", "birth date in the document doesn't match the passenger of the ticket (id 6382589b-ab8e-4519-8c00-d0fe095179b3)"),
+		},
+		{
+			name: "fail/invalid destination country",
+			args: args{apis: &ticketsDomain.ApisData{DestinationAddress: ticketsDomain.DestinationAddress{Country: "TUR", City: "Antalya", Address: "Lara Cd. 15"}}, ticket: getTicket(true, passport)},
+			want: nil,
+			err:  terr.BadRequest("INVALID_DESTINATION_COUNTRY", "destination country (TUR) must be ISO 3166-1 alpha-2 code"),
+		},
+		{
+			name: "fail/empty destination address",
+			args: args{apis: &ticketsDomain.ApisData{DestinationAddress: ticketsDomain.DestinationAddress{Country: "TR", City: "Antalya", Address: "  "}}, ticket: getTicket(true, passport)},
+			want: nil,
+			err:  terr.BadRequest("INVALID_DESTINATION_ADDRESS", "destination address must contain from 1 to 200 characters"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := validateApisData(tt.args.apis, tt.args.ticket, timestamp)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
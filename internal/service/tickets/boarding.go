@@ -0,0 +1,151 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	flightsDomain "homework/internal/domain/flights"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// посадка на рейс начинается после окончания регистрации - за 1 час до вылета
+const boardingStartBeforeDeparture = time.Hour
+
+// номер места в посадочном талоне младенца без места
+const boardingPassInfantSeat = "INF"
+
+// BoardTicket отмечает посадку пассажира на рейс при сканировании на выходе на посадку.
+// сканируется посадочный талон или id билета. посадить можно только зарегистрированный билет (статус 5 Registered).
+// билету устанавливается статус 7 (Boarded), пользователю начисляются бонусы за билет
+func (s service) BoardTicket(ctx context.Context, paramsBoardTicket *ticketsDomain.ParamsBoardTicket) (uuid.UUID, error) {
+
+	// код посадочного талона или id билета
+	ticketId, isBoardingPass, err := parseBoardingCode(paramsBoardTicket.Code)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// проверяем, что по переданному FlightId существует рейс и посадка на рейс идет
+	flight, err := s.flightsStorage.GetFlightById(ctx, paramsBoardTicket.FlightId)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	err = validateBoardingTime(flight, paramsBoardTicket.StatusTimestamp)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	ticket, err := s.ticketsStorage.GetTicketById(ctx, ticketId)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// проверки билета:
+	// билет на данный рейс
+	if ticket.Flight.Id != flight.Id {
+		return uuid.UUID{}, terr.BadRequest("WRONG_FLIGHT", fmt.Sprintf("ticket (id %s) is for another flight (id %s)", ticket.Id, ticket.Flight.Id))
+	}
+
+	// посадить можно только зарегистрированный билет со статусом 5 (Registered)
+	if ticket.Status.Id == 7 {
+		return uuid.UUID{}, terr.Conflict("TICKET_ALREADY_BOARDED", fmt.Sprintf("ticket (id %s) is already boarded", ticket.Id))
+	}
+	if ticket.Status.Id != 5 {
+		return uuid.UUID{}, terr.BadRequest("INVALID_STATUS_TICKET", fmt.Sprintf("ticket (id %s) has wrong status (%s)", ticket.Id, ticket.Status.Name))
+	}
+
+	// посадочный талон соответствует текущим рейсу и месту билета (например, место не менялось после печати талона)
+	if isBoardingPass && !strings.EqualFold(strings.TrimSpace(paramsBoardTicket.Code), formatBoardingPassCode(ticket)) {
+		return uuid.UUID{}, terr.BadRequest("BOARDING_PASS_OUTDATED", fmt.Sprintf("boarding pass doesn't match the ticket (id %s)", ticket.Id))
+	}
+
+	// младенец проходит на посадку вместе с сопровождающим взрослым, который уже прошел посадку
+	if ticket.PassengerType == ticketsDomain.PassengerTypeInfant && ticket.AccompanyingTicketId != nil {
+		accompanyingTicket, err := s.ticketsStorage.GetTicketById(ctx, *ticket.AccompanyingTicketId)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+		if accompanyingTicket.Status.Id != 7 {
+			return uuid.UUID{}, terr.BadRequest("ACCOMPANYING_NOT_BOARDED", fmt.Sprintf("accompanying ticket (id %s) isn't boarded", accompanyingTicket.Id))
+		}
+	}
+
+	// Все проверки пройдены
+
+	// Выполняем изменение билета и начисление бонусов за билет на баланс пользователя
+	paramsBoardTicket.TicketId = ticket.Id
+	return s.ticketsStorage.BoardTicket(ctx, paramsBoardTicket)
+}
+
+// CloseFlight закрывает рейс по окончании посадки:
+// зарегистрированные билеты, по которым посадка не пройдена, получают статус 8 (NoShow),
+// оплаченные незарегистрированные билеты - статус 6 (Closed)
+func (s service) CloseFlight(ctx context.Context, paramsCloseFlight *ticketsDomain.ParamsCloseFlight) (*ticketsDomain.ClosedFlight, error) {
+
+	// проверяем, что по переданному FlightId существует рейс и посадка на рейс началась
+	flight, err := s.flightsStorage.GetFlightById(ctx, paramsCloseFlight.FlightId)
+	if err != nil {
+		return nil, err
+	}
+	err = validateBoardingTime(flight, paramsCloseFlight.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ticketsStorage.CloseFlight(ctx, paramsCloseFlight)
+}
+
+// validateBoardingTime проверяет, что посадка на рейс идет на момент timestamp:
+// рейс не закрыт и до вылета осталось не больше 1 часа
+func validateBoardingTime(flight *flightsDomain.Flight, timestamp time.Time) error {
+
+	if flight.ClosedTimestamp != nil {
+		return terr.Conflict("FLIGHT_ALREADY_CLOSED", fmt.Sprintf("flight (id %s) is already closed", flight.Id))
+	}
+	if flight.DepartureDate.Sub(timestamp) > boardingStartBeforeDeparture {
+		return terr.BadRequest("BOARDING_DOESNT_START", "boarding hasn't started yet")
+	}
+	return nil
+}
+
+// formatBoardingPassCode формирует код посадочного талона зарегистрированного билета:
+// рейс, место (INF для младенца без места) и id билета без дефисов, разделенные символом '/'
+func formatBoardingPassCode(ticket *ticketsDomain.Ticket) string {
+
+	seatNumber := boardingPassInfantSeat
+	if ticket.Seat != nil {
+		seatNumber = ticket.Seat.Number
+	}
+	return fmt.Sprintf("%s/%s/%s",
+		strings.ReplaceAll(ticket.Flight.Name, " ", ""),
+		seatNumber,
+		strings.ReplaceAll(ticket.Id.String(), "-", ""),
+	)
+}
+
+// parseBoardingCode получает id билета из отсканированного кода: id билета или код посадочного талона.
+// возвращает признак того, что отсканирован посадочный талон
+func parseBoardingCode(code string) (uuid.UUID, bool, error) {
+
+	code = strings.TrimSpace(code)
+
+	parts := strings.Split(code, "/")
+	switch len(parts) {
+	case 1:
+		ticketId, err := uuid.Parse(code)
+		if err == nil {
+			return ticketId, false, nil
+		}
+	case 3:
+		ticketId, err := uuid.Parse(parts[2])
+		if err == nil {
+			return ticketId, true, nil
+		}
+	}
+	return uuid.UUID{}, false, terr.BadRequest("INVALID_BOARDING_CODE", fmt.Sprintf("code (%s) is neither a boarding pass nor a ticket id", code))
+}
@@ -0,0 +1,329 @@
+package tickets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	ticketsDomain "homework/internal/domain/tickets"
+	mockTicketsService "homework/internal/service/tickets/mock"
+	"homework/internal/util/terr"
+)
+
+func Test_ParseBoardingCode(t *testing.T) {
+
+	// Arrange
+	ticketId := uuid.MustParse("6382589b-ab8e-4519-8c00-d0fe095179b3")
+
+	var tests = []struct {
+		name           string
+		code           string
+		want           uuid.UUID
+		isBoardingPass bool
+		err            error
+	}{
+		{
+			name:           "success/ticket id",
+			code:           " 6382589b-ab8e-4519-8c00-d0fe095179b3 ",
+			want:           ticketId,
+			isBoardingPass: false,
+			err:            nil,
+		},
+		{
+			name:           "success/boarding pass",
+			code:           "SU1234/12A/6382589BAB8E45198C00D0FE095179B3",
+			want:           ticketId,
+			isBoardingPass: true,
+			err:            nil,
+		},
+		{
+			name:           "fail/invalid code",
+			code:           "SU1234/12A",
+			want:           uuid.UUID{},
+			isBoardingPass: false,
+			err:            terr.BadRequest("INVALID_BOARDING_CODE", "code (SU1234/12A) is neither a boarding pass nor a ticket id"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, isBoardingPass, err := parseBoardingCode(tt.code)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.isBoardingPass, isBoardingPass)
+		})
+	}
+}
+
+func Test_FormatBoardingPassCode(t *testing.T) {
+
+	// Arrange
+	ticketId := uuid.MustParse("6382589b-ab8e-4519-8c00-d0fe095179b3")
+	flight := flightsDomain.Flight{Name: "SU 1234"}
+
+	var tests = []struct {
+		name   string
+		ticket *ticketsDomain.Ticket
+		want   string
+	}{
+		{
+			name:   "success/seat",
+			ticket: &ticketsDomain.Ticket{Id: ticketId, Flight: flight, Seat: &flightsDomain.Seat{Number: "12A"}},
+			want:   "SU1234/12A/6382589bab8e45198c00d0fe095179b3",
+		},
+		{
+			name:   "success/infant without seat",
+			ticket: &ticketsDomain.Ticket{Id: ticketId, Flight: flight},
+			want:   "SU1234/INF/6382589bab8e45198c00d0fe095179b3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := formatBoardingPassCode(tt.ticket)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ValidateBoardingTime(t *testing.T) {
+
+	// Arrange
+	flightId := uuid.MustParse("7d5925a6-2016-4c72-9298-517fc40d936c")
+	departureDate := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	closedTimestamp := departureDate.Add(-10 * time.Minute)
+
+	var tests = []struct {
+		name      string
+		flight    *flightsDomain.Flight
+		timestamp time.Time
+		err       error
+	}{
+		{
+			name:      "success/boarding",
+			flight:    &flightsDomain.Flight{Id: flightId, DepartureDate: departureDate},
+			timestamp: departureDate.Add(-40 * time.Minute),
+			err:       nil,
+		},
+		{
+			name:      "fail/boarding doesn't start",
+			flight:    &flightsDomain.Flight{Id: flightId, DepartureDate: departureDate},
+			timestamp: departureDate.Add(-2 * time.Hour),
+			err:       terr.BadRequest("BOARDING_DOESNT_START", "boarding hasn't started yet"),
+		},
+		{
+			name:      "fail/flight is closed",
+			flight:    &flightsDomain.Flight{Id: flightId, DepartureDate: departureDate, ClosedTimestamp: &closedTimestamp},
+			timestamp: departureDate.Add(-5 * time.Minute),
+			err:       terr.Conflict("FLIGHT_ALREADY_CLOSED", "flight (id 7d5925a6-2016-4c72-9298-517fc40d936c) is already closed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := validateBoardingTime(tt.flight, tt.timestamp)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func Test_BoardTicket(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	ticketId := uuid.MustParse("6382589b-ab8e-4519-8c00-d0fe095179b3")
+	accompanyingTicketId := uuid.MustParse("1f0e4c3a-93f6-4a8e-b0a5-2b8f1c6d7e90")
+	flightId := uuid.MustParse("7d5925a6-2016-4c72-9298-517fc40d936c")
+	otherFlightId := uuid.MustParse("c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c")
+	boardingPassCode := "SU1234/12A/6382589bab8e45198c00d0fe095179b3"
+	closedTimestamp := timestamp.Add(-5 * time.Minute)
+
+	newFlight := func(update func(flight *flightsDomain.Flight)) *flightsDomain.Flight {
+		flight := &flightsDomain.Flight{
+			Id:            flightId,
+			Name:          "SU 1234",
+			DepartureDate: timestamp.Add(30 * time.Minute),
+		}
+		if update != nil {
+			update(flight)
+		}
+		return flight
+	}
+	newTicket := func(update func(ticket *ticketsDomain.Ticket)) *ticketsDomain.Ticket {
+		ticket := &ticketsDomain.Ticket{
+			Id:            ticketId,
+			Status:        ticketsDomain.Status{Id: 5, Name: "Registered"},
+			Flight:        *newFlight(nil),
+			PassengerType: ticketsDomain.PassengerTypeAdult,
+			Seat:          &flightsDomain.Seat{Number: "12A"},
+		}
+		if update != nil {
+			update(ticket)
+		}
+		return ticket
+	}
+	newInfantTicket := func() *ticketsDomain.Ticket {
+		return newTicket(func(ticket *ticketsDomain.Ticket) {
+			ticket.PassengerType = ticketsDomain.PassengerTypeInfant
+			ticket.AccompanyingTicketId = &accompanyingTicketId
+			ticket.Seat = nil
+		})
+	}
+
+	var tests = []struct {
+		name               string
+		code               string
+		flight             *flightsDomain.Flight
+		ticket             *ticketsDomain.Ticket
+		accompanyingTicket *ticketsDomain.Ticket
+		storageErr         error
+		err                error
+	}{
+		{
+			name:   "success/boarding pass",
+			code:   boardingPassCode,
+			flight: newFlight(nil),
+			ticket: newTicket(nil),
+			err:    nil,
+		},
+		{
+			name:               "success/infant with boarded accompanying adult",
+			code:               ticketId.String(),
+			flight:             newFlight(nil),
+			ticket:             newInfantTicket(),
+			accompanyingTicket: &ticketsDomain.Ticket{Id: accompanyingTicketId, Status: ticketsDomain.Status{Id: 7, Name: "Boarded"}},
+			err:                nil,
+		},
+		{
+			name: "fail/invalid code",
+			code: "SU1234/12A",
+			err:  terr.BadRequest("INVALID_BOARDING_CODE", "code (SU1234/12A) is neither a boarding pass nor a ticket id"),
+		},
+		{
+			name:   "fail/boarding doesn't start",
+			code:   boardingPassCode,
+			flight: newFlight(func(flight *flightsDomain.Flight) { flight.DepartureDate = timestamp.Add(2 * time.Hour) }),
+			err:    terr.BadRequest("BOARDING_DOESNT_START", "boarding hasn't started yet"),
+		},
+		{
+			name:   "fail/flight is closed",
+			code:   boardingPassCode,
+			flight: newFlight(func(flight *flightsDomain.Flight) { flight.ClosedTimestamp = &closedTimestamp }),
+			err:    terr.Conflict("FLIGHT_ALREADY_CLOSED", "flight (id 7d5925a6-2016-4c72-9298-517fc40d936c) is already closed"),
+		},
+		{
+			name:   "fail/ticket for another flight",
+			code:   boardingPassCode,
+			flight: newFlight(nil),
+			ticket: newTicket(func(ticket *ticketsDomain.Ticket) { ticket.Flight.Id = otherFlightId }),
+			err:    terr.BadRequest("WRONG_FLIGHT", "ticket (id 6382589b-ab8e-4519-8c00-d0fe095179b3) is for another flight (id c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c)"),
+		},
+		{
+			name:   "fail/already boarded",
+			code:   boardingPassCode,
+			flight: newFlight(nil),
+			ticket: newTicket(func(ticket *ticketsDomain.Ticket) { ticket.Status = ticketsDomain.Status{Id: 7, Name: "Boarded"} }),
+			err:    terr.Conflict("TICKET_ALREADY_BOARDED", "ticket (id 6382589b-ab8e-4519-8c00-d0fe095179b3) is already boarded"),
+		},
+		{
+			name:   "fail/ticket isn't registered",
+			code:   boardingPassCode,
+			flight: newFlight(nil),
+			ticket: newTicket(func(ticket *ticketsDomain.Ticket) { ticket.Status = ticketsDomain.Status{Id: 2, Name: "Paid"} }),
+			err:    terr.BadRequest("INVALID_STATUS_TICKET", "ticket (id 6382589b-ab8e-4519-8c00-d0fe095179b3) has wrong status (Paid)"),
+		},
+		{
+			name:   "fail/boarding pass outdated",
+			code:   boardingPassCode,
+			flight: newFlight(nil),
+			ticket: newTicket(func(ticket *ticketsDomain.Ticket) { ticket.Seat = &flightsDomain.Seat{Number: "14C"} }),
+			err:    terr.BadRequest("BOARDING_PASS_OUTDATED", "boarding pass doesn't match the ticket (id 6382589b-ab8e-4519-8c00-d0fe095179b3)"),
+		},
+		{
+			name:               "fail/accompanying adult isn't boarded",
+			code:               ticketId.String(),
+			flight:             newFlight(nil),
+			ticket:             newInfantTicket(),
+			accompanyingTicket: &ticketsDomain.Ticket{Id: accompanyingTicketId, Status: ticketsDomain.Status{Id: 5, Name: "Registered"}},
+			err:                terr.BadRequest("ACCOMPANYING_NOT_BOARDED", "accompanying ticket (id 1f0e4c3a-93f6-4a8e-b0a5-2b8f1c6d7e90) isn't boarded"),
+		},
+		{
+			name:       "fail/ticket status changed concurrently",
+			code:       boardingPassCode,
+			flight:     newFlight(nil),
+			ticket:     newTicket(nil),
+			storageErr: terr.Conflict("INVALID_STATUS_TICKET", "ticket (id 6382589b-ab8e-4519-8c00-d0fe095179b3) isn't registered anymore"),
+			err:        terr.Conflict("INVALID_STATUS_TICKET", "ticket (id 6382589b-ab8e-4519-8c00-d0fe095179b3) isn't registered anymore"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			paramsBoardTicket := &ticketsDomain.ParamsBoardTicket{
+				StatusTimestamp: timestamp,
+				FlightId:        flightId,
+				Code:            tt.code,
+			}
+
+			ticketsStorage := mockTicketsService.NewMockTicketsStorage(ctrl)
+			flightsStorage := mockTicketsService.NewMockFlightsStorage(ctrl)
+			if tt.flight != nil {
+				flightsStorage.EXPECT().GetFlightById(ctx, flightId).Return(tt.flight, nil)
+			}
+			if tt.ticket != nil {
+				ticketsStorage.EXPECT().GetTicketById(ctx, ticketId).Return(tt.ticket, nil)
+			}
+			if tt.accompanyingTicket != nil {
+				ticketsStorage.EXPECT().GetTicketById(ctx, accompanyingTicketId).Return(tt.accompanyingTicket, nil)
+			}
+			var gotParams ticketsDomain.ParamsBoardTicket
+			if tt.err == nil || tt.storageErr != nil {
+				ticketsStorage.EXPECT().
+					BoardTicket(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, paramsBoardTicket *ticketsDomain.ParamsBoardTicket) (uuid.UUID, error) {
+						gotParams = *paramsBoardTicket
+						if tt.storageErr != nil {
+							return uuid.UUID{}, tt.storageErr
+						}
+						return paramsBoardTicket.TicketId, nil
+					})
+			}
+			s := service{ticketsStorage: ticketsStorage, flightsStorage: flightsStorage}
+
+			// Act
+			got, err := s.BoardTicket(ctx, paramsBoardTicket)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, ticketId, got)
+			assert.Equal(t, ticketsDomain.ParamsBoardTicket{
+				StatusTimestamp: timestamp,
+				FlightId:        flightId,
+				Code:            tt.code,
+				TicketId:        ticketId,
+			}, gotParams)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	flightsDomain "homework/internal/domain/flights"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// getExpectedDepartureDate возвращает ожидаемое время вылета рейса:
+// для задержанного рейса - расчетное время вылета, иначе - время вылета по расписанию
+func getExpectedDepartureDate(flight *flightsDomain.Flight) time.Time {
+
+	if flight.Status.State == flightsDomain.FlightStateDelayed && flight.Status.EstimatedDeparture != nil {
+		return *flight.Status.EstimatedDeparture
+	}
+	return flight.DepartureDate
+}
+
+// validateCheckInTime проверяет, что регистрация на рейс открыта на момент timestamp:
+// рейс не отменен, до ожидаемого вылета осталось больше 1 часа и меньше 24 часов
+func validateCheckInTime(flight *flightsDomain.Flight, timestamp time.Time) error {
+
+	if flight.Status.State == flightsDomain.FlightStateCancelled {
+		return terr.Conflict("FLIGHT_CANCELLED", fmt.Sprintf("flight (id %s) is cancelled", flight.Id))
+	}
+
+	departureDate := getExpectedDepartureDate(flight)
+	if departureDate.Sub(timestamp).Hours() > 24 {
+		return terr.BadRequest("CHECK_IN_DOESNT_START", "check-in hasn't started yet")
+	} else if departureDate.Sub(timestamp).Hours() < 1 {
+		return terr.BadRequest("CHECK_IN_ALREADY_CLOSED", "check-in is already closed")
+	}
+	return nil
+}
+
+// RegisterFlightTickets регистрирует на рейс все оплаченные билеты пользователя одной операцией.
+// для международного рейса по каждому пассажиру собираются данные APIS.
+// места назначаются с учетом мест, выбранных и назначенных другим билетам в этой же регистрации
+func (s service) RegisterFlightTickets(ctx context.Context, paramsRegisterFlightTickets *ticketsDomain.ParamsRegisterFlightTickets) ([]uuid.UUID, error) {
+
+	// проверяем, что по переданному UserId существует пользователь
+	user, err := s.usersStorage.GetUserById(ctx, paramsRegisterFlightTickets.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// баланс пользователя должен быть заполнен, т.к. билеты уже были куплены и это должно быть отражено в балансе пользователя
+	if user.Balance == nil {
+		return nil, terr.BadRequest("INVALID_USER", "no information about the user's balance")
+	}
+
+	// проверяем, что по переданному FlightId существует рейс и регистрация на рейс открыта
+	flight, err := s.flightsStorage.GetFlightById(ctx, paramsRegisterFlightTickets.FlightId)
+	if err != nil {
+		return nil, err
+	}
+	err = validateCheckInTime(flight, paramsRegisterFlightTickets.StatusTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	// параметры отдельных билетов
+	paramsTickets := make(map[uuid.UUID]ticketsDomain.ParamsRegisterTicket, len(paramsRegisterFlightTickets.Tickets))
+	for _, paramsTicket := range paramsRegisterFlightTickets.Tickets {
+		if _, ok := paramsTickets[paramsTicket.TicketId]; ok {
+			return nil, terr.BadRequest("DUPLICATE_TICKET", fmt.Sprintf("ticket (id %s) is passed more than once", paramsTicket.TicketId))
+		}
+		paramsTickets[paramsTicket.TicketId] = paramsTicket
+	}
+
+	// регистрируются оплаченные билеты пользователя на рейс со статусом 2 (Paid)
+	userTickets, err := s.ticketsStorage.GetUserFlightTickets(ctx, paramsRegisterFlightTickets.UserId, paramsRegisterFlightTickets.FlightId)
+	if err != nil {
+		return nil, err
+	}
+	var tickets []ticketsDomain.Ticket
+	paidTicketsIds := make(map[uuid.UUID]bool, len(userTickets))
+	for _, ticket := range userTickets {
+		if ticket.Status.Id == 2 {
+			tickets = append(tickets, ticket)
+			paidTicketsIds[ticket.Id] = true
+		}
+	}
+	for ticketId := range paramsTickets {
+		if !paidTicketsIds[ticketId] {
+			return nil, terr.BadRequest("INVALID_TICKET", fmt.Sprintf("ticket (id %s) isn't a paid ticket of the user on the flight (id %s)", ticketId, flight.Id))
+		}
+	}
+	if len(tickets) == 0 {
+		return nil, terr.BadRequest("NO_TICKETS_TO_REGISTER", fmt.Sprintf("the user has no paid tickets on the flight (id %s)", flight.Id))
+	}
+
+	// сначала проверяются билеты с выбранным пассажиром местом, чтобы автоматически назначенные места их не заняли
+	sort.SliceStable(tickets, func(i, j int) bool {
+		return paramsTickets[tickets[i].Id].SeatId != nil && paramsTickets[tickets[j].Id].SeatId == nil
+	})
+
+	// проверки всех билетов выполняются до регистрации: билеты регистрируются все вместе или ни один
+	assignedSeatsIds := make(map[uuid.UUID]uuid.UUID, len(tickets))
+	paramsRegisterTickets := make([]ticketsDomain.ParamsRegisterTicket, 0, len(tickets))
+	for i := range tickets {
+
+		paramsRegisterTicket := paramsTickets[tickets[i].Id]
+		paramsRegisterTicket.StatusTimestamp = paramsRegisterFlightTickets.StatusTimestamp
+		paramsRegisterTicket.TicketId = tickets[i].Id
+		paramsRegisterTicket.UserId = paramsRegisterFlightTickets.UserId
+
+		err = s.prepareRegisterTicket(ctx, &tickets[i], &paramsRegisterTicket, assignedSeatsIds)
+		if err != nil {
+			return nil, err
+		}
+		paramsRegisterTickets = append(paramsRegisterTickets, paramsRegisterTicket)
+	}
+
+	// Все проверки пройдены
+
+	// Выполняем изменение билетов
+	ticketsIds, err := s.ticketsStorage.RegisterTickets(ctx, paramsRegisterTickets)
+	return ticketsIds, err
+}
+
+// prepareRegisterTicket проверяет данные APIS и место билета для регистрации на рейс и заполняет параметры регистрации:
+// данные APIS и назначенное место.
+// assignedSeatsIds - места, назначенные другим билетам в этой же регистрации (по id билета), дополняется местом билета
+func (s service) prepareRegisterTicket(ctx context.Context, ticket *ticketsDomain.Ticket, paramsRegisterTicket *ticketsDomain.ParamsRegisterTicket, assignedSeatsIds map[uuid.UUID]uuid.UUID) error {
+
+	// для международного рейса проверяем данные APIS и паспорт пассажира
+	apis, err := validateApisData(paramsRegisterTicket.Apis, ticket, paramsRegisterTicket.StatusTimestamp)
+	if err != nil {
+		return err
+	}
+	paramsRegisterTicket.Apis = apis
+
+	// младенцу на руках у взрослого место не назначается
+	if ticket.PassengerType == ticketsDomain.PassengerTypeInfant && paramsRegisterTicket.SeatId != nil {
+		return terr.BadRequest("INFANT_SEAT_NOT_ALLOWED", "infant on lap can't have a seat")
+	}
+
+	// место, назначенное при покупке билета (оплаченный выбор места), сохраняется
+	if ticket.Seat != nil {
+		if paramsRegisterTicket.SeatId != nil && *paramsRegisterTicket.SeatId != ticket.Seat.Id {
+			return terr.BadRequest("SEAT_ALREADY_ASSIGNED", fmt.Sprintf("ticket (id %s) already has a seat", ticket.Id))
+		}
+		paramsRegisterTicket.SeatId = nil
+	}
+
+	// назначаем место, если при покупке билета место не было назначено
+	if ticket.Seat == nil && ticket.PassengerType != ticketsDomain.PassengerTypeInfant {
+
+		// получаем список вакантных мест рейса с заданным классом ClassSeatsId
+		// и исключаем из него места, назначенные в этой же регистрации
+		vacantSeats, err := s.flightsStorage.GetFlightVacantSeatsByClassId(ctx, ticket.Flight.Id, ticket.ClassSeats.Id)
+		if err != nil {
+			return err
+		}
+		vacantSeats.Seats = excludeAssignedSeats(vacantSeats.Seats, assignedSeatsIds)
+
+		// ряд у аварийного выхода не назначается детям и взрослым с младенцами
+		exitRowAllowed, err := s.isExitRowAllowed(ctx, ticket)
+		if err != nil {
+			return err
+		}
+
+		if paramsRegisterTicket.SeatId == nil {
+
+			// место не передано - назначаем место автоматически
+			seat, err := s.assignSeat(ctx, ticket, vacantSeats, exitRowAllowed, assignedSeatsIds)
+			if err != nil {
+				return err
+			}
+			paramsRegisterTicket.SeatId = &seat.Id
+		} else {
+
+			// проверяем, что место есть в списке свободных мест
+			var vacantSeat *flightsDomain.Seat
+			seatId := *paramsRegisterTicket.SeatId
+			for i, seat := range vacantSeats.Seats {
+				if seat.Id == seatId {
+					vacantSeat = &vacantSeats.Seats[i]
+					break
+				}
+			}
+			// место занято
+			if vacantSeat == nil {
+				return terr.BadRequest("SEAT_DOESNT_VACANT", fmt.Sprintf("seat (id %s) isn't in the list of vacant seats", seatId))
+			}
+			// место в ряду у аварийного выхода
+			if vacantSeat.IsExitRow && !exitRowAllowed {
+				return terr.BadRequest("EXIT_ROW_NOT_ALLOWED", fmt.Sprintf("seat (id %s) is in the exit row and isn't allowed for the passenger", seatId))
+			}
+		}
+
+		if assignedSeatsIds != nil {
+			assignedSeatsIds[ticket.Id] = *paramsRegisterTicket.SeatId
+		}
+	}
+
+	return nil
+}
+
+// excludeAssignedSeats исключает из списка мест места, назначенные билетам в текущей регистрации
+func excludeAssignedSeats(seats []flightsDomain.Seat, assignedSeatsIds map[uuid.UUID]uuid.UUID) []flightsDomain.Seat {
+
+	if len(assignedSeatsIds) == 0 {
+		return seats
+	}
+
+	busySeatsIds := make(map[uuid.UUID]bool, len(assignedSeatsIds))
+	for _, seatId := range assignedSeatsIds {
+		busySeatsIds[seatId] = true
+	}
+
+	vacantSeats := make([]flightsDomain.Seat, 0, len(seats))
+	for _, seat := range seats {
+		if !busySeatsIds[seat.Id] {
+			vacantSeats = append(vacantSeats, seat)
+		}
+	}
+	return vacantSeats
+}
@@ -0,0 +1,285 @@
+package tickets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	ticketsDomain "homework/internal/domain/tickets"
+	usersDomain "homework/internal/domain/users"
+	mockTicketsService "homework/internal/service/tickets/mock"
+	"homework/internal/util/terr"
+)
+
+func Test_ValidateCheckInTime(t *testing.T) {
+
+	// Arrange
+	departureDate := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	estimatedDeparture := departureDate.Add(3 * time.Hour)
+
+	var tests = []struct {
+		name      string
+		status    flightsDomain.FlightStatus
+		timestamp time.Time
+		err       error
+	}{
+		{
+			name:      "success/check-in is open",
+			status:    flightsDomain.FlightStatus{State: flightsDomain.FlightStateOnTime},
+			timestamp: departureDate.Add(-3 * time.Hour),
+			err:       nil,
+		},
+		{
+			name:      "success/check-in is open until estimated departure of the delayed flight",
+			status:    flightsDomain.FlightStatus{State: flightsDomain.FlightStateDelayed, EstimatedDeparture: &estimatedDeparture},
+			timestamp: departureDate.Add(30 * time.Minute),
+			err:       nil,
+		},
+		{
+			name:      "fail/check-in doesn't start",
+			status:    flightsDomain.FlightStatus{State: flightsDomain.FlightStateOnTime},
+			timestamp: departureDate.Add(-25 * time.Hour),
+			err:       terr.BadRequest("CHECK_IN_DOESNT_START", "check-in hasn't started yet"),
+		},
+		{
+			name:      "fail/check-in is closed",
+			status:    flightsDomain.FlightStatus{State: flightsDomain.FlightStateOnTime},
+			timestamp: departureDate.Add(-30 * time.Minute),
+			err:       terr.BadRequest("CHECK_IN_ALREADY_CLOSED", "check-in is already closed"),
+		},
+		{
+			name:      "fail/flight is cancelled",
+			status:    flightsDomain.FlightStatus{State: flightsDomain.FlightStateCancelled},
+			timestamp: departureDate.Add(-3 * time.Hour),
+			err:       terr.Conflict("FLIGHT_CANCELLED", "flight (id 00000000-0000-0000-0000-000000000000) is cancelled"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			flight := &flightsDomain.Flight{
+				DepartureDate: departureDate,
+				Status:        tt.status,
+			}
+
+			// Act
+			err := validateCheckInTime(flight, tt.timestamp)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func Test_RegisterFlightTickets(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	userId := uuid.MustParse("b8a1d0f4-5c3e-4e2a-9f7d-6a1b2c3d4e5f")
+	flightId := uuid.MustParse("7d5925a6-2016-4c72-9298-517fc40d936c")
+	classSeatsId := uuid.MustParse("2c4b1c4e-0d7a-4a7c-9a57-5f5c2f1a9b10")
+	ticketsIds := []uuid.UUID{
+		uuid.MustParse("6382589b-ab8e-4519-8c00-d0fe095179b3"),
+		uuid.MustParse("1f0e4c3a-93f6-4a8e-b0a5-2b8f1c6d7e90"),
+		uuid.MustParse("c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c"),
+	}
+	seatsIds := []uuid.UUID{
+		uuid.MustParse("b1f5bb9c-4a38-4d3e-a2a6-a8c3de43e3a7"),
+		uuid.MustParse("c6eff2bf-525d-4b81-b995-d812874bbba8"),
+	}
+	passportExpiryDate := timestamp.AddDate(5, 0, 0)
+	user := &usersDomain.User{Id: userId, Balance: &usersDomain.UserBalance{}}
+
+	newFlight := func(update func(flight *flightsDomain.Flight)) *flightsDomain.Flight {
+		flight := &flightsDomain.Flight{
+			Id:            flightId,
+			DepartureDate: timestamp.Add(3 * time.Hour),
+			Status:        flightsDomain.FlightStatus{State: flightsDomain.FlightStateOnTime},
+		}
+		if update != nil {
+			update(flight)
+		}
+		return flight
+	}
+	newTicket := func(ticketId uuid.UUID, statusId int, seatId *uuid.UUID) ticketsDomain.Ticket {
+		ticket := ticketsDomain.Ticket{
+			Id:            ticketId,
+			Status:        ticketsDomain.Status{Id: statusId},
+			Flight:        *newFlight(nil),
+			User:          usersDomain.User{Id: userId},
+			PassengerType: ticketsDomain.PassengerTypeAdult,
+			ClassSeats:    flightsDomain.ClassSeats{Id: classSeatsId},
+		}
+		if seatId != nil {
+			ticket.Seat = &flightsDomain.Seat{Id: *seatId}
+		}
+		return ticket
+	}
+	// оплаченные билеты: первому место назначено при покупке, второму место не назначено; третий билет возвращен
+	userTickets := []ticketsDomain.Ticket{
+		newTicket(ticketsIds[0], 2, &seatsIds[0]),
+		newTicket(ticketsIds[1], 2, nil),
+		newTicket(ticketsIds[2], 4, nil),
+	}
+	internationalTickets := []ticketsDomain.Ticket{newTicket(ticketsIds[0], 2, &seatsIds[0])}
+	internationalTickets[0].Flight.IsInternational = true
+	internationalTickets[0].Passenger.Document = &ticketsDomain.IdentityDocument{
+		Type:       ticketsDomain.DocumentTypePassport,
+		ExpiryDate: &passportExpiryDate,
+	}
+
+	var tests = []struct {
+		name        string
+		userErr     error
+		user        *usersDomain.User
+		flight      *flightsDomain.Flight
+		userTickets []ticketsDomain.Ticket
+		args        []ticketsDomain.ParamsRegisterTicket
+		storageErr  error
+		wantParams  []ticketsDomain.ParamsRegisterTicket
+		err         error
+	}{
+		{
+			name:        "success",
+			user:        user,
+			flight:      newFlight(nil),
+			userTickets: userTickets,
+			args:        []ticketsDomain.ParamsRegisterTicket{{TicketId: ticketsIds[1], SeatId: &seatsIds[1]}},
+			wantParams: []ticketsDomain.ParamsRegisterTicket{
+				{StatusTimestamp: timestamp, TicketId: ticketsIds[1], UserId: userId, SeatId: &seatsIds[1]},
+				{StatusTimestamp: timestamp, TicketId: ticketsIds[0], UserId: userId},
+			},
+			err: nil,
+		},
+		{
+			name:    "fail/user not found",
+			userErr: terr.NotFound("user (id b8a1d0f4-5c3e-4e2a-9f7d-6a1b2c3d4e5f) not found"),
+			err:     terr.NotFound("user (id b8a1d0f4-5c3e-4e2a-9f7d-6a1b2c3d4e5f) not found"),
+		},
+		{
+			name: "fail/no user balance",
+			user: &usersDomain.User{Id: userId},
+			err:  terr.BadRequest("INVALID_USER", "no information about the user's balance"),
+		},
+		{
+			name:   "fail/check-in is closed",
+			user:   user,
+			flight: newFlight(func(flight *flightsDomain.Flight) { flight.DepartureDate = timestamp.Add(30 * time.Minute) }),
+			err:    terr.BadRequest("CHECK_IN_ALREADY_CLOSED", "check-in is already closed"),
+		},
+		{
+			name:   "fail/flight is cancelled",
+			user:   user,
+			flight: newFlight(func(flight *flightsDomain.Flight) { flight.Status.State = flightsDomain.FlightStateCancelled }),
+			err:    terr.Conflict("FLIGHT_CANCELLED", "flight (id 7d5925a6-2016-4c72-9298-517fc40d936c) is cancelled"),
+		},
+		{
+			name:   "fail/duplicate ticket",
+			user:   user,
+			flight: newFlight(nil),
+			args:   []ticketsDomain.ParamsRegisterTicket{{TicketId: ticketsIds[0]}, {TicketId: ticketsIds[0]}},
+			err:    terr.BadRequest("DUPLICATE_TICKET", "ticket (id 6382589b-ab8e-4519-8c00-d0fe095179b3) is passed more than once"),
+		},
+		{
+			name:        "fail/ticket isn't paid",
+			user:        user,
+			flight:      newFlight(nil),
+			userTickets: userTickets,
+			args:        []ticketsDomain.ParamsRegisterTicket{{TicketId: ticketsIds[2]}},
+			err:         terr.BadRequest("INVALID_TICKET", "ticket (id c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c) isn't a paid ticket of the user on the flight (id 7d5925a6-2016-4c72-9298-517fc40d936c)"),
+		},
+		{
+			name:        "fail/no tickets to register",
+			user:        user,
+			flight:      newFlight(nil),
+			userTickets: userTickets[2:],
+			err:         terr.BadRequest("NO_TICKETS_TO_REGISTER", "the user has no paid tickets on the flight (id 7d5925a6-2016-4c72-9298-517fc40d936c)"),
+		},
+		{
+			name:        "fail/seat isn't vacant",
+			user:        user,
+			flight:      newFlight(nil),
+			userTickets: userTickets,
+			args:        []ticketsDomain.ParamsRegisterTicket{{TicketId: ticketsIds[1], SeatId: &seatsIds[0]}},
+			err:         terr.BadRequest("SEAT_DOESNT_VACANT", "seat (id b1f5bb9c-4a38-4d3e-a2a6-a8c3de43e3a7) isn't in the list of vacant seats"),
+		},
+		{
+			name:        "fail/apis required",
+			user:        user,
+			flight:      newFlight(nil),
+			userTickets: internationalTickets,
+			err:         terr.BadRequest("APIS_REQUIRED", "advance passenger information is required for the ticket (id 6382589b-ab8e-4519-8c00-d0fe095179b3) of the international flight"),
+		},
+		{
+			name:        "fail/seat is taken by a concurrent registration",
+			user:        user,
+			flight:      newFlight(nil),
+			userTickets: userTickets,
+			args:        []ticketsDomain.ParamsRegisterTicket{{TicketId: ticketsIds[1], SeatId: &seatsIds[1]}},
+			storageErr:  terr.Conflict("SEAT_ALREADY_TAKEN", "seat (id c6eff2bf-525d-4b81-b995-d812874bbba8) is already taken"),
+			err:         terr.Conflict("SEAT_ALREADY_TAKEN", "seat (id c6eff2bf-525d-4b81-b995-d812874bbba8) is already taken"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			paramsRegisterFlightTickets := &ticketsDomain.ParamsRegisterFlightTickets{
+				StatusTimestamp: timestamp,
+				UserId:          userId,
+				FlightId:        flightId,
+				Tickets:         tt.args,
+			}
+
+			ticketsStorage := mockTicketsService.NewMockTicketsStorage(ctrl)
+			flightsStorage := mockTicketsService.NewMockFlightsStorage(ctrl)
+			usersStorage := mockTicketsService.NewMockUsersStorage(ctrl)
+			usersStorage.EXPECT().GetUserById(ctx, userId).Return(tt.user, tt.userErr)
+			flightsStorage.EXPECT().GetFlightById(ctx, flightId).Return(tt.flight, nil).AnyTimes()
+			ticketsStorage.EXPECT().GetUserFlightTickets(ctx, userId, flightId).Return(tt.userTickets, nil).AnyTimes()
+			ticketsStorage.EXPECT().GetAccompaniedTickets(ctx, gomock.Any()).Return(nil, nil).AnyTimes()
+			flightsStorage.EXPECT().
+				GetFlightVacantSeatsByClassId(ctx, flightId, classSeatsId).
+				Return(&flightsDomain.VacantSeats{ClassSeatsId: classSeatsId, Seats: []flightsDomain.Seat{{Id: seatsIds[1]}}}, nil).
+				AnyTimes()
+			var gotParams []ticketsDomain.ParamsRegisterTicket
+			if tt.err == nil || tt.storageErr != nil {
+				ticketsStorage.EXPECT().
+					RegisterTickets(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, paramsRegisterTickets []ticketsDomain.ParamsRegisterTicket) ([]uuid.UUID, error) {
+						gotParams = paramsRegisterTickets
+						if tt.storageErr != nil {
+							return nil, tt.storageErr
+						}
+						ticketsIds := make([]uuid.UUID, 0, len(paramsRegisterTickets))
+						for _, paramsRegisterTicket := range paramsRegisterTickets {
+							ticketsIds = append(ticketsIds, paramsRegisterTicket.TicketId)
+						}
+						return ticketsIds, nil
+					})
+			}
+			s := service{ticketsStorage: ticketsStorage, flightsStorage: flightsStorage, usersStorage: usersStorage}
+
+			// Act
+			got, err := s.RegisterFlightTickets(ctx, paramsRegisterFlightTickets)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.wantParams, gotParams)
+			assert.Equal(t, []uuid.UUID{ticketsIds[1], ticketsIds[0]}, got)
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// срок согласования билета компании от момента создания. после согласования срок оплаты отсчитывается от времени согласования
+const ticketApprovalWindow = 24 * time.Hour
+
+// порядок классов мест для правила поездок "максимальный класс мест" (наименования без учета регистра)
+var classesSeatsRanks = map[string]int{
+	"economy":         1,
+	"premium economy": 2,
+	"comfort":         2,
+	"business":        3,
+	"first":           4,
+}
+
+// hasCompanyRole проверяет, что у пользователя есть роль в компании
+func hasCompanyRole(company *ticketsDomain.Company, userId uuid.UUID, role string) bool {
+	for _, member := range company.Members {
+		if member.User.Id != userId {
+			continue
+		}
+		for _, memberRole := range member.Roles {
+			if memberRole == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkTravelPolicy возвращает нарушения правил поездок компании билетом класса мест classSeatsName
+// со стоимостью price в валюте учета. класс мест, отсутствующий в порядке классов, нарушает правило максимального класса,
+// если не совпадает с ним по наименованию
+func checkTravelPolicy(policy *ticketsDomain.TravelPolicy, classSeatsName string, price money.Money) []string {
+
+	var violations []string
+	if policy == nil {
+		return violations
+	}
+
+	if policy.MaxClassSeatsName != nil && !strings.EqualFold(*policy.MaxClassSeatsName, classSeatsName) {
+		rank, okRank := classesSeatsRanks[strings.ToLower(classSeatsName)]
+		maxRank, okMaxRank := classesSeatsRanks[strings.ToLower(*policy.MaxClassSeatsName)]
+		if !okRank || !okMaxRank || rank > maxRank {
+			violations = append(violations, fmt.Sprintf("class %s is above the maximum class %s", classSeatsName, *policy.MaxClassSeatsName))
+		}
+	}
+
+	if policy.MaxPrice != nil && price.Amount > policy.MaxPrice.Amount {
+		violations = append(violations, fmt.Sprintf("price %s is more than the maximum price %s", price, *policy.MaxPrice))
+	}
+	return violations
+}
+
+// getCompanyCreateTicket проверяет роли пользователей компании при оформлении билета компании:
+// пользователь билета - путешественник компании, оформивший билет BookerId (если это не сам путешественник) - букер компании
+func (s service) getCompanyCreateTicket(ctx context.Context, paramsCreateTicket *ticketsDomain.ParamsCreateTicket) (*ticketsDomain.Company, error) {
+
+	if paramsCreateTicket.CompanyId == nil {
+		if paramsCreateTicket.BookerId != nil {
+			return nil, terr.BadRequest("INVALID_BOOKER", "booker is set only for a ticket of a company")
+		}
+		return nil, nil
+	}
+
+	company, err := s.ticketsStorage.GetCompanyById(ctx, *paramsCreateTicket.CompanyId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasCompanyRole(company, paramsCreateTicket.UserId, ticketsDomain.CompanyRoleTraveler) {
+		return nil, terr.BadRequest("COMPANY_ROLE_REQUIRED", fmt.Sprintf("user (id %s) isn't a traveler of company (id %s)", paramsCreateTicket.UserId, company.Id))
+	}
+
+	if paramsCreateTicket.BookerId != nil && *paramsCreateTicket.BookerId != paramsCreateTicket.UserId &&
+		!hasCompanyRole(company, *paramsCreateTicket.BookerId, ticketsDomain.CompanyRoleBooker) {
+		return nil, terr.BadRequest("COMPANY_ROLE_REQUIRED", fmt.Sprintf("user (id %s) isn't a booker of company (id %s)", *paramsCreateTicket.BookerId, company.Id))
+	}
+	return company, nil
+}
+
+// setTravelPolicyCreateTicket проверяет билет компании по правилам поездок: билет с нарушениями правил
+// или при обязательном согласовании всех билетов ожидает согласования, иначе согласован сразу
+func setTravelPolicyCreateTicket(paramsCreateTicket *ticketsDomain.ParamsCreateTicket, company *ticketsDomain.Company, flight *flightsDomain.Flight) {
+
+	if company == nil {
+		return
+	}
+
+	var classSeatsName string
+	for _, flightPrice := range flight.PricesTickets {
+		if flightPrice.ClassSeats.Id == paramsCreateTicket.ClassSeatsId {
+			classSeatsName = flightPrice.ClassSeats.Name
+			break
+		}
+	}
+
+	price := paramsCreateTicket.Price.Convert(paramsCreateTicket.ExchangeRate)
+	paramsCreateTicket.PolicyViolations = checkTravelPolicy(company.TravelPolicy, classSeatsName, price)
+
+	paramsCreateTicket.ApprovalStatus = ticketsDomain.ApprovalStatusApproved
+	if len(paramsCreateTicket.PolicyViolations) > 0 || (company.TravelPolicy != nil && company.TravelPolicy.ApprovalRequired) {
+		paramsCreateTicket.ApprovalStatus = ticketsDomain.ApprovalStatusPending
+	}
+}
+
+// validateCompanyPayForTicket проверяет оплату билета компании: билет согласован и оплачивается по счету компании,
+// бонусы, сертификаты и кредиты не используются
+func validateCompanyPayForTicket(paramsPayForTicket *ticketsDomain.ParamsPayForTicket, ticket *ticketsDomain.Ticket) error {
+
+	if ticket.Company.ApprovalStatus != ticketsDomain.ApprovalStatusApproved {
+		return terr.BadRequest("TICKET_NOT_APPROVED", fmt.Sprintf("ticket (id %s) isn't approved (%s)", ticket.Id, ticket.Company.ApprovalStatus))
+	}
+
+	if paramsPayForTicket.PaidWithBonuses.Amount > 0 || len(paramsPayForTicket.Vouchers) > 0 {
+		return terr.BadRequest("INVALID_PAYMENT_METHOD", "ticket of a company is paid by the company invoice")
+	}
+	return nil
+}
+
+// getCompanyTicketPayments - оплата билета компании: вся стоимость билета в валюте учета оплачивается по счету компании
+func getCompanyTicketPayments(paramsPayForTicket *ticketsDomain.ParamsPayForTicket, price money.Money) []ticketsDomain.TicketPayment {
+	return []ticketsDomain.TicketPayment{
+		{
+			Method:    ticketsDomain.PaymentMethodInvoice,
+			Amount:    price,
+			Timestamp: paramsPayForTicket.StatusTimestamp,
+		},
+	}
+}
+
+func (s service) GetCompanyById(ctx context.Context, companyId uuid.UUID) (*ticketsDomain.Company, error) {
+	return s.ticketsStorage.GetCompanyById(ctx, companyId)
+}
+
+// согласование или отклонение билета компании, ожидающего согласования, пользователем с ролью approver.
+// пользователь не может согласовать свой билет
+func (s service) ApproveTicket(ctx context.Context, paramsApproveTicket *ticketsDomain.ParamsApproveTicket) (uuid.UUID, error) {
+
+	// по id получаем билет для согласования
+	ticket, err := s.ticketsStorage.GetTicketById(ctx, paramsApproveTicket.TicketId)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// проверки билета:
+	// билет компании со статусом 1 (Created), ожидающий согласования
+	if ticket.Company == nil {
+		return uuid.UUID{}, terr.BadRequest("INVALID_TICKET", fmt.Sprintf("ticket (id %s) isn't a ticket of a company", ticket.Id))
+	}
+	if ticket.Status.Id != 1 {
+		return uuid.UUID{}, terr.BadRequest("INVALID_STATUS_TICKET", fmt.Sprintf("ticket (id %s) has wrong status (%s)", ticket.Id, ticket.Status.Name))
+	}
+	if ticket.Company.ApprovalStatus != ticketsDomain.ApprovalStatusPending {
+		return uuid.UUID{}, terr.Conflict("TICKET_NOT_PENDING_APPROVAL", fmt.Sprintf("ticket (id %s) isn't pending approval", ticket.Id))
+	}
+
+	// билет, не согласованный в срок, должен быть отменен
+	if paramsApproveTicket.StatusTimestamp.Sub(ticket.Status.Timestamp) > ticketApprovalWindow {
+		return uuid.UUID{}, terr.BadRequest("TICKET_ALREADY_CANCELED", "time to approve is over")
+	}
+
+	// проверки пользователя:
+	// пользователь - согласующий компании билета и не путешественник билета
+	company, err := s.ticketsStorage.GetCompanyById(ctx, ticket.Company.CompanyId)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	if !hasCompanyRole(company, paramsApproveTicket.UserId, ticketsDomain.CompanyRoleApprover) {
+		return uuid.UUID{}, terr.BadRequest("COMPANY_ROLE_REQUIRED", fmt.Sprintf("user (id %s) isn't an approver of company (id %s)", paramsApproveTicket.UserId, company.Id))
+	}
+	if paramsApproveTicket.UserId == ticket.User.Id {
+		return uuid.UUID{}, terr.BadRequest("SELF_APPROVAL", "user cannot approve own ticket")
+	}
+
+	return s.ticketsStorage.ApproveTicket(ctx, paramsApproveTicket)
+}
+
+// выставление счета компании за закрытый месяц
+func (s service) CreateCompanyInvoice(ctx context.Context, paramsCreateCompanyInvoice *ticketsDomain.ParamsCreateCompanyInvoice) (*ticketsDomain.CompanyInvoice, error) {
+
+	// проверяем, что по переданному CompanyId существует компания
+	_, err := s.ticketsStorage.GetCompanyById(ctx, paramsCreateCompanyInvoice.CompanyId)
+	if err != nil {
+		return nil, err
+	}
+
+	periodFrom := paramsCreateCompanyInvoice.PeriodFrom
+	paramsCreateCompanyInvoice.PeriodFrom = time.Date(periodFrom.Year(), periodFrom.Month(), 1, 0, 0, 0, 0, time.UTC)
+	paramsCreateCompanyInvoice.PeriodTo = paramsCreateCompanyInvoice.PeriodFrom.AddDate(0, 1, 0)
+
+	// счет выставляется только за закончившийся месяц
+	if paramsCreateCompanyInvoice.PeriodTo.After(paramsCreateCompanyInvoice.Timestamp) {
+		return nil, terr.BadRequest("INVOICE_PERIOD_NOT_CLOSED", fmt.Sprintf("period %s isn't closed", paramsCreateCompanyInvoice.PeriodFrom.Format("2006-01")))
+	}
+
+	return s.ticketsStorage.CreateCompanyInvoice(ctx, paramsCreateCompanyInvoice)
+}
+
+// отчет по билетам компании
+func (s service) GetCompanyReport(ctx context.Context, paramsGetCompanyReport *ticketsDomain.ParamsGetCompanyReport) (*ticketsDomain.CompanyReport, error) {
+
+	// проверяем, что по переданному CompanyId существует компания
+	_, err := s.ticketsStorage.GetCompanyById(ctx, paramsGetCompanyReport.CompanyId)
+	if err != nil {
+		return nil, err
+	}
+
+	tickets, err := s.ticketsStorage.GetCompanyTickets(ctx, paramsGetCompanyReport)
+	if err != nil {
+		return nil, err
+	}
+
+	return getCompanyReport(paramsGetCompanyReport.CompanyId, tickets), nil
+}
+
+// addCompanyReportRow добавляет билет стоимостью price в строку отчета
+func addCompanyReportRow(row *ticketsDomain.CompanyReportRow, price money.Money) {
+	row.CountTickets++
+	row.Amount = row.Amount.Add(price)
+}
+
+// getCompanyReport формирует отчет по билетам компании. стоимость билетов - в валюте учета по курсу,
+// зафиксированному в билете. статусы упорядочены по id статуса, путешественники - по имени
+func getCompanyReport(companyId uuid.UUID, tickets []ticketsDomain.Ticket) *ticketsDomain.CompanyReport {
+
+	zero := money.New(0, money.BaseCurrency)
+	report := ticketsDomain.CompanyReport{
+		CompanyId:       companyId,
+		Total:           ticketsDomain.CompanyReportRow{Amount: zero},
+		OutOfPolicy:     ticketsDomain.CompanyReportRow{Amount: zero},
+		PendingApproval: ticketsDomain.CompanyReportRow{Amount: zero},
+		Statuses:        make([]ticketsDomain.CompanyReportStatus, 0),
+		Travelers:       make([]ticketsDomain.CompanyReportTraveler, 0),
+	}
+
+	mapStatuses := make(map[int]*ticketsDomain.CompanyReportStatus)
+	mapTravelers := make(map[uuid.UUID]*ticketsDomain.CompanyReportTraveler)
+	statusesIds := make([]int, 0)
+	travelersIds := make([]uuid.UUID, 0)
+
+	for i := range tickets {
+		ticket := &tickets[i]
+		price := ticket.Price.Convert(ticket.ExchangeRate)
+
+		status, ok := mapStatuses[ticket.Status.Id]
+		if !ok {
+			status = &ticketsDomain.CompanyReportStatus{
+				StatusName:       ticket.Status.Name,
+				CompanyReportRow: ticketsDomain.CompanyReportRow{Amount: zero},
+			}
+			mapStatuses[ticket.Status.Id] = status
+			statusesIds = append(statusesIds, ticket.Status.Id)
+		}
+		addCompanyReportRow(&status.CompanyReportRow, price)
+
+		if ticket.Company != nil && ticket.Status.Id == 1 && ticket.Company.ApprovalStatus == ticketsDomain.ApprovalStatusPending {
+			addCompanyReportRow(&report.PendingApproval, price)
+		}
+
+		// отмененные и возвращенные билеты учитываются только в разрезе статусов
+		if ticket.Status.Id == 3 || ticket.Status.Id == 4 {
+			continue
+		}
+		addCompanyReportRow(&report.Total, price)
+
+		if ticket.Company != nil && len(ticket.Company.PolicyViolations) > 0 {
+			addCompanyReportRow(&report.OutOfPolicy, price)
+		}
+
+		traveler, ok := mapTravelers[ticket.User.Id]
+		if !ok {
+			traveler = &ticketsDomain.CompanyReportTraveler{
+				User:             ticket.User,
+				CompanyReportRow: ticketsDomain.CompanyReportRow{Amount: zero},
+			}
+			mapTravelers[ticket.User.Id] = traveler
+			travelersIds = append(travelersIds, ticket.User.Id)
+		}
+		addCompanyReportRow(&traveler.CompanyReportRow, price)
+	}
+
+	sort.Ints(statusesIds)
+	for _, statusId := range statusesIds {
+		report.Statuses = append(report.Statuses, *mapStatuses[statusId])
+	}
+
+	for _, userId := range travelersIds {
+		report.Travelers = append(report.Travelers, *mapTravelers[userId])
+	}
+	sort.SliceStable(report.Travelers, func(i, j int) bool {
+		return report.Travelers[i].User.Name < report.Travelers[j].User.Name
+	})
+
+	return &report
+}
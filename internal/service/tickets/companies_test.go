@@ -0,0 +1,258 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	usersDomain "homework/internal/domain/users"
+	"homework/internal/util/terr"
+)
+
+func Test_CheckTravelPolicy(t *testing.T) {
+
+	// Arrange
+	economy := "Economy"
+	maxPrice := money.New(3000000, money.BaseCurrency)
+
+	var tests = []struct {
+		name           string
+		policy         *ticketsDomain.TravelPolicy
+		classSeatsName string
+		price          money.Money
+		want           []string
+	}{
+		{
+			name:           "without policy",
+			policy:         nil,
+			classSeatsName: "Business",
+			price:          money.New(9000000, money.BaseCurrency),
+			want:           nil,
+		},
+		{
+			name:           "within policy",
+			policy:         &ticketsDomain.TravelPolicy{MaxClassSeatsName: &economy, MaxPrice: &maxPrice},
+			classSeatsName: "economy",
+			price:          money.New(3000000, money.BaseCurrency),
+			want:           nil,
+		},
+		{
+			name:           "class above the maximum class",
+			policy:         &ticketsDomain.TravelPolicy{MaxClassSeatsName: &economy},
+			classSeatsName: "Business",
+			price:          money.New(9000000, money.BaseCurrency),
+			want:           []string{"class Business is above the maximum class Economy"},
+		},
+		{
+			name:           "unknown class",
+			policy:         &ticketsDomain.TravelPolicy{MaxClassSeatsName: &economy},
+			classSeatsName: "Business class",
+			price:          money.New(9000000, money.BaseCurrency),
+			want:           []string{"class Business class is above the maximum class Economy"},
+		},
+		{
+			name:           "class and price violations",
+			policy:         &ticketsDomain.TravelPolicy{MaxClassSeatsName: &economy, MaxPrice: &maxPrice},
+			classSeatsName: "First",
+			price:          money.New(3000001, money.BaseCurrency),
+			want: []string{
+				"class First is above the maximum class Economy",
+				"price 30000.01 RUB is more than the maximum price 30000.00 RUB",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := checkTravelPolicy(tt.policy, tt.classSeatsName, tt.price)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_SetTravelPolicyCreateTicket(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	classSeatsId := uuid.MustParse("a2f5bd61-1bd5-4d6b-8e2b-2f8f7b7a6c01")
+	flight := &flightsDomain.Flight{
+		PricesTickets: []flightsDomain.FlightPrice{
+			{ClassSeats: flightsDomain.ClassSeats{Id: classSeatsId, Name: "Business"}},
+		},
+	}
+	economy := "Economy"
+
+	var tests = []struct {
+		name               string
+		company            *ticketsDomain.Company
+		wantApprovalStatus string
+		wantViolations     int
+	}{
+		{
+			name:               "ticket of a user",
+			company:            nil,
+			wantApprovalStatus: "",
+			wantViolations:     0,
+		},
+		{
+			name:               "within policy",
+			company:            &ticketsDomain.Company{},
+			wantApprovalStatus: ticketsDomain.ApprovalStatusApproved,
+			wantViolations:     0,
+		},
+		{
+			name:               "approval required",
+			company:            &ticketsDomain.Company{TravelPolicy: &ticketsDomain.TravelPolicy{ApprovalRequired: true}},
+			wantApprovalStatus: ticketsDomain.ApprovalStatusPending,
+			wantViolations:     0,
+		},
+		{
+			name:               "out of policy",
+			company:            &ticketsDomain.Company{TravelPolicy: &ticketsDomain.TravelPolicy{MaxClassSeatsName: &economy}},
+			wantApprovalStatus: ticketsDomain.ApprovalStatusPending,
+			wantViolations:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paramsCreateTicket := &ticketsDomain.ParamsCreateTicket{
+				ClassSeatsId: classSeatsId,
+				Price:        money.New(100000, money.CurrencyUSD),
+				ExchangeRate: money.ExchangeRate{From: money.CurrencyUSD, To: money.BaseCurrency, Rate: 90, Timestamp: timestamp},
+			}
+
+			// Act
+			setTravelPolicyCreateTicket(paramsCreateTicket, tt.company, flight)
+
+			// Assert
+			assert.Equal(t, tt.wantApprovalStatus, paramsCreateTicket.ApprovalStatus)
+			assert.Len(t, paramsCreateTicket.PolicyViolations, tt.wantViolations)
+		})
+	}
+}
+
+func Test_HasCompanyRole(t *testing.T) {
+
+	// Arrange
+	userId := uuid.MustParse("fdef87aa-7694-47c6-a5cd-50984326a071")
+	company := &ticketsDomain.Company{
+		Members: []ticketsDomain.CompanyMember{
+			{
+				User:  usersDomain.User{Id: userId},
+				Roles: []string{ticketsDomain.CompanyRoleBooker, ticketsDomain.CompanyRoleTraveler},
+			},
+		},
+	}
+
+	// Act & Assert
+	assert.True(t, hasCompanyRole(company, userId, ticketsDomain.CompanyRoleTraveler))
+	assert.False(t, hasCompanyRole(company, userId, ticketsDomain.CompanyRoleApprover))
+	assert.False(t, hasCompanyRole(company, uuid.New(), ticketsDomain.CompanyRoleTraveler))
+}
+
+func Test_ValidateCompanyPayForTicket(t *testing.T) {
+
+	// Arrange
+	ticketId := uuid.MustParse("6382589b-ab8e-4519-8c00-d0fe095179b3")
+
+	var tests = []struct {
+		name           string
+		approvalStatus string
+		params         *ticketsDomain.ParamsPayForTicket
+		err            error
+	}{
+		{
+			name:           "success",
+			approvalStatus: ticketsDomain.ApprovalStatusApproved,
+			params:         &ticketsDomain.ParamsPayForTicket{},
+			err:            nil,
+		},
+		{
+			name:           "fail/ticket pending approval",
+			approvalStatus: ticketsDomain.ApprovalStatusPending,
+			params:         &ticketsDomain.ParamsPayForTicket{},
+			err:            terr.BadRequest("TICKET_NOT_APPROVED", "ticket (id 6382589b-ab8e-4519-8c00-d0fe095179b3) isn't approved (pending)"),
+		},
+		{
+			name:           "fail/paid with bonuses",
+			approvalStatus: ticketsDomain.ApprovalStatusApproved,
+			params:         &ticketsDomain.ParamsPayForTicket{PaidWithBonuses: money.New(10000, money.BaseCurrency)},
+			err:            terr.BadRequest("INVALID_PAYMENT_METHOD", "ticket of a company is paid by the company invoice"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket := &ticketsDomain.Ticket{
+				Id:      ticketId,
+				Company: &ticketsDomain.TicketCompany{ApprovalStatus: tt.approvalStatus},
+			}
+
+			// Act
+			err := validateCompanyPayForTicket(tt.params, ticket)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func Test_GetCompanyReport(t *testing.T) {
+
+	// Arrange
+	companyId := uuid.MustParse("1b7e5c3a-0d2f-4e8b-9a61-3c5d7e9f1a2b")
+	anna := usersDomain.User{Id: uuid.MustParse("fdef87aa-7694-47c6-a5cd-50984326a071"), Name: "Anna"}
+	boris := usersDomain.User{Id: uuid.MustParse("244f9f9a-f730-4860-b5aa-479c19320fa5"), Name: "Boris"}
+	rate := money.ExchangeRate{From: money.CurrencyUSD, To: money.BaseCurrency, Rate: 90}
+
+	newTicket := func(user usersDomain.User, status ticketsDomain.Status, amount int64, approvalStatus string, violations []string) ticketsDomain.Ticket {
+		return ticketsDomain.Ticket{
+			Status:       status,
+			User:         user,
+			Price:        money.New(amount, money.CurrencyUSD),
+			ExchangeRate: rate,
+			Company: &ticketsDomain.TicketCompany{
+				CompanyId:        companyId,
+				ApprovalStatus:   approvalStatus,
+				PolicyViolations: violations,
+			},
+		}
+	}
+	created := ticketsDomain.Status{Id: 1, Name: "Created"}
+	paid := ticketsDomain.Status{Id: 2, Name: "Paid"}
+	refunded := ticketsDomain.Status{Id: 4, Name: "Refunded"}
+
+	tickets := []ticketsDomain.Ticket{
+		newTicket(boris, paid, 10000, ticketsDomain.ApprovalStatusApproved, nil),
+		newTicket(anna, created, 20000, ticketsDomain.ApprovalStatusPending, []string{"class Business is above the maximum class Economy"}),
+		newTicket(anna, refunded, 30000, ticketsDomain.ApprovalStatusApproved, nil),
+		newTicket(boris, paid, 40000, ticketsDomain.ApprovalStatusApproved, nil),
+	}
+
+	// Act
+	got := getCompanyReport(companyId, tickets)
+
+	// Assert
+	assert.Equal(t, companyId, got.CompanyId)
+	assert.Equal(t, ticketsDomain.CompanyReportRow{CountTickets: 3, Amount: money.New(6300000, money.BaseCurrency)}, got.Total)
+	assert.Equal(t, ticketsDomain.CompanyReportRow{CountTickets: 1, Amount: money.New(1800000, money.BaseCurrency)}, got.OutOfPolicy)
+	assert.Equal(t, ticketsDomain.CompanyReportRow{CountTickets: 1, Amount: money.New(1800000, money.BaseCurrency)}, got.PendingApproval)
+	assert.Equal(t, []ticketsDomain.CompanyReportStatus{
+		{StatusName: "Created", CompanyReportRow: ticketsDomain.CompanyReportRow{CountTickets: 1, Amount: money.New(1800000, money.BaseCurrency)}},
+		{StatusName: "Paid", CompanyReportRow: ticketsDomain.CompanyReportRow{CountTickets: 2, Amount: money.New(4500000, money.BaseCurrency)}},
+		{StatusName: "Refunded", CompanyReportRow: ticketsDomain.CompanyReportRow{CountTickets: 1, Amount: money.New(2700000, money.BaseCurrency)}},
+	}, got.Statuses)
+	assert.Equal(t, []ticketsDomain.CompanyReportTraveler{
+		{User: anna, CompanyReportRow: ticketsDomain.CompanyReportRow{CountTickets: 1, Amount: money.New(1800000, money.BaseCurrency)}},
+		{User: boris, CompanyReportRow: ticketsDomain.CompanyReportRow{CountTickets: 2, Amount: money.New(4500000, money.BaseCurrency)}},
+	}, got.Travelers)
+}
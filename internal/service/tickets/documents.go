@@ -0,0 +1,118 @@
+package tickets
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	flightsDomain "homework/internal/domain/flights"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// номер документа: латинские буквы, цифры и дефис
+var regexpDocumentNumber = regexp.MustCompile(`^[A-Z0-9-]{4,20}$`)
+
+// код страны ISO 3166-1 alpha-2
+var regexpCountryCode = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// максимальный возраст пассажира, лет
+const maxPassengerAge = 120
+
+// возраст, с которого вместо свидетельства о рождении выдается удостоверение личности, лет
+const ageNationalId = 14
+
+// проверка документа, удостоверяющего личность пассажира, на момент timestamp
+func validateIdentityDocument(document *ticketsDomain.IdentityDocument, timestamp time.Time) error {
+
+	// тип документа из списка допустимых
+	switch document.Type {
+	case ticketsDomain.DocumentTypePassport, ticketsDomain.DocumentTypeNationalId, ticketsDomain.DocumentTypeBirthCertificate:
+	default:
+		return terr.BadRequest("INVALID_DOCUMENT_TYPE", fmt.Sprintf("document type (%s) is not supported", document.Type))
+	}
+
+	// номер документа
+	if !regexpDocumentNumber.MatchString(document.Number) {
+		return terr.BadRequest("INVALID_DOCUMENT_NUMBER", "document number must contain from 4 to 20 characters A-Z, 0-9 or '-'")
+	}
+
+	// страна выдачи документа и гражданство
+	if !regexpCountryCode.MatchString(document.IssuingCountry) {
+		return terr.BadRequest("INVALID_DOCUMENT_ISSUING_COUNTRY", fmt.Sprintf("issuing country (%s) must be ISO 3166-1 alpha-2 code", document.IssuingCountry))
+	}
+	if !regexpCountryCode.MatchString(document.Nationality) {
+		return terr.BadRequest("INVALID_NATIONALITY", fmt.Sprintf("nationality (%s) must be ISO 3166-1 alpha-2 code", document.Nationality))
+	}
+
+	// дата рождения не в будущем и не раньше максимального возраста
+	if document.BirthDate.After(timestamp) {
+		return terr.BadRequest("INVALID_BIRTH_DATE", "birth date is in the future")
+	}
+	if document.BirthDate.Before(timestamp.AddDate(-maxPassengerAge, 0, 0)) {
+		return terr.BadRequest("INVALID_BIRTH_DATE", fmt.Sprintf("birth date is more than %d years ago", maxPassengerAge))
+	}
+
+	// срок действия паспорта обязателен
+	if document.Type == ticketsDomain.DocumentTypePassport && document.ExpiryDate == nil {
+		return terr.BadRequest("INVALID_DOCUMENT_EXPIRY_DATE", "passport expiry date is required")
+	}
+
+	// срок действия позже даты рождения и еще не истек
+	if document.ExpiryDate != nil {
+		if !document.ExpiryDate.After(document.BirthDate) {
+			return terr.BadRequest("INVALID_DOCUMENT_EXPIRY_DATE", "document expiry date is before birth date")
+		}
+		if document.ExpiryDate.Before(timestamp) {
+			return terr.BadRequest("DOCUMENT_EXPIRED", "document has expired")
+		}
+	}
+
+	// свидетельство о рождении - только до 14 лет, удостоверение личности - начиная с 14 лет
+	isUnderAgeNationalId := document.BirthDate.AddDate(ageNationalId, 0, 0).After(timestamp)
+	if document.Type == ticketsDomain.DocumentTypeBirthCertificate && !isUnderAgeNationalId {
+		return terr.BadRequest("INVALID_DOCUMENT_TYPE", fmt.Sprintf("birth certificate is valid only under %d years old", ageNationalId))
+	}
+	if document.Type == ticketsDomain.DocumentTypeNationalId && isUnderAgeNationalId {
+		return terr.BadRequest("INVALID_DOCUMENT_TYPE", fmt.Sprintf("national id is valid only from %d years old", ageNationalId))
+	}
+
+	return nil
+}
+
+// проверка документа пассажира для перелета рейсом flight.
+// для международного рейса необходим паспорт, действующий до окончания перелета
+func validateFlightDocument(document *ticketsDomain.IdentityDocument, flight *flightsDomain.Flight) error {
+
+	if !flight.IsInternational {
+		return nil
+	}
+
+	if document == nil || document.Type != ticketsDomain.DocumentTypePassport {
+		return terr.BadRequest("PASSPORT_REQUIRED", fmt.Sprintf("passport is required for the international flight (id %s)", flight.Id))
+	}
+
+	arrivalDate := flight.DepartureDate.Add(flight.Duration)
+	if document.ExpiryDate == nil || !document.ExpiryDate.After(arrivalDate) {
+		return terr.BadRequest("PASSPORT_EXPIRES_BEFORE_FLIGHT", fmt.Sprintf("passport has to be valid after the flight (id %s)", flight.Id))
+	}
+
+	return nil
+}
+
+// представление документа в виде строки, которая хранится в данных пассажира (IdentityDataPassenger)
+func formatIdentityDocument(document *ticketsDomain.IdentityDocument) string {
+
+	identityData := fmt.Sprintf("%s %s, %s, nationality %s, born %s",
+		document.Type,
+		document.Number,
+		document.IssuingCountry,
+		document.Nationality,
+		document.BirthDate.Format("2006-01-02"),
+	)
+	if document.ExpiryDate != nil {
+		identityData += fmt.Sprintf(", valid until %s", document.ExpiryDate.Format("2006-01-02"))
+	}
+
+	return identityData
+}
@@ -0,0 +1,160 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+func Test_ValidateIdentityDocument(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	adultBirthDate := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	childBirthDate := time.Date(2015, 3, 2, 0, 0, 0, 0, time.UTC)
+	expiryDate := time.Date(2030, 5, 17, 0, 0, 0, 0, time.UTC)
+	expiredDate := time.Date(2022, 5, 17, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name string
+		args ticketsDomain.IdentityDocument
+		err  error
+	}{
+		{
+			name: "success/passport",
+			args: ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypePassport, Number: "4011123456", IssuingCountry: "RU", Nationality: "RU", BirthDate: adultBirthDate, ExpiryDate: &expiryDate},
+			err:  nil,
+		},
+		{
+			name: "success/national id without expiry date",
+			args: ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypeNationalId, Number: "AB-123456", IssuingCountry: "KZ", Nationality: "KZ", BirthDate: adultBirthDate},
+			err:  nil,
+		},
+		{
+			name: "success/birth certificate",
+			args: ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypeBirthCertificate, Number: "IVMN-123456", IssuingCountry: "RU", Nationality: "RU", BirthDate: childBirthDate},
+			err:  nil,
+		},
+		{
+			name: "fail/invalid number",
+			args: ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypePassport, Number: "40 11", IssuingCountry: "RU", Nationality: "RU", BirthDate: adultBirthDate, ExpiryDate: &expiryDate},
+			err:  terr.BadRequest("INVALID_DOCUMENT_NUMBER", "document number must contain from 4 to 20 characters A-Z, 0-9 or '-'"),
+		},
+		{
+			name: "fail/unknown type",
+			args: ticketsDomain.IdentityDocument{Type: "driver_license", Number: "4011123456", IssuingCountry: "RU", Nationality: "RU", BirthDate: adultBirthDate},
+			err:  terr.BadRequest("INVALID_DOCUMENT_TYPE", "document type (driver_license) is not supported"),
+		},
+		{
+			name: "fail/invalid issuing country",
+			args: ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypePassport, Number: "4011123456", IssuingCountry: "RUS", Nationality: "RU", BirthDate: adultBirthDate, ExpiryDate: &expiryDate},
+			err:  terr.BadRequest("INVALID_DOCUMENT_ISSUING_COUNTRY", "issuing country (RUS) must be ISO 3166-1 alpha-2 code"),
+		},
+		{
+			name: "fail/birth date in the future",
+			args: ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypePassport, Number: "4011123456", IssuingCountry: "RU", Nationality: "RU", BirthDate: expiryDate, ExpiryDate: &expiryDate},
+			err:  terr.BadRequest("INVALID_BIRTH_DATE", "birth date is in the future"),
+		},
+		{
+			name: "fail/passport without expiry date",
+			args: ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypePassport, Number: "4011123456", IssuingCountry: "RU", Nationality: "RU", BirthDate: adultBirthDate},
+			err:  terr.BadRequest("INVALID_DOCUMENT_EXPIRY_DATE", "passport expiry date is required"),
+		},
+		{
+			name: "fail/expired document",
+			args: ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypePassport, Number: "4011123456", IssuingCountry: "RU", Nationality: "RU", BirthDate: adultBirthDate, ExpiryDate: &expiredDate},
+			err:  terr.BadRequest("DOCUMENT_EXPIRED", "document has expired"),
+		},
+		{
+			name: "fail/birth certificate of adult",
+			args: ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypeBirthCertificate, Number: "4011123456", IssuingCountry: "RU", Nationality: "RU", BirthDate: adultBirthDate},
+			err:  terr.BadRequest("INVALID_DOCUMENT_TYPE", "birth certificate is valid only under 14 years old"),
+		},
+		{
+			name: "fail/national id of child",
+			args: ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypeNationalId, Number: "4011123456", IssuingCountry: "RU", Nationality: "RU", BirthDate: childBirthDate},
+			err:  terr.BadRequest("INVALID_DOCUMENT_TYPE", "national id is valid only from 14 years old"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := validateIdentityDocument(&tt.args, timestamp)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func Test_ValidateFlightDocument(t *testing.T) {
+
+	// Arrange
+	flightId := uuid.MustParse("7d5925a6-2016-4c72-9298-517fc40d936c")
+	departureDate := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	birthDate := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	expiryDate := time.Date(2030, 5, 17, 0, 0, 0, 0, time.UTC)
+	expiryDateDuringFlight := departureDate.Add(time.Hour)
+
+	internationalFlight := &flightsDomain.Flight{Id: flightId, DepartureDate: departureDate, Duration: 3 * time.Hour, IsInternational: true}
+	domesticFlight := &flightsDomain.Flight{Id: flightId, DepartureDate: departureDate, Duration: 3 * time.Hour}
+
+	passport := &ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypePassport, Number: "4011123456", IssuingCountry: "RU", Nationality: "RU", BirthDate: birthDate, ExpiryDate: &expiryDate}
+	nationalId := &ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypeNationalId, Number: "4011123456", IssuingCountry: "RU", Nationality: "RU", BirthDate: birthDate}
+	expiringPassport := &ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypePassport, Number: "4011123456", IssuingCountry: "RU", Nationality: "RU", BirthDate: birthDate, ExpiryDate: &expiryDateDuringFlight}
+
+	var tests = []struct {
+		name     string
+		document *ticketsDomain.IdentityDocument
+		flight   *flightsDomain.Flight
+		err      error
+	}{
+		{
+			name:     "success/domestic flight without document",
+			document: nil,
+			flight:   domesticFlight,
+			err:      nil,
+		},
+		{
+			name:     "success/international flight with passport",
+			document: passport,
+			flight:   internationalFlight,
+			err:      nil,
+		},
+		{
+			name:     "fail/international flight without document",
+			document: nil,
+			flight:   internationalFlight,
+			err:      terr.BadRequest("PASSPORT_REQUIRED", "passport is required for the international flight (id 7d5925a6-2016-4c72-9298-517fc40d936c)"),
+		},
+		{
+			name:     "fail/international flight with national id",
+			document: nationalId,
+			flight:   internationalFlight,
+			err:      terr.BadRequest("PASSPORT_REQUIRED", "passport is required for the international flight (id 7d5925a6-2016-4c72-9298-517fc40d936c)"),
+		},
+		{
+			name:     "fail/passport expires before arrival",
+			document: expiringPassport,
+			flight:   internationalFlight,
+			err:      terr.BadRequest("PASSPORT_EXPIRES_BEFORE_FLIGHT", "passport has to be valid after the flight (id 7d5925a6-2016-4c72-9298-517fc40d936c)"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := validateFlightDocument(tt.document, tt.flight)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package tickets
+
+import (
+	"time"
+
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	usersDomain "homework/internal/domain/users"
+)
+
+// getParamsLoyalty формирует параметры расчета бонусов по билету для правил программы лояльности:
+// маршрут и класс мест билета, стоимость билета и дополнительных услуг в валюте учета по курсу билета.
+// дополнительные услуги - позиции состава стоимости, кроме тарифа, сборов и скидок
+func getParamsLoyalty(ticket *ticketsDomain.Ticket, price money.Money, timestamp time.Time) *usersDomain.ParamsLoyalty {
+
+	priceAncillaries := money.New(0, ticket.Price.Currency)
+	for _, ticketItem := range ticket.Items {
+		if ticketItem.Type == ticketsDomain.TicketItemTypeFare ||
+			ticketItem.Type == ticketsDomain.TicketItemTypePromoDiscount ||
+			ticketItem.TaxFeeId != nil {
+			continue
+		}
+		priceAncillaries = priceAncillaries.Add(ticketItem.Price)
+	}
+
+	return &usersDomain.ParamsLoyalty{
+		Timestamp:          timestamp,
+		UserId:             ticket.User.Id,
+		DepartureAirportId: ticket.Flight.DepartureAirport.Id,
+		ArrivalAirportId:   ticket.Flight.ArrivalAirport.Id,
+		ClassSeatsName:     ticket.ClassSeats.Name,
+		Price:              price,
+		PriceAncillaries:   priceAncillaries.Convert(ticket.ExchangeRate),
+	}
+}
+
+// getParamsLoyaltyAncillary формирует параметры расчета бонусов за услугу, купленную к уже оплаченному билету:
+// стоимость услуги в валюте учета является и стоимостью, и стоимостью дополнительных услуг,
+// поэтому бонусы начисляются, только если начисление за дополнительные услуги не отключено правилом
+func getParamsLoyaltyAncillary(ticket *ticketsDomain.Ticket, price money.Money, timestamp time.Time) *usersDomain.ParamsLoyalty {
+
+	return &usersDomain.ParamsLoyalty{
+		Timestamp:          timestamp,
+		UserId:             ticket.User.Id,
+		DepartureAirportId: ticket.Flight.DepartureAirport.Id,
+		ArrivalAirportId:   ticket.Flight.ArrivalAirport.Id,
+		ClassSeatsName:     ticket.ClassSeats.Name,
+		Price:              price,
+		PriceAncillaries:   price,
+	}
+}
@@ -0,0 +1,121 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	usersDomain "homework/internal/domain/users"
+)
+
+func Test_GetParamsLoyalty(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	userId := uuid.MustParse("fdef87aa-7694-47c6-a5cd-50984326a071")
+	departureAirportId := uuid.MustParse("4b1c2d3e-5f60-4718-8a9b-0c1d2e3f4a5b")
+	arrivalAirportId := uuid.MustParse("7e8f9a0b-1c2d-4e3f-9a5b-6c7d8e9f0a1b")
+	taxFeeId := uuid.MustParse("9a0e3c44-6b1d-4d0c-8f5e-2f9c1b7d3a21")
+	ticket := &ticketsDomain.Ticket{
+		User: usersDomain.User{Id: userId},
+		Flight: flightsDomain.Flight{
+			DepartureAirport: flightsDomain.Airport{Id: departureAirportId},
+			ArrivalAirport:   flightsDomain.Airport{Id: arrivalAirportId},
+		},
+		ClassSeats:   flightsDomain.ClassSeats{Name: "Business"},
+		Price:        money.New(10000, money.CurrencyUSD),
+		ExchangeRate: money.ExchangeRate{From: money.CurrencyUSD, To: money.CurrencyRUB, Rate: 80},
+		Items: []ticketsDomain.TicketItem{
+			{Type: ticketsDomain.TicketItemTypeFare, Price: money.New(8000, money.CurrencyUSD)},
+			{Type: flightsDomain.TaxFeeTypeAirportTax, TaxFeeId: &taxFeeId, Price: money.New(1500, money.CurrencyUSD)},
+			{Type: flightsDomain.AncillaryTypeExtraBaggage, Price: money.New(1000, money.CurrencyUSD)},
+			{Type: ticketsDomain.TicketItemTypePromoDiscount, Price: money.New(-500, money.CurrencyUSD)},
+		},
+	}
+	price := money.New(800000, money.CurrencyRUB)
+
+	// Act
+	got := getParamsLoyalty(ticket, price, timestamp)
+
+	// Assert
+	assert.Equal(t, &usersDomain.ParamsLoyalty{
+		Timestamp:          timestamp,
+		UserId:             userId,
+		DepartureAirportId: departureAirportId,
+		ArrivalAirportId:   arrivalAirportId,
+		ClassSeatsName:     "Business",
+		Price:              price,
+		PriceAncillaries:   money.New(80000, money.CurrencyRUB),
+	}, got)
+}
+
+func Test_GetParamsLoyaltyAncillary(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	userId := uuid.MustParse("fdef87aa-7694-47c6-a5cd-50984326a071")
+	departureAirportId := uuid.MustParse("4b1c2d3e-5f60-4718-8a9b-0c1d2e3f4a5b")
+	arrivalAirportId := uuid.MustParse("7e8f9a0b-1c2d-4e3f-9a5b-6c7d8e9f0a1b")
+	ticket := &ticketsDomain.Ticket{
+		User: usersDomain.User{Id: userId},
+		Flight: flightsDomain.Flight{
+			DepartureAirport: flightsDomain.Airport{Id: departureAirportId},
+			ArrivalAirport:   flightsDomain.Airport{Id: arrivalAirportId},
+		},
+		ClassSeats: flightsDomain.ClassSeats{Name: "Business"},
+		Items: []ticketsDomain.TicketItem{
+			{Type: ticketsDomain.TicketItemTypeFare, Price: money.New(8000, money.CurrencyUSD)},
+			{Type: flightsDomain.AncillaryTypeExtraBaggage, Price: money.New(1000, money.CurrencyUSD)},
+		},
+	}
+	price := money.New(40000, money.CurrencyRUB)
+	falseValue := false
+	trueValue := true
+
+	var tests = []struct {
+		name  string
+		rules []usersDomain.LoyaltyRule
+		want  money.Money
+	}{
+		{
+			name:  "success/ancillaries accrual by default",
+			rules: nil,
+			want:  money.New(2000, money.CurrencyRUB),
+		},
+		{
+			name:  "success/ancillaries accrual enabled",
+			rules: []usersDomain.LoyaltyRule{{AncillariesAccrual: &trueValue}},
+			want:  money.New(2000, money.CurrencyRUB),
+		},
+		{
+			name:  "success/ancillaries accrual disabled",
+			rules: []usersDomain.LoyaltyRule{{AncillariesAccrual: &falseValue}},
+			want:  money.New(0, money.CurrencyRUB),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			paramsLoyalty := getParamsLoyaltyAncillary(ticket, price, timestamp)
+			got := usersDomain.CalcAccruedBonuses(paramsLoyalty, 5, tt.rules)
+
+			// Assert
+			assert.Equal(t, &usersDomain.ParamsLoyalty{
+				Timestamp:          timestamp,
+				UserId:             userId,
+				DepartureAirportId: departureAirportId,
+				ArrivalAirportId:   arrivalAirportId,
+				ClassSeatsName:     "Business",
+				Price:              price,
+				PriceAncillaries:   price,
+			}, paramsLoyalty)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
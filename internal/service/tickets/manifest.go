@@ -0,0 +1,89 @@
+package tickets
+
+import (
+	"context"
+	"strings"
+
+	ticketsDomain "homework/internal/domain/tickets"
+	usersDomain "homework/internal/domain/users"
+	"homework/internal/util/terr"
+)
+
+// количество последних символов номера документа, которые не маскируются
+const countDocumentNumberVisible = 4
+
+// GetFlightManifest формирует манифест рейса: пассажиры действующих билетов с классом, местом, статусом, багажом и документами.
+// полные персональные данные выводятся только для роли admin, для остальных ролей данные маскируются
+func (s service) GetFlightManifest(ctx context.Context, paramsGetFlightManifest *ticketsDomain.ParamsGetFlightManifest) (*ticketsDomain.FlightManifest, error) {
+
+	// роль вызывающего: без роли доступа нет, неизвестная роль - доступ запрещен
+	switch paramsGetFlightManifest.Role {
+	case usersDomain.RoleAdmin, usersDomain.RoleAgent:
+	case "":
+		return nil, terr.Unauthorized()
+	default:
+		return nil, terr.Forbidden()
+	}
+
+	// проверяем, что по переданному FlightId существует рейс
+	flight, err := s.flightsStorage.GetFlightById(ctx, paramsGetFlightManifest.FlightId)
+	if err != nil {
+		return nil, err
+	}
+
+	passengers, err := s.ticketsStorage.GetFlightManifest(ctx, flight.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	isMasked := paramsGetFlightManifest.Role != usersDomain.RoleAdmin
+	if isMasked {
+		for i := range passengers {
+			maskManifestPassenger(&passengers[i])
+		}
+	}
+
+	return &ticketsDomain.FlightManifest{
+		Flight:     *flight,
+		Timestamp:  paramsGetFlightManifest.Timestamp,
+		IsMasked:   isMasked,
+		Passengers: passengers,
+	}, nil
+}
+
+// maskManifestPassenger маскирует персональные данные пассажира манифеста:
+// ФИО - фамилия и инициалы, номер документа - последние 4 символа,
+// дата рождения, срок действия документа и адрес пребывания (кроме страны и города) не выводятся
+func maskManifestPassenger(passenger *ticketsDomain.ManifestPassenger) {
+
+	passenger.NamePassenger = maskName(passenger.NamePassenger)
+	passenger.DocumentNumber = maskDocumentNumber(passenger.DocumentNumber)
+	passenger.BirthDate = nil
+	passenger.DocumentExpiryDate = nil
+	passenger.DestinationAddress = ""
+}
+
+// maskName оставляет от ФИО фамилию и инициалы: "Иванов Иван Иванович" - "Иванов И. И."
+func maskName(name string) string {
+
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		return ""
+	}
+
+	masked := words[0]
+	for _, word := range words[1:] {
+		masked += " " + string([]rune(word)[0]) + "."
+	}
+	return masked
+}
+
+// maskDocumentNumber заменяет символы номера документа на '*', кроме последних 4 символов
+func maskDocumentNumber(number string) string {
+
+	runes := []rune(number)
+	if len(runes) <= countDocumentNumberVisible {
+		return strings.Repeat("*", len(runes))
+	}
+	return strings.Repeat("*", len(runes)-countDocumentNumberVisible) + string(runes[len(runes)-countDocumentNumberVisible:])
+}
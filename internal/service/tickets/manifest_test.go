@@ -0,0 +1,95 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	ticketsDomain "homework/internal/domain/tickets"
+)
+
+func Test_MaskManifestPassenger(t *testing.T) {
+
+	// Arrange
+	ticketId := uuid.MustParse("6382589b-ab8e-4519-8c00-d0fe095179b3")
+	birthDate := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	expiryDate := time.Date(2030, 5, 17, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name      string
+		passenger ticketsDomain.ManifestPassenger
+		want      ticketsDomain.ManifestPassenger
+	}{
+		{
+			name: "success/passenger with apis data",
+			passenger: ticketsDomain.ManifestPassenger{
+				TicketId: ticketId, NamePassenger: "Иванов Иван Иванович", SeatNumber: "12A",
+				DocumentType: ticketsDomain.DocumentTypePassport, DocumentNumber: "754011123456", Nationality: "RU",
+				BirthDate: &birthDate, DocumentExpiryDate: &expiryDate,
+				DestinationCountry: "TR", DestinationCity: "Antalya", DestinationAddress: "Lara Cd. 15",
+			},
+			want: ticketsDomain.ManifestPassenger{
+				TicketId: ticketId, NamePassenger: "Иванов И. И.", SeatNumber: "12A",
+				DocumentType: ticketsDomain.DocumentTypePassport, DocumentNumber: "********3456", Nationality: "RU",
+				DestinationCountry: "TR", DestinationCity: "Antalya",
+			},
+		},
+		{
+			name: "success/passenger without document",
+			passenger: ticketsDomain.ManifestPassenger{
+				TicketId: ticketId, NamePassenger: "Петров",
+			},
+			want: ticketsDomain.ManifestPassenger{
+				TicketId: ticketId, NamePassenger: "Петров",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			passenger := tt.passenger
+			maskManifestPassenger(&passenger)
+
+			// Assert
+			assert.Equal(t, tt.want, passenger)
+		})
+	}
+}
+
+func Test_MaskDocumentNumber(t *testing.T) {
+
+	var tests = []struct {
+		name   string
+		number string
+		want   string
+	}{
+		{
+			name:   "success/long number",
+			number: "AB-123456",
+			want:   "*****3456",
+		},
+		{
+			name:   "success/short number",
+			number: "1234",
+			want:   "****",
+		},
+		{
+			name:   "success/empty number",
+			number: "",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := maskDocumentNumber(tt.number)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
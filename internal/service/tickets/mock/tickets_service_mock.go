@@ -8,6 +8,7 @@ import (
 	context "context"
 	tickets "homework/internal/domain/tickets"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -36,6 +37,111 @@ func (m *MockTicketsService) EXPECT() *MockTicketsServiceMockRecorder {
 	return m.recorder
 }
 
+// AddTicketAncillary mocks base method.
+func (m *MockTicketsService) AddTicketAncillary(arg0 context.Context, arg1 *tickets.ParamsAddTicketAncillary) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTicketAncillary", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTicketAncillary indicates an expected call of AddTicketAncillary.
+func (mr *MockTicketsServiceMockRecorder) AddTicketAncillary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTicketAncillary", reflect.TypeOf((*MockTicketsService)(nil).AddTicketAncillary), arg0, arg1)
+}
+
+// ApproveTicket mocks base method.
+func (m *MockTicketsService) ApproveTicket(arg0 context.Context, arg1 *tickets.ParamsApproveTicket) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveTicket", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveTicket indicates an expected call of ApproveTicket.
+func (mr *MockTicketsServiceMockRecorder) ApproveTicket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveTicket", reflect.TypeOf((*MockTicketsService)(nil).ApproveTicket), arg0, arg1)
+}
+
+// BoardTicket mocks base method.
+func (m *MockTicketsService) BoardTicket(arg0 context.Context, arg1 *tickets.ParamsBoardTicket) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BoardTicket", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BoardTicket indicates an expected call of BoardTicket.
+func (mr *MockTicketsServiceMockRecorder) BoardTicket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BoardTicket", reflect.TypeOf((*MockTicketsService)(nil).BoardTicket), arg0, arg1)
+}
+
+// CloseFlight mocks base method.
+func (m *MockTicketsService) CloseFlight(arg0 context.Context, arg1 *tickets.ParamsCloseFlight) (*tickets.ClosedFlight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseFlight", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.ClosedFlight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseFlight indicates an expected call of CloseFlight.
+func (mr *MockTicketsServiceMockRecorder) CloseFlight(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseFlight", reflect.TypeOf((*MockTicketsService)(nil).CloseFlight), arg0, arg1)
+}
+
+// CreateCompanyInvoice mocks base method.
+func (m *MockTicketsService) CreateCompanyInvoice(arg0 context.Context, arg1 *tickets.ParamsCreateCompanyInvoice) (*tickets.CompanyInvoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCompanyInvoice", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.CompanyInvoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCompanyInvoice indicates an expected call of CreateCompanyInvoice.
+func (mr *MockTicketsServiceMockRecorder) CreateCompanyInvoice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompanyInvoice", reflect.TypeOf((*MockTicketsService)(nil).CreateCompanyInvoice), arg0, arg1)
+}
+
+// CreatePassenger mocks base method.
+func (m *MockTicketsService) CreatePassenger(arg0 context.Context, arg1 *tickets.ParamsCreatePassenger) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePassenger", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePassenger indicates an expected call of CreatePassenger.
+func (mr *MockTicketsServiceMockRecorder) CreatePassenger(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePassenger", reflect.TypeOf((*MockTicketsService)(nil).CreatePassenger), arg0, arg1)
+}
+
+// CreatePriceHold mocks base method.
+func (m *MockTicketsService) CreatePriceHold(arg0 context.Context, arg1 *tickets.ParamsCreatePriceHold) (*tickets.PriceHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePriceHold", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.PriceHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePriceHold indicates an expected call of CreatePriceHold.
+func (mr *MockTicketsServiceMockRecorder) CreatePriceHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePriceHold", reflect.TypeOf((*MockTicketsService)(nil).CreatePriceHold), arg0, arg1)
+}
+
 // CreateTicket mocks base method.
 func (m *MockTicketsService) CreateTicket(arg0 context.Context, arg1 *tickets.ParamsCreateTicket) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +157,66 @@ func (mr *MockTicketsServiceMockRecorder) CreateTicket(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTicket", reflect.TypeOf((*MockTicketsService)(nil).CreateTicket), arg0, arg1)
 }
 
+// DeletePassenger mocks base method.
+func (m *MockTicketsService) DeletePassenger(arg0 context.Context, arg1 *tickets.ParamsDeletePassenger) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePassenger", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePassenger indicates an expected call of DeletePassenger.
+func (mr *MockTicketsServiceMockRecorder) DeletePassenger(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePassenger", reflect.TypeOf((*MockTicketsService)(nil).DeletePassenger), arg0, arg1)
+}
+
+// GetCompanyById mocks base method.
+func (m *MockTicketsService) GetCompanyById(arg0 context.Context, arg1 uuid.UUID) (*tickets.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyById", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyById indicates an expected call of GetCompanyById.
+func (mr *MockTicketsServiceMockRecorder) GetCompanyById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyById", reflect.TypeOf((*MockTicketsService)(nil).GetCompanyById), arg0, arg1)
+}
+
+// GetCompanyReport mocks base method.
+func (m *MockTicketsService) GetCompanyReport(arg0 context.Context, arg1 *tickets.ParamsGetCompanyReport) (*tickets.CompanyReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyReport", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.CompanyReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyReport indicates an expected call of GetCompanyReport.
+func (mr *MockTicketsServiceMockRecorder) GetCompanyReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyReport", reflect.TypeOf((*MockTicketsService)(nil).GetCompanyReport), arg0, arg1)
+}
+
+// GetFlightManifest mocks base method.
+func (m *MockTicketsService) GetFlightManifest(arg0 context.Context, arg1 *tickets.ParamsGetFlightManifest) (*tickets.FlightManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlightManifest", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.FlightManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlightManifest indicates an expected call of GetFlightManifest.
+func (mr *MockTicketsServiceMockRecorder) GetFlightManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlightManifest", reflect.TypeOf((*MockTicketsService)(nil).GetFlightManifest), arg0, arg1)
+}
+
 // GetTicketById mocks base method.
 func (m *MockTicketsService) GetTicketById(arg0 context.Context, arg1 uuid.UUID) (*tickets.Ticket, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +232,81 @@ func (mr *MockTicketsServiceMockRecorder) GetTicketById(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTicketById", reflect.TypeOf((*MockTicketsService)(nil).GetTicketById), arg0, arg1)
 }
 
+// GetTicketDocument mocks base method.
+func (m *MockTicketsService) GetTicketDocument(arg0 context.Context, arg1 *tickets.ParamsGetTicketDocument) (*tickets.TicketDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTicketDocument", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.TicketDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTicketDocument indicates an expected call of GetTicketDocument.
+func (mr *MockTicketsServiceMockRecorder) GetTicketDocument(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTicketDocument", reflect.TypeOf((*MockTicketsService)(nil).GetTicketDocument), arg0, arg1)
+}
+
+// GetUserPassengers mocks base method.
+func (m *MockTicketsService) GetUserPassengers(arg0 context.Context, arg1 uuid.UUID) ([]tickets.Passenger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPassengers", arg0, arg1)
+	ret0, _ := ret[0].([]tickets.Passenger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPassengers indicates an expected call of GetUserPassengers.
+func (mr *MockTicketsServiceMockRecorder) GetUserPassengers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPassengers", reflect.TypeOf((*MockTicketsService)(nil).GetUserPassengers), arg0, arg1)
+}
+
+// GetUserTickets mocks base method.
+func (m *MockTicketsService) GetUserTickets(arg0 context.Context, arg1 *tickets.ParamsGetUserTickets) (*tickets.TicketsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTickets", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.TicketsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTickets indicates an expected call of GetUserTickets.
+func (mr *MockTicketsServiceMockRecorder) GetUserTickets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTickets", reflect.TypeOf((*MockTicketsService)(nil).GetUserTickets), arg0, arg1)
+}
+
+// GetVoucherByCode mocks base method.
+func (m *MockTicketsService) GetVoucherByCode(arg0 context.Context, arg1 string) (*tickets.Voucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVoucherByCode", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.Voucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoucherByCode indicates an expected call of GetVoucherByCode.
+func (mr *MockTicketsServiceMockRecorder) GetVoucherByCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoucherByCode", reflect.TypeOf((*MockTicketsService)(nil).GetVoucherByCode), arg0, arg1)
+}
+
+// JoinWaitlist mocks base method.
+func (m *MockTicketsService) JoinWaitlist(arg0 context.Context, arg1 *tickets.ParamsJoinWaitlist) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinWaitlist", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JoinWaitlist indicates an expected call of JoinWaitlist.
+func (mr *MockTicketsServiceMockRecorder) JoinWaitlist(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinWaitlist", reflect.TypeOf((*MockTicketsService)(nil).JoinWaitlist), arg0, arg1)
+}
+
 // PayForTicket mocks base method.
 func (m *MockTicketsService) PayForTicket(arg0 context.Context, arg1 *tickets.ParamsPayForTicket) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -81,6 +322,50 @@ func (mr *MockTicketsServiceMockRecorder) PayForTicket(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayForTicket", reflect.TypeOf((*MockTicketsService)(nil).PayForTicket), arg0, arg1)
 }
 
+// ProcessWaitlist mocks base method.
+func (m *MockTicketsService) ProcessWaitlist(arg0 context.Context, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessWaitlist", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessWaitlist indicates an expected call of ProcessWaitlist.
+func (mr *MockTicketsServiceMockRecorder) ProcessWaitlist(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessWaitlist", reflect.TypeOf((*MockTicketsService)(nil).ProcessWaitlist), arg0, arg1)
+}
+
+// RefundTicket mocks base method.
+func (m *MockTicketsService) RefundTicket(arg0 context.Context, arg1 *tickets.ParamsRefundTicket) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundTicket", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundTicket indicates an expected call of RefundTicket.
+func (mr *MockTicketsServiceMockRecorder) RefundTicket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundTicket", reflect.TypeOf((*MockTicketsService)(nil).RefundTicket), arg0, arg1)
+}
+
+// RegisterFlightTickets mocks base method.
+func (m *MockTicketsService) RegisterFlightTickets(arg0 context.Context, arg1 *tickets.ParamsRegisterFlightTickets) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterFlightTickets", arg0, arg1)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterFlightTickets indicates an expected call of RegisterFlightTickets.
+func (mr *MockTicketsServiceMockRecorder) RegisterFlightTickets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFlightTickets", reflect.TypeOf((*MockTicketsService)(nil).RegisterFlightTickets), arg0, arg1)
+}
+
 // RegisterTicket mocks base method.
 func (m *MockTicketsService) RegisterTicket(arg0 context.Context, arg1 *tickets.ParamsRegisterTicket) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -95,3 +380,18 @@ func (mr *MockTicketsServiceMockRecorder) RegisterTicket(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTicket", reflect.TypeOf((*MockTicketsService)(nil).RegisterTicket), arg0, arg1)
 }
+
+// UpdatePassenger mocks base method.
+func (m *MockTicketsService) UpdatePassenger(arg0 context.Context, arg1 *tickets.ParamsUpdatePassenger) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassenger", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePassenger indicates an expected call of UpdatePassenger.
+func (mr *MockTicketsServiceMockRecorder) UpdatePassenger(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassenger", reflect.TypeOf((*MockTicketsService)(nil).UpdatePassenger), arg0, arg1)
+}
@@ -0,0 +1,860 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: homework/internal/service/tickets (interfaces: TicketsStorage,FlightsStorage,UsersStorage,ExchangeRatesProvider)
+
+// Package mock_tickets is a generated GoMock package.
+package mock_tickets
+
+import (
+	context "context"
+	flights "homework/internal/domain/flights"
+	money "homework/internal/domain/money"
+	tickets "homework/internal/domain/tickets"
+	users "homework/internal/domain/users"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockTicketsStorage is a mock of TicketsStorage interface.
+type MockTicketsStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockTicketsStorageMockRecorder
+}
+
+// MockTicketsStorageMockRecorder is the mock recorder for MockTicketsStorage.
+type MockTicketsStorageMockRecorder struct {
+	mock *MockTicketsStorage
+}
+
+// NewMockTicketsStorage creates a new mock instance.
+func NewMockTicketsStorage(ctrl *gomock.Controller) *MockTicketsStorage {
+	mock := &MockTicketsStorage{ctrl: ctrl}
+	mock.recorder = &MockTicketsStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTicketsStorage) EXPECT() *MockTicketsStorageMockRecorder {
+	return m.recorder
+}
+
+// AddTicketAncillary mocks base method.
+func (m *MockTicketsStorage) AddTicketAncillary(arg0 context.Context, arg1 *tickets.ParamsAddTicketAncillary) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTicketAncillary", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTicketAncillary indicates an expected call of AddTicketAncillary.
+func (mr *MockTicketsStorageMockRecorder) AddTicketAncillary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTicketAncillary", reflect.TypeOf((*MockTicketsStorage)(nil).AddTicketAncillary), arg0, arg1)
+}
+
+// ApproveTicket mocks base method.
+func (m *MockTicketsStorage) ApproveTicket(arg0 context.Context, arg1 *tickets.ParamsApproveTicket) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveTicket", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveTicket indicates an expected call of ApproveTicket.
+func (mr *MockTicketsStorageMockRecorder) ApproveTicket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveTicket", reflect.TypeOf((*MockTicketsStorage)(nil).ApproveTicket), arg0, arg1)
+}
+
+// BoardTicket mocks base method.
+func (m *MockTicketsStorage) BoardTicket(arg0 context.Context, arg1 *tickets.ParamsBoardTicket) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BoardTicket", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BoardTicket indicates an expected call of BoardTicket.
+func (mr *MockTicketsStorageMockRecorder) BoardTicket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BoardTicket", reflect.TypeOf((*MockTicketsStorage)(nil).BoardTicket), arg0, arg1)
+}
+
+// CancelUnpaidTickets mocks base method.
+func (m *MockTicketsStorage) CancelUnpaidTickets(arg0 context.Context, arg1, arg2, arg3 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUnpaidTickets", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelUnpaidTickets indicates an expected call of CancelUnpaidTickets.
+func (mr *MockTicketsStorageMockRecorder) CancelUnpaidTickets(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUnpaidTickets", reflect.TypeOf((*MockTicketsStorage)(nil).CancelUnpaidTickets), arg0, arg1, arg2, arg3)
+}
+
+// CloseFlight mocks base method.
+func (m *MockTicketsStorage) CloseFlight(arg0 context.Context, arg1 *tickets.ParamsCloseFlight) (*tickets.ClosedFlight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseFlight", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.ClosedFlight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseFlight indicates an expected call of CloseFlight.
+func (mr *MockTicketsStorageMockRecorder) CloseFlight(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseFlight", reflect.TypeOf((*MockTicketsStorage)(nil).CloseFlight), arg0, arg1)
+}
+
+// CloseWaitlistEntry mocks base method.
+func (m *MockTicketsStorage) CloseWaitlistEntry(arg0 context.Context, arg1 *tickets.ParamsCloseWaitlistEntry) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseWaitlistEntry", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseWaitlistEntry indicates an expected call of CloseWaitlistEntry.
+func (mr *MockTicketsStorageMockRecorder) CloseWaitlistEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseWaitlistEntry", reflect.TypeOf((*MockTicketsStorage)(nil).CloseWaitlistEntry), arg0, arg1)
+}
+
+// CreateCompanyInvoice mocks base method.
+func (m *MockTicketsStorage) CreateCompanyInvoice(arg0 context.Context, arg1 *tickets.ParamsCreateCompanyInvoice) (*tickets.CompanyInvoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCompanyInvoice", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.CompanyInvoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCompanyInvoice indicates an expected call of CreateCompanyInvoice.
+func (mr *MockTicketsStorageMockRecorder) CreateCompanyInvoice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompanyInvoice", reflect.TypeOf((*MockTicketsStorage)(nil).CreateCompanyInvoice), arg0, arg1)
+}
+
+// CreatePassenger mocks base method.
+func (m *MockTicketsStorage) CreatePassenger(arg0 context.Context, arg1 *tickets.ParamsCreatePassenger) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePassenger", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePassenger indicates an expected call of CreatePassenger.
+func (mr *MockTicketsStorageMockRecorder) CreatePassenger(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePassenger", reflect.TypeOf((*MockTicketsStorage)(nil).CreatePassenger), arg0, arg1)
+}
+
+// CreatePriceHold mocks base method.
+func (m *MockTicketsStorage) CreatePriceHold(arg0 context.Context, arg1 *tickets.ParamsCreatePriceHold) (*tickets.PriceHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePriceHold", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.PriceHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePriceHold indicates an expected call of CreatePriceHold.
+func (mr *MockTicketsStorageMockRecorder) CreatePriceHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePriceHold", reflect.TypeOf((*MockTicketsStorage)(nil).CreatePriceHold), arg0, arg1)
+}
+
+// CreateTicket mocks base method.
+func (m *MockTicketsStorage) CreateTicket(arg0 context.Context, arg1 *tickets.ParamsCreateTicket) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTicket", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTicket indicates an expected call of CreateTicket.
+func (mr *MockTicketsStorageMockRecorder) CreateTicket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTicket", reflect.TypeOf((*MockTicketsStorage)(nil).CreateTicket), arg0, arg1)
+}
+
+// CreateWaitlistEntry mocks base method.
+func (m *MockTicketsStorage) CreateWaitlistEntry(arg0 context.Context, arg1 *tickets.ParamsJoinWaitlist) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWaitlistEntry", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWaitlistEntry indicates an expected call of CreateWaitlistEntry.
+func (mr *MockTicketsStorageMockRecorder) CreateWaitlistEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWaitlistEntry", reflect.TypeOf((*MockTicketsStorage)(nil).CreateWaitlistEntry), arg0, arg1)
+}
+
+// DeletePassenger mocks base method.
+func (m *MockTicketsStorage) DeletePassenger(arg0 context.Context, arg1 *tickets.ParamsDeletePassenger) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePassenger", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePassenger indicates an expected call of DeletePassenger.
+func (mr *MockTicketsStorageMockRecorder) DeletePassenger(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePassenger", reflect.TypeOf((*MockTicketsStorage)(nil).DeletePassenger), arg0, arg1)
+}
+
+// ExpirePriceHold mocks base method.
+func (m *MockTicketsStorage) ExpirePriceHold(arg0 context.Context, arg1 *tickets.ParamsProcessPriceHold) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePriceHold", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePriceHold indicates an expected call of ExpirePriceHold.
+func (mr *MockTicketsStorageMockRecorder) ExpirePriceHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePriceHold", reflect.TypeOf((*MockTicketsStorage)(nil).ExpirePriceHold), arg0, arg1)
+}
+
+// GetAccompaniedTickets mocks base method.
+func (m *MockTicketsStorage) GetAccompaniedTickets(arg0 context.Context, arg1 uuid.UUID) ([]tickets.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccompaniedTickets", arg0, arg1)
+	ret0, _ := ret[0].([]tickets.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccompaniedTickets indicates an expected call of GetAccompaniedTickets.
+func (mr *MockTicketsStorageMockRecorder) GetAccompaniedTickets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccompaniedTickets", reflect.TypeOf((*MockTicketsStorage)(nil).GetAccompaniedTickets), arg0, arg1)
+}
+
+// GetActivePriceHolds mocks base method.
+func (m *MockTicketsStorage) GetActivePriceHolds(arg0 context.Context, arg1 time.Time) ([]tickets.PriceHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivePriceHolds", arg0, arg1)
+	ret0, _ := ret[0].([]tickets.PriceHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivePriceHolds indicates an expected call of GetActivePriceHolds.
+func (mr *MockTicketsStorageMockRecorder) GetActivePriceHolds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivePriceHolds", reflect.TypeOf((*MockTicketsStorage)(nil).GetActivePriceHolds), arg0, arg1)
+}
+
+// GetCompanyById mocks base method.
+func (m *MockTicketsStorage) GetCompanyById(arg0 context.Context, arg1 uuid.UUID) (*tickets.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyById", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyById indicates an expected call of GetCompanyById.
+func (mr *MockTicketsStorageMockRecorder) GetCompanyById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyById", reflect.TypeOf((*MockTicketsStorage)(nil).GetCompanyById), arg0, arg1)
+}
+
+// GetCompanyTickets mocks base method.
+func (m *MockTicketsStorage) GetCompanyTickets(arg0 context.Context, arg1 *tickets.ParamsGetCompanyReport) ([]tickets.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyTickets", arg0, arg1)
+	ret0, _ := ret[0].([]tickets.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyTickets indicates an expected call of GetCompanyTickets.
+func (mr *MockTicketsStorageMockRecorder) GetCompanyTickets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyTickets", reflect.TypeOf((*MockTicketsStorage)(nil).GetCompanyTickets), arg0, arg1)
+}
+
+// GetCountFlightInfants mocks base method.
+func (m *MockTicketsStorage) GetCountFlightInfants(arg0 context.Context, arg1 uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountFlightInfants", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountFlightInfants indicates an expected call of GetCountFlightInfants.
+func (mr *MockTicketsStorageMockRecorder) GetCountFlightInfants(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountFlightInfants", reflect.TypeOf((*MockTicketsStorage)(nil).GetCountFlightInfants), arg0, arg1)
+}
+
+// GetCountPassengerActiveTickets mocks base method.
+func (m *MockTicketsStorage) GetCountPassengerActiveTickets(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountPassengerActiveTickets", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountPassengerActiveTickets indicates an expected call of GetCountPassengerActiveTickets.
+func (mr *MockTicketsStorageMockRecorder) GetCountPassengerActiveTickets(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountPassengerActiveTickets", reflect.TypeOf((*MockTicketsStorage)(nil).GetCountPassengerActiveTickets), arg0, arg1, arg2)
+}
+
+// GetCountPassengerWaitlistEntries mocks base method.
+func (m *MockTicketsStorage) GetCountPassengerWaitlistEntries(arg0 context.Context, arg1, arg2 uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountPassengerWaitlistEntries", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountPassengerWaitlistEntries indicates an expected call of GetCountPassengerWaitlistEntries.
+func (mr *MockTicketsStorageMockRecorder) GetCountPassengerWaitlistEntries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountPassengerWaitlistEntries", reflect.TypeOf((*MockTicketsStorage)(nil).GetCountPassengerWaitlistEntries), arg0, arg1, arg2)
+}
+
+// GetExpiredWaitlistEntries mocks base method.
+func (m *MockTicketsStorage) GetExpiredWaitlistEntries(arg0 context.Context, arg1 time.Time) ([]tickets.WaitlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredWaitlistEntries", arg0, arg1)
+	ret0, _ := ret[0].([]tickets.WaitlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredWaitlistEntries indicates an expected call of GetExpiredWaitlistEntries.
+func (mr *MockTicketsStorageMockRecorder) GetExpiredWaitlistEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredWaitlistEntries", reflect.TypeOf((*MockTicketsStorage)(nil).GetExpiredWaitlistEntries), arg0, arg1)
+}
+
+// GetFlightManifest mocks base method.
+func (m *MockTicketsStorage) GetFlightManifest(arg0 context.Context, arg1 uuid.UUID) ([]tickets.ManifestPassenger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlightManifest", arg0, arg1)
+	ret0, _ := ret[0].([]tickets.ManifestPassenger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlightManifest indicates an expected call of GetFlightManifest.
+func (mr *MockTicketsStorageMockRecorder) GetFlightManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlightManifest", reflect.TypeOf((*MockTicketsStorage)(nil).GetFlightManifest), arg0, arg1)
+}
+
+// GetPassengerById mocks base method.
+func (m *MockTicketsStorage) GetPassengerById(arg0 context.Context, arg1 uuid.UUID) (*tickets.Passenger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPassengerById", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.Passenger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPassengerById indicates an expected call of GetPassengerById.
+func (mr *MockTicketsStorageMockRecorder) GetPassengerById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPassengerById", reflect.TypeOf((*MockTicketsStorage)(nil).GetPassengerById), arg0, arg1)
+}
+
+// GetPromoCodeByCode mocks base method.
+func (m *MockTicketsStorage) GetPromoCodeByCode(arg0 context.Context, arg1 string) (*tickets.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromoCodeByCode", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromoCodeByCode indicates an expected call of GetPromoCodeByCode.
+func (mr *MockTicketsStorageMockRecorder) GetPromoCodeByCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromoCodeByCode", reflect.TypeOf((*MockTicketsStorage)(nil).GetPromoCodeByCode), arg0, arg1)
+}
+
+// GetPromoCodeById mocks base method.
+func (m *MockTicketsStorage) GetPromoCodeById(arg0 context.Context, arg1 uuid.UUID) (*tickets.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromoCodeById", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromoCodeById indicates an expected call of GetPromoCodeById.
+func (mr *MockTicketsStorageMockRecorder) GetPromoCodeById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromoCodeById", reflect.TypeOf((*MockTicketsStorage)(nil).GetPromoCodeById), arg0, arg1)
+}
+
+// GetPromoCodeUsage mocks base method.
+func (m *MockTicketsStorage) GetPromoCodeUsage(arg0 context.Context, arg1, arg2 uuid.UUID) (*tickets.PromoCodeUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromoCodeUsage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*tickets.PromoCodeUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromoCodeUsage indicates an expected call of GetPromoCodeUsage.
+func (mr *MockTicketsStorageMockRecorder) GetPromoCodeUsage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromoCodeUsage", reflect.TypeOf((*MockTicketsStorage)(nil).GetPromoCodeUsage), arg0, arg1, arg2)
+}
+
+// GetTicketById mocks base method.
+func (m *MockTicketsStorage) GetTicketById(arg0 context.Context, arg1 uuid.UUID) (*tickets.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTicketById", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTicketById indicates an expected call of GetTicketById.
+func (mr *MockTicketsStorageMockRecorder) GetTicketById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTicketById", reflect.TypeOf((*MockTicketsStorage)(nil).GetTicketById), arg0, arg1)
+}
+
+// GetTicketDocuments mocks base method.
+func (m *MockTicketsStorage) GetTicketDocuments(arg0 context.Context, arg1 uuid.UUID) ([]tickets.TicketDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTicketDocuments", arg0, arg1)
+	ret0, _ := ret[0].([]tickets.TicketDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTicketDocuments indicates an expected call of GetTicketDocuments.
+func (mr *MockTicketsStorageMockRecorder) GetTicketDocuments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTicketDocuments", reflect.TypeOf((*MockTicketsStorage)(nil).GetTicketDocuments), arg0, arg1)
+}
+
+// GetUserFlightTickets mocks base method.
+func (m *MockTicketsStorage) GetUserFlightTickets(arg0 context.Context, arg1, arg2 uuid.UUID) ([]tickets.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserFlightTickets", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]tickets.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserFlightTickets indicates an expected call of GetUserFlightTickets.
+func (mr *MockTicketsStorageMockRecorder) GetUserFlightTickets(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFlightTickets", reflect.TypeOf((*MockTicketsStorage)(nil).GetUserFlightTickets), arg0, arg1, arg2)
+}
+
+// GetUserPassengers mocks base method.
+func (m *MockTicketsStorage) GetUserPassengers(arg0 context.Context, arg1 uuid.UUID) ([]tickets.Passenger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPassengers", arg0, arg1)
+	ret0, _ := ret[0].([]tickets.Passenger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPassengers indicates an expected call of GetUserPassengers.
+func (mr *MockTicketsStorageMockRecorder) GetUserPassengers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPassengers", reflect.TypeOf((*MockTicketsStorage)(nil).GetUserPassengers), arg0, arg1)
+}
+
+// GetUserTickets mocks base method.
+func (m *MockTicketsStorage) GetUserTickets(arg0 context.Context, arg1 *tickets.ParamsGetUserTickets) (*tickets.TicketsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTickets", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.TicketsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTickets indicates an expected call of GetUserTickets.
+func (mr *MockTicketsStorageMockRecorder) GetUserTickets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTickets", reflect.TypeOf((*MockTicketsStorage)(nil).GetUserTickets), arg0, arg1)
+}
+
+// GetVoucherByCode mocks base method.
+func (m *MockTicketsStorage) GetVoucherByCode(arg0 context.Context, arg1 string) (*tickets.Voucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVoucherByCode", arg0, arg1)
+	ret0, _ := ret[0].(*tickets.Voucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoucherByCode indicates an expected call of GetVoucherByCode.
+func (mr *MockTicketsStorageMockRecorder) GetVoucherByCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoucherByCode", reflect.TypeOf((*MockTicketsStorage)(nil).GetVoucherByCode), arg0, arg1)
+}
+
+// GetWaitlistClassesSeats mocks base method.
+func (m *MockTicketsStorage) GetWaitlistClassesSeats(arg0 context.Context) ([]tickets.WaitlistClassSeats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWaitlistClassesSeats", arg0)
+	ret0, _ := ret[0].([]tickets.WaitlistClassSeats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWaitlistClassesSeats indicates an expected call of GetWaitlistClassesSeats.
+func (mr *MockTicketsStorageMockRecorder) GetWaitlistClassesSeats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWaitlistClassesSeats", reflect.TypeOf((*MockTicketsStorage)(nil).GetWaitlistClassesSeats), arg0)
+}
+
+// GetWaitlistEntries mocks base method.
+func (m *MockTicketsStorage) GetWaitlistEntries(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 int) ([]tickets.WaitlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWaitlistEntries", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]tickets.WaitlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWaitlistEntries indicates an expected call of GetWaitlistEntries.
+func (mr *MockTicketsStorageMockRecorder) GetWaitlistEntries(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWaitlistEntries", reflect.TypeOf((*MockTicketsStorage)(nil).GetWaitlistEntries), arg0, arg1, arg2, arg3)
+}
+
+// PayForTicket mocks base method.
+func (m *MockTicketsStorage) PayForTicket(arg0 context.Context, arg1 *tickets.ParamsPayForTicket) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayForTicket", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayForTicket indicates an expected call of PayForTicket.
+func (mr *MockTicketsStorageMockRecorder) PayForTicket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayForTicket", reflect.TypeOf((*MockTicketsStorage)(nil).PayForTicket), arg0, arg1)
+}
+
+// RefundTicket mocks base method.
+func (m *MockTicketsStorage) RefundTicket(arg0 context.Context, arg1 *tickets.ParamsRefundTicket) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundTicket", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundTicket indicates an expected call of RefundTicket.
+func (mr *MockTicketsStorageMockRecorder) RefundTicket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundTicket", reflect.TypeOf((*MockTicketsStorage)(nil).RefundTicket), arg0, arg1)
+}
+
+// RegisterTicket mocks base method.
+func (m *MockTicketsStorage) RegisterTicket(arg0 context.Context, arg1 *tickets.ParamsRegisterTicket) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterTicket", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterTicket indicates an expected call of RegisterTicket.
+func (mr *MockTicketsStorageMockRecorder) RegisterTicket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTicket", reflect.TypeOf((*MockTicketsStorage)(nil).RegisterTicket), arg0, arg1)
+}
+
+// RegisterTickets mocks base method.
+func (m *MockTicketsStorage) RegisterTickets(arg0 context.Context, arg1 []tickets.ParamsRegisterTicket) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterTickets", arg0, arg1)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterTickets indicates an expected call of RegisterTickets.
+func (mr *MockTicketsStorageMockRecorder) RegisterTickets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTickets", reflect.TypeOf((*MockTicketsStorage)(nil).RegisterTickets), arg0, arg1)
+}
+
+// RemindPriceHold mocks base method.
+func (m *MockTicketsStorage) RemindPriceHold(arg0 context.Context, arg1 *tickets.ParamsProcessPriceHold) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemindPriceHold", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemindPriceHold indicates an expected call of RemindPriceHold.
+func (mr *MockTicketsStorageMockRecorder) RemindPriceHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemindPriceHold", reflect.TypeOf((*MockTicketsStorage)(nil).RemindPriceHold), arg0, arg1)
+}
+
+// UpdatePassenger mocks base method.
+func (m *MockTicketsStorage) UpdatePassenger(arg0 context.Context, arg1 *tickets.ParamsUpdatePassenger) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassenger", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePassenger indicates an expected call of UpdatePassenger.
+func (mr *MockTicketsStorageMockRecorder) UpdatePassenger(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassenger", reflect.TypeOf((*MockTicketsStorage)(nil).UpdatePassenger), arg0, arg1)
+}
+
+// MockFlightsStorage is a mock of FlightsStorage interface.
+type MockFlightsStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockFlightsStorageMockRecorder
+}
+
+// MockFlightsStorageMockRecorder is the mock recorder for MockFlightsStorage.
+type MockFlightsStorageMockRecorder struct {
+	mock *MockFlightsStorage
+}
+
+// NewMockFlightsStorage creates a new mock instance.
+func NewMockFlightsStorage(ctrl *gomock.Controller) *MockFlightsStorage {
+	mock := &MockFlightsStorage{ctrl: ctrl}
+	mock.recorder = &MockFlightsStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFlightsStorage) EXPECT() *MockFlightsStorageMockRecorder {
+	return m.recorder
+}
+
+// DeleteExpiredSeatHolds mocks base method.
+func (m *MockFlightsStorage) DeleteExpiredSeatHolds(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredSeatHolds", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredSeatHolds indicates an expected call of DeleteExpiredSeatHolds.
+func (mr *MockFlightsStorageMockRecorder) DeleteExpiredSeatHolds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredSeatHolds", reflect.TypeOf((*MockFlightsStorage)(nil).DeleteExpiredSeatHolds), arg0, arg1)
+}
+
+// GetFlightAncillaryById mocks base method.
+func (m *MockFlightsStorage) GetFlightAncillaryById(arg0 context.Context, arg1 uuid.UUID) (*flights.FlightAncillary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlightAncillaryById", arg0, arg1)
+	ret0, _ := ret[0].(*flights.FlightAncillary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlightAncillaryById indicates an expected call of GetFlightAncillaryById.
+func (mr *MockFlightsStorageMockRecorder) GetFlightAncillaryById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlightAncillaryById", reflect.TypeOf((*MockFlightsStorage)(nil).GetFlightAncillaryById), arg0, arg1)
+}
+
+// GetFlightById mocks base method.
+func (m *MockFlightsStorage) GetFlightById(arg0 context.Context, arg1 uuid.UUID) (*flights.Flight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlightById", arg0, arg1)
+	ret0, _ := ret[0].(*flights.Flight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlightById indicates an expected call of GetFlightById.
+func (mr *MockFlightsStorageMockRecorder) GetFlightById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlightById", reflect.TypeOf((*MockFlightsStorage)(nil).GetFlightById), arg0, arg1)
+}
+
+// GetFlightVacantSeatsByClassId mocks base method.
+func (m *MockFlightsStorage) GetFlightVacantSeatsByClassId(arg0 context.Context, arg1, arg2 uuid.UUID) (*flights.VacantSeats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlightVacantSeatsByClassId", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*flights.VacantSeats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlightVacantSeatsByClassId indicates an expected call of GetFlightVacantSeatsByClassId.
+func (mr *MockFlightsStorageMockRecorder) GetFlightVacantSeatsByClassId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlightVacantSeatsByClassId", reflect.TypeOf((*MockFlightsStorage)(nil).GetFlightVacantSeatsByClassId), arg0, arg1, arg2)
+}
+
+// GetSeatHoldById mocks base method.
+func (m *MockFlightsStorage) GetSeatHoldById(arg0 context.Context, arg1 uuid.UUID) (*flights.SeatHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeatHoldById", arg0, arg1)
+	ret0, _ := ret[0].(*flights.SeatHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeatHoldById indicates an expected call of GetSeatHoldById.
+func (mr *MockFlightsStorageMockRecorder) GetSeatHoldById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeatHoldById", reflect.TypeOf((*MockFlightsStorage)(nil).GetSeatHoldById), arg0, arg1)
+}
+
+// GetSeatsByClassId mocks base method.
+func (m *MockFlightsStorage) GetSeatsByClassId(arg0 context.Context, arg1 uuid.UUID) ([]flights.Seat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeatsByClassId", arg0, arg1)
+	ret0, _ := ret[0].([]flights.Seat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeatsByClassId indicates an expected call of GetSeatsByClassId.
+func (mr *MockFlightsStorageMockRecorder) GetSeatsByClassId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeatsByClassId", reflect.TypeOf((*MockFlightsStorage)(nil).GetSeatsByClassId), arg0, arg1)
+}
+
+// MockUsersStorage is a mock of UsersStorage interface.
+type MockUsersStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsersStorageMockRecorder
+}
+
+// MockUsersStorageMockRecorder is the mock recorder for MockUsersStorage.
+type MockUsersStorageMockRecorder struct {
+	mock *MockUsersStorage
+}
+
+// NewMockUsersStorage creates a new mock instance.
+func NewMockUsersStorage(ctrl *gomock.Controller) *MockUsersStorage {
+	mock := &MockUsersStorage{ctrl: ctrl}
+	mock.recorder = &MockUsersStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsersStorage) EXPECT() *MockUsersStorageMockRecorder {
+	return m.recorder
+}
+
+// GetAccruedBonuses mocks base method.
+func (m *MockUsersStorage) GetAccruedBonuses(arg0 context.Context, arg1 *users.ParamsLoyalty) (money.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccruedBonuses", arg0, arg1)
+	ret0, _ := ret[0].(money.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccruedBonuses indicates an expected call of GetAccruedBonuses.
+func (mr *MockUsersStorageMockRecorder) GetAccruedBonuses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccruedBonuses", reflect.TypeOf((*MockUsersStorage)(nil).GetAccruedBonuses), arg0, arg1)
+}
+
+// GetBonusRedemptionLimit mocks base method.
+func (m *MockUsersStorage) GetBonusRedemptionLimit(arg0 context.Context, arg1 *users.ParamsLoyalty) (money.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBonusRedemptionLimit", arg0, arg1)
+	ret0, _ := ret[0].(money.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBonusRedemptionLimit indicates an expected call of GetBonusRedemptionLimit.
+func (mr *MockUsersStorageMockRecorder) GetBonusRedemptionLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBonusRedemptionLimit", reflect.TypeOf((*MockUsersStorage)(nil).GetBonusRedemptionLimit), arg0, arg1)
+}
+
+// GetUserById mocks base method.
+func (m *MockUsersStorage) GetUserById(arg0 context.Context, arg1 uuid.UUID) (*users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", arg0, arg1)
+	ret0, _ := ret[0].(*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockUsersStorageMockRecorder) GetUserById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockUsersStorage)(nil).GetUserById), arg0, arg1)
+}
+
+// MockExchangeRatesProvider is a mock of ExchangeRatesProvider interface.
+type MockExchangeRatesProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRatesProviderMockRecorder
+}
+
+// MockExchangeRatesProviderMockRecorder is the mock recorder for MockExchangeRatesProvider.
+type MockExchangeRatesProviderMockRecorder struct {
+	mock *MockExchangeRatesProvider
+}
+
+// NewMockExchangeRatesProvider creates a new mock instance.
+func NewMockExchangeRatesProvider(ctrl *gomock.Controller) *MockExchangeRatesProvider {
+	mock := &MockExchangeRatesProvider{ctrl: ctrl}
+	mock.recorder = &MockExchangeRatesProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRatesProvider) EXPECT() *MockExchangeRatesProviderMockRecorder {
+	return m.recorder
+}
+
+// GetExchangeRate mocks base method.
+func (m *MockExchangeRatesProvider) GetExchangeRate(arg0 context.Context, arg1, arg2 string, arg3 time.Time) (*money.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*money.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRate indicates an expected call of GetExchangeRate.
+func (mr *MockExchangeRatesProviderMockRecorder) GetExchangeRate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRate", reflect.TypeOf((*MockExchangeRatesProvider)(nil).GetExchangeRate), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,117 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// возраст на дату вылета, с которого пассажир считается ребенком, лет
+const ageChild = 2
+
+// возраст на дату вылета, с которого пассажир считается взрослым, лет
+const ageAdult = 12
+
+// определение типа пассажира по дате рождения на дату вылета:
+// младенец - до 2 лет, ребенок - от 2 до 12 лет, взрослый - от 12 лет
+func getPassengerType(birthDate time.Time, departureDate time.Time) string {
+
+	if birthDate.AddDate(ageChild, 0, 0).After(departureDate) {
+		return ticketsDomain.PassengerTypeInfant
+	}
+	if birthDate.AddDate(ageAdult, 0, 0).After(departureDate) {
+		return ticketsDomain.PassengerTypeChild
+	}
+	return ticketsDomain.PassengerTypeAdult
+}
+
+// стоимость билета выбранного класса с учетом скидки для детей и младенцев
+func getPriceTicketByPassengerType(flightPrice *flightsDomain.FlightPrice, passengerType string) money.Money {
+
+	var discountPercent int
+	switch passengerType {
+	case ticketsDomain.PassengerTypeChild:
+		discountPercent = flightPrice.ChildDiscountPercent
+	case ticketsDomain.PassengerTypeInfant:
+		discountPercent = flightPrice.InfantDiscountPercent
+	}
+
+	return flightPrice.PriceTicket.Percent(100 - discountPercent)
+}
+
+// расчет стоимости билета как суммы позиций состава стоимости:
+// стоимость билета выбранного класса с учетом скидки для детей и младенцев
+// + сборы рейса
+// + стоимость дополнительного багажа * количество дополнительного багажа
+// + стоимость выбора места, если место было выбрано на этапе создания билета
+func setPriceCreateTicket(paramsCreateTicket *ticketsDomain.ParamsCreateTicket, flight *flightsDomain.Flight) {
+
+	priceTicket := money.New(0, flight.Currency)
+	for _, flightPrice := range flight.PricesTickets {
+		if flightPrice.ClassSeats.Id == paramsCreateTicket.ClassSeatsId {
+			priceTicket = getPriceTicketByPassengerType(&flightPrice, paramsCreateTicket.PassengerType)
+			break
+		}
+	}
+	paramsCreateTicket.Items = getTicketItemsCreateTicket(paramsCreateTicket, flight, priceTicket)
+	paramsCreateTicket.Price = getTicketItemsPrice(paramsCreateTicket.Items)
+}
+
+// проверка билета сопровождающего взрослого для билета ребенка или младенца
+func (s service) checkAccompanyingTicket(ctx context.Context, paramsCreateTicket *ticketsDomain.ParamsCreateTicket) error {
+
+	// билет ребенка и младенца оформляется только с сопровождением взрослого
+	if paramsCreateTicket.AccompanyingTicketId == nil {
+		return terr.BadRequest("ACCOMPANYING_ADULT_REQUIRED", fmt.Sprintf("%s has to be accompanied by an adult ticket", paramsCreateTicket.PassengerType))
+	}
+
+	// проверяем, что по переданному AccompanyingTicketId существует билет
+	accompanyingTicket, err := s.ticketsStorage.GetTicketById(ctx, *paramsCreateTicket.AccompanyingTicketId)
+	if err != nil {
+		return err
+	}
+
+	// проверки билета сопровождающего:
+	// билет взрослого на тот же рейс, оформленный тем же пользователем
+	if accompanyingTicket.PassengerType != ticketsDomain.PassengerTypeAdult {
+		return terr.BadRequest("INVALID_ACCOMPANYING_TICKET", fmt.Sprintf("ticket (id %s) isn't an adult ticket", accompanyingTicket.Id))
+	}
+	if accompanyingTicket.Flight.Id != paramsCreateTicket.FlightId {
+		return terr.BadRequest("INVALID_ACCOMPANYING_TICKET", fmt.Sprintf("ticket (id %s) is for another flight (id %s)", accompanyingTicket.Id, accompanyingTicket.Flight.Id))
+	}
+	if accompanyingTicket.User.Id != paramsCreateTicket.UserId {
+		return terr.BadRequest("INVALID_ACCOMPANYING_TICKET", fmt.Sprintf("the user of the ticket (id %s) doesn't match the user (id %s)", accompanyingTicket.Id, paramsCreateTicket.UserId))
+	}
+
+	// билет действующий: статус 1(Created), 2(Paid) или 5(Registered)
+	if accompanyingTicket.Status.Id != 1 && accompanyingTicket.Status.Id != 2 && accompanyingTicket.Status.Id != 5 {
+		return terr.BadRequest("INVALID_ACCOMPANYING_TICKET", fmt.Sprintf("ticket (id %s) has wrong status (%s)", accompanyingTicket.Id, accompanyingTicket.Status.Name))
+	}
+
+	if paramsCreateTicket.PassengerType != ticketsDomain.PassengerTypeInfant {
+		return nil
+	}
+
+	// младенец летит на руках у взрослого, поэтому в том же классе
+	if accompanyingTicket.ClassSeats.Id != paramsCreateTicket.ClassSeatsId {
+		return terr.BadRequest("INVALID_ACCOMPANYING_TICKET", fmt.Sprintf("class seat of the ticket (id %s) doesn't match class seat (id %s)", accompanyingTicket.Id, paramsCreateTicket.ClassSeatsId))
+	}
+
+	// у одного взрослого может быть только один младенец на руках
+	accompaniedTickets, err := s.ticketsStorage.GetAccompaniedTickets(ctx, accompanyingTicket.Id)
+	if err != nil {
+		return err
+	}
+	for _, accompaniedTicket := range accompaniedTickets {
+		if accompaniedTicket.PassengerType == ticketsDomain.PassengerTypeInfant {
+			return terr.BadRequest("ADULT_ALREADY_HAS_INFANT", fmt.Sprintf("ticket (id %s) already accompanies an infant", accompanyingTicket.Id))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+)
+
+func Test_GetPassengerType(t *testing.T) {
+
+	// Arrange
+	departureDate := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name      string
+		birthDate time.Time
+		want      string
+	}{
+		{
+			name:      "infant",
+			birthDate: time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC),
+			want:      ticketsDomain.PassengerTypeInfant,
+		},
+		{
+			name:      "child/exactly 2 years on departure",
+			birthDate: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+			want:      ticketsDomain.PassengerTypeChild,
+		},
+		{
+			name:      "child/day before 12 years",
+			birthDate: time.Date(2011, 6, 2, 0, 0, 0, 0, time.UTC),
+			want:      ticketsDomain.PassengerTypeChild,
+		},
+		{
+			name:      "adult/exactly 12 years on departure",
+			birthDate: time.Date(2011, 6, 1, 0, 0, 0, 0, time.UTC),
+			want:      ticketsDomain.PassengerTypeAdult,
+		},
+		{
+			name:      "adult",
+			birthDate: time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+			want:      ticketsDomain.PassengerTypeAdult,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := getPassengerType(tt.birthDate, departureDate)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_GetPriceTicketByPassengerType(t *testing.T) {
+
+	// Arrange
+	flightPrice := &flightsDomain.FlightPrice{
+		PriceTicket:           money.New(600000, money.CurrencyRUB),
+		ChildDiscountPercent:  25,
+		InfantDiscountPercent: 90,
+	}
+
+	var tests = []struct {
+		name          string
+		passengerType string
+		want          money.Money
+	}{
+		{
+			name:          "adult",
+			passengerType: ticketsDomain.PassengerTypeAdult,
+			want:          money.New(600000, money.CurrencyRUB),
+		},
+		{
+			name:          "child",
+			passengerType: ticketsDomain.PassengerTypeChild,
+			want:          money.New(450000, money.CurrencyRUB),
+		},
+		{
+			name:          "infant",
+			passengerType: ticketsDomain.PassengerTypeInfant,
+			want:          money.New(60000, money.CurrencyRUB),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := getPriceTicketByPassengerType(flightPrice, tt.passengerType)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
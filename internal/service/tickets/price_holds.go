@@ -0,0 +1,164 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	flightsDomain "homework/internal/domain/flights"
+	ticketsDomain "homework/internal/domain/tickets"
+	usersDomain "homework/internal/domain/users"
+	"homework/internal/util/terr"
+)
+
+// за сколько до окончания удержания цены пользователю отправляется напоминание об оплате
+const priceHoldReminderLead = 6 * time.Hour
+
+// getPriceHoldDuration возвращает срок удержания цены билета в зависимости от времени до вылета:
+// за 30 и более дней - 72 часа, за 14 и более дней - 48 часов, иначе 24 часа, но не больше срока,
+// разрешенного тарифом maxHours. удержание заканчивается не позже закрытия продажи билетов (за 2 часа до вылета).
+// нулевой срок - удержание недоступно
+func getPriceHoldDuration(timestamp time.Time, departureDate time.Time, maxHours int) time.Duration {
+
+	if maxHours <= 0 {
+		return 0
+	}
+
+	daysToDeparture := departureDate.Sub(timestamp).Hours() / 24
+	hours := 24
+	switch {
+	case daysToDeparture >= 30:
+		hours = 72
+	case daysToDeparture >= 14:
+		hours = 48
+	}
+	if hours > maxHours {
+		hours = maxHours
+	}
+
+	duration := time.Duration(hours) * time.Hour
+	if timestamp.Add(duration).After(departureDate.Add(-2 * time.Hour)) {
+		return 0
+	}
+	return duration
+}
+
+func (s service) CreatePriceHold(ctx context.Context, paramsCreatePriceHold *ticketsDomain.ParamsCreatePriceHold) (*ticketsDomain.PriceHold, error) {
+
+	// по id получаем билет для удержания цены
+	ticket, err := s.ticketsStorage.GetTicketById(ctx, paramsCreatePriceHold.TicketId)
+	if err != nil {
+		return nil, err
+	}
+
+	// проверки билета:
+	// переданный пользователь соответствует пользователю билета
+	if paramsCreatePriceHold.UserId != ticket.User.Id {
+		return nil, terr.BadRequest("INVALID_USER", fmt.Sprintf("the user (id %s) doesn't match the user of the ticket (id %s)", paramsCreatePriceHold.UserId, ticket.User.Id))
+	}
+	// удержать цену можно только для нового билета со статусом 1 (Created)
+	if ticket.Status.Id != 1 {
+		return nil, terr.BadRequest("INVALID_STATUS_TICKET", fmt.Sprintf("ticket (id %s) has wrong status (%s)", ticket.Id, ticket.Status.Name))
+	}
+	// билет, не оплаченный в течение 15 мин, должен быть отменен
+	if paramsCreatePriceHold.Timestamp.Sub(ticket.Status.Timestamp) > ticketPaymentWindow {
+		return nil, terr.BadRequest("TICKET_ALREADY_CANCELED", "time to pay is over")
+	}
+	// билет компании оплачивается по счету компании, удержание цены для него не предусмотрено
+	if ticket.Company != nil {
+		return nil, terr.BadRequest("PRICE_HOLD_NOT_AVAILABLE", fmt.Sprintf("price hold isn't available for ticket (id %s) of a company", ticket.Id))
+	}
+	if ticket.PriceHold != nil {
+		return nil, terr.Conflict("PRICE_HOLD_ALREADY_EXISTS", fmt.Sprintf("price of ticket (id %s) is already held", ticket.Id))
+	}
+
+	// проверки рейса:
+	// рейс не отменен, тариф класса мест билета допускает удержание цены
+	flight, err := s.flightsStorage.GetFlightById(ctx, ticket.Flight.Id)
+	if err != nil {
+		return nil, err
+	}
+	if flight.Status.State == flightsDomain.FlightStateCancelled {
+		return nil, terr.Conflict("FLIGHT_CANCELLED", fmt.Sprintf("flight (id %s) is cancelled", flight.Id))
+	}
+
+	var flightPrice flightsDomain.FlightPrice
+	for _, price := range flight.PricesTickets {
+		if price.ClassSeats.Id == ticket.ClassSeats.Id {
+			flightPrice = price
+			break
+		}
+	}
+
+	duration := getPriceHoldDuration(paramsCreatePriceHold.Timestamp, flight.DepartureDate, flightPrice.PriceHoldMaxHours)
+	if duration == 0 {
+		return nil, terr.BadRequest("PRICE_HOLD_NOT_AVAILABLE", fmt.Sprintf("price hold isn't available for ticket (id %s)", ticket.Id))
+	}
+
+	// Все проверки пройдены
+
+	// Здесь по логике бизнес-процесса выполняется обращение к платежной системе
+	// и производится оплата картой платы за удержание по тарифу класса мест. Плата за удержание не возвращается
+	paramsCreatePriceHold.Fee = flightPrice.PriceHoldFee
+	paramsCreatePriceHold.ExpiryTimestamp = paramsCreatePriceHold.Timestamp.Add(duration)
+
+	return s.ticketsStorage.CreatePriceHold(ctx, paramsCreatePriceHold)
+}
+
+// processPriceHolds обрабатывает действующие удержания цены: по истекшим удержаниям билет отменяется с освобождением места,
+// по удержаниям, истекающим в течение priceHoldReminderLead, пользователю отправляется напоминание об оплате.
+// ошибка обработки одного удержания выводится в лог и не останавливает обработку остальных, возвращается первая ошибка
+func (s service) processPriceHolds(ctx context.Context, timestamp time.Time) error {
+
+	priceHolds, err := s.ticketsStorage.GetActivePriceHolds(ctx, timestamp.Add(priceHoldReminderLead))
+	if err != nil {
+		logProcessWaitlistError(err, "get active price holds")
+		return err
+	}
+
+	var errProcess error
+	for _, priceHold := range priceHolds {
+		// уведомление хранит ссылку на id билета, поэтому id копируется для каждого удержания
+		ticketId := priceHold.TicketId
+		paramsProcessPriceHold := &ticketsDomain.ParamsProcessPriceHold{
+			Timestamp:   timestamp,
+			PriceHoldId: priceHold.Id,
+			TicketId:    priceHold.TicketId,
+		}
+		switch {
+		case !timestamp.Before(priceHold.ExpiryTimestamp):
+			paramsProcessPriceHold.Notification = usersDomain.Notification{
+				UserId:   priceHold.UserId,
+				Type:     usersDomain.NotificationTypePriceHoldExpired,
+				Message:  fmt.Sprintf("The price hold of the ticket (id %s) has expired. The ticket is canceled.", priceHold.TicketId),
+				TicketId: &ticketId,
+			}
+			_, err = s.ticketsStorage.ExpirePriceHold(ctx, paramsProcessPriceHold)
+		case priceHold.ReminderTimestamp == nil:
+			paramsProcessPriceHold.Notification = usersDomain.Notification{
+				UserId:   priceHold.UserId,
+				Type:     usersDomain.NotificationTypePriceHoldReminder,
+				Message:  fmt.Sprintf("The price hold of the ticket (id %s) expires at %s. Please pay for the ticket.", priceHold.TicketId, priceHold.ExpiryTimestamp.Format(time.RFC3339)),
+				TicketId: &ticketId,
+			}
+			_, err = s.ticketsStorage.RemindPriceHold(ctx, paramsProcessPriceHold)
+		default:
+			continue
+		}
+		if err = ignorePriceHoldProcessed(err); err != nil {
+			logProcessWaitlistError(err, "process price hold (id %s)", priceHold.Id)
+			if errProcess == nil {
+				errProcess = err
+			}
+		}
+	}
+	return errProcess
+}
+
+// удержание могло быть обработано параллельно (например, билет оплачен), это не ошибка
+func ignorePriceHoldProcessed(err error) error {
+	if terr.Equal(err, terr.Conflict("PRICE_HOLD_ALREADY_PROCESSED", "")) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,409 @@
+package tickets
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	usersDomain "homework/internal/domain/users"
+	mockTicketsService "homework/internal/service/tickets/mock"
+	"homework/internal/util/terr"
+)
+
+func Test_GetPriceHoldDuration(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name          string
+		departureDate time.Time
+		maxHours      int
+		want          time.Duration
+	}{
+		{
+			name:          "30 days to departure",
+			departureDate: timestamp.AddDate(0, 0, 30),
+			maxHours:      72,
+			want:          72 * time.Hour,
+		},
+		{
+			name:          "14 days to departure",
+			departureDate: timestamp.AddDate(0, 0, 14),
+			maxHours:      72,
+			want:          48 * time.Hour,
+		},
+		{
+			name:          "3 days to departure",
+			departureDate: timestamp.AddDate(0, 0, 3),
+			maxHours:      72,
+			want:          24 * time.Hour,
+		},
+		{
+			name:          "limited by fare",
+			departureDate: timestamp.AddDate(0, 0, 30),
+			maxHours:      24,
+			want:          24 * time.Hour,
+		},
+		{
+			name:          "not available by fare",
+			departureDate: timestamp.AddDate(0, 0, 30),
+			maxHours:      0,
+			want:          0,
+		},
+		{
+			name:          "hold ends after ticket sales close",
+			departureDate: timestamp.Add(25 * time.Hour),
+			maxHours:      72,
+			want:          0,
+		},
+		{
+			name:          "hold ends at ticket sales close",
+			departureDate: timestamp.Add(26 * time.Hour),
+			maxHours:      72,
+			want:          24 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := getPriceHoldDuration(timestamp, tt.departureDate, tt.maxHours)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_CreatePriceHold(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	ticketId := uuid.MustParse("0e7c1d5a-3b2f-4f7e-8a9c-6d1e2f3a4b5c")
+	userId := uuid.MustParse("07d87607-1f06-4599-8af5-07229525c106")
+	otherUserId := uuid.MustParse("c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c")
+	flightId := uuid.MustParse("7d5925a6-2016-4c72-9298-517fc40d936c")
+	classSeatsId := uuid.MustParse("2c4b1c4e-0d7a-4a7c-9a57-5f5c2f1a9b10")
+	priceHoldId := uuid.MustParse("9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d")
+	fee := money.New(1500, money.CurrencyUSD)
+
+	newTicket := func(update func(ticket *ticketsDomain.Ticket)) *ticketsDomain.Ticket {
+		ticket := &ticketsDomain.Ticket{
+			Id:         ticketId,
+			Status:     ticketsDomain.Status{Id: 1, Name: "Created", Timestamp: timestamp.Add(-5 * time.Minute)},
+			Flight:     flightsDomain.Flight{Id: flightId},
+			User:       usersDomain.User{Id: userId},
+			ClassSeats: flightsDomain.ClassSeats{Id: classSeatsId},
+		}
+		if update != nil {
+			update(ticket)
+		}
+		return ticket
+	}
+	newFlight := func(update func(flight *flightsDomain.Flight)) *flightsDomain.Flight {
+		flight := &flightsDomain.Flight{
+			Id:            flightId,
+			DepartureDate: timestamp.AddDate(0, 0, 40),
+			PricesTickets: []flightsDomain.FlightPrice{
+				{ClassSeats: flightsDomain.ClassSeats{Id: classSeatsId}, PriceHoldMaxHours: 72, PriceHoldFee: fee},
+			},
+		}
+		if update != nil {
+			update(flight)
+		}
+		return flight
+	}
+
+	var tests = []struct {
+		name       string
+		ticket     *ticketsDomain.Ticket
+		flight     *flightsDomain.Flight
+		storageErr error
+		wantExpiry time.Time
+		err        error
+	}{
+		{
+			name:       "success",
+			ticket:     newTicket(nil),
+			flight:     newFlight(nil),
+			wantExpiry: timestamp.Add(72 * time.Hour),
+			err:        nil,
+		},
+		{
+			name:   "success/hold limited by the class fare",
+			ticket: newTicket(nil),
+			flight: newFlight(func(flight *flightsDomain.Flight) {
+				flight.PricesTickets[0].PriceHoldMaxHours = 24
+			}),
+			wantExpiry: timestamp.Add(24 * time.Hour),
+			err:        nil,
+		},
+		{
+			name:   "fail/another user",
+			ticket: newTicket(func(ticket *ticketsDomain.Ticket) { ticket.User.Id = otherUserId }),
+			err:    terr.BadRequest("INVALID_USER", "the user (id 07d87607-1f06-4599-8af5-07229525c106) doesn't match the user of the ticket (id c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c)"),
+		},
+		{
+			name:   "fail/ticket is paid",
+			ticket: newTicket(func(ticket *ticketsDomain.Ticket) { ticket.Status = ticketsDomain.Status{Id: 2, Name: "Paid"} }),
+			err:    terr.BadRequest("INVALID_STATUS_TICKET", "ticket (id 0e7c1d5a-3b2f-4f7e-8a9c-6d1e2f3a4b5c) has wrong status (Paid)"),
+		},
+		{
+			name:   "fail/time to pay is over",
+			ticket: newTicket(func(ticket *ticketsDomain.Ticket) { ticket.Status.Timestamp = timestamp.Add(-20 * time.Minute) }),
+			err:    terr.BadRequest("TICKET_ALREADY_CANCELED", "time to pay is over"),
+		},
+		{
+			name:   "fail/company ticket",
+			ticket: newTicket(func(ticket *ticketsDomain.Ticket) { ticket.Company = &ticketsDomain.TicketCompany{} }),
+			err:    terr.BadRequest("PRICE_HOLD_NOT_AVAILABLE", "price hold isn't available for ticket (id 0e7c1d5a-3b2f-4f7e-8a9c-6d1e2f3a4b5c) of a company"),
+		},
+		{
+			name:   "fail/price is already held",
+			ticket: newTicket(func(ticket *ticketsDomain.Ticket) { ticket.PriceHold = &ticketsDomain.PriceHold{Id: priceHoldId} }),
+			err:    terr.Conflict("PRICE_HOLD_ALREADY_EXISTS", "price of ticket (id 0e7c1d5a-3b2f-4f7e-8a9c-6d1e2f3a4b5c) is already held"),
+		},
+		{
+			name:   "fail/flight is cancelled",
+			ticket: newTicket(nil),
+			flight: newFlight(func(flight *flightsDomain.Flight) { flight.Status.State = flightsDomain.FlightStateCancelled }),
+			err:    terr.Conflict("FLIGHT_CANCELLED", "flight (id 7d5925a6-2016-4c72-9298-517fc40d936c) is cancelled"),
+		},
+		{
+			name:   "fail/class fare doesn't allow hold",
+			ticket: newTicket(nil),
+			flight: newFlight(func(flight *flightsDomain.Flight) { flight.PricesTickets[0].PriceHoldMaxHours = 0 }),
+			err:    terr.BadRequest("PRICE_HOLD_NOT_AVAILABLE", "price hold isn't available for ticket (id 0e7c1d5a-3b2f-4f7e-8a9c-6d1e2f3a4b5c)"),
+		},
+		{
+			name:   "fail/hold would expire too close to departure",
+			ticket: newTicket(nil),
+			flight: newFlight(func(flight *flightsDomain.Flight) { flight.DepartureDate = timestamp.Add(12 * time.Hour) }),
+			err:    terr.BadRequest("PRICE_HOLD_NOT_AVAILABLE", "price hold isn't available for ticket (id 0e7c1d5a-3b2f-4f7e-8a9c-6d1e2f3a4b5c)"),
+		},
+		{
+			name:       "fail/ticket canceled concurrently",
+			ticket:     newTicket(nil),
+			flight:     newFlight(nil),
+			storageErr: terr.Conflict("INVALID_STATUS_TICKET", "ticket (id 0e7c1d5a-3b2f-4f7e-8a9c-6d1e2f3a4b5c) isn't awaiting payment"),
+			err:        terr.Conflict("INVALID_STATUS_TICKET", "ticket (id 0e7c1d5a-3b2f-4f7e-8a9c-6d1e2f3a4b5c) isn't awaiting payment"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			paramsCreatePriceHold := &ticketsDomain.ParamsCreatePriceHold{
+				Timestamp: timestamp,
+				TicketId:  ticketId,
+				UserId:    userId,
+			}
+
+			ticketsStorage := mockTicketsService.NewMockTicketsStorage(ctrl)
+			flightsStorage := mockTicketsService.NewMockFlightsStorage(ctrl)
+			ticketsStorage.EXPECT().GetTicketById(ctx, ticketId).Return(tt.ticket, nil)
+			if tt.flight != nil {
+				flightsStorage.EXPECT().GetFlightById(ctx, flightId).Return(tt.flight, nil)
+			}
+			var gotParams ticketsDomain.ParamsCreatePriceHold
+			if tt.err == nil || tt.storageErr != nil {
+				ticketsStorage.EXPECT().
+					CreatePriceHold(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, paramsCreatePriceHold *ticketsDomain.ParamsCreatePriceHold) (*ticketsDomain.PriceHold, error) {
+						gotParams = *paramsCreatePriceHold
+						if tt.storageErr != nil {
+							return nil, tt.storageErr
+						}
+						return &ticketsDomain.PriceHold{
+							Id:              priceHoldId,
+							TicketId:        paramsCreatePriceHold.TicketId,
+							UserId:          paramsCreatePriceHold.UserId,
+							Fee:             paramsCreatePriceHold.Fee,
+							Status:          ticketsDomain.PriceHoldStatusActive,
+							HoldTimestamp:   paramsCreatePriceHold.Timestamp,
+							ExpiryTimestamp: paramsCreatePriceHold.ExpiryTimestamp,
+						}, nil
+					})
+			}
+			s := service{ticketsStorage: ticketsStorage, flightsStorage: flightsStorage}
+
+			// Act
+			got, err := s.CreatePriceHold(ctx, paramsCreatePriceHold)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, ticketsDomain.ParamsCreatePriceHold{
+				Timestamp:       timestamp,
+				TicketId:        ticketId,
+				UserId:          userId,
+				Fee:             fee,
+				ExpiryTimestamp: tt.wantExpiry,
+			}, gotParams)
+			assert.Equal(t, &ticketsDomain.PriceHold{
+				Id:              priceHoldId,
+				TicketId:        ticketId,
+				UserId:          userId,
+				Fee:             fee,
+				Status:          ticketsDomain.PriceHoldStatusActive,
+				HoldTimestamp:   timestamp,
+				ExpiryTimestamp: tt.wantExpiry,
+			}, got)
+		})
+	}
+}
+
+func Test_ProcessPriceHolds(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	userId := uuid.MustParse("07d87607-1f06-4599-8af5-07229525c106")
+	reminderTimestamp := timestamp.Add(-time.Hour)
+	expiredPriceHold := ticketsDomain.PriceHold{
+		Id:              uuid.MustParse("9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"),
+		TicketId:        uuid.MustParse("0e7c1d5a-3b2f-4f7e-8a9c-6d1e2f3a4b5c"),
+		UserId:          userId,
+		ExpiryTimestamp: timestamp,
+	}
+	expiringPriceHold := ticketsDomain.PriceHold{
+		Id:              uuid.MustParse("5e0b0a6f-8f0a-4b67-9c55-0a4f1d3e2b11"),
+		TicketId:        uuid.MustParse("6382589b-ab8e-4519-8c00-d0fe095179b3"),
+		UserId:          userId,
+		ExpiryTimestamp: timestamp.Add(2 * time.Hour),
+	}
+	remindedPriceHold := ticketsDomain.PriceHold{
+		Id:                uuid.MustParse("2c4b1c4e-0d7a-4a7c-9a57-5f5c2f1a9b10"),
+		TicketId:          uuid.MustParse("1f0e4c3a-93f6-4a8e-b0a5-2b8f1c6d7e90"),
+		UserId:            userId,
+		ExpiryTimestamp:   timestamp.Add(3 * time.Hour),
+		ReminderTimestamp: &reminderTimestamp,
+	}
+	errSQLDatabase := terr.SQLDatabaseError(errors.New(""))
+
+	var tests = []struct {
+		name      string
+		errExpire error
+		errRemind error
+		err       error
+	}{
+		{
+			name: "success",
+			err:  nil,
+		},
+		{
+			name:      "success/expired hold already processed",
+			errExpire: terr.Conflict("PRICE_HOLD_ALREADY_PROCESSED", "price hold is already processed"),
+			err:       nil,
+		},
+		{
+			name:      "fail/expire error doesn't stop the reminder",
+			errExpire: errSQLDatabase,
+			err:       errSQLDatabase,
+		},
+		{
+			name:      "fail/remind error",
+			errRemind: errSQLDatabase,
+			err:       errSQLDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			ticketsStorage := mockTicketsService.NewMockTicketsStorage(ctrl)
+			ticketsStorage.EXPECT().GetActivePriceHolds(ctx, timestamp.Add(priceHoldReminderLead)).
+				Return([]ticketsDomain.PriceHold{expiredPriceHold, expiringPriceHold, remindedPriceHold}, nil)
+			// по истекшему удержанию билет отменяется, по истекающему - отправляется напоминание, повторное напоминание не отправляется
+			var gotExpire, gotRemind ticketsDomain.ParamsProcessPriceHold
+			ticketsStorage.EXPECT().
+				ExpirePriceHold(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, paramsProcessPriceHold *ticketsDomain.ParamsProcessPriceHold) (uuid.UUID, error) {
+					gotExpire = *paramsProcessPriceHold
+					return paramsProcessPriceHold.TicketId, tt.errExpire
+				})
+			ticketsStorage.EXPECT().
+				RemindPriceHold(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, paramsProcessPriceHold *ticketsDomain.ParamsProcessPriceHold) (uuid.UUID, error) {
+					gotRemind = *paramsProcessPriceHold
+					return paramsProcessPriceHold.TicketId, tt.errRemind
+				})
+			s := service{ticketsStorage: ticketsStorage}
+
+			// Act
+			err := s.processPriceHolds(ctx, timestamp)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, ticketsDomain.ParamsProcessPriceHold{
+				Timestamp:   timestamp,
+				PriceHoldId: expiredPriceHold.Id,
+				TicketId:    expiredPriceHold.TicketId,
+				Notification: usersDomain.Notification{
+					UserId:   userId,
+					Type:     usersDomain.NotificationTypePriceHoldExpired,
+					Message:  "The price hold of the ticket (id 0e7c1d5a-3b2f-4f7e-8a9c-6d1e2f3a4b5c) has expired. The ticket is canceled.",
+					TicketId: &expiredPriceHold.TicketId,
+				},
+			}, gotExpire)
+			assert.Equal(t, expiringPriceHold.Id, gotRemind.PriceHoldId)
+			assert.Equal(t, usersDomain.NotificationTypePriceHoldReminder, gotRemind.Notification.Type)
+		})
+	}
+}
+
+func Test_IgnorePriceHoldProcessed(t *testing.T) {
+
+	// Arrange
+	errSQLDatabase := terr.SQLDatabaseError(errors.New(""))
+
+	var tests = []struct {
+		name string
+		args error
+		err  error
+	}{
+		{
+			name: "no error",
+			args: nil,
+			err:  nil,
+		},
+		{
+			name: "price hold already processed",
+			args: terr.Conflict("PRICE_HOLD_ALREADY_PROCESSED", "price hold is already processed"),
+			err:  nil,
+		},
+		{
+			name: "sql database error",
+			args: errSQLDatabase,
+			err:  errSQLDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := ignorePriceHoldProcessed(tt.args)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+const ticketItemNamePromoDiscount = "Promo code"
+
+// validatePromoCode проверяет период действия промокода и условия применения к рейсу и классу мест билета
+func validatePromoCode(promoCode *ticketsDomain.PromoCode, flight *flightsDomain.Flight, classSeatsName string, timestamp time.Time) error {
+
+	if promoCode.ValidFrom != nil && timestamp.Before(*promoCode.ValidFrom) {
+		return terr.BadRequest("PROMO_CODE_NOT_ACTIVE", fmt.Sprintf("promo code %s is valid from %s", promoCode.Code, promoCode.ValidFrom.Format(time.RFC3339)))
+	}
+	if promoCode.ValidTo != nil && timestamp.After(*promoCode.ValidTo) {
+		return terr.BadRequest("PROMO_CODE_EXPIRED", fmt.Sprintf("promo code %s has expired", promoCode.Code))
+	}
+	if promoCode.DepartureAirportId != nil && *promoCode.DepartureAirportId != flight.DepartureAirport.Id {
+		return terr.BadRequest("PROMO_CODE_NOT_APPLICABLE", fmt.Sprintf("promo code %s isn't applicable to the departure airport of the flight", promoCode.Code))
+	}
+	if promoCode.ArrivalAirportId != nil && *promoCode.ArrivalAirportId != flight.ArrivalAirport.Id {
+		return terr.BadRequest("PROMO_CODE_NOT_APPLICABLE", fmt.Sprintf("promo code %s isn't applicable to the arrival airport of the flight", promoCode.Code))
+	}
+	if promoCode.ClassSeatsName != nil && *promoCode.ClassSeatsName != classSeatsName {
+		return terr.BadRequest("PROMO_CODE_NOT_APPLICABLE", fmt.Sprintf("promo code %s isn't applicable to the class seats %s", promoCode.Code, classSeatsName))
+	}
+	return nil
+}
+
+// checkPromoCodeUsage проверяет ограничения количества использований промокода всего и пользователем
+func checkPromoCodeUsage(promoCode *ticketsDomain.PromoCode, usage *ticketsDomain.PromoCodeUsage) error {
+
+	if promoCode.MaxUses != nil && usage.CountUses >= *promoCode.MaxUses {
+		return terr.Conflict("PROMO_CODE_LIMIT_EXCEEDED", fmt.Sprintf("promo code %s has reached the limit of uses", promoCode.Code))
+	}
+	if promoCode.MaxUsesPerUser != nil && usage.CountUserUses >= *promoCode.MaxUsesPerUser {
+		return terr.Conflict("PROMO_CODE_LIMIT_EXCEEDED", fmt.Sprintf("promo code %s has reached the limit of uses per user", promoCode.Code))
+	}
+	return nil
+}
+
+// getPromoDiscount рассчитывает скидку по промокоду от тарифа билета.
+// discountAmount - фиксированная скидка промокода в валюте тарифа. скидка не превышает тариф
+func getPromoDiscount(promoCode *ticketsDomain.PromoCode, fare money.Money, discountAmount money.Money) money.Money {
+
+	discount := money.New(0, fare.Currency)
+	switch promoCode.DiscountType {
+	case ticketsDomain.PromoDiscountTypePercent:
+		discount = fare.Percent(promoCode.DiscountPercent)
+	case ticketsDomain.PromoDiscountTypeFixed:
+		discount = discountAmount
+	}
+	if discount.Amount > fare.Amount {
+		discount = fare
+	}
+	return discount
+}
+
+// getTicketItemsFare - сумма позиций тарифа билета
+func getTicketItemsFare(ticketItems []ticketsDomain.TicketItem, currency string) money.Money {
+
+	fare := money.New(0, currency)
+	for _, ticketItem := range ticketItems {
+		if ticketItem.Type == ticketsDomain.TicketItemTypeFare {
+			fare = fare.Add(ticketItem.Price)
+		}
+	}
+	return fare
+}
+
+// convertPromoCodeAmount пересчитывает сумму промокода в валюту цен рейса по курсу на момент оформления билета
+func (s service) convertPromoCodeAmount(ctx context.Context, amount money.Money, currency string, timestamp time.Time) (money.Money, error) {
+
+	if amount.Currency == currency {
+		return amount, nil
+	}
+	exchangeRate, err := s.exchangeRatesProvider.GetExchangeRate(ctx, amount.Currency, currency, timestamp)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return amount.Convert(*exchangeRate), nil
+}
+
+// applyPromoCode применяет промокод при оформлении билета: проверяет условия промокода
+// и добавляет в состав стоимости билета позицию скидки с отрицательной суммой.
+// использование промокода фиксируется только при оплате билета
+func (s service) applyPromoCode(ctx context.Context, paramsCreateTicket *ticketsDomain.ParamsCreateTicket, flight *flightsDomain.Flight) error {
+
+	if paramsCreateTicket.PromoCode == "" {
+		return nil
+	}
+
+	promoCode, err := s.ticketsStorage.GetPromoCodeByCode(ctx, paramsCreateTicket.PromoCode)
+	if err != nil {
+		return err
+	}
+
+	classSeatsName := ""
+	for _, flightPrice := range flight.PricesTickets {
+		if flightPrice.ClassSeats.Id == paramsCreateTicket.ClassSeatsId {
+			classSeatsName = flightPrice.ClassSeats.Name
+			break
+		}
+	}
+	err = validatePromoCode(promoCode, flight, classSeatsName, paramsCreateTicket.StatusTimestamp)
+	if err != nil {
+		return err
+	}
+
+	// минимальная стоимость билета задана в валюте промокода
+	minSpend, err := s.convertPromoCodeAmount(ctx, promoCode.MinSpend, flight.Currency, paramsCreateTicket.StatusTimestamp)
+	if err != nil {
+		return err
+	}
+	if paramsCreateTicket.Price.Amount < minSpend.Amount {
+		return terr.BadRequest("PROMO_CODE_MIN_SPEND", fmt.Sprintf("promo code %s requires the minimum ticket price %s", promoCode.Code, promoCode.MinSpend))
+	}
+
+	// предварительная проверка ограничений количества использований. окончательно они проверяются при оплате билета
+	usage, err := s.ticketsStorage.GetPromoCodeUsage(ctx, promoCode.Id, paramsCreateTicket.UserId)
+	if err != nil {
+		return err
+	}
+	err = checkPromoCodeUsage(promoCode, usage)
+	if err != nil {
+		return err
+	}
+
+	discountAmount, err := s.convertPromoCodeAmount(ctx, promoCode.DiscountAmount, flight.Currency, paramsCreateTicket.StatusTimestamp)
+	if err != nil {
+		return err
+	}
+	discount := getPromoDiscount(promoCode, getTicketItemsFare(paramsCreateTicket.Items, flight.Currency), discountAmount)
+	if discount.IsZero() {
+		return nil
+	}
+
+	paramsCreateTicket.Items = append(paramsCreateTicket.Items, ticketsDomain.TicketItem{
+		Type:      ticketsDomain.TicketItemTypePromoDiscount,
+		Name:      fmt.Sprintf("%s %s", ticketItemNamePromoDiscount, promoCode.Code),
+		Quantity:  1,
+		Price:     money.New(-discount.Amount, discount.Currency),
+		Timestamp: paramsCreateTicket.StatusTimestamp,
+	})
+	paramsCreateTicket.Price = getTicketItemsPrice(paramsCreateTicket.Items)
+	paramsCreateTicket.PromoCodeId = &promoCode.Id
+	return nil
+}
+
+// setPromoCodePayForTicket передает в оплату промокод билета и сумму скидки по нему для фиксации использования промокода
+func (s service) setPromoCodePayForTicket(ctx context.Context, paramsPayForTicket *ticketsDomain.ParamsPayForTicket, ticket *ticketsDomain.Ticket) error {
+
+	if ticket.PromoCodeId == nil {
+		return nil
+	}
+
+	promoCode, err := s.ticketsStorage.GetPromoCodeById(ctx, *ticket.PromoCodeId)
+	if err != nil {
+		return err
+	}
+
+	// промокод мог истечь между оформлением и оплатой билета
+	if promoCode.ValidTo != nil && paramsPayForTicket.StatusTimestamp.After(*promoCode.ValidTo) {
+		return terr.BadRequest("PROMO_CODE_EXPIRED", fmt.Sprintf("promo code %s has expired", promoCode.Code))
+	}
+
+	discount := money.New(0, ticket.Price.Currency)
+	for _, ticketItem := range ticket.Items {
+		if ticketItem.Type == ticketsDomain.TicketItemTypePromoDiscount {
+			discount = discount.Sub(ticketItem.Price)
+		}
+	}
+	paramsPayForTicket.PromoCode = promoCode
+	paramsPayForTicket.PromoDiscount = discount
+	return nil
+}
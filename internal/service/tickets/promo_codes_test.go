@@ -0,0 +1,159 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+func Test_GetPromoDiscount(t *testing.T) {
+
+	// Arrange
+	fare := money.New(600000, money.CurrencyRUB)
+
+	var tests = []struct {
+		name           string
+		promoCode      *ticketsDomain.PromoCode
+		discountAmount money.Money
+		want           money.Money
+	}{
+		{
+			name:      "percent of the fare",
+			promoCode: &ticketsDomain.PromoCode{DiscountType: ticketsDomain.PromoDiscountTypePercent, DiscountPercent: 10},
+			want:      money.New(60000, money.CurrencyRUB),
+		},
+		{
+			name:           "fixed amount",
+			promoCode:      &ticketsDomain.PromoCode{DiscountType: ticketsDomain.PromoDiscountTypeFixed},
+			discountAmount: money.New(150000, money.CurrencyRUB),
+			want:           money.New(150000, money.CurrencyRUB),
+		},
+		{
+			name:           "fixed amount isn't more than the fare",
+			promoCode:      &ticketsDomain.PromoCode{DiscountType: ticketsDomain.PromoDiscountTypeFixed},
+			discountAmount: money.New(1000000, money.CurrencyRUB),
+			want:           fare,
+		},
+		{
+			name:      "unknown discount type",
+			promoCode: &ticketsDomain.PromoCode{DiscountType: "bogus", DiscountPercent: 10},
+			want:      money.New(0, money.CurrencyRUB),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := getPromoDiscount(tt.promoCode, fare, tt.discountAmount)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ValidatePromoCode(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	validFrom := time.Date(2023, 6, 10, 0, 0, 0, 0, time.UTC)
+	validTo := time.Date(2023, 5, 31, 0, 0, 0, 0, time.UTC)
+	departureAirportId := uuid.MustParse("4b1c2d3e-5f60-4718-8a9b-0c1d2e3f4a5b")
+	otherAirportId := uuid.MustParse("7e8f9a0b-1c2d-4e3f-9a5b-6c7d8e9f0a1b")
+	business := "Business"
+	flight := &flightsDomain.Flight{DepartureAirport: flightsDomain.Airport{Id: departureAirportId}}
+
+	var tests = []struct {
+		name      string
+		promoCode *ticketsDomain.PromoCode
+		err       error
+	}{
+		{
+			name:      "success/without conditions",
+			promoCode: &ticketsDomain.PromoCode{Code: "SUMMER10"},
+			err:       nil,
+		},
+		{
+			name:      "success/departure airport and class match",
+			promoCode: &ticketsDomain.PromoCode{Code: "SUMMER10", DepartureAirportId: &departureAirportId, ClassSeatsName: &business},
+			err:       nil,
+		},
+		{
+			name:      "fail/not active yet",
+			promoCode: &ticketsDomain.PromoCode{Code: "SUMMER10", ValidFrom: &validFrom},
+			err:       terr.BadRequest("PROMO_CODE_NOT_ACTIVE", "promo code SUMMER10 is valid from 2023-06-10T00:00:00Z"),
+		},
+		{
+			name:      "fail/expired",
+			promoCode: &ticketsDomain.PromoCode{Code: "SUMMER10", ValidTo: &validTo},
+			err:       terr.BadRequest("PROMO_CODE_EXPIRED", "promo code SUMMER10 has expired"),
+		},
+		{
+			name:      "fail/other departure airport",
+			promoCode: &ticketsDomain.PromoCode{Code: "SUMMER10", DepartureAirportId: &otherAirportId},
+			err:       terr.BadRequest("PROMO_CODE_NOT_APPLICABLE", "promo code SUMMER10 isn't applicable to the departure airport of the flight"),
+		},
+		{
+			name:      "fail/other arrival airport",
+			promoCode: &ticketsDomain.PromoCode{Code: "SUMMER10", ArrivalAirportId: &otherAirportId},
+			err:       terr.BadRequest("PROMO_CODE_NOT_APPLICABLE", "promo code SUMMER10 isn't applicable to the arrival airport of the flight"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := validatePromoCode(tt.promoCode, flight, business, timestamp)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func Test_CheckPromoCodeUsage(t *testing.T) {
+
+	// Arrange
+	maxUses := 100
+	maxUsesPerUser := 1
+	promoCode := &ticketsDomain.PromoCode{Code: "SUMMER10", MaxUses: &maxUses, MaxUsesPerUser: &maxUsesPerUser}
+
+	var tests = []struct {
+		name  string
+		usage *ticketsDomain.PromoCodeUsage
+		err   error
+	}{
+		{
+			name:  "success",
+			usage: &ticketsDomain.PromoCodeUsage{CountUses: 99, CountUserUses: 0},
+			err:   nil,
+		},
+		{
+			name:  "fail/limit of uses",
+			usage: &ticketsDomain.PromoCodeUsage{CountUses: 100, CountUserUses: 0},
+			err:   terr.Conflict("PROMO_CODE_LIMIT_EXCEEDED", "promo code SUMMER10 has reached the limit of uses"),
+		},
+		{
+			name:  "fail/limit of uses per user",
+			usage: &ticketsDomain.PromoCodeUsage{CountUses: 10, CountUserUses: 1},
+			err:   terr.Conflict("PROMO_CODE_LIMIT_EXCEEDED", "promo code SUMMER10 has reached the limit of uses per user"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := checkPromoCodeUsage(promoCode, tt.usage)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
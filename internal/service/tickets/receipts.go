@@ -0,0 +1,111 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// setTicketDocumentPayments заполняет суммы оплаты билета (для кредит-ноты - суммы возврата) в валюте учета
+// по курсу, зафиксированному в билете. бонусы - по сумме бонусов билета, сертификаты, кредиты и счет компании -
+// по оплатам билета, картой оплачен остаток стоимости билета (в том числе дополнительные услуги)
+func setTicketDocumentPayments(document *ticketsDomain.TicketDocument, ticket *ticketsDomain.Ticket) {
+
+	price := ticket.Price.Convert(ticket.ExchangeRate)
+
+	payments := make([]ticketsDomain.TicketPayment, 0, len(ticket.Payments)+1)
+	paidWithoutCard := money.New(0, price.Currency)
+	if ticket.PaidWithBonuses.Amount > 0 {
+		payments = append(payments, ticketsDomain.TicketPayment{
+			Method: ticketsDomain.PaymentMethodBonuses,
+			Amount: ticket.PaidWithBonuses,
+		})
+		paidWithoutCard = paidWithoutCard.Add(ticket.PaidWithBonuses)
+	}
+	for _, payment := range ticket.Payments {
+		if payment.Method == ticketsDomain.PaymentMethodBonuses || payment.Method == ticketsDomain.PaymentMethodCard {
+			continue
+		}
+		payments = append(payments, payment)
+		paidWithoutCard = paidWithoutCard.Add(payment.Amount)
+	}
+	if paidWithCard := price.Sub(paidWithoutCard); paidWithCard.Amount > 0 {
+		payments = append(payments, ticketsDomain.TicketPayment{
+			Method: ticketsDomain.PaymentMethodCard,
+			Amount: paidWithCard,
+		})
+	}
+
+	document.Payments = payments
+	document.PaidWithBonuses = ticket.PaidWithBonuses
+	document.PaidWithMoney = price.Sub(ticket.PaidWithBonuses)
+}
+
+// getTicketDocumentTicket возвращает билет на момент выдачи документа timestamp: состав стоимости и оплаты
+// без дополнительных услуг, купленных позже, стоимость билета - по этому составу.
+// поэтому выданный чек не меняется при покупке услуг после оплаты билета
+func getTicketDocumentTicket(ticket *ticketsDomain.Ticket, timestamp time.Time) ticketsDomain.Ticket {
+
+	documentTicket := *ticket
+
+	documentTicket.Items = make([]ticketsDomain.TicketItem, 0, len(ticket.Items))
+	for _, item := range ticket.Items {
+		if !item.Timestamp.After(timestamp) {
+			documentTicket.Items = append(documentTicket.Items, item)
+		}
+	}
+	if len(documentTicket.Items) > 0 {
+		documentTicket.Price = getTicketItemsPrice(documentTicket.Items)
+	}
+
+	documentTicket.Payments = make([]ticketsDomain.TicketPayment, 0, len(ticket.Payments))
+	for _, payment := range ticket.Payments {
+		if !payment.Timestamp.After(timestamp) {
+			documentTicket.Payments = append(documentTicket.Payments, payment)
+		}
+	}
+
+	return documentTicket
+}
+
+func (s service) GetTicketDocument(ctx context.Context, paramsGetTicketDocument *ticketsDomain.ParamsGetTicketDocument) (*ticketsDomain.TicketDocument, error) {
+
+	// по id получаем билет
+	ticket, err := s.ticketsStorage.GetTicketById(ctx, paramsGetTicketDocument.TicketId)
+	if err != nil {
+		return nil, err
+	}
+
+	// чек выдается при оплате билета, кредит-нота - при возврате билета
+	documents, err := s.ticketsStorage.GetTicketDocuments(ctx, ticket.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	var document *ticketsDomain.TicketDocument
+	var receiptNumber int64
+	for i := range documents {
+		if documents[i].Type == paramsGetTicketDocument.Type {
+			document = &documents[i]
+		}
+		if documents[i].Type == ticketsDomain.TicketDocumentTypeReceipt {
+			receiptNumber = documents[i].Number
+		}
+	}
+	if document == nil {
+		return nil, terr.BadRequest("INVALID_STATUS_TICKET", fmt.Sprintf("ticket (id %s) has wrong status (%s)", ticket.Id, ticket.Status.Name))
+	}
+
+	if document.Type == ticketsDomain.TicketDocumentTypeCreditNote {
+		document.ReceiptNumber = receiptNumber
+	}
+	document.Seller = s.seller
+	document.Ticket = getTicketDocumentTicket(ticket, document.Timestamp)
+	setTicketDocumentPayments(document, &document.Ticket)
+
+	return document, nil
+}
@@ -0,0 +1,169 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+)
+
+func Test_SetTicketDocumentPayments(t *testing.T) {
+
+	// Arrange
+	voucherId := uuid.MustParse("9a0e3c44-6b1d-4d0c-8f5e-2f9c1b7d3a21")
+	rate := money.ExchangeRate{From: money.CurrencyUSD, To: money.BaseCurrency, Rate: 90}
+	giftCertificate := ticketsDomain.TicketPayment{
+		Method:      ticketsDomain.PaymentMethodGiftCertificate,
+		VoucherId:   &voucherId,
+		VoucherCode: "GC-7F3A9B2C1D0E",
+		Amount:      money.New(500000, money.BaseCurrency),
+	}
+
+	var tests = []struct {
+		name   string
+		ticket *ticketsDomain.Ticket
+		want   *ticketsDomain.TicketDocument
+	}{
+		{
+			name: "bonuses, gift certificate and card",
+			ticket: &ticketsDomain.Ticket{
+				Price:           money.New(10000, money.CurrencyUSD),
+				ExchangeRate:    rate,
+				PaidWithBonuses: money.New(200000, money.BaseCurrency),
+				Payments: []ticketsDomain.TicketPayment{
+					{Method: ticketsDomain.PaymentMethodBonuses, Amount: money.New(200000, money.BaseCurrency)},
+					giftCertificate,
+					{Method: ticketsDomain.PaymentMethodCard, Amount: money.New(200000, money.BaseCurrency)},
+				},
+			},
+			want: &ticketsDomain.TicketDocument{
+				PaidWithBonuses: money.New(200000, money.BaseCurrency),
+				PaidWithMoney:   money.New(700000, money.BaseCurrency),
+				Payments: []ticketsDomain.TicketPayment{
+					{Method: ticketsDomain.PaymentMethodBonuses, Amount: money.New(200000, money.BaseCurrency)},
+					giftCertificate,
+					// картой оплачен остаток стоимости, в том числе дополнительные услуги, купленные после оплаты билета
+					{Method: ticketsDomain.PaymentMethodCard, Amount: money.New(200000, money.BaseCurrency)},
+				},
+			},
+		},
+		{
+			name: "ticket paid before payments were recorded",
+			ticket: &ticketsDomain.Ticket{
+				Price:           money.New(10000, money.CurrencyUSD),
+				ExchangeRate:    rate,
+				PaidWithBonuses: money.New(0, money.BaseCurrency),
+			},
+			want: &ticketsDomain.TicketDocument{
+				PaidWithBonuses: money.New(0, money.BaseCurrency),
+				PaidWithMoney:   money.New(900000, money.BaseCurrency),
+				Payments: []ticketsDomain.TicketPayment{
+					{Method: ticketsDomain.PaymentMethodCard, Amount: money.New(900000, money.BaseCurrency)},
+				},
+			},
+		},
+		{
+			name: "company invoice",
+			ticket: &ticketsDomain.Ticket{
+				Price:           money.New(900000, money.BaseCurrency),
+				ExchangeRate:    money.NewIdentityRate(money.BaseCurrency, rate.Timestamp),
+				PaidWithBonuses: money.New(0, money.BaseCurrency),
+				Payments: []ticketsDomain.TicketPayment{
+					{Method: ticketsDomain.PaymentMethodInvoice, Amount: money.New(900000, money.BaseCurrency)},
+				},
+			},
+			want: &ticketsDomain.TicketDocument{
+				PaidWithBonuses: money.New(0, money.BaseCurrency),
+				PaidWithMoney:   money.New(900000, money.BaseCurrency),
+				Payments: []ticketsDomain.TicketPayment{
+					{Method: ticketsDomain.PaymentMethodInvoice, Amount: money.New(900000, money.BaseCurrency)},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document := &ticketsDomain.TicketDocument{}
+
+			// Act
+			setTicketDocumentPayments(document, tt.ticket)
+
+			// Assert
+			assert.Equal(t, tt.want, document)
+		})
+	}
+}
+
+func Test_GetTicketDocumentTicket(t *testing.T) {
+
+	// Arrange
+	paymentTimestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	ancillaryTimestamp := paymentTimestamp.Add(24 * time.Hour)
+	refundTimestamp := paymentTimestamp.Add(48 * time.Hour)
+
+	fare := ticketsDomain.TicketItem{Type: ticketsDomain.TicketItemTypeFare, Name: "Fare", Quantity: 1, Price: money.New(10000, money.CurrencyUSD), Timestamp: paymentTimestamp.Add(-time.Minute)}
+	meal := ticketsDomain.TicketItem{Type: "meal", Name: "Meal", Quantity: 1, Price: money.New(1500, money.CurrencyUSD), Timestamp: ancillaryTimestamp}
+	ticketPayment := ticketsDomain.TicketPayment{Method: ticketsDomain.PaymentMethodCard, Amount: money.New(900000, money.BaseCurrency), Timestamp: paymentTimestamp}
+	mealPayment := ticketsDomain.TicketPayment{Method: ticketsDomain.PaymentMethodCard, Amount: money.New(135000, money.BaseCurrency), Timestamp: ancillaryTimestamp}
+
+	ticket := &ticketsDomain.Ticket{
+		Price:    money.New(11500, money.CurrencyUSD),
+		Items:    []ticketsDomain.TicketItem{fare, meal},
+		Payments: []ticketsDomain.TicketPayment{ticketPayment, mealPayment},
+	}
+
+	var tests = []struct {
+		name      string
+		ticket    *ticketsDomain.Ticket
+		timestamp time.Time
+		want      ticketsDomain.Ticket
+	}{
+		{
+			name:      "receipt without ancillary purchased later",
+			ticket:    ticket,
+			timestamp: paymentTimestamp,
+			want: ticketsDomain.Ticket{
+				Price:    money.New(10000, money.CurrencyUSD),
+				Items:    []ticketsDomain.TicketItem{fare},
+				Payments: []ticketsDomain.TicketPayment{ticketPayment},
+			},
+		},
+		{
+			name:      "credit note with ancillary",
+			ticket:    ticket,
+			timestamp: refundTimestamp,
+			want: ticketsDomain.Ticket{
+				Price:    money.New(11500, money.CurrencyUSD),
+				Items:    []ticketsDomain.TicketItem{fare, meal},
+				Payments: []ticketsDomain.TicketPayment{ticketPayment, mealPayment},
+			},
+		},
+		{
+			name: "ticket without items",
+			ticket: &ticketsDomain.Ticket{
+				Price: money.New(10000, money.CurrencyUSD),
+			},
+			timestamp: paymentTimestamp,
+			want: ticketsDomain.Ticket{
+				Price:    money.New(10000, money.CurrencyUSD),
+				Items:    []ticketsDomain.TicketItem{},
+				Payments: []ticketsDomain.TicketPayment{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := getTicketDocumentTicket(tt.ticket, tt.timestamp)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	flightsDomain "homework/internal/domain/flights"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// положение места в схеме салона класса мест: ряд и место в ряду
+type seatPosition struct {
+	row   int
+	place int
+}
+
+// isExitRowAllowed проверяет, можно ли пассажиру билета сидеть в ряду у аварийного выхода.
+// в ряду у аварийного выхода не могут сидеть дети и взрослые, сопровождающие младенца
+func (s service) isExitRowAllowed(ctx context.Context, ticket *ticketsDomain.Ticket) (bool, error) {
+
+	if ticket.PassengerType == ticketsDomain.PassengerTypeChild {
+		return false, nil
+	}
+
+	accompaniedTickets, err := s.ticketsStorage.GetAccompaniedTickets(ctx, ticket.Id)
+	if err != nil {
+		return false, err
+	}
+	for _, accompaniedTicket := range accompaniedTickets {
+		if accompaniedTicket.PassengerType == ticketsDomain.PassengerTypeInfant {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// assignSeat выбирает свободное место для автоматического назначения при регистрации на рейс.
+// пассажир размещается рядом с уже назначенными местами других билетов пользователя на рейс в том же классе,
+// в том числе с местами, назначенными в текущей регистрации (assignedSeatsIds - места по id билета)
+func (s service) assignSeat(ctx context.Context, ticket *ticketsDomain.Ticket, vacantSeats *flightsDomain.VacantSeats, isExitRowAllowed bool, assignedSeatsIds map[uuid.UUID]uuid.UUID) (*flightsDomain.Seat, error) {
+
+	// схема салона: все места класса
+	seats, err := s.flightsStorage.GetSeatsByClassId(ctx, ticket.ClassSeats.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// билеты пользователя на рейс в том же классе:
+	// назначенные места и количество пассажиров, которым место еще не назначено
+	userTickets, err := s.ticketsStorage.GetUserFlightTickets(ctx, ticket.User.Id, ticket.Flight.Id)
+	if err != nil {
+		return nil, err
+	}
+	var groupSeatsIds []uuid.UUID
+	countGroupWithoutSeats := 1
+	for _, userTicket := range userTickets {
+		if userTicket.Id == ticket.Id || userTicket.ClassSeats.Id != ticket.ClassSeats.Id ||
+			userTicket.PassengerType == ticketsDomain.PassengerTypeInfant {
+			continue
+		}
+		if userTicket.Seat != nil {
+			groupSeatsIds = append(groupSeatsIds, userTicket.Seat.Id)
+		} else if seatId, ok := assignedSeatsIds[userTicket.Id]; ok {
+			groupSeatsIds = append(groupSeatsIds, seatId)
+		} else {
+			countGroupWithoutSeats++
+		}
+	}
+
+	vacantSeatsIds := make(map[uuid.UUID]bool, len(vacantSeats.Seats))
+	for _, seat := range vacantSeats.Seats {
+		vacantSeatsIds[seat.Id] = true
+	}
+
+	seat := selectSeat(seats, ticket.ClassSeats.CountInRow, vacantSeatsIds, groupSeatsIds, countGroupWithoutSeats, isExitRowAllowed)
+	if seat == nil {
+		return nil, terr.Conflict("NO_VACANT_SEAT", fmt.Sprintf("no vacant seats to assign with class seat (id %s)", ticket.ClassSeats.Id))
+	}
+	return seat, nil
+}
+
+// selectSeat выбирает место из свободных мест класса:
+// - если у группы (билетов пользователя) уже есть места, то ближайшее к ним место: рядом, в том же ряду, в соседних рядах;
+// - иначе первое место ряда, в котором хватает свободных мест на всю группу, а если такого ряда нет, то ряда с наибольшим количеством свободных мест.
+// места ряда у аварийного выхода выбираются, только если это разрешено пассажиру
+func selectSeat(seats []flightsDomain.Seat, countInRow int, vacantSeatsIds map[uuid.UUID]bool, groupSeatsIds []uuid.UUID, countGroupWithoutSeats int, isExitRowAllowed bool) *flightsDomain.Seat {
+
+	positions := getSeatsPositions(seats, countInRow)
+
+	// места, которые можно назначить
+	var candidates []flightsDomain.Seat
+	countRowCandidates := make(map[int]int)
+	for _, seat := range seats {
+		if !vacantSeatsIds[seat.Id] || (seat.IsExitRow && !isExitRowAllowed) {
+			continue
+		}
+		candidates = append(candidates, seat)
+		countRowCandidates[positions[seat.Id].row]++
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	// места группы, которые есть в схеме салона
+	var groupPositions []seatPosition
+	for _, seatId := range groupSeatsIds {
+		if position, ok := positions[seatId]; ok {
+			groupPositions = append(groupPositions, position)
+		}
+	}
+
+	if len(groupPositions) > 0 {
+		// расстояние между местами: место в соседнем ряду дальше любого места в том же ряду
+		bestIndex, bestDistance := 0, -1
+		for i, seat := range candidates {
+			position := positions[seat.Id]
+			for _, groupPosition := range groupPositions {
+				distance := abs(position.row-groupPosition.row)*(countInRow+1) + abs(position.place-groupPosition.place)
+				if bestDistance < 0 || distance < bestDistance {
+					bestIndex, bestDistance = i, distance
+				}
+			}
+		}
+		return &candidates[bestIndex]
+	}
+
+	// первый ряд, в котором хватает мест на всю группу, иначе ряд с наибольшим количеством свободных мест
+	bestIndex := 0
+	for i, seat := range candidates {
+		row := positions[seat.Id].row
+		if countRowCandidates[row] >= countGroupWithoutSeats {
+			return &candidates[i]
+		}
+		if countRowCandidates[row] > countRowCandidates[positions[candidates[bestIndex].Id].row] {
+			bestIndex = i
+		}
+	}
+	return &candidates[bestIndex]
+}
+
+// getSeatsPositions определяет положение мест в схеме салона:
+// места упорядочиваются по номеру и разбиваются на ряды по количеству мест в ряду класса (CountInRow)
+func getSeatsPositions(seats []flightsDomain.Seat, countInRow int) map[uuid.UUID]seatPosition {
+
+	sort.SliceStable(seats, func(i, j int) bool {
+		return lessSeatNumber(seats[i].Number, seats[j].Number)
+	})
+
+	if countInRow <= 0 {
+		countInRow = len(seats)
+	}
+
+	positions := make(map[uuid.UUID]seatPosition, len(seats))
+	for i, seat := range seats {
+		positions[seat.Id] = seatPosition{
+			row:   i / countInRow,
+			place: i % countInRow,
+		}
+	}
+	return positions
+}
+
+// lessSeatNumber сравнивает номера мест: сначала по числовой части (1A < 2A < 10A), затем по буквенной (1A < 1B)
+func lessSeatNumber(a string, b string) bool {
+
+	digitsA, lettersA := splitSeatNumber(a)
+	digitsB, lettersB := splitSeatNumber(b)
+	if digitsA != digitsB {
+		return digitsA < digitsB
+	}
+	return lettersA < lettersB
+}
+
+func splitSeatNumber(number string) (int, string) {
+
+	var digits, letters strings.Builder
+	for _, r := range number {
+		if unicode.IsDigit(r) {
+			digits.WriteRune(r)
+		} else {
+			letters.WriteRune(unicode.ToUpper(r))
+		}
+	}
+
+	row, _ := strconv.Atoi(digits.String())
+	return row, letters.String()
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
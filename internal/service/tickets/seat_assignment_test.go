@@ -0,0 +1,169 @@
+package tickets
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+)
+
+func Test_LessSeatNumber(t *testing.T) {
+
+	var tests = []struct {
+		name string
+		a    string
+		b    string
+		want bool
+	}{
+		{
+			name: "success/row number",
+			a:    "2A",
+			b:    "10A",
+			want: true,
+		},
+		{
+			name: "success/letter in row",
+			a:    "1A",
+			b:    "1B",
+			want: true,
+		},
+		{
+			name: "success/letter before number",
+			a:    "A2",
+			b:    "A1",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := lessSeatNumber(tt.a, tt.b)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_SelectSeat(t *testing.T) {
+
+	// Arrange
+	// схема салона: 3 ряда по 3 места, 2-й ряд у аварийного выхода
+	numbers := []string{"1A", "1B", "1C", "2A", "2B", "2C", "3A", "3B", "3C"}
+	seatsIds := make(map[string]uuid.UUID, len(numbers))
+	for _, number := range numbers {
+		seatsIds[number] = uuid.New()
+	}
+	getSeats := func() []flightsDomain.Seat {
+		seats := make([]flightsDomain.Seat, 0, len(numbers))
+		for i := len(numbers) - 1; i >= 0; i-- {
+			seats = append(seats, flightsDomain.Seat{
+				Id:        seatsIds[numbers[i]],
+				Number:    numbers[i],
+				IsExitRow: numbers[i][0] == '2',
+			})
+		}
+		return seats
+	}
+	getVacantSeatsIds := func(numbers ...string) map[uuid.UUID]bool {
+		vacantSeatsIds := make(map[uuid.UUID]bool, len(numbers))
+		for _, number := range numbers {
+			vacantSeatsIds[seatsIds[number]] = true
+		}
+		return vacantSeatsIds
+	}
+
+	type args struct {
+		vacantSeatsIds         map[uuid.UUID]bool
+		groupSeatsNumbers      []string
+		countGroupWithoutSeats int
+		isExitRowAllowed       bool
+	}
+	var tests = []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "success/first vacant seat",
+			args: args{
+				vacantSeatsIds:         getVacantSeatsIds("1B", "1C", "3A"),
+				countGroupWithoutSeats: 1,
+				isExitRowAllowed:       true,
+			},
+			want: "1B",
+		},
+		{
+			name: "success/row with seats for the whole group",
+			args: args{
+				vacantSeatsIds:         getVacantSeatsIds("1C", "3A", "3B", "3C"),
+				countGroupWithoutSeats: 3,
+				isExitRowAllowed:       true,
+			},
+			want: "3A",
+		},
+		{
+			name: "success/next to the group seat",
+			args: args{
+				vacantSeatsIds:         getVacantSeatsIds("1A", "3A", "3C"),
+				groupSeatsNumbers:      []string{"3B"},
+				countGroupWithoutSeats: 1,
+				isExitRowAllowed:       true,
+			},
+			want: "3A",
+		},
+		{
+			name: "success/nearest row to the group seat",
+			args: args{
+				vacantSeatsIds:         getVacantSeatsIds("1A", "2C"),
+				groupSeatsNumbers:      []string{"3C"},
+				countGroupWithoutSeats: 1,
+				isExitRowAllowed:       true,
+			},
+			want: "2C",
+		},
+		{
+			name: "success/exit row isn't allowed",
+			args: args{
+				vacantSeatsIds:         getVacantSeatsIds("1A", "2C"),
+				groupSeatsNumbers:      []string{"3C"},
+				countGroupWithoutSeats: 1,
+				isExitRowAllowed:       false,
+			},
+			want: "1A",
+		},
+		{
+			name: "fail/only exit row seats",
+			args: args{
+				vacantSeatsIds:         getVacantSeatsIds("2A", "2B"),
+				countGroupWithoutSeats: 1,
+				isExitRowAllowed:       false,
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var groupSeatsIds []uuid.UUID
+			for _, number := range tt.args.groupSeatsNumbers {
+				groupSeatsIds = append(groupSeatsIds, seatsIds[number])
+			}
+
+			// Act
+			got := selectSeat(getSeats(), 3, tt.args.vacantSeatsIds, groupSeatsIds, tt.args.countGroupWithoutSeats, tt.args.isExitRowAllowed)
+
+			// Assert
+			if tt.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			assert.NotNil(t, got)
+			assert.Equal(t, tt.want, got.Number)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// проверка удержания места, по которому оформляется билет.
+// удержанное место (или квота класса) уже учтено как занятое, поэтому проверка наличия свободных мест класса не выполняется
+func (s service) checkSeatHold(ctx context.Context, paramsCreateTicket *ticketsDomain.ParamsCreateTicket) error {
+
+	// проверяем, что по переданному SeatHoldId существует удержание
+	seatHold, err := s.flightsStorage.GetSeatHoldById(ctx, *paramsCreateTicket.SeatHoldId)
+	if err != nil {
+		return err
+	}
+
+	// проверки удержания:
+	// удержание пользователя билета на тот же рейс и класс мест, еще не истекло
+	if seatHold.UserId != paramsCreateTicket.UserId {
+		return terr.BadRequest("INVALID_SEAT_HOLD", fmt.Sprintf("the user of the seat hold (id %s) doesn't match the user of the ticket (id %s)", seatHold.Id, paramsCreateTicket.UserId))
+	}
+	if seatHold.FlightId != paramsCreateTicket.FlightId {
+		return terr.BadRequest("INVALID_SEAT_HOLD", fmt.Sprintf("seat hold (id %s) is for another flight (id %s)", seatHold.Id, seatHold.FlightId))
+	}
+	if seatHold.ClassSeatsId != paramsCreateTicket.ClassSeatsId {
+		return terr.BadRequest("INVALID_SEAT_HOLD", fmt.Sprintf("class seat of the seat hold (id %s) doesn't match class seat (id %s)", seatHold.Id, paramsCreateTicket.ClassSeatsId))
+	}
+	if !seatHold.ExpiresAt.After(paramsCreateTicket.StatusTimestamp) {
+		return terr.Conflict("SEAT_HOLD_EXPIRED", fmt.Sprintf("seat hold (id %s) has expired", seatHold.Id))
+	}
+
+	// удержано конкретное место: билет оформляется на это место
+	if seatHold.SeatId != nil {
+		if paramsCreateTicket.SeatId != nil && *paramsCreateTicket.SeatId != *seatHold.SeatId {
+			return terr.BadRequest("INVALID_SEAT_HOLD", fmt.Sprintf("seat (id %s) doesn't match the seat of the seat hold (id %s)", paramsCreateTicket.SeatId, seatHold.Id))
+		}
+		seatId := *seatHold.SeatId
+		paramsCreateTicket.SeatId = &seatId
+		return nil
+	}
+
+	// удержана квота класса: если место было указано, то проверяем, что место есть в списке свободных мест
+	if paramsCreateTicket.SeatId == nil {
+		return nil
+	}
+
+	vacantSeats, err := s.flightsStorage.GetFlightVacantSeatsByClassId(ctx, paramsCreateTicket.FlightId, paramsCreateTicket.ClassSeatsId)
+	if err != nil {
+		return err
+	}
+
+	seatId := *paramsCreateTicket.SeatId
+	for _, seat := range vacantSeats.Seats {
+		if seat.Id == seatId {
+			return nil
+		}
+	}
+
+	// место занято
+	return terr.BadRequest("SEAT_DOESNT_VACANT", fmt.Sprintf("seat (id %s) isn't in the list of vacant seats", seatId))
+}
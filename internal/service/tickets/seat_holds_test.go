@@ -0,0 +1,136 @@
+package tickets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	ticketsDomain "homework/internal/domain/tickets"
+	mockTicketsService "homework/internal/service/tickets/mock"
+	"homework/internal/util/terr"
+)
+
+func Test_CheckSeatHold(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	seatHoldId := uuid.MustParse("5e0b0a6f-8f0a-4b67-9c55-0a4f1d3e2b11")
+	flightId := uuid.MustParse("7d5925a6-2016-4c72-9298-517fc40d936c")
+	userId := uuid.MustParse("07d87607-1f06-4599-8af5-07229525c106")
+	classSeatsId := uuid.MustParse("2c4b1c4e-0d7a-4a7c-9a57-5f5c2f1a9b10")
+	seatId := uuid.MustParse("b1f5bb9c-4a38-4d3e-a2a6-a8c3de43e3a7")
+	otherId := uuid.MustParse("c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c")
+
+	newSeatHold := func(update func(seatHold *flightsDomain.SeatHold)) *flightsDomain.SeatHold {
+		seatHold := &flightsDomain.SeatHold{
+			Id:           seatHoldId,
+			FlightId:     flightId,
+			ClassSeatsId: classSeatsId,
+			SeatId:       &seatId,
+			UserId:       userId,
+			Timestamp:    timestamp.Add(-time.Minute),
+			ExpiresAt:    timestamp.Add(4 * time.Minute),
+		}
+		if update != nil {
+			update(seatHold)
+		}
+		return seatHold
+	}
+
+	var tests = []struct {
+		name        string
+		seatHold    *flightsDomain.SeatHold
+		seatId      *uuid.UUID
+		vacantSeats *flightsDomain.VacantSeats
+		wantSeatId  *uuid.UUID
+		err         error
+	}{
+		{
+			name:       "success/held seat",
+			seatHold:   newSeatHold(nil),
+			wantSeatId: &seatId,
+			err:        nil,
+		},
+		{
+			name:        "success/held class quota with vacant seat",
+			seatHold:    newSeatHold(func(seatHold *flightsDomain.SeatHold) { seatHold.SeatId = nil }),
+			seatId:      &seatId,
+			vacantSeats: &flightsDomain.VacantSeats{ClassSeatsId: classSeatsId, Seats: []flightsDomain.Seat{{Id: seatId}}},
+			wantSeatId:  &seatId,
+			err:         nil,
+		},
+		{
+			name:     "fail/another user",
+			seatHold: newSeatHold(func(seatHold *flightsDomain.SeatHold) { seatHold.UserId = otherId }),
+			err:      terr.BadRequest("INVALID_SEAT_HOLD", "the user of the seat hold (id 5e0b0a6f-8f0a-4b67-9c55-0a4f1d3e2b11) doesn't match the user of the ticket (id 07d87607-1f06-4599-8af5-07229525c106)"),
+		},
+		{
+			name:     "fail/another flight",
+			seatHold: newSeatHold(func(seatHold *flightsDomain.SeatHold) { seatHold.FlightId = otherId }),
+			err:      terr.BadRequest("INVALID_SEAT_HOLD", "seat hold (id 5e0b0a6f-8f0a-4b67-9c55-0a4f1d3e2b11) is for another flight (id c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c)"),
+		},
+		{
+			name:     "fail/another class seats",
+			seatHold: newSeatHold(func(seatHold *flightsDomain.SeatHold) { seatHold.ClassSeatsId = otherId }),
+			err:      terr.BadRequest("INVALID_SEAT_HOLD", "class seat of the seat hold (id 5e0b0a6f-8f0a-4b67-9c55-0a4f1d3e2b11) doesn't match class seat (id 2c4b1c4e-0d7a-4a7c-9a57-5f5c2f1a9b10)"),
+		},
+		{
+			name:     "fail/seat hold expired",
+			seatHold: newSeatHold(func(seatHold *flightsDomain.SeatHold) { seatHold.ExpiresAt = timestamp }),
+			err:      terr.Conflict("SEAT_HOLD_EXPIRED", "seat hold (id 5e0b0a6f-8f0a-4b67-9c55-0a4f1d3e2b11) has expired"),
+		},
+		{
+			name:     "fail/another seat",
+			seatHold: newSeatHold(nil),
+			seatId:   &otherId,
+			err:      terr.BadRequest("INVALID_SEAT_HOLD", "seat (id c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c) doesn't match the seat of the seat hold (id 5e0b0a6f-8f0a-4b67-9c55-0a4f1d3e2b11)"),
+		},
+		{
+			name:        "fail/seat isn't vacant",
+			seatHold:    newSeatHold(func(seatHold *flightsDomain.SeatHold) { seatHold.SeatId = nil }),
+			seatId:      &seatId,
+			vacantSeats: &flightsDomain.VacantSeats{ClassSeatsId: classSeatsId, Seats: []flightsDomain.Seat{{Id: otherId}}},
+			err:         terr.BadRequest("SEAT_DOESNT_VACANT", "seat (id b1f5bb9c-4a38-4d3e-a2a6-a8c3de43e3a7) isn't in the list of vacant seats"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			paramsCreateTicket := &ticketsDomain.ParamsCreateTicket{
+				StatusTimestamp: timestamp,
+				FlightId:        flightId,
+				UserId:          userId,
+				ClassSeatsId:    classSeatsId,
+				SeatId:          tt.seatId,
+				SeatHoldId:      &seatHoldId,
+			}
+
+			flightsStorage := mockTicketsService.NewMockFlightsStorage(ctrl)
+			flightsStorage.EXPECT().GetSeatHoldById(ctx, seatHoldId).Return(tt.seatHold, nil)
+			if tt.vacantSeats != nil {
+				flightsStorage.EXPECT().GetFlightVacantSeatsByClassId(ctx, flightId, classSeatsId).Return(tt.vacantSeats, nil)
+			}
+			s := service{flightsStorage: flightsStorage}
+
+			// Act
+			err := s.checkSeatHold(ctx, paramsCreateTicket)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.wantSeatId, paramsCreateTicket.SeatId)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package tickets
+
+import (
+	"context"
+	"time"
+
+	flightsDomain "homework/internal/domain/flights"
+	ticketsDomain "homework/internal/domain/tickets"
+)
+
+// convertFlightTaxesFees пересчитывает сборы рейса в валюту цен рейса по курсу на момент оформления билета.
+// в билет сборы входят в валюте билета, а в позиции сохраняется id сбора, по которому она сформирована
+func (s service) convertFlightTaxesFees(ctx context.Context, flight *flightsDomain.Flight, timestamp time.Time) error {
+
+	for i, taxFee := range flight.TaxesFees {
+		if taxFee.Price.Currency == flight.Currency {
+			continue
+		}
+		exchangeRate, err := s.exchangeRatesProvider.GetExchangeRate(ctx, taxFee.Price.Currency, flight.Currency, timestamp)
+		if err != nil {
+			return err
+		}
+		flight.TaxesFees[i].Price = taxFee.Price.Convert(*exchangeRate)
+	}
+	return nil
+}
+
+// позиции состава стоимости билета по сборам рейса: каждый сбор - отдельная позиция
+func getTicketItemsTaxesFees(paramsCreateTicket *ticketsDomain.ParamsCreateTicket, flight *flightsDomain.Flight) []ticketsDomain.TicketItem {
+
+	ticketItems := make([]ticketsDomain.TicketItem, 0, len(flight.TaxesFees))
+	for _, taxFee := range flight.TaxesFees {
+		taxFeeId := taxFee.Id
+		ticketItems = append(ticketItems, ticketsDomain.TicketItem{
+			Type:      taxFee.Type,
+			Name:      taxFee.Name,
+			TaxFeeId:  &taxFeeId,
+			Quantity:  1,
+			Price:     taxFee.Price,
+			Timestamp: paramsCreateTicket.StatusTimestamp,
+		})
+	}
+	return ticketItems
+}
@@ -0,0 +1,58 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+)
+
+func Test_GetTicketItemsTaxesFees(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	airportTaxId := uuid.MustParse("9a0e3c44-6b1d-4d0c-8f5e-2f9c1b7d3a21")
+	fuelSurchargeId := uuid.MustParse("3f7b2d10-8c4e-4a9b-b1d2-6e5f4c3b2a19")
+	airportTax := flightsDomain.FlightTaxFee{Id: airportTaxId, Type: flightsDomain.TaxFeeTypeAirportTax, Name: "Airport tax", Price: money.New(45000, money.CurrencyRUB)}
+	fuelSurcharge := flightsDomain.FlightTaxFee{Id: fuelSurchargeId, Type: flightsDomain.TaxFeeTypeFuelSurcharge, Name: "Fuel surcharge", Price: money.New(120000, money.CurrencyRUB)}
+	paramsCreateTicket := &ticketsDomain.ParamsCreateTicket{StatusTimestamp: timestamp}
+
+	var tests = []struct {
+		name      string
+		args      *flightsDomain.Flight
+		want      []ticketsDomain.TicketItem
+		wantPrice money.Money
+	}{
+		{
+			name:      "flight without taxes and fees",
+			args:      &flightsDomain.Flight{},
+			want:      []ticketsDomain.TicketItem{},
+			wantPrice: money.Money{},
+		},
+		{
+			name: "each tax and fee is a separate item",
+			args: &flightsDomain.Flight{TaxesFees: []flightsDomain.FlightTaxFee{airportTax, fuelSurcharge}},
+			want: []ticketsDomain.TicketItem{
+				{Type: flightsDomain.TaxFeeTypeAirportTax, Name: "Airport tax", TaxFeeId: &airportTaxId, Quantity: 1, Price: money.New(45000, money.CurrencyRUB), Timestamp: timestamp},
+				{Type: flightsDomain.TaxFeeTypeFuelSurcharge, Name: "Fuel surcharge", TaxFeeId: &fuelSurchargeId, Quantity: 1, Price: money.New(120000, money.CurrencyRUB), Timestamp: timestamp},
+			},
+			wantPrice: money.New(165000, money.CurrencyRUB),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := getTicketItemsTaxesFees(paramsCreateTicket, tt.args)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPrice, getTicketItemsPrice(got))
+		})
+	}
+}
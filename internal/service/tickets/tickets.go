@@ -3,50 +3,243 @@ package tickets
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
 	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
 	ticketsDomain "homework/internal/domain/tickets"
 	usersDomain "homework/internal/domain/users"
 	"homework/internal/util/terr"
 )
 
 type TicketsService interface {
+	GetUserPassengers(ctx context.Context, userId uuid.UUID) ([]ticketsDomain.Passenger, error)
+	CreatePassenger(ctx context.Context, paramsCreatePassenger *ticketsDomain.ParamsCreatePassenger) (uuid.UUID, error)
+	UpdatePassenger(ctx context.Context, paramsUpdatePassenger *ticketsDomain.ParamsUpdatePassenger) (uuid.UUID, error)
+	DeletePassenger(ctx context.Context, paramsDeletePassenger *ticketsDomain.ParamsDeletePassenger) (uuid.UUID, error)
 	GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticketsDomain.Ticket, error)
+	GetUserTickets(ctx context.Context, paramsGetUserTickets *ticketsDomain.ParamsGetUserTickets) (*ticketsDomain.TicketsPage, error)
 	CreateTicket(ctx context.Context, paramsCreateTicket *ticketsDomain.ParamsCreateTicket) (uuid.UUID, error)
 	PayForTicket(ctx context.Context, paramsPayForTicket *ticketsDomain.ParamsPayForTicket) (uuid.UUID, error)
 	RefundTicket(ctx context.Context, paramsRefundTicket *ticketsDomain.ParamsRefundTicket) (uuid.UUID, error)
 	RegisterTicket(ctx context.Context, paramsRegisterTicket *ticketsDomain.ParamsRegisterTicket) (uuid.UUID, error)
+	RegisterFlightTickets(ctx context.Context, paramsRegisterFlightTickets *ticketsDomain.ParamsRegisterFlightTickets) ([]uuid.UUID, error)
+	BoardTicket(ctx context.Context, paramsBoardTicket *ticketsDomain.ParamsBoardTicket) (uuid.UUID, error)
+	CloseFlight(ctx context.Context, paramsCloseFlight *ticketsDomain.ParamsCloseFlight) (*ticketsDomain.ClosedFlight, error)
+	GetFlightManifest(ctx context.Context, paramsGetFlightManifest *ticketsDomain.ParamsGetFlightManifest) (*ticketsDomain.FlightManifest, error)
+	AddTicketAncillary(ctx context.Context, paramsAddTicketAncillary *ticketsDomain.ParamsAddTicketAncillary) (uuid.UUID, error)
+	JoinWaitlist(ctx context.Context, paramsJoinWaitlist *ticketsDomain.ParamsJoinWaitlist) (uuid.UUID, error)
+	ProcessWaitlist(ctx context.Context, timestamp time.Time) error
+	GetVoucherByCode(ctx context.Context, code string) (*ticketsDomain.Voucher, error)
+	GetCompanyById(ctx context.Context, companyId uuid.UUID) (*ticketsDomain.Company, error)
+	ApproveTicket(ctx context.Context, paramsApproveTicket *ticketsDomain.ParamsApproveTicket) (uuid.UUID, error)
+	CreateCompanyInvoice(ctx context.Context, paramsCreateCompanyInvoice *ticketsDomain.ParamsCreateCompanyInvoice) (*ticketsDomain.CompanyInvoice, error)
+	GetCompanyReport(ctx context.Context, paramsGetCompanyReport *ticketsDomain.ParamsGetCompanyReport) (*ticketsDomain.CompanyReport, error)
+	CreatePriceHold(ctx context.Context, paramsCreatePriceHold *ticketsDomain.ParamsCreatePriceHold) (*ticketsDomain.PriceHold, error)
+	GetTicketDocument(ctx context.Context, paramsGetTicketDocument *ticketsDomain.ParamsGetTicketDocument) (*ticketsDomain.TicketDocument, error)
 }
 
 type TicketsStorage interface {
 	GetPassengerById(ctx context.Context, passengerId uuid.UUID) (*ticketsDomain.Passenger, error)
+	GetUserPassengers(ctx context.Context, userId uuid.UUID) ([]ticketsDomain.Passenger, error)
+	GetCountPassengerActiveTickets(ctx context.Context, passengerId uuid.UUID, timestamp time.Time) (int, error)
+	CreatePassenger(ctx context.Context, paramsCreatePassenger *ticketsDomain.ParamsCreatePassenger) (uuid.UUID, error)
+	UpdatePassenger(ctx context.Context, paramsUpdatePassenger *ticketsDomain.ParamsUpdatePassenger) (uuid.UUID, error)
+	DeletePassenger(ctx context.Context, paramsDeletePassenger *ticketsDomain.ParamsDeletePassenger) (uuid.UUID, error)
 	GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticketsDomain.Ticket, error)
+	GetUserTickets(ctx context.Context, paramsGetUserTickets *ticketsDomain.ParamsGetUserTickets) (*ticketsDomain.TicketsPage, error)
+	GetAccompaniedTickets(ctx context.Context, ticketId uuid.UUID) ([]ticketsDomain.Ticket, error)
+	GetUserFlightTickets(ctx context.Context, userId uuid.UUID, flightId uuid.UUID) ([]ticketsDomain.Ticket, error)
+	GetCountFlightInfants(ctx context.Context, flightId uuid.UUID) (int, error)
+	GetCountPassengerWaitlistEntries(ctx context.Context, passengerId uuid.UUID, flightId uuid.UUID) (int, error)
+	GetWaitlistClassesSeats(ctx context.Context) ([]ticketsDomain.WaitlistClassSeats, error)
+	GetWaitlistEntries(ctx context.Context, flightId uuid.UUID, classSeatsId uuid.UUID, limit int) ([]ticketsDomain.WaitlistEntry, error)
+	GetExpiredWaitlistEntries(ctx context.Context, timestamp time.Time) ([]ticketsDomain.WaitlistEntry, error)
+	CreateWaitlistEntry(ctx context.Context, paramsJoinWaitlist *ticketsDomain.ParamsJoinWaitlist) (uuid.UUID, error)
+	CloseWaitlistEntry(ctx context.Context, paramsCloseWaitlistEntry *ticketsDomain.ParamsCloseWaitlistEntry) (uuid.UUID, error)
+	CancelUnpaidTickets(ctx context.Context, timestamp time.Time, createdBefore time.Time, pendingApprovalBefore time.Time) (int64, error)
+	GetPromoCodeByCode(ctx context.Context, code string) (*ticketsDomain.PromoCode, error)
+	GetPromoCodeById(ctx context.Context, promoCodeId uuid.UUID) (*ticketsDomain.PromoCode, error)
+	GetPromoCodeUsage(ctx context.Context, promoCodeId uuid.UUID, userId uuid.UUID) (*ticketsDomain.PromoCodeUsage, error)
+	GetVoucherByCode(ctx context.Context, code string) (*ticketsDomain.Voucher, error)
+	GetCompanyById(ctx context.Context, companyId uuid.UUID) (*ticketsDomain.Company, error)
+	GetCompanyTickets(ctx context.Context, paramsGetCompanyReport *ticketsDomain.ParamsGetCompanyReport) ([]ticketsDomain.Ticket, error)
+	ApproveTicket(ctx context.Context, paramsApproveTicket *ticketsDomain.ParamsApproveTicket) (uuid.UUID, error)
+	CreateCompanyInvoice(ctx context.Context, paramsCreateCompanyInvoice *ticketsDomain.ParamsCreateCompanyInvoice) (*ticketsDomain.CompanyInvoice, error)
+	CreatePriceHold(ctx context.Context, paramsCreatePriceHold *ticketsDomain.ParamsCreatePriceHold) (*ticketsDomain.PriceHold, error)
+	GetActivePriceHolds(ctx context.Context, expiresBefore time.Time) ([]ticketsDomain.PriceHold, error)
+	RemindPriceHold(ctx context.Context, paramsProcessPriceHold *ticketsDomain.ParamsProcessPriceHold) (uuid.UUID, error)
+	ExpirePriceHold(ctx context.Context, paramsProcessPriceHold *ticketsDomain.ParamsProcessPriceHold) (uuid.UUID, error)
+	GetTicketDocuments(ctx context.Context, ticketId uuid.UUID) ([]ticketsDomain.TicketDocument, error)
 	CreateTicket(ctx context.Context, paramsCreateTicket *ticketsDomain.ParamsCreateTicket) (uuid.UUID, error)
 	PayForTicket(ctx context.Context, paramsPayForTicket *ticketsDomain.ParamsPayForTicket) (uuid.UUID, error)
 	RefundTicket(ctx context.Context, paramsRefundTicket *ticketsDomain.ParamsRefundTicket) (uuid.UUID, error)
 	RegisterTicket(ctx context.Context, paramsRegisterTicket *ticketsDomain.ParamsRegisterTicket) (uuid.UUID, error)
+	RegisterTickets(ctx context.Context, paramsRegisterTickets []ticketsDomain.ParamsRegisterTicket) ([]uuid.UUID, error)
+	BoardTicket(ctx context.Context, paramsBoardTicket *ticketsDomain.ParamsBoardTicket) (uuid.UUID, error)
+	CloseFlight(ctx context.Context, paramsCloseFlight *ticketsDomain.ParamsCloseFlight) (*ticketsDomain.ClosedFlight, error)
+	GetFlightManifest(ctx context.Context, flightId uuid.UUID) ([]ticketsDomain.ManifestPassenger, error)
+	AddTicketAncillary(ctx context.Context, paramsAddTicketAncillary *ticketsDomain.ParamsAddTicketAncillary) (uuid.UUID, error)
 }
 
 type FlightsStorage interface {
 	GetFlightById(ctx context.Context, flightId uuid.UUID) (*flightsDomain.Flight, error)
 	GetFlightVacantSeatsByClassId(ctx context.Context, flightId uuid.UUID, classSeatsId uuid.UUID) (*flightsDomain.VacantSeats, error)
+	GetSeatsByClassId(ctx context.Context, classSeatsId uuid.UUID) ([]flightsDomain.Seat, error)
+	GetFlightAncillaryById(ctx context.Context, flightAncillaryId uuid.UUID) (*flightsDomain.FlightAncillary, error)
+	GetSeatHoldById(ctx context.Context, seatHoldId uuid.UUID) (*flightsDomain.SeatHold, error)
+	DeleteExpiredSeatHolds(ctx context.Context, timestamp time.Time) (int64, error)
 }
 
 type UsersStorage interface {
-	GetAccruedBonuses(ctx context.Context, userId uuid.UUID, ticketPrice int) (int, error)
+	GetAccruedBonuses(ctx context.Context, paramsLoyalty *usersDomain.ParamsLoyalty) (money.Money, error)
+	GetBonusRedemptionLimit(ctx context.Context, paramsLoyalty *usersDomain.ParamsLoyalty) (money.Money, error)
 	GetUserById(ctx context.Context, userId uuid.UUID) (*usersDomain.User, error)
 }
 
+// источник курсов валют: курс на момент timestamp
+type ExchangeRatesProvider interface {
+	GetExchangeRate(ctx context.Context, currencyFrom string, currencyTo string, timestamp time.Time) (*money.ExchangeRate, error)
+}
+
 type service struct {
-	ticketsStorage TicketsStorage
-	flightsStorage FlightsStorage
-	usersStorage   UsersStorage
+	ticketsStorage        TicketsStorage
+	flightsStorage        FlightsStorage
+	usersStorage          UsersStorage
+	exchangeRatesProvider ExchangeRatesProvider
+	seller                ticketsDomain.Seller
+}
+
+// setExchangeRateCreateTicket фиксирует в билете курс валюты цен рейса к валюте учета на момент оформления билета.
+// по этому курсу в валюте учета считаются сумма покупок пользователя, бонусы за билет и возврат билета
+func (s service) setExchangeRateCreateTicket(ctx context.Context, paramsCreateTicket *ticketsDomain.ParamsCreateTicket, flight *flightsDomain.Flight) error {
+
+	exchangeRate, err := s.exchangeRatesProvider.GetExchangeRate(ctx, flight.Currency, money.BaseCurrency, paramsCreateTicket.StatusTimestamp)
+	if err != nil {
+		return err
+	}
+	paramsCreateTicket.ExchangeRate = *exchangeRate
+	return nil
+}
+
+// getUserPassenger получает пассажира по id и проверяет, что он принадлежит пользователю
+func (s service) getUserPassenger(ctx context.Context, passengerId uuid.UUID, userId uuid.UUID) (*ticketsDomain.Passenger, error) {
+
+	// проверяем, что по переданному PassengerId существует пассажир
+	passenger, err := s.ticketsStorage.GetPassengerById(ctx, passengerId)
+	if err != nil {
+		return nil, err
+	}
+
+	// проверки пассажира:
+	// пользователь пассажира соответствует переданному пользователю
+	if userId != passenger.User.Id {
+		return nil, terr.BadRequest("INVALID_PASSENGER", fmt.Sprintf("the passenger's user (id %s) doesn't match the user (id %s)", passenger.User.Id, userId))
+	}
+
+	return passenger, nil
+}
+
+func (s service) GetUserPassengers(ctx context.Context, userId uuid.UUID) ([]ticketsDomain.Passenger, error) {
+
+	// проверяем, что по переданному UserId существует пользователь
+	_, err := s.usersStorage.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ticketsStorage.GetUserPassengers(ctx, userId)
+}
+
+func (s service) CreatePassenger(ctx context.Context, paramsCreatePassenger *ticketsDomain.ParamsCreatePassenger) (uuid.UUID, error) {
+
+	// проверяем, что по переданному UserId существует пользователь
+	_, err := s.usersStorage.GetUserById(ctx, paramsCreatePassenger.UserId)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// проверяем документ пассажира
+	err = validateIdentityDocument(&paramsCreatePassenger.Document, paramsCreatePassenger.Timestamp)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	paramsCreatePassenger.IdentityDataPassenger = formatIdentityDocument(&paramsCreatePassenger.Document)
+
+	return s.ticketsStorage.CreatePassenger(ctx, paramsCreatePassenger)
+}
+
+func (s service) UpdatePassenger(ctx context.Context, paramsUpdatePassenger *ticketsDomain.ParamsUpdatePassenger) (uuid.UUID, error) {
+
+	// проверяем, что пассажир существует и принадлежит пользователю
+	_, err := s.getUserPassenger(ctx, paramsUpdatePassenger.PassengerId, paramsUpdatePassenger.UserId)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// проверяем документ пассажира
+	err = validateIdentityDocument(&paramsUpdatePassenger.Document, paramsUpdatePassenger.Timestamp)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	paramsUpdatePassenger.IdentityDataPassenger = formatIdentityDocument(&paramsUpdatePassenger.Document)
+
+	// изменение пассажира не затрагивает уже оформленные билеты: в них хранится копия данных пассажира
+	return s.ticketsStorage.UpdatePassenger(ctx, paramsUpdatePassenger)
+}
+
+func (s service) DeletePassenger(ctx context.Context, paramsDeletePassenger *ticketsDomain.ParamsDeletePassenger) (uuid.UUID, error) {
+
+	// проверяем, что пассажир существует и принадлежит пользователю
+	_, err := s.getUserPassenger(ctx, paramsDeletePassenger.PassengerId, paramsDeletePassenger.UserId)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// удалить можно только пассажира без действующих билетов
+	countActiveTickets, err := s.ticketsStorage.GetCountPassengerActiveTickets(ctx, paramsDeletePassenger.PassengerId, paramsDeletePassenger.Timestamp)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	if countActiveTickets > 0 {
+		return uuid.UUID{}, terr.Conflict("PASSENGER_HAS_ACTIVE_TICKETS", fmt.Sprintf("passenger (id %s) has %d active tickets", paramsDeletePassenger.PassengerId, countActiveTickets))
+	}
+
+	return s.ticketsStorage.DeletePassenger(ctx, paramsDeletePassenger)
 }
 
 func (s service) GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticketsDomain.Ticket, error) {
-	return s.ticketsStorage.GetTicketById(ctx, ticketId)
+
+	ticket, err := s.ticketsStorage.GetTicketById(ctx, ticketId)
+	if err != nil {
+		return nil, err
+	}
+
+	// посадочный талон выдается зарегистрированному билету (статусы 5 Registered, 7 Boarded)
+	if ticket.Status.Id == 5 || ticket.Status.Id == 7 {
+		ticket.BoardingPassCode = formatBoardingPassCode(ticket)
+	}
+	return ticket, nil
+}
+
+func (s service) GetUserTickets(ctx context.Context, paramsGetUserTickets *ticketsDomain.ParamsGetUserTickets) (*ticketsDomain.TicketsPage, error) {
+
+	// проверяем, что по переданному UserId существует пользователь
+	_, err := s.usersStorage.GetUserById(ctx, paramsGetUserTickets.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// проверки отбора:
+	// начало периода дат вылета не позже его окончания
+	if paramsGetUserTickets.DepartureDateFrom != nil && paramsGetUserTickets.DepartureDateTo != nil &&
+		paramsGetUserTickets.DepartureDateFrom.After(*paramsGetUserTickets.DepartureDateTo) {
+		return nil, terr.BadRequest("INVALID_DEPARTURE_DATE_RANGE", "departure date from is after departure date to")
+	}
+
+	return s.ticketsStorage.GetUserTickets(ctx, paramsGetUserTickets)
 }
 
 func (s service) CreateTicket(ctx context.Context, paramsCreateTicket *ticketsDomain.ParamsCreateTicket) (uuid.UUID, error) {
@@ -58,6 +251,10 @@ func (s service) CreateTicket(ctx context.Context, paramsCreateTicket *ticketsDo
 	}
 
 	// проверки рейса:
+	// рейс не отменен
+	if flight.Status.State == flightsDomain.FlightStateCancelled {
+		return uuid.UUID{}, terr.Conflict("FLIGHT_CANCELLED", fmt.Sprintf("flight (id %s) is cancelled", flight.Id))
+	}
 	// до вылета осталось больше 2 часов
 	if flight.DepartureDate.Sub(paramsCreateTicket.StatusTimestamp).Hours() < 2 {
 		return uuid.UUID{}, terr.BadRequest("FLIGHT_ALREADY_CLOSED", "sale of tickets for the flight is closed")
@@ -69,6 +266,15 @@ func (s service) CreateTicket(ctx context.Context, paramsCreateTicket *ticketsDo
 		return uuid.UUID{}, err
 	}
 
+	// для билета компании проверяем роли пользователей компании
+	company, err := s.getCompanyCreateTicket(ctx, paramsCreateTicket)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// документ пассажира, по которому оформляется билет
+	var document *ticketsDomain.IdentityDocument
+
 	// если пассажир уже существует, то проверяем его
 	if paramsCreateTicket.PassengerId != nil {
 
@@ -87,54 +293,128 @@ func (s service) CreateTicket(ctx context.Context, paramsCreateTicket *ticketsDo
 		// проверки того, что на данного пассажира уже может быть билет на данный рейс нет.
 		// причина: в ржд можно купить на одного пассажира несколько билетов, чтобы выкупить полностью купе.
 		// предполагаю, что и на самолет можно купить несколько билетов на одного пассажира, чтобы выкупить весь ряд или весь самолет.
+
+		document = passenger.Document
+	} else {
+
+		// проверяем документ нового пассажира
+		err = validateIdentityDocument(&paramsCreateTicket.ParamsCreatePassenger.Document, paramsCreateTicket.StatusTimestamp)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+		paramsCreateTicket.ParamsCreatePassenger.IdentityDataPassenger = formatIdentityDocument(&paramsCreateTicket.ParamsCreatePassenger.Document)
+
+		document = &paramsCreateTicket.ParamsCreatePassenger.Document
 	}
 
-	// проверяем, что на данном рейсе существуют места с заданным классом ClassSeatsId
-	vacantSeats, err := s.flightsStorage.GetFlightVacantSeatsByClassId(ctx, paramsCreateTicket.FlightId, paramsCreateTicket.ClassSeatsId)
+	// для международного рейса проверяем паспорт пассажира
+	err = validateFlightDocument(document, flight)
 	if err != nil {
 		return uuid.UUID{}, err
 	}
 
-	// проверки класса места:
-	// есть свободные места данного класса
-	if vacantSeats.CountVacantSeats == 0 {
-		return uuid.UUID{}, terr.BadRequest("NO_VACANT_SEAT", fmt.Sprintf("no vacant seats with class seat (id %s) ", paramsCreateTicket.ClassSeatsId))
+	// тип пассажира определяется по дате рождения на дату вылета.
+	// пассажир без документа (созданный до появления структурированных документов) считается взрослым
+	paramsCreateTicket.PassengerType = ticketsDomain.PassengerTypeAdult
+	if document != nil {
+		paramsCreateTicket.PassengerType = getPassengerType(document.BirthDate, flight.DepartureDate)
 	}
 
-	// если место было указано, то проверяем его
-	if paramsCreateTicket.SeatId != nil {
+	// билет ребенка и младенца оформляется с сопровождением взрослого,
+	// у взрослого сопровождающего нет
+	if paramsCreateTicket.PassengerType == ticketsDomain.PassengerTypeAdult {
+		if paramsCreateTicket.AccompanyingTicketId != nil {
+			return uuid.UUID{}, terr.BadRequest("INVALID_ACCOMPANYING_TICKET", "accompanying ticket is set only for a child or an infant")
+		}
+	} else {
+		err = s.checkAccompanyingTicket(ctx, paramsCreateTicket)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+	}
 
-		// проверяем, что место есть в списке свободных мест
-		isSeatVacant := false
-		seatId := *paramsCreateTicket.SeatId
-		for _, seat := range vacantSeats.Seats {
-			if seat.Id == seatId {
-				isSeatVacant = true
-				break
-			}
+	if paramsCreateTicket.PassengerType == ticketsDomain.PassengerTypeInfant {
+
+		// младенец летит на руках у взрослого без отдельного места
+		if paramsCreateTicket.SeatId != nil || paramsCreateTicket.SeatHoldId != nil {
+			return uuid.UUID{}, terr.BadRequest("INFANT_SEAT_NOT_ALLOWED", "infant on lap can't have a seat")
 		}
 
-		// место занято
-		if !isSeatVacant {
-			return uuid.UUID{}, terr.BadRequest("SEAT_DOESNT_VACANT", fmt.Sprintf("seat (id %s) isn't in the list of vacant seats", seatId))
+		// количество младенцев на рейсе ограничено
+		countInfants, err := s.ticketsStorage.GetCountFlightInfants(ctx, paramsCreateTicket.FlightId)
+		if err != nil {
+			return uuid.UUID{}, err
 		}
-	}
+		if countInfants >= flight.MaxInfants {
+			return uuid.UUID{}, terr.BadRequest("INFANT_LIMIT_EXCEEDED", fmt.Sprintf("flight (id %s) has reached the limit of infants (%d)", flight.Id, flight.MaxInfants))
+		}
+	} else if paramsCreateTicket.SeatHoldId != nil {
+
+		// билет оформляется по удержанному месту
+		err = s.checkSeatHold(ctx, paramsCreateTicket)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+	} else {
 
-	// рассчитаем стоимость билета как сумму стоимости билета выбранного класса
-	// + стоимость дополнительного багажа * количество дополнительного багажа
-	// + стоимость выбора места, если место было выбрано на этапе создания билета
-	var price int
-	for _, flightPrice := range flight.PricesTickets {
-		if flightPrice.ClassSeats.Id == paramsCreateTicket.ClassSeatsId {
-			price = flightPrice.PriceTicket
-			break
+		// удаляем истекшие удержания мест, чтобы они не уменьшали количество свободных мест
+		_, err = s.flightsStorage.DeleteExpiredSeatHolds(ctx, paramsCreateTicket.StatusTimestamp)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+
+		// проверяем, что на данном рейсе существуют места с заданным классом ClassSeatsId
+		vacantSeats, err := s.flightsStorage.GetFlightVacantSeatsByClassId(ctx, paramsCreateTicket.FlightId, paramsCreateTicket.ClassSeatsId)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+
+		// проверки класса места:
+		// есть свободные места данного класса
+		if vacantSeats.CountVacantSeats <= 0 {
+			return uuid.UUID{}, terr.BadRequest("NO_VACANT_SEAT", fmt.Sprintf("no vacant seats with class seat (id %s) ", paramsCreateTicket.ClassSeatsId))
+		}
+
+		// если место было указано, то проверяем его
+		if paramsCreateTicket.SeatId != nil {
+
+			// проверяем, что место есть в списке свободных мест
+			isSeatVacant := false
+			seatId := *paramsCreateTicket.SeatId
+			for _, seat := range vacantSeats.Seats {
+				if seat.Id == seatId {
+					isSeatVacant = true
+					break
+				}
+			}
+
+			// место занято
+			if !isSeatVacant {
+				return uuid.UUID{}, terr.BadRequest("SEAT_DOESNT_VACANT", fmt.Sprintf("seat (id %s) isn't in the list of vacant seats", seatId))
+			}
 		}
 	}
-	price += paramsCreateTicket.CountAdditionalBaggage * flight.PriceAdditionalBaggage
-	if paramsCreateTicket.SeatId != nil {
-		price += flight.PriceSeatSelection
+
+	// рассчитаем стоимость билета как сумму позиций состава стоимости
+	err = s.convertFlightTaxesFees(ctx, flight, paramsCreateTicket.StatusTimestamp)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	setPriceCreateTicket(paramsCreateTicket, flight)
+
+	// скидка по промокоду уменьшает стоимость билета
+	err = s.applyPromoCode(ctx, paramsCreateTicket, flight)
+	if err != nil {
+		return uuid.UUID{}, err
 	}
-	paramsCreateTicket.Price = price
+
+	err = s.setExchangeRateCreateTicket(ctx, paramsCreateTicket, flight)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// билет компании с нарушением правил поездок ожидает согласования
+	setTravelPolicyCreateTicket(paramsCreateTicket, company, flight)
 
 	// создаем билет и пассажира, если он не существует
 	ticketId, err := s.ticketsStorage.CreateTicket(ctx, paramsCreateTicket)
@@ -155,8 +435,14 @@ func (s service) PayForTicket(ctx context.Context, paramsPayForTicket *ticketsDo
 		return uuid.UUID{}, terr.BadRequest("INVALID_STATUS_TICKET", fmt.Sprintf("ticket (id %s) has wrong status (%s)", paramsPayForTicket.TicketId, ticket.Status.Name))
 	}
 
-	// оплатить можно только билет, созданный менее 15 мин назад, иначе билет должен быть отменен
-	if paramsPayForTicket.StatusTimestamp.Sub(ticket.Status.Timestamp).Minutes() > 15 {
+	// оплатить можно только билет, созданный (для билета компании - согласованный) менее 15 мин назад, иначе билет должен быть отменен;
+	// для билета с действующей фиксацией цены срок оплаты продлевается до окончания фиксации
+	if ticket.PriceHold != nil && ticket.PriceHold.Status == ticketsDomain.PriceHoldStatusActive {
+		if !paramsPayForTicket.StatusTimestamp.Before(ticket.PriceHold.ExpiryTimestamp) {
+			return uuid.UUID{}, terr.BadRequest("PRICE_HOLD_EXPIRED", fmt.Sprintf("price hold of ticket (id %s) has expired", ticket.Id))
+		}
+		paramsPayForTicket.PriceHoldId = &ticket.PriceHold.Id
+	} else if paramsPayForTicket.StatusTimestamp.Sub(ticket.Status.Timestamp) > ticketPaymentWindow {
 		return uuid.UUID{}, terr.BadRequest("TICKET_ALREADY_CANCELED", "time to pay is over")
 	}
 
@@ -172,36 +458,86 @@ func (s service) PayForTicket(ctx context.Context, paramsPayForTicket *ticketsDo
 		return uuid.UUID{}, terr.BadRequest("INVALID_USER", fmt.Sprintf("the user (id %s) doesn't match the user of the ticket (id %s)", paramsPayForTicket.UserId, ticket.User.Id))
 	}
 
+	// билет компании оплачивается по счету компании после согласования
+	if ticket.Company != nil {
+		err = validateCompanyPayForTicket(paramsPayForTicket, ticket)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+	}
+
+	// стоимость билета в валюте учета по курсу, зафиксированному при оформлении билета
+	price := ticket.Price.Convert(ticket.ExchangeRate)
+
+	// параметры правил программы лояльности по билету
+	paramsLoyalty := getParamsLoyalty(ticket, price, paramsPayForTicket.StatusTimestamp)
+
 	// проверки, если передается сумма бонусов для оплаты
-	if paramsPayForTicket.PaidWithBonuses > 0 {
+	if paramsPayForTicket.PaidWithBonuses.Amount > 0 {
+
+		// бонусы списываются в валюте учета
+		if paramsPayForTicket.PaidWithBonuses.Currency != money.BaseCurrency {
+			return uuid.UUID{}, terr.BadRequest("INVALID_CURRENCY", fmt.Sprintf("bonuses are paid in %s", money.BaseCurrency))
+		}
 
 		// проверяем, что у пользователя достаточно бонусов
-		if user.Balance == nil || user.Balance.SumBonuses < paramsPayForTicket.PaidWithBonuses {
+		if user.Balance == nil || user.Balance.SumBonuses.Amount < paramsPayForTicket.PaidWithBonuses.Amount {
 			return uuid.UUID{}, terr.BadRequest("INVALID_SUM_BONUSES", "user doesn't have enough bonuses")
 		}
 
-		// проверяем, что переданная сумма бонусов не превышает половину стоимости билета
-		if paramsPayForTicket.PaidWithBonuses > int(ticket.Price/2) {
-			return uuid.UUID{}, terr.BadRequest("INVALID_SUM_BONUSES", "sum bonuses is more than half of the ticket price")
+		// проверяем, что переданная сумма бонусов не превышает долю стоимости билета, оплачиваемую бонусами
+		// (по умолчанию половину стоимости билета, иначе - по правилам программы лояльности)
+		redemptionLimit, err := s.usersStorage.GetBonusRedemptionLimit(ctx, paramsLoyalty)
+		if err != nil {
+			return uuid.UUID{}, err
 		}
+		if paramsPayForTicket.PaidWithBonuses.Amount > redemptionLimit.Amount {
+			return uuid.UUID{}, terr.BadRequest("INVALID_SUM_BONUSES", fmt.Sprintf("sum bonuses is more than the limit of bonuses for the ticket (%s)", redemptionLimit))
+		}
+	}
+
+	// промокод билета: его использование фиксируется вместе с оплатой
+	err = s.setPromoCodePayForTicket(ctx, paramsPayForTicket, ticket)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// проверки сертификатов и кредитов, если они передаются для оплаты
+	err = s.setVouchersPayForTicket(ctx, paramsPayForTicket)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// распределение стоимости билета по способам оплаты:
+	// сумма бонусов, сертификатов и кредитов не превышает стоимость билета.
+	// билет компании оплачивается по счету компании
+	if ticket.Company != nil {
+		paramsPayForTicket.Payments = getCompanyTicketPayments(paramsPayForTicket, price)
+	} else {
+		payments, err := getTicketPayments(paramsPayForTicket, price)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+		paramsPayForTicket.Payments = payments
 	}
 
 	// Все проверки пройдены
 
 	// Здесь по логике бизнес-процесса выполняется обращение к платежной системе
-	// и производится оплата на сумму ticket.Price-PaidWithBonuses
+	// и производится оплата картой остатка стоимости билета за вычетом бонусов, сертификатов и кредитов.
+	// Билет компании оплачивается по ежемесячному счету компании
 
 	// Получаем сумму бонусных баллов AccruedBonuses, начисляемых за приобретение билета.
 	// Бонусные баллы поступят на счет пользователя только после регистрации на рейс. До этого момента информация о них хранится только в билете.
-	// Расчет бонусов - % от суммы общей покупок пользователя.
-	accruedBonuses, err := s.usersStorage.GetAccruedBonuses(ctx, paramsPayForTicket.UserId, ticket.Price)
+	// Расчет бонусов - % от суммы общей покупок пользователя с учетом правил программы лояльности.
+	accruedBonuses, err := s.usersStorage.GetAccruedBonuses(ctx, paramsLoyalty)
 	if err != nil {
 		return uuid.UUID{}, err
 	}
 	paramsPayForTicket.AccruedBonuses = accruedBonuses
 
-	// передаем стоимость билета для изменения баланса пользователя
-	paramsPayForTicket.Price = ticket.Price
+	// передаем стоимость билета в валюте учета для изменения баланса пользователя
+	paramsPayForTicket.Price = price
 	paramsPayForTicket.UserBalanceInit = user.Balance != nil
 
 	// Выполняем изменение билета, в т.ч. начисление бонусов за билет, и изменение баланса пользователя
@@ -223,11 +559,22 @@ func (s service) RefundTicket(ctx context.Context, paramsRefundTicket *ticketsDo
 		return uuid.UUID{}, terr.BadRequest("INVALID_STATUS_TICKET", fmt.Sprintf("ticket (id %s) has wrong status (%s)", paramsRefundTicket.TicketId, ticket.Status.Name))
 	}
 
-	// вернуть билет можно только в случае, если до вылета осталось больше 24 часов
-	if ticket.Flight.DepartureDate.Sub(paramsRefundTicket.StatusTimestamp).Hours() < 24 {
+	// вернуть билет можно только в случае, если до ожидаемого вылета осталось больше 24 часов.
+	// билет отмененного рейса можно вернуть в любой момент
+	if ticket.Flight.Status.State != flightsDomain.FlightStateCancelled &&
+		getExpectedDepartureDate(&ticket.Flight).Sub(paramsRefundTicket.StatusTimestamp).Hours() < 24 {
 		return uuid.UUID{}, terr.BadRequest("REFUND_ALREADY_CLOSED", "flight ticket refund is not possible")
 	}
 
+	// билет взрослого нельзя вернуть, пока по нему летят ребенок или младенец
+	accompaniedTickets, err := s.ticketsStorage.GetAccompaniedTickets(ctx, ticket.Id)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	if len(accompaniedTickets) > 0 {
+		return uuid.UUID{}, terr.Conflict("TICKET_HAS_ACCOMPANIED_TICKETS", fmt.Sprintf("ticket (id %s) accompanies %d active tickets of children or infants", ticket.Id, len(accompaniedTickets)))
+	}
+
 	// проверяем, что по переданному UserId существует пользователь
 	user, err := s.usersStorage.GetUserById(ctx, paramsRefundTicket.UserId)
 	if err != nil {
@@ -247,12 +594,27 @@ func (s service) RefundTicket(ctx context.Context, paramsRefundTicket *ticketsDo
 
 	// Все проверки пройдены
 
-	// передаем стоимость билета для изменения баланса пользователя
-	paramsRefundTicket.Price = ticket.Price
+	// передаем стоимость билета в валюте учета по курсу, зафиксированному при оформлении билета, для изменения баланса пользователя
+	paramsRefundTicket.Price = ticket.Price.Convert(ticket.ExchangeRate)
+
+	// возврат по способам оплаты билета
+	setRefundTicketPayments(paramsRefundTicket, ticket, paramsRefundTicket.Price)
 
 	// Выполняем изменение билета и изменение баланса пользователя
 	ticketId, err := s.ticketsStorage.RefundTicket(ctx, paramsRefundTicket)
-	return ticketId, err
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// освободившееся место сразу передается следующему в листе ожидания.
+	// ошибка оформления билета по листу ожидания не отменяет возврат: ошибка выводится в лог,
+	// лист ожидания будет обработан повторно фоновым заданием
+	err = s.processWaitlistClassSeats(ctx, ticket.Flight.Id, ticket.ClassSeats.Id, paramsRefundTicket.StatusTimestamp)
+	if err != nil {
+		logProcessWaitlistError(err, "refund ticket (id %s)", ticketId)
+	}
+
+	return ticketId, nil
 }
 
 func (s service) RegisterTicket(ctx context.Context, paramsRegisterTicket *ticketsDomain.ParamsRegisterTicket) (uuid.UUID, error) {
@@ -269,11 +631,10 @@ func (s service) RegisterTicket(ctx context.Context, paramsRegisterTicket *ticke
 		return uuid.UUID{}, terr.BadRequest("INVALID_STATUS_TICKET", fmt.Sprintf("ticket (id %s) has wrong status (%s)", paramsRegisterTicket.TicketId, ticket.Status.Name))
 	}
 
-	// зарегистрировать билет можно только в случае, если до вылета осталось больше 1 часа и меньше 24 часов
-	if ticket.Flight.DepartureDate.Sub(paramsRegisterTicket.StatusTimestamp).Hours() > 24 {
-		return uuid.UUID{}, terr.BadRequest("CHECK_IN_DOESNT_START", "check-in hasn't started yet")
-	} else if ticket.Flight.DepartureDate.Sub(paramsRegisterTicket.StatusTimestamp).Hours() < 1 {
-		return uuid.UUID{}, terr.BadRequest("CHECK_IN_ALREADY_CLOSED", "check-in is already closed")
+	// зарегистрировать билет можно только в случае, если рейс не отменен и до вылета осталось больше 1 часа и меньше 24 часов
+	err = validateCheckInTime(&ticket.Flight, paramsRegisterTicket.StatusTimestamp)
+	if err != nil {
+		return uuid.UUID{}, err
 	}
 
 	// проверяем, что по переданному UserId существует пользователь
@@ -293,51 +654,25 @@ func (s service) RegisterTicket(ctx context.Context, paramsRegisterTicket *ticke
 		return uuid.UUID{}, terr.BadRequest("INVALID_USER", "no information about the user's balance")
 	}
 
-	// проверяем место, если при покупке билета место не было назначено
-	if ticket.Seat == nil {
-
-		// проверяем, что передано место.
-		// т.к. в билете место еще не заполнено, значит, место должно назначаться при регистрации на рейс
-		if paramsRegisterTicket.SeatId == nil {
-			return uuid.UUID{}, terr.BadRequest("SEAT_DOESNT_ASSIGNED", "seat has to be assigned")
-		}
-
-		// проверяем, что на данном рейсе существуют место SeatId
-		// получаем список вакантных места рейса с заданным классом ClassSeatsId
-		vacantSeats, err := s.flightsStorage.GetFlightVacantSeatsByClassId(ctx, ticket.Flight.Id, ticket.ClassSeats.Id)
-		if err != nil {
-			return uuid.UUID{}, err
-		}
-
-		// проверяем, что место есть в списке свободных мест
-		isSeatVacant := false
-		seatId := *paramsRegisterTicket.SeatId
-		for _, seat := range vacantSeats.Seats {
-			if seat.Id == seatId {
-				isSeatVacant = true
-				break
-			}
-		}
-		// место занято
-		if !isSeatVacant {
-			return uuid.UUID{}, terr.BadRequest("SEAT_DOESNT_VACANT", fmt.Sprintf("seat (id %s) isn't in the list of vacant seats", seatId))
-		}
+	// проверки данных APIS и места, назначение места
+	err = s.prepareRegisterTicket(ctx, ticket, paramsRegisterTicket, nil)
+	if err != nil {
+		return uuid.UUID{}, err
 	}
 
 	// Все проверки пройдены
 
-	// передаем сумму начисленных за билет бонусов для изменения баланса пользователя
-	paramsRegisterTicket.AccruedBonuses = ticket.AccruedBonuses
-
-	// Выполняем изменение билета и изменение баланса пользователя
+	// Выполняем изменение билета. Бонусы за билет начисляются при посадке на рейс
 	ticketId, err := s.ticketsStorage.RegisterTicket(ctx, paramsRegisterTicket)
 	return ticketId, err
 }
 
-func NewTicketsService(ticketsStorage TicketsStorage, flightsStorage FlightsStorage, usersStorage UsersStorage) TicketsService {
+func NewTicketsService(ticketsStorage TicketsStorage, flightsStorage FlightsStorage, usersStorage UsersStorage, exchangeRatesProvider ExchangeRatesProvider, seller ticketsDomain.Seller) TicketsService {
 	return &service{
-		ticketsStorage: ticketsStorage,
-		flightsStorage: flightsStorage,
-		usersStorage:   usersStorage,
+		ticketsStorage:        ticketsStorage,
+		flightsStorage:        flightsStorage,
+		usersStorage:          usersStorage,
+		exchangeRatesProvider: exchangeRatesProvider,
+		seller:                seller,
 	}
 }
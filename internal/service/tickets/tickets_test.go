@@ -10,12 +10,15 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
+	"homework/internal/domain/money"
 	ticketsDomain "homework/internal/domain/tickets"
+	usersDomain "homework/internal/domain/users"
 	mockTicketsService "homework/internal/service/tickets/mock"
 	"homework/internal/util/terr"
 )
 
 //go:generate mockgen -destination ./mock/tickets_service_mock.go homework/internal/service/tickets TicketsService
+//go:generate mockgen -destination ./mock/tickets_storage_mock.go homework/internal/service/tickets TicketsStorage,FlightsStorage,UsersStorage,ExchangeRatesProvider
 
 func Test_CreateTicket(t *testing.T) {
 
@@ -35,7 +38,7 @@ func Test_CreateTicket(t *testing.T) {
 		ClassSeatsId:           classSeatId,
 		SeatId:                 &seatId,
 		CountAdditionalBaggage: 1,
-		Price:                  1000,
+		Price:                  money.New(100000, money.CurrencyRUB),
 	}
 
 	var tests = []struct {
@@ -83,6 +86,126 @@ func Test_CreateTicket(t *testing.T) {
 	}
 }
 
+func Test_GetUserTickets(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	userId := uuid.MustParse("07d87607-1f06-4599-8af5-07229525c106")
+	ticketId := uuid.MustParse("6382589b-ab8e-4519-8c00-d0fe095179b3")
+	nextTicketId := uuid.MustParse("b8d0b64d-08d8-4f9d-8c5c-cabd44957f16")
+	departureDateFrom := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	departureDateTo := time.Date(2023, 5, 31, 0, 0, 0, 0, time.UTC)
+	cursor := &ticketsDomain.TicketsCursor{DepartureDate: timestamp.AddDate(0, 0, 3), TicketId: ticketId}
+	nextCursor := &ticketsDomain.TicketsCursor{DepartureDate: timestamp.AddDate(0, 0, 5), TicketId: nextTicketId}
+
+	var tests = []struct {
+		name        string
+		args        *ticketsDomain.ParamsGetUserTickets
+		userErr     error
+		ticketsPage *ticketsDomain.TicketsPage
+		want        *ticketsDomain.TicketsPage
+		err         error
+	}{
+		{
+			name: "success/upcoming paid tickets after cursor",
+			args: &ticketsDomain.ParamsGetUserTickets{
+				Timestamp:     timestamp,
+				UserId:        userId,
+				StatusesNames: []string{"Paid", "Registered"},
+				Period:        ticketsDomain.TicketsPeriodUpcoming,
+				Cursor:        cursor,
+				Limit:         1,
+			},
+			ticketsPage: &ticketsDomain.TicketsPage{
+				Tickets:    []ticketsDomain.Ticket{{Id: nextTicketId}},
+				NextCursor: nextCursor,
+			},
+			want: &ticketsDomain.TicketsPage{
+				Tickets:    []ticketsDomain.Ticket{{Id: nextTicketId}},
+				NextCursor: nextCursor,
+			},
+			err: nil,
+		},
+		{
+			name: "success/past tickets in departure date range",
+			args: &ticketsDomain.ParamsGetUserTickets{
+				Timestamp:         timestamp,
+				UserId:            userId,
+				Period:            ticketsDomain.TicketsPeriodPast,
+				DepartureDateFrom: &departureDateFrom,
+				DepartureDateTo:   &departureDateTo,
+				Limit:             20,
+			},
+			ticketsPage: &ticketsDomain.TicketsPage{
+				Tickets: []ticketsDomain.Ticket{{Id: ticketId}},
+			},
+			want: &ticketsDomain.TicketsPage{
+				Tickets: []ticketsDomain.Ticket{{Id: ticketId}},
+			},
+			err: nil,
+		},
+		{
+			name: "fail/invalid departure date range",
+			args: &ticketsDomain.ParamsGetUserTickets{
+				Timestamp:         timestamp,
+				UserId:            userId,
+				DepartureDateFrom: &departureDateTo,
+				DepartureDateTo:   &departureDateFrom,
+				Limit:             20,
+			},
+			want: nil,
+			err:  terr.BadRequest("INVALID_DEPARTURE_DATE_RANGE", "departure date from is after departure date to"),
+		},
+		{
+			name: "fail/user not found",
+			args: &ticketsDomain.ParamsGetUserTickets{
+				Timestamp: timestamp,
+				UserId:    userId,
+				Limit:     20,
+			},
+			userErr: terr.NotFound("user (id 07d87607-1f06-4599-8af5-07229525c106) not found"),
+			want:    nil,
+			err:     terr.NotFound("user (id 07d87607-1f06-4599-8af5-07229525c106) not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			usersStorage := mockTicketsService.NewMockUsersStorage(ctrl)
+			ticketsStorage := mockTicketsService.NewMockTicketsStorage(ctrl)
+			usersStorage.EXPECT().GetUserById(ctx, userId).Return(&usersDomain.User{Id: userId}, tt.userErr)
+
+			// в хранилище передаются отбор, курсор и количество билетов без изменений
+			var gotParams ticketsDomain.ParamsGetUserTickets
+			if tt.ticketsPage != nil {
+				ticketsStorage.EXPECT().
+					GetUserTickets(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, paramsGetUserTickets *ticketsDomain.ParamsGetUserTickets) (*ticketsDomain.TicketsPage, error) {
+						gotParams = *paramsGetUserTickets
+						return tt.ticketsPage, nil
+					})
+			}
+			s := service{ticketsStorage: ticketsStorage, usersStorage: usersStorage}
+
+			// Act
+			got, err := s.GetUserTickets(ctx, tt.args)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, *tt.args, gotParams)
+		})
+	}
+}
+
 func Test_PayForTicket(t *testing.T) {
 
 	// Arrange
@@ -191,3 +314,169 @@ func Test_RegisterTicket(t *testing.T) {
 		})
 	}
 }
+
+func Test_UpdatePassenger(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	passengerId := uuid.MustParse("b8d0b64d-08d8-4f9d-8c5c-cabd44957f16")
+	userId := uuid.MustParse("07d87607-1f06-4599-8af5-07229525c106")
+	otherUserId := uuid.MustParse("c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c")
+	birthDate := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	expiryDate := time.Date(2030, 5, 17, 0, 0, 0, 0, time.UTC)
+	document := ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypePassport, Number: "4011123456", IssuingCountry: "RU", Nationality: "RU", BirthDate: birthDate, ExpiryDate: &expiryDate}
+
+	var tests = []struct {
+		name      string
+		passenger *ticketsDomain.Passenger
+		document  ticketsDomain.IdentityDocument
+		want      uuid.UUID
+		err       error
+	}{
+		{
+			name:      "success",
+			passenger: &ticketsDomain.Passenger{Id: passengerId, User: usersDomain.User{Id: userId}},
+			document:  document,
+			want:      passengerId,
+			err:       nil,
+		},
+		{
+			name:      "fail/passenger not found",
+			passenger: nil,
+			document:  document,
+			want:      uuid.UUID{},
+			err:       terr.NotFound("passenger (id b8d0b64d-08d8-4f9d-8c5c-cabd44957f16) not found"),
+		},
+		{
+			name:      "fail/passenger of another user",
+			passenger: &ticketsDomain.Passenger{Id: passengerId, User: usersDomain.User{Id: otherUserId}},
+			document:  document,
+			want:      uuid.UUID{},
+			err:       terr.BadRequest("INVALID_PASSENGER", "the passenger's user (id c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c) doesn't match the user (id 07d87607-1f06-4599-8af5-07229525c106)"),
+		},
+		{
+			name:      "fail/invalid document",
+			passenger: &ticketsDomain.Passenger{Id: passengerId, User: usersDomain.User{Id: userId}},
+			document:  ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypePassport, Number: "4011123456", IssuingCountry: "RU", Nationality: "RU", BirthDate: birthDate},
+			want:      uuid.UUID{},
+			err:       terr.BadRequest("INVALID_DOCUMENT_EXPIRY_DATE", "passport expiry date is required"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			paramsUpdatePassenger := &ticketsDomain.ParamsUpdatePassenger{
+				Timestamp:     timestamp,
+				PassengerId:   passengerId,
+				UserId:        userId,
+				NamePassenger: "Иванов Иван Иванович",
+				Document:      tt.document,
+			}
+
+			ticketsStorage := mockTicketsService.NewMockTicketsStorage(ctrl)
+			if tt.passenger != nil {
+				ticketsStorage.EXPECT().GetPassengerById(ctx, passengerId).Return(tt.passenger, nil)
+			} else {
+				ticketsStorage.EXPECT().GetPassengerById(ctx, passengerId).Return(nil, tt.err)
+			}
+			if tt.err == nil {
+				ticketsStorage.EXPECT().UpdatePassenger(ctx, paramsUpdatePassenger).Return(tt.want, nil)
+			}
+			s := service{ticketsStorage: ticketsStorage}
+
+			// Act
+			got, err := s.UpdatePassenger(ctx, paramsUpdatePassenger)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, formatIdentityDocument(&tt.document), paramsUpdatePassenger.IdentityDataPassenger)
+		})
+	}
+}
+
+func Test_DeletePassenger(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	passengerId := uuid.MustParse("b8d0b64d-08d8-4f9d-8c5c-cabd44957f16")
+	userId := uuid.MustParse("07d87607-1f06-4599-8af5-07229525c106")
+	otherUserId := uuid.MustParse("c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c")
+
+	var tests = []struct {
+		name               string
+		passenger          *ticketsDomain.Passenger
+		countActiveTickets int
+		storageErr         error
+		want               uuid.UUID
+		err                error
+	}{
+		{
+			name:      "success",
+			passenger: &ticketsDomain.Passenger{Id: passengerId, User: usersDomain.User{Id: userId}},
+			want:      passengerId,
+			err:       nil,
+		},
+		{
+			name:      "fail/passenger of another user",
+			passenger: &ticketsDomain.Passenger{Id: passengerId, User: usersDomain.User{Id: otherUserId}},
+			want:      uuid.UUID{},
+			err:       terr.BadRequest("INVALID_PASSENGER", "the passenger's user (id c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c) doesn't match the user (id 07d87607-1f06-4599-8af5-07229525c106)"),
+		},
+		{
+			name:               "fail/passenger has active tickets",
+			passenger:          &ticketsDomain.Passenger{Id: passengerId, User: usersDomain.User{Id: userId}},
+			countActiveTickets: 2,
+			want:               uuid.UUID{},
+			err:                terr.Conflict("PASSENGER_HAS_ACTIVE_TICKETS", "passenger (id b8d0b64d-08d8-4f9d-8c5c-cabd44957f16) has 2 active tickets"),
+		},
+		{
+			name:       "fail/ticket created concurrently",
+			passenger:  &ticketsDomain.Passenger{Id: passengerId, User: usersDomain.User{Id: userId}},
+			storageErr: terr.Conflict("PASSENGER_HAS_ACTIVE_TICKETS", "passenger (id b8d0b64d-08d8-4f9d-8c5c-cabd44957f16) has active tickets"),
+			want:       uuid.UUID{},
+			err:        terr.Conflict("PASSENGER_HAS_ACTIVE_TICKETS", "passenger (id b8d0b64d-08d8-4f9d-8c5c-cabd44957f16) has active tickets"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			paramsDeletePassenger := &ticketsDomain.ParamsDeletePassenger{
+				Timestamp:   timestamp,
+				PassengerId: passengerId,
+				UserId:      userId,
+			}
+
+			ticketsStorage := mockTicketsService.NewMockTicketsStorage(ctrl)
+			ticketsStorage.EXPECT().GetPassengerById(ctx, passengerId).Return(tt.passenger, nil)
+			ticketsStorage.EXPECT().GetCountPassengerActiveTickets(ctx, passengerId, timestamp).Return(tt.countActiveTickets, nil).AnyTimes()
+			if tt.err == nil || tt.storageErr != nil {
+				ticketsStorage.EXPECT().DeletePassenger(ctx, paramsDeletePassenger).Return(tt.want, tt.storageErr)
+			}
+			s := service{ticketsStorage: ticketsStorage}
+
+			// Act
+			got, err := s.DeletePassenger(ctx, paramsDeletePassenger)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// срок действия кредита на перелет, выпущенного при возврате билета
+const travelCreditValidity = 365 * 24 * time.Hour
+
+// префикс кода кредита на перелет
+const travelCreditCodePrefix = "TC-"
+
+func (s service) GetVoucherByCode(ctx context.Context, code string) (*ticketsDomain.Voucher, error) {
+	return s.ticketsStorage.GetVoucherByCode(ctx, code)
+}
+
+// validateVoucherPayment проверяет, что сертификатом или кредитом можно оплатить переданную сумму
+func validateVoucherPayment(voucherPayment *ticketsDomain.ParamsVoucherPayment, userId uuid.UUID, timestamp time.Time) error {
+
+	voucher := voucherPayment.Voucher
+
+	// сертификаты и кредиты, как и бонусы, списываются в валюте учета
+	if voucherPayment.Amount.Currency != voucher.Balance.Currency {
+		return terr.BadRequest("INVALID_CURRENCY", fmt.Sprintf("voucher %s is paid in %s", voucher.Code, voucher.Balance.Currency))
+	}
+	if !timestamp.Before(voucher.ExpiryTimestamp) {
+		return terr.BadRequest("VOUCHER_EXPIRED", fmt.Sprintf("voucher %s has expired", voucher.Code))
+	}
+	// кредит может использовать только пользователь, которому он выпущен
+	if voucher.UserId != nil && *voucher.UserId != userId {
+		return terr.BadRequest("INVALID_VOUCHER", fmt.Sprintf("voucher %s belongs to another user", voucher.Code))
+	}
+	if voucher.Balance.Amount < voucherPayment.Amount.Amount {
+		return terr.BadRequest("INSUFFICIENT_VOUCHER_BALANCE", fmt.Sprintf("voucher %s doesn't have enough balance", voucher.Code))
+	}
+	return nil
+}
+
+// setVouchersPayForTicket получает и проверяет сертификаты и кредиты, которыми оплачивается билет
+func (s service) setVouchersPayForTicket(ctx context.Context, paramsPayForTicket *ticketsDomain.ParamsPayForTicket) error {
+
+	codes := make(map[string]bool, len(paramsPayForTicket.Vouchers))
+	for i := range paramsPayForTicket.Vouchers {
+
+		voucherPayment := &paramsPayForTicket.Vouchers[i]
+		voucher, err := s.ticketsStorage.GetVoucherByCode(ctx, voucherPayment.Code)
+		if err != nil {
+			return err
+		}
+
+		// один сертификат передается в оплате один раз
+		if codes[strings.ToUpper(voucher.Code)] {
+			return terr.BadRequest("INVALID_VOUCHER", fmt.Sprintf("voucher %s is passed more than once", voucher.Code))
+		}
+		codes[strings.ToUpper(voucher.Code)] = true
+
+		voucherPayment.Voucher = voucher
+		err = validateVoucherPayment(voucherPayment, paramsPayForTicket.UserId, paramsPayForTicket.StatusTimestamp)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getTicketPayments распределяет стоимость билета в валюте учета по способам оплаты:
+// бонусы, сертификаты и кредиты, остаток стоимости оплачивается картой
+func getTicketPayments(paramsPayForTicket *ticketsDomain.ParamsPayForTicket, price money.Money) ([]ticketsDomain.TicketPayment, error) {
+
+	payments := make([]ticketsDomain.TicketPayment, 0, len(paramsPayForTicket.Vouchers)+2)
+	paid := money.New(0, price.Currency)
+
+	if paramsPayForTicket.PaidWithBonuses.Amount > 0 {
+		payments = append(payments, ticketsDomain.TicketPayment{
+			Method:    ticketsDomain.PaymentMethodBonuses,
+			Amount:    paramsPayForTicket.PaidWithBonuses,
+			Timestamp: paramsPayForTicket.StatusTimestamp,
+		})
+		paid = paid.Add(paramsPayForTicket.PaidWithBonuses)
+	}
+
+	for _, voucherPayment := range paramsPayForTicket.Vouchers {
+		voucherId := voucherPayment.Voucher.Id
+		payments = append(payments, ticketsDomain.TicketPayment{
+			Method:      voucherPayment.Voucher.Type,
+			VoucherId:   &voucherId,
+			VoucherCode: voucherPayment.Voucher.Code,
+			Amount:      voucherPayment.Amount,
+			Timestamp:   paramsPayForTicket.StatusTimestamp,
+		})
+		paid = paid.Add(voucherPayment.Amount)
+	}
+
+	if paid.Amount > price.Amount {
+		return nil, terr.BadRequest("INVALID_PAYMENT_AMOUNT", "sum of bonuses and vouchers is more than the ticket price")
+	}
+
+	if paid.Amount < price.Amount {
+		payments = append(payments, ticketsDomain.TicketPayment{
+			Method:    ticketsDomain.PaymentMethodCard,
+			Amount:    price.Sub(paid),
+			Timestamp: paramsPayForTicket.StatusTimestamp,
+		})
+	}
+	return payments, nil
+}
+
+// setRefundTicketPayments распределяет возврат стоимости билета в валюте учета по способам оплаты.
+// оплаченное сертификатами и кредитами возвращается на их остаток, бонусы - в бонусы пользователя,
+// оплаченное картой - в бонусы пользователя или кредитом на перелет, если он запрошен,
+// оплаченное по счету компании - уменьшает следующий счет компании.
+// для билетов, оплаченных до учета способов оплаты, оплатой картой считается стоимость билета за вычетом бонусов
+func setRefundTicketPayments(paramsRefundTicket *ticketsDomain.ParamsRefundTicket, ticket *ticketsDomain.Ticket, price money.Money) {
+
+	paidWithVouchers := money.New(0, price.Currency)
+	paidWithInvoice := money.New(0, price.Currency)
+	for _, payment := range ticket.Payments {
+		if payment.RefundTimestamp != nil {
+			continue
+		}
+		if payment.VoucherId != nil {
+			paidWithVouchers = paidWithVouchers.Add(payment.Amount)
+		} else if payment.Method == ticketsDomain.PaymentMethodInvoice {
+			paidWithInvoice = paidWithInvoice.Add(payment.Amount)
+		}
+	}
+	paidWithCard := price.Sub(ticket.PaidWithBonuses).Sub(paidWithVouchers).Sub(paidWithInvoice)
+
+	paramsRefundTicket.Payments = ticket.Payments
+	paramsRefundTicket.RefundToBonuses = ticket.PaidWithBonuses
+	paramsRefundTicket.TravelCredit = nil
+
+	if paidWithCard.Amount <= 0 {
+		return
+	}
+	if !paramsRefundTicket.RefundAsCredit {
+		paramsRefundTicket.RefundToBonuses = paramsRefundTicket.RefundToBonuses.Add(paidWithCard)
+		return
+	}
+
+	userId := paramsRefundTicket.UserId
+	ticketId := paramsRefundTicket.TicketId
+	paramsRefundTicket.TravelCredit = &ticketsDomain.Voucher{
+		Id:              uuid.New(),
+		Type:            ticketsDomain.VoucherTypeTravelCredit,
+		Code:            newVoucherCode(travelCreditCodePrefix),
+		UserId:          &userId,
+		TicketId:        &ticketId,
+		Amount:          paidWithCard,
+		Balance:         paidWithCard,
+		IssueTimestamp:  paramsRefundTicket.StatusTimestamp,
+		ExpiryTimestamp: paramsRefundTicket.StatusTimestamp.Add(travelCreditValidity),
+	}
+}
+
+// код нового сертификата или кредита: префикс и 12 случайных символов
+func newVoucherCode(prefix string) string {
+	return prefix + strings.ToUpper(strings.ReplaceAll(uuid.New().String(), "-", "")[:12])
+}
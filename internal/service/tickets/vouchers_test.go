@@ -0,0 +1,218 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+func Test_ValidateVoucherPayment(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	userId := uuid.MustParse("fdef87aa-7694-47c6-a5cd-50984326a071")
+	otherUserId := uuid.MustParse("0b8f5a1e-2c3d-4e5f-8a9b-1c2d3e4f5a6b")
+	giftCertificate := &ticketsDomain.Voucher{
+		Type:            ticketsDomain.VoucherTypeGiftCertificate,
+		Code:            "GIFT-5000",
+		Balance:         money.New(500000, money.BaseCurrency),
+		ExpiryTimestamp: timestamp.AddDate(0, 6, 0),
+	}
+	expiredCertificate := &ticketsDomain.Voucher{
+		Type:            ticketsDomain.VoucherTypeGiftCertificate,
+		Code:            "GIFT-OLD",
+		Balance:         money.New(500000, money.BaseCurrency),
+		ExpiryTimestamp: timestamp.AddDate(0, -1, 0),
+	}
+	otherUserCredit := &ticketsDomain.Voucher{
+		Type:            ticketsDomain.VoucherTypeTravelCredit,
+		Code:            "TC-1",
+		UserId:          &otherUserId,
+		Balance:         money.New(500000, money.BaseCurrency),
+		ExpiryTimestamp: timestamp.AddDate(1, 0, 0),
+	}
+
+	var tests = []struct {
+		name string
+		args *ticketsDomain.ParamsVoucherPayment
+		err  error
+	}{
+		{
+			name: "success",
+			args: &ticketsDomain.ParamsVoucherPayment{Amount: money.New(300000, money.BaseCurrency), Voucher: giftCertificate},
+			err:  nil,
+		},
+		{
+			name: "fail/expired",
+			args: &ticketsDomain.ParamsVoucherPayment{Amount: money.New(300000, money.BaseCurrency), Voucher: expiredCertificate},
+			err:  terr.BadRequest("VOUCHER_EXPIRED", "voucher GIFT-OLD has expired"),
+		},
+		{
+			name: "fail/credit of another user",
+			args: &ticketsDomain.ParamsVoucherPayment{Amount: money.New(300000, money.BaseCurrency), Voucher: otherUserCredit},
+			err:  terr.BadRequest("INVALID_VOUCHER", "voucher TC-1 belongs to another user"),
+		},
+		{
+			name: "fail/insufficient balance",
+			args: &ticketsDomain.ParamsVoucherPayment{Amount: money.New(600000, money.BaseCurrency), Voucher: giftCertificate},
+			err:  terr.BadRequest("INSUFFICIENT_VOUCHER_BALANCE", "voucher GIFT-5000 doesn't have enough balance"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := validateVoucherPayment(tt.args, userId, timestamp)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func Test_GetTicketPayments(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	price := money.New(1000000, money.BaseCurrency)
+	voucherId := uuid.MustParse("9a0e3c44-6b1d-4d0c-8f5e-2f9c1b7d3a21")
+	voucher := &ticketsDomain.Voucher{Id: voucherId, Type: ticketsDomain.VoucherTypeGiftCertificate, Code: "GIFT-5000"}
+
+	var tests = []struct {
+		name string
+		args *ticketsDomain.ParamsPayForTicket
+		want []ticketsDomain.TicketPayment
+		err  error
+	}{
+		{
+			name: "card only",
+			args: &ticketsDomain.ParamsPayForTicket{StatusTimestamp: timestamp},
+			want: []ticketsDomain.TicketPayment{
+				{Method: ticketsDomain.PaymentMethodCard, Amount: price, Timestamp: timestamp},
+			},
+		},
+		{
+			name: "bonuses, voucher and card",
+			args: &ticketsDomain.ParamsPayForTicket{
+				StatusTimestamp: timestamp,
+				PaidWithBonuses: money.New(200000, money.BaseCurrency),
+				Vouchers:        []ticketsDomain.ParamsVoucherPayment{{Amount: money.New(500000, money.BaseCurrency), Voucher: voucher}},
+			},
+			want: []ticketsDomain.TicketPayment{
+				{Method: ticketsDomain.PaymentMethodBonuses, Amount: money.New(200000, money.BaseCurrency), Timestamp: timestamp},
+				{Method: ticketsDomain.PaymentMethodGiftCertificate, VoucherId: &voucherId, VoucherCode: "GIFT-5000", Amount: money.New(500000, money.BaseCurrency), Timestamp: timestamp},
+				{Method: ticketsDomain.PaymentMethodCard, Amount: money.New(300000, money.BaseCurrency), Timestamp: timestamp},
+			},
+		},
+		{
+			name: "voucher covers the whole price",
+			args: &ticketsDomain.ParamsPayForTicket{
+				StatusTimestamp: timestamp,
+				Vouchers:        []ticketsDomain.ParamsVoucherPayment{{Amount: price, Voucher: voucher}},
+			},
+			want: []ticketsDomain.TicketPayment{
+				{Method: ticketsDomain.PaymentMethodGiftCertificate, VoucherId: &voucherId, VoucherCode: "GIFT-5000", Amount: price, Timestamp: timestamp},
+			},
+		},
+		{
+			name: "fail/more than the price",
+			args: &ticketsDomain.ParamsPayForTicket{
+				StatusTimestamp: timestamp,
+				PaidWithBonuses: money.New(200000, money.BaseCurrency),
+				Vouchers:        []ticketsDomain.ParamsVoucherPayment{{Amount: price, Voucher: voucher}},
+			},
+			err: terr.BadRequest("INVALID_PAYMENT_AMOUNT", "sum of bonuses and vouchers is more than the ticket price"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := getTicketPayments(tt.args, price)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if tt.err == nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_SetRefundTicketPayments(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	price := money.New(1000000, money.BaseCurrency)
+	voucherId := uuid.MustParse("9a0e3c44-6b1d-4d0c-8f5e-2f9c1b7d3a21")
+	ticket := &ticketsDomain.Ticket{
+		PaidWithBonuses: money.New(200000, money.BaseCurrency),
+		Payments: []ticketsDomain.TicketPayment{
+			{Method: ticketsDomain.PaymentMethodBonuses, Amount: money.New(200000, money.BaseCurrency)},
+			{Method: ticketsDomain.PaymentMethodGiftCertificate, VoucherId: &voucherId, Amount: money.New(500000, money.BaseCurrency)},
+			{Method: ticketsDomain.PaymentMethodCard, Amount: money.New(300000, money.BaseCurrency)},
+		},
+	}
+
+	t.Run("card payment is refunded to bonuses", func(t *testing.T) {
+		paramsRefundTicket := &ticketsDomain.ParamsRefundTicket{StatusTimestamp: timestamp}
+
+		// Act
+		setRefundTicketPayments(paramsRefundTicket, ticket, price)
+
+		// Assert
+		assert.Equal(t, money.New(500000, money.BaseCurrency), paramsRefundTicket.RefundToBonuses)
+		assert.Nil(t, paramsRefundTicket.TravelCredit)
+		assert.Equal(t, ticket.Payments, paramsRefundTicket.Payments)
+	})
+
+	t.Run("card payment is refunded as travel credit", func(t *testing.T) {
+		userId := uuid.MustParse("fdef87aa-7694-47c6-a5cd-50984326a071")
+		paramsRefundTicket := &ticketsDomain.ParamsRefundTicket{StatusTimestamp: timestamp, UserId: userId, RefundAsCredit: true}
+
+		// Act
+		setRefundTicketPayments(paramsRefundTicket, ticket, price)
+
+		// Assert
+		assert.Equal(t, money.New(200000, money.BaseCurrency), paramsRefundTicket.RefundToBonuses)
+		assert.NotNil(t, paramsRefundTicket.TravelCredit)
+		assert.Equal(t, ticketsDomain.VoucherTypeTravelCredit, paramsRefundTicket.TravelCredit.Type)
+		assert.Equal(t, money.New(300000, money.BaseCurrency), paramsRefundTicket.TravelCredit.Balance)
+		assert.Equal(t, &userId, paramsRefundTicket.TravelCredit.UserId)
+		assert.Equal(t, timestamp.Add(travelCreditValidity), paramsRefundTicket.TravelCredit.ExpiryTimestamp)
+	})
+
+	t.Run("ticket paid before payments were recorded", func(t *testing.T) {
+		paramsRefundTicket := &ticketsDomain.ParamsRefundTicket{StatusTimestamp: timestamp}
+
+		// Act
+		setRefundTicketPayments(paramsRefundTicket, &ticketsDomain.Ticket{PaidWithBonuses: money.New(200000, money.BaseCurrency)}, price)
+
+		// Assert
+		assert.Equal(t, price, paramsRefundTicket.RefundToBonuses)
+		assert.Nil(t, paramsRefundTicket.TravelCredit)
+	})
+
+	t.Run("invoice payment isn't refunded to the user", func(t *testing.T) {
+		paramsRefundTicket := &ticketsDomain.ParamsRefundTicket{StatusTimestamp: timestamp, RefundAsCredit: true}
+		companyTicket := &ticketsDomain.Ticket{
+			PaidWithBonuses: money.New(0, money.BaseCurrency),
+			Payments: []ticketsDomain.TicketPayment{
+				{Method: ticketsDomain.PaymentMethodInvoice, Amount: price},
+			},
+		}
+
+		// Act
+		setRefundTicketPayments(paramsRefundTicket, companyTicket, price)
+
+		// Assert
+		assert.Equal(t, money.New(0, money.BaseCurrency), paramsRefundTicket.RefundToBonuses)
+		assert.Nil(t, paramsRefundTicket.TravelCredit)
+	})
+}
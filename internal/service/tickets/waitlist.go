@@ -0,0 +1,270 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	flightsDomain "homework/internal/domain/flights"
+	ticketsDomain "homework/internal/domain/tickets"
+	usersDomain "homework/internal/domain/users"
+	"homework/internal/util/terr"
+)
+
+// срок оплаты билета со статусом 1(Created) от момента создания
+const ticketPaymentWindow = 15 * time.Minute
+
+func (s service) JoinWaitlist(ctx context.Context, paramsJoinWaitlist *ticketsDomain.ParamsJoinWaitlist) (uuid.UUID, error) {
+
+	// проверяем, что по переданному FlightId существует рейс
+	flight, err := s.flightsStorage.GetFlightById(ctx, paramsJoinWaitlist.FlightId)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// проверки рейса:
+	// до вылета осталось больше 2 часов
+	if flight.DepartureDate.Sub(paramsJoinWaitlist.Timestamp).Hours() < 2 {
+		return uuid.UUID{}, terr.BadRequest("FLIGHT_ALREADY_CLOSED", "sale of tickets for the flight is closed")
+	}
+
+	// проверяем, что по переданному UserId существует пользователь
+	_, err = s.usersStorage.GetUserById(ctx, paramsJoinWaitlist.UserId)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// проверяем, что пассажир существует и принадлежит пользователю
+	passenger, err := s.getUserPassenger(ctx, paramsJoinWaitlist.PassengerId, paramsJoinWaitlist.UserId)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// для международного рейса проверяем паспорт пассажира
+	err = validateFlightDocument(passenger.Document, flight)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// билет по листу ожидания оформляется без сопровождающего, поэтому в лист ожидания ставятся только взрослые.
+	// младенцу без места лист ожидания не нужен, ребенок оформляется с сопровождением взрослого
+	if passenger.Document != nil && getPassengerType(passenger.Document.BirthDate, flight.DepartureDate) != ticketsDomain.PassengerTypeAdult {
+		return uuid.UUID{}, terr.BadRequest("WAITLIST_ADULT_ONLY", "only adult passengers can join the waitlist")
+	}
+
+	if paramsJoinWaitlist.CountAdditionalBaggage < 0 {
+		return uuid.UUID{}, terr.BadRequest("INVALID_COUNT_ADDITIONAL_BAGGAGE", "count of additional baggage is a positive number")
+	}
+
+	// проверяем, что на данном рейсе существуют места с заданным классом ClassSeatsId
+	vacantSeats, err := s.flightsStorage.GetFlightVacantSeatsByClassId(ctx, paramsJoinWaitlist.FlightId, paramsJoinWaitlist.ClassSeatsId)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// в лист ожидания можно встать, только если свободных мест данного класса нет
+	if vacantSeats.CountVacantSeats > 0 {
+		return uuid.UUID{}, terr.BadRequest("VACANT_SEATS_AVAILABLE", fmt.Sprintf("there are vacant seats with class seat (id %s)", paramsJoinWaitlist.ClassSeatsId))
+	}
+
+	// пассажир может стоять в листе ожидания рейса только один раз
+	countWaitlistEntries, err := s.ticketsStorage.GetCountPassengerWaitlistEntries(ctx, paramsJoinWaitlist.PassengerId, paramsJoinWaitlist.FlightId)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	if countWaitlistEntries > 0 {
+		return uuid.UUID{}, terr.Conflict("ALREADY_IN_WAITLIST", fmt.Sprintf("passenger (id %s) is already in the waitlist of the flight (id %s)", paramsJoinWaitlist.PassengerId, paramsJoinWaitlist.FlightId))
+	}
+
+	return s.ticketsStorage.CreateWaitlistEntry(ctx, paramsJoinWaitlist)
+}
+
+// обработка листа ожидания, выполняемая фоновым заданием:
+// - по истекшим удержаниям цены отменяются билеты, по истекающим - отправляются напоминания об оплате
+// - отменяются неоплаченные билеты, срок оплаты (для билетов компании - срок согласования) которых истек, и удаляются истекшие удержания мест
+// - закрываются записи листа ожидания рейсов, продажа билетов на которые закрыта
+// - по освободившимся местам оформляются билеты следующим в листе ожидания
+// ошибка одного шага или одной записи выводится в лог и не останавливает обработку остальных,
+// задание возвращает первую ошибку
+func (s service) ProcessWaitlist(ctx context.Context, timestamp time.Time) error {
+
+	var errProcess error
+	logError := func(err error, format string, args ...interface{}) {
+		logProcessWaitlistError(err, format, args...)
+		if errProcess == nil {
+			errProcess = err
+		}
+	}
+
+	// ошибки удержаний цены выводятся в лог при обработке
+	err := s.processPriceHolds(ctx, timestamp)
+	if err != nil {
+		errProcess = err
+	}
+
+	_, err = s.ticketsStorage.CancelUnpaidTickets(ctx, timestamp, timestamp.Add(-ticketPaymentWindow), timestamp.Add(-ticketApprovalWindow))
+	if err != nil {
+		logError(err, "cancel unpaid tickets")
+	}
+
+	_, err = s.flightsStorage.DeleteExpiredSeatHolds(ctx, timestamp)
+	if err != nil {
+		logError(err, "delete expired seat holds")
+	}
+
+	expiredWaitlistEntries, err := s.ticketsStorage.GetExpiredWaitlistEntries(ctx, timestamp)
+	if err != nil {
+		logError(err, "get expired waitlist entries")
+	}
+	for _, waitlistEntry := range expiredWaitlistEntries {
+		err = s.closeWaitlistEntry(ctx, &waitlistEntry, ticketsDomain.WaitlistStatusExpired, timestamp,
+			usersDomain.NotificationTypeWaitlistExpired,
+			fmt.Sprintf("Ticket sales for the flight (id %s) are closed. The waitlist entry has expired.", waitlistEntry.FlightId))
+		if err != nil {
+			logError(err, "close expired waitlist entry (id %s)", waitlistEntry.Id)
+		}
+	}
+
+	waitlistClassesSeats, err := s.ticketsStorage.GetWaitlistClassesSeats(ctx)
+	if err != nil {
+		logError(err, "get waitlist classes seats")
+	}
+	// ошибки оформления по листу ожидания выводятся в лог при обработке класса мест
+	for _, waitlistClassSeats := range waitlistClassesSeats {
+		err = s.processWaitlistClassSeats(ctx, waitlistClassSeats.FlightId, waitlistClassSeats.ClassSeatsId, timestamp)
+		if err != nil && errProcess == nil {
+			errProcess = err
+		}
+	}
+
+	return errProcess
+}
+
+// вывод в лог ошибки обработки листа ожидания
+func logProcessWaitlistError(err error, format string, args ...interface{}) {
+	log.Printf("process waitlist: %s: %v\n", fmt.Sprintf(format, args...), err)
+}
+
+// оформление билетов по листу ожидания класса мест рейса в пределах количества свободных мест.
+// ошибка оформления по одной записи выводится в лог и не останавливает оформление по остальным записям
+func (s service) processWaitlistClassSeats(ctx context.Context, flightId uuid.UUID, classSeatsId uuid.UUID, timestamp time.Time) error {
+
+	flight, err := s.flightsStorage.GetFlightById(ctx, flightId)
+	if err != nil {
+		logProcessWaitlistError(err, "get flight (id %s)", flightId)
+		return err
+	}
+
+	// продажа билетов на рейс закрыта: записи листа ожидания закрываются отдельно
+	if flight.DepartureDate.Sub(timestamp).Hours() < 2 {
+		return nil
+	}
+
+	vacantSeats, err := s.flightsStorage.GetFlightVacantSeatsByClassId(ctx, flightId, classSeatsId)
+	if err != nil {
+		logProcessWaitlistError(err, "get vacant seats of flight (id %s) class seats (id %s)", flightId, classSeatsId)
+		return err
+	}
+	if vacantSeats.CountVacantSeats <= 0 {
+		return nil
+	}
+
+	waitlistEntries, err := s.ticketsStorage.GetWaitlistEntries(ctx, flightId, classSeatsId, vacantSeats.CountVacantSeats)
+	if err != nil {
+		logProcessWaitlistError(err, "get waitlist entries of flight (id %s) class seats (id %s)", flightId, classSeatsId)
+		return err
+	}
+
+	var errProcess error
+	for _, waitlistEntry := range waitlistEntries {
+		err = s.fulfillWaitlistEntry(ctx, &waitlistEntry, flight, timestamp)
+		if err != nil {
+			logProcessWaitlistError(err, "fulfill waitlist entry (id %s)", waitlistEntry.Id)
+			if errProcess == nil {
+				errProcess = err
+			}
+		}
+	}
+
+	return errProcess
+}
+
+// оформление билета со статусом 1(Created) по записи листа ожидания.
+// срок оплаты билета отсчитывается от момента оформления
+func (s service) fulfillWaitlistEntry(ctx context.Context, waitlistEntry *ticketsDomain.WaitlistEntry, flight *flightsDomain.Flight, timestamp time.Time) error {
+
+	// пассажир мог быть удален или его документ мог стать недействительным с момента постановки в лист ожидания
+	passenger, err := s.ticketsStorage.GetPassengerById(ctx, waitlistEntry.PassengerId)
+	if err != nil && !terr.Equal(err, terr.NotFound("")) {
+		return err
+	}
+	errPassenger := err
+	if errPassenger == nil {
+		errPassenger = validateFlightDocument(passenger.Document, flight)
+	}
+	if errPassenger != nil {
+		return s.closeWaitlistEntry(ctx, waitlistEntry, ticketsDomain.WaitlistStatusCanceled, timestamp,
+			usersDomain.NotificationTypeWaitlistCanceled,
+			fmt.Sprintf("A seat on the flight (id %s) became available, but the ticket couldn't be issued: %s", flight.Id, errPassenger.Error()))
+	}
+
+	paramsCreateTicket := &ticketsDomain.ParamsCreateTicket{
+		StatusTimestamp:        timestamp,
+		FlightId:               waitlistEntry.FlightId,
+		UserId:                 waitlistEntry.UserId,
+		PassengerId:            &passenger.Id,
+		PassengerType:          ticketsDomain.PassengerTypeAdult,
+		ClassSeatsId:           waitlistEntry.ClassSeatsId,
+		CountAdditionalBaggage: waitlistEntry.CountAdditionalBaggage,
+	}
+	err = s.convertFlightTaxesFees(ctx, flight, timestamp)
+	if err != nil {
+		return err
+	}
+	setPriceCreateTicket(paramsCreateTicket, flight)
+	err = s.setExchangeRateCreateTicket(ctx, paramsCreateTicket, flight)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.ticketsStorage.CloseWaitlistEntry(ctx, &ticketsDomain.ParamsCloseWaitlistEntry{
+		StatusTimestamp:    timestamp,
+		WaitlistEntryId:    waitlistEntry.Id,
+		Status:             ticketsDomain.WaitlistStatusFulfilled,
+		ParamsCreateTicket: paramsCreateTicket,
+		Notification: usersDomain.Notification{
+			UserId: waitlistEntry.UserId,
+			Type:   usersDomain.NotificationTypeWaitlistTicketCreated,
+			Message: fmt.Sprintf("A seat on the flight (id %s) became available. The ticket is created and has to be paid by %s.",
+				flight.Id, timestamp.Add(ticketPaymentWindow).Format(time.RFC3339)),
+		},
+	})
+	return ignoreWaitlistEntryClosed(err)
+}
+
+// закрытие записи листа ожидания без оформления билета с уведомлением пользователя
+func (s service) closeWaitlistEntry(ctx context.Context, waitlistEntry *ticketsDomain.WaitlistEntry, status string, timestamp time.Time, notificationType string, message string) error {
+
+	_, err := s.ticketsStorage.CloseWaitlistEntry(ctx, &ticketsDomain.ParamsCloseWaitlistEntry{
+		StatusTimestamp: timestamp,
+		WaitlistEntryId: waitlistEntry.Id,
+		Status:          status,
+		Notification: usersDomain.Notification{
+			UserId:  waitlistEntry.UserId,
+			Type:    notificationType,
+			Message: message,
+		},
+	})
+	return ignoreWaitlistEntryClosed(err)
+}
+
+// запись листа ожидания могла быть закрыта параллельной обработкой (например, при возврате билета), это не ошибка
+func ignoreWaitlistEntryClosed(err error) error {
+	if terr.Equal(err, terr.Conflict("WAITLIST_ENTRY_ALREADY_CLOSED", "")) {
+		return nil
+	}
+	return err
+}
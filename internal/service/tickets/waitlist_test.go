@@ -0,0 +1,279 @@
+package tickets
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	flightsDomain "homework/internal/domain/flights"
+	ticketsDomain "homework/internal/domain/tickets"
+	usersDomain "homework/internal/domain/users"
+	mockTicketsService "homework/internal/service/tickets/mock"
+	"homework/internal/util/terr"
+)
+
+func Test_JoinWaitlist(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	waitlistEntryId := uuid.MustParse("5e0b0a6f-8f0a-4b67-9c55-0a4f1d3e2b11")
+	flightId := uuid.MustParse("7d5925a6-2016-4c72-9298-517fc40d936c")
+	userId := uuid.MustParse("07d87607-1f06-4599-8af5-07229525c106")
+	otherUserId := uuid.MustParse("c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c")
+	passengerId := uuid.MustParse("6382589b-ab8e-4519-8c00-d0fe095179b3")
+	classSeatsId := uuid.MustParse("2c4b1c4e-0d7a-4a7c-9a57-5f5c2f1a9b10")
+	adultBirthDate := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	childBirthDate := time.Date(2015, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	flight := &flightsDomain.Flight{Id: flightId, DepartureDate: timestamp.AddDate(0, 0, 7)}
+	newPassenger := func(userId uuid.UUID, birthDate time.Time) *ticketsDomain.Passenger {
+		return &ticketsDomain.Passenger{
+			Id:       passengerId,
+			User:     usersDomain.User{Id: userId},
+			Document: &ticketsDomain.IdentityDocument{Type: ticketsDomain.DocumentTypeNationalId, BirthDate: birthDate},
+		}
+	}
+	noVacantSeats := &flightsDomain.VacantSeats{ClassSeatsId: classSeatsId, CountVacantSeats: 0}
+
+	var tests = []struct {
+		name                   string
+		flight                 *flightsDomain.Flight
+		passenger              *ticketsDomain.Passenger
+		countAdditionalBaggage int
+		vacantSeats            *flightsDomain.VacantSeats
+		countWaitlistEntries   int
+		want                   uuid.UUID
+		err                    error
+	}{
+		{
+			name:        "success",
+			flight:      flight,
+			passenger:   newPassenger(userId, adultBirthDate),
+			vacantSeats: noVacantSeats,
+			want:        waitlistEntryId,
+			err:         nil,
+		},
+		{
+			name:        "success/oversold class",
+			flight:      flight,
+			passenger:   newPassenger(userId, adultBirthDate),
+			vacantSeats: &flightsDomain.VacantSeats{ClassSeatsId: classSeatsId, CountVacantSeats: -2},
+			want:        waitlistEntryId,
+			err:         nil,
+		},
+		{
+			name:   "fail/flight already closed",
+			flight: &flightsDomain.Flight{Id: flightId, DepartureDate: timestamp.Add(time.Hour)},
+			want:   uuid.UUID{},
+			err:    terr.BadRequest("FLIGHT_ALREADY_CLOSED", "sale of tickets for the flight is closed"),
+		},
+		{
+			name:      "fail/passenger of another user",
+			flight:    flight,
+			passenger: newPassenger(otherUserId, adultBirthDate),
+			want:      uuid.UUID{},
+			err:       terr.BadRequest("INVALID_PASSENGER", "the passenger's user (id c8e2a1d4-5b7f-4e3a-9c6d-1f0b2a3e4d5c) doesn't match the user (id 07d87607-1f06-4599-8af5-07229525c106)"),
+		},
+		{
+			name:      "fail/passport required for international flight",
+			flight:    &flightsDomain.Flight{Id: flightId, DepartureDate: timestamp.AddDate(0, 0, 7), IsInternational: true},
+			passenger: newPassenger(userId, adultBirthDate),
+			want:      uuid.UUID{},
+			err:       terr.BadRequest("PASSPORT_REQUIRED", "passport is required for the international flight (id 7d5925a6-2016-4c72-9298-517fc40d936c)"),
+		},
+		{
+			name:      "fail/child passenger",
+			flight:    flight,
+			passenger: newPassenger(userId, childBirthDate),
+			want:      uuid.UUID{},
+			err:       terr.BadRequest("WAITLIST_ADULT_ONLY", "only adult passengers can join the waitlist"),
+		},
+		{
+			name:                   "fail/negative count of additional baggage",
+			flight:                 flight,
+			passenger:              newPassenger(userId, adultBirthDate),
+			countAdditionalBaggage: -1,
+			want:                   uuid.UUID{},
+			err:                    terr.BadRequest("INVALID_COUNT_ADDITIONAL_BAGGAGE", "count of additional baggage is a positive number"),
+		},
+		{
+			name:        "fail/vacant seats available",
+			flight:      flight,
+			passenger:   newPassenger(userId, adultBirthDate),
+			vacantSeats: &flightsDomain.VacantSeats{ClassSeatsId: classSeatsId, CountVacantSeats: 1},
+			want:        uuid.UUID{},
+			err:         terr.BadRequest("VACANT_SEATS_AVAILABLE", "there are vacant seats with class seat (id 2c4b1c4e-0d7a-4a7c-9a57-5f5c2f1a9b10)"),
+		},
+		{
+			name:                 "fail/already in waitlist",
+			flight:               flight,
+			passenger:            newPassenger(userId, adultBirthDate),
+			vacantSeats:          noVacantSeats,
+			countWaitlistEntries: 1,
+			want:                 uuid.UUID{},
+			err:                  terr.Conflict("ALREADY_IN_WAITLIST", "passenger (id 6382589b-ab8e-4519-8c00-d0fe095179b3) is already in the waitlist of the flight (id 7d5925a6-2016-4c72-9298-517fc40d936c)"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			paramsJoinWaitlist := &ticketsDomain.ParamsJoinWaitlist{
+				Timestamp:              timestamp,
+				FlightId:               flightId,
+				UserId:                 userId,
+				PassengerId:            passengerId,
+				ClassSeatsId:           classSeatsId,
+				CountAdditionalBaggage: tt.countAdditionalBaggage,
+			}
+
+			ticketsStorage := mockTicketsService.NewMockTicketsStorage(ctrl)
+			flightsStorage := mockTicketsService.NewMockFlightsStorage(ctrl)
+			usersStorage := mockTicketsService.NewMockUsersStorage(ctrl)
+			flightsStorage.EXPECT().GetFlightById(ctx, flightId).Return(tt.flight, nil)
+			usersStorage.EXPECT().GetUserById(ctx, userId).Return(&usersDomain.User{Id: userId}, nil).AnyTimes()
+			ticketsStorage.EXPECT().GetPassengerById(ctx, passengerId).Return(tt.passenger, nil).AnyTimes()
+			flightsStorage.EXPECT().GetFlightVacantSeatsByClassId(ctx, flightId, classSeatsId).Return(tt.vacantSeats, nil).AnyTimes()
+			ticketsStorage.EXPECT().GetCountPassengerWaitlistEntries(ctx, passengerId, flightId).Return(tt.countWaitlistEntries, nil).AnyTimes()
+			if tt.err == nil {
+				ticketsStorage.EXPECT().CreateWaitlistEntry(ctx, paramsJoinWaitlist).Return(tt.want, nil)
+			}
+			s := service{ticketsStorage: ticketsStorage, flightsStorage: flightsStorage, usersStorage: usersStorage}
+
+			// Act
+			got, err := s.JoinWaitlist(ctx, paramsJoinWaitlist)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_IgnoreWaitlistEntryClosed(t *testing.T) {
+
+	// Arrange
+	errSQLDatabase := terr.SQLDatabaseError(errors.New(""))
+
+	var tests = []struct {
+		name string
+		args error
+		err  error
+	}{
+		{
+			name: "no error",
+			args: nil,
+			err:  nil,
+		},
+		{
+			name: "entry already closed",
+			args: terr.Conflict("WAITLIST_ENTRY_ALREADY_CLOSED", "waitlist entry is already closed"),
+			err:  nil,
+		},
+		{
+			name: "sql database error",
+			args: errSQLDatabase,
+			err:  errSQLDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := ignoreWaitlistEntryClosed(tt.args)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func Test_ProcessWaitlist(t *testing.T) {
+
+	// Arrange
+	timestamp := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	errSQLDatabase := terr.SQLDatabaseError(errors.New("connection reset"))
+	expiredPriceHold := ticketsDomain.PriceHold{
+		Id:              uuid.MustParse("9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"),
+		TicketId:        uuid.MustParse("0e7c1d5a-3b2f-4f7e-8a9c-6d1e2f3a4b5c"),
+		ExpiryTimestamp: timestamp.Add(-time.Minute),
+	}
+	expiringPriceHold := ticketsDomain.PriceHold{
+		Id:              uuid.MustParse("1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e"),
+		TicketId:        uuid.MustParse("2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f"),
+		ExpiryTimestamp: timestamp.Add(time.Hour),
+	}
+	failedFlightId := uuid.MustParse("7d5925a6-2016-4c72-9298-517fc40d936c")
+	closedFlightId := uuid.MustParse("3e4f5a6b-7c8d-4e9f-8a0b-1c2d3e4f5a6b")
+	classSeatsId := uuid.MustParse("2c4b1c4e-0d7a-4a7c-9a57-5f5c2f1a9b10")
+
+	var tests = []struct {
+		name         string
+		errExpire    error
+		errCancel    error
+		errGetFlight error
+		err          error
+	}{
+		{
+			name: "success",
+			err:  nil,
+		},
+		{
+			name:         "fail/errors don't stop processing",
+			errExpire:    errSQLDatabase,
+			errCancel:    terr.SQLDatabaseError(errors.New("timeout")),
+			errGetFlight: terr.NotFound("flight not found"),
+			err:          errSQLDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			ticketsStorage := mockTicketsService.NewMockTicketsStorage(ctrl)
+			flightsStorage := mockTicketsService.NewMockFlightsStorage(ctrl)
+
+			// все шаги выполняются, даже если предыдущие завершились ошибкой
+			ticketsStorage.EXPECT().GetActivePriceHolds(ctx, timestamp.Add(priceHoldReminderLead)).
+				Return([]ticketsDomain.PriceHold{expiredPriceHold, expiringPriceHold}, nil)
+			ticketsStorage.EXPECT().ExpirePriceHold(ctx, gomock.Any()).Return(expiredPriceHold.TicketId, tt.errExpire)
+			ticketsStorage.EXPECT().RemindPriceHold(ctx, gomock.Any()).Return(expiringPriceHold.TicketId, nil)
+			ticketsStorage.EXPECT().CancelUnpaidTickets(ctx, timestamp, timestamp.Add(-ticketPaymentWindow), timestamp.Add(-ticketApprovalWindow)).
+				Return(int64(0), tt.errCancel)
+			flightsStorage.EXPECT().DeleteExpiredSeatHolds(ctx, timestamp).Return(int64(0), nil)
+			ticketsStorage.EXPECT().GetExpiredWaitlistEntries(ctx, timestamp).Return(nil, nil)
+			ticketsStorage.EXPECT().GetWaitlistClassesSeats(ctx).Return([]ticketsDomain.WaitlistClassSeats{
+				{FlightId: failedFlightId, ClassSeatsId: classSeatsId},
+				{FlightId: closedFlightId, ClassSeatsId: classSeatsId},
+			}, nil)
+			flightsStorage.EXPECT().GetFlightById(ctx, failedFlightId).
+				Return(&flightsDomain.Flight{Id: failedFlightId, DepartureDate: timestamp.Add(time.Hour)}, tt.errGetFlight)
+			flightsStorage.EXPECT().GetFlightById(ctx, closedFlightId).
+				Return(&flightsDomain.Flight{Id: closedFlightId, DepartureDate: timestamp.Add(time.Hour)}, nil)
+
+			s := service{ticketsStorage: ticketsStorage, flightsStorage: flightsStorage}
+
+			// Act
+			err := s.ProcessWaitlist(ctx, timestamp)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: homework/internal/service/users (interfaces: UsersStorage)
+
+// Package mock_users is a generated GoMock package.
+package mock_users
+
+import (
+	context "context"
+	users "homework/internal/domain/users"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockUsersStorage is a mock of UsersStorage interface.
+type MockUsersStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsersStorageMockRecorder
+}
+
+// MockUsersStorageMockRecorder is the mock recorder for MockUsersStorage.
+type MockUsersStorageMockRecorder struct {
+	mock *MockUsersStorage
+}
+
+// NewMockUsersStorage creates a new mock instance.
+func NewMockUsersStorage(ctrl *gomock.Controller) *MockUsersStorage {
+	mock := &MockUsersStorage{ctrl: ctrl}
+	mock.recorder = &MockUsersStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsersStorage) EXPECT() *MockUsersStorageMockRecorder {
+	return m.recorder
+}
+
+// CreateUser mocks base method.
+func (m *MockUsersStorage) CreateUser(arg0 context.Context, arg1 *users.ParamsCreateUser) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockUsersStorageMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUsersStorage)(nil).CreateUser), arg0, arg1)
+}
+
+// GetUserById mocks base method.
+func (m *MockUsersStorage) GetUserById(arg0 context.Context, arg1 uuid.UUID) (*users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", arg0, arg1)
+	ret0, _ := ret[0].(*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockUsersStorageMockRecorder) GetUserById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockUsersStorage)(nil).GetUserById), arg0, arg1)
+}
+
+// GetUserByReferralCode mocks base method.
+func (m *MockUsersStorage) GetUserByReferralCode(arg0 context.Context, arg1 string) (*users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByReferralCode", arg0, arg1)
+	ret0, _ := ret[0].(*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByReferralCode indicates an expected call of GetUserByReferralCode.
+func (mr *MockUsersStorageMockRecorder) GetUserByReferralCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByReferralCode", reflect.TypeOf((*MockUsersStorage)(nil).GetUserByReferralCode), arg0, arg1)
+}
+
+// GetUserNotifications mocks base method.
+func (m *MockUsersStorage) GetUserNotifications(arg0 context.Context, arg1 uuid.UUID) ([]users.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserNotifications", arg0, arg1)
+	ret0, _ := ret[0].([]users.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserNotifications indicates an expected call of GetUserNotifications.
+func (mr *MockUsersStorageMockRecorder) GetUserNotifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserNotifications", reflect.TypeOf((*MockUsersStorage)(nil).GetUserNotifications), arg0, arg1)
+}
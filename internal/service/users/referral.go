@@ -0,0 +1,90 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	usersDomain "homework/internal/domain/users"
+	"homework/internal/util/terr"
+)
+
+// длина реферального кода пользователя
+const referralCodeLength = 8
+
+// регистрация пользователя. если передан реферальный код, то пользователь сохраняется как приглашенный
+// владельцем кода. повторные аккаунты (тот же нормализованный адрес электронной почты) и приглашение самого себя запрещены
+func (s service) CreateUser(ctx context.Context, paramsCreateUser *usersDomain.ParamsCreateUser) (uuid.UUID, error) {
+
+	err := validateParamsCreateUser(paramsCreateUser)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	paramsCreateUser.EmailNormalized = usersDomain.NormalizeEmail(paramsCreateUser.Email)
+
+	err = s.setReferrerCreateUser(ctx, paramsCreateUser)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(paramsCreateUser.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return uuid.Nil, terr.BadRequest("INVALID_PASSWORD", err.Error())
+	}
+	paramsCreateUser.PasswordHash = string(passwordHash)
+	paramsCreateUser.UserReferralCode = newReferralCode()
+
+	return s.usersStorage.CreateUser(ctx, paramsCreateUser)
+}
+
+func validateParamsCreateUser(paramsCreateUser *usersDomain.ParamsCreateUser) error {
+
+	if strings.TrimSpace(paramsCreateUser.Name) == "" {
+		return terr.BadRequest("INVALID_NAME", "name is empty")
+	}
+
+	address, err := mail.ParseAddress(paramsCreateUser.Email)
+	if err != nil || address.Address != paramsCreateUser.Email {
+		return terr.BadRequest("INVALID_EMAIL", fmt.Sprintf("invalid email %s", paramsCreateUser.Email))
+	}
+
+	if len(paramsCreateUser.Password) < usersDomain.MinPasswordLength {
+		return terr.BadRequest("INVALID_PASSWORD",
+			fmt.Sprintf("password is shorter than %d characters", usersDomain.MinPasswordLength))
+	}
+	return nil
+}
+
+// заполнение пригласившего пользователя по реферальному коду.
+// код, принадлежащий аккаунту с тем же нормализованным адресом электронной почты, считается приглашением самого себя
+func (s service) setReferrerCreateUser(ctx context.Context, paramsCreateUser *usersDomain.ParamsCreateUser) error {
+
+	if paramsCreateUser.ReferralCode == "" {
+		return nil
+	}
+
+	referrer, err := s.usersStorage.GetUserByReferralCode(ctx, paramsCreateUser.ReferralCode)
+	if err != nil {
+		if terrErr, ok := err.(*terr.Error); ok && terrErr.HTTPStatusCode == http.StatusNotFound {
+			return terr.BadRequest("INVALID_REFERRAL_CODE",
+				fmt.Sprintf("not found referral code %s", paramsCreateUser.ReferralCode))
+		}
+		return err
+	}
+
+	if usersDomain.NormalizeEmail(referrer.Email) == paramsCreateUser.EmailNormalized {
+		return terr.BadRequest("SELF_REFERRAL", "user cannot be referred by own referral code")
+	}
+
+	paramsCreateUser.ReferrerId = &referrer.Id
+	return nil
+}
+
+func newReferralCode() string {
+	return strings.ToUpper(strings.ReplaceAll(uuid.New().String(), "-", "")[:referralCodeLength])
+}
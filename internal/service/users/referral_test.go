@@ -0,0 +1,79 @@
+package users
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	usersDomain "homework/internal/domain/users"
+	"homework/internal/util/terr"
+)
+
+func Test_ValidateParamsCreateUser(t *testing.T) {
+
+	// Arrange
+	var tests = []struct {
+		name string
+		args *usersDomain.ParamsCreateUser
+		err  error
+	}{
+		{
+			name: "success",
+			args: &usersDomain.ParamsCreateUser{
+				Name:     "User 123",
+				Email:    "123@gmail.com",
+				Password: "password123",
+			},
+			err: nil,
+		},
+		{
+			name: "fail/empty name",
+			args: &usersDomain.ParamsCreateUser{
+				Name:     " ",
+				Email:    "123@gmail.com",
+				Password: "password123",
+			},
+			err: terr.BadRequest("INVALID_NAME", "name is empty"),
+		},
+		{
+			name: "fail/invalid email",
+			args: &usersDomain.ParamsCreateUser{
+				Name:     "User 123",
+				Email:    "User 123 <123@gmail.com>",
+				Password: "password123",
+			},
+			err: terr.BadRequest("INVALID_EMAIL", "invalid email User 123 <123@gmail.com>"),
+		},
+		{
+			name: "fail/short password",
+			args: &usersDomain.ParamsCreateUser{
+				Name:     "User 123",
+				Email:    "123@gmail.com",
+				Password: "pass",
+			},
+			err: terr.BadRequest("INVALID_PASSWORD", "password is shorter than 8 characters"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := validateParamsCreateUser(tt.args)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func Test_NewReferralCode(t *testing.T) {
+
+	// Act
+	code := newReferralCode()
+	otherCode := newReferralCode()
+
+	// Assert
+	assert.Len(t, code, referralCodeLength)
+	assert.Regexp(t, "^[0-9A-F]+$", code)
+	assert.NotEqual(t, code, otherCode)
+}
@@ -11,17 +11,33 @@ type service struct {
 }
 
 type UsersService interface {
+	CreateUser(ctx context.Context, paramsCreateUser *usersDomain.ParamsCreateUser) (uuid.UUID, error)
 	GetUserById(ctx context.Context, userId uuid.UUID) (*usersDomain.User, error)
+	GetUserNotifications(ctx context.Context, userId uuid.UUID) ([]usersDomain.Notification, error)
 }
 
 type UsersStorage interface {
+	CreateUser(ctx context.Context, paramsCreateUser *usersDomain.ParamsCreateUser) (uuid.UUID, error)
 	GetUserById(ctx context.Context, userId uuid.UUID) (*usersDomain.User, error)
+	GetUserByReferralCode(ctx context.Context, referralCode string) (*usersDomain.User, error)
+	GetUserNotifications(ctx context.Context, userId uuid.UUID) ([]usersDomain.Notification, error)
 }
 
 func (s service) GetUserById(ctx context.Context, userId uuid.UUID) (*usersDomain.User, error) {
 	return s.usersStorage.GetUserById(ctx, userId)
 }
 
+func (s service) GetUserNotifications(ctx context.Context, userId uuid.UUID) ([]usersDomain.Notification, error) {
+
+	// проверяем, что по переданному UserId существует пользователь
+	_, err := s.usersStorage.GetUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.usersStorage.GetUserNotifications(ctx, userId)
+}
+
 func NewUsersService(usersStorage UsersStorage) UsersService {
 	return &service{usersStorage: usersStorage}
 }
@@ -3,10 +3,12 @@ package users
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 
 	usersDomain "homework/internal/domain/users"
 	mockUsersService "homework/internal/service/users/mock"
@@ -14,6 +16,7 @@ import (
 )
 
 //go:generate mockgen -destination ./mock/users_service_mock.go homework/internal/service/users UsersService
+//go:generate mockgen -destination ./mock/users_storage_mock.go homework/internal/service/users UsersStorage
 
 func Test_GetUserByID(t *testing.T) {
 
@@ -53,11 +56,11 @@ func Test_GetUserByID(t *testing.T) {
 			ctx := context.Background()
 			usersService := mockUsersService.NewMockUsersService(ctrl)
 			usersService.EXPECT().
-				GetUserByID(ctx, tt.args).
+				GetUserById(ctx, tt.args).
 				Return(tt.want, tt.err)
 
 			// Act
-			got, err := usersService.GetUserByID(ctx, tt.args)
+			got, err := usersService.GetUserById(ctx, tt.args)
 
 			// Assert
 			assert.Equal(t, tt.err, err)
@@ -69,3 +72,186 @@ func Test_GetUserByID(t *testing.T) {
 	}
 
 }
+
+func Test_GetUserNotifications(t *testing.T) {
+
+	// Arrange
+	userId := uuid.MustParse("244f9f9a-f730-4860-b5aa-479c19320fa5")
+	ticketId := uuid.MustParse("6382589b-ab8e-4519-8c00-d0fe095179b3")
+	notifications := []usersDomain.Notification{
+		{
+			Id:        uuid.MustParse("5e0b0a6f-8f0a-4b67-9c55-0a4f1d3e2b11"),
+			UserId:    userId,
+			Type:      usersDomain.NotificationTypeWaitlistTicketCreated,
+			Message:   "A seat on the flight became available.",
+			TicketId:  &ticketId,
+			Timestamp: time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	var tests = []struct {
+		name    string
+		userErr error
+		want    []usersDomain.Notification
+		err     error
+	}{
+		{
+			name: "success",
+			want: notifications,
+			err:  nil,
+		},
+		{
+			name:    "fail/user not found",
+			userErr: terr.NotFound("user (id 244f9f9a-f730-4860-b5aa-479c19320fa5) not found"),
+			want:    nil,
+			err:     terr.NotFound("user (id 244f9f9a-f730-4860-b5aa-479c19320fa5) not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			usersStorage := mockUsersService.NewMockUsersStorage(ctrl)
+			if tt.userErr != nil {
+				usersStorage.EXPECT().GetUserById(ctx, userId).Return(nil, tt.userErr)
+			} else {
+				usersStorage.EXPECT().GetUserById(ctx, userId).Return(&usersDomain.User{Id: userId}, nil)
+				usersStorage.EXPECT().GetUserNotifications(ctx, userId).Return(tt.want, nil)
+			}
+			usersService := NewUsersService(usersStorage)
+
+			// Act
+			got, err := usersService.GetUserNotifications(ctx, userId)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_CreateUser(t *testing.T) {
+
+	// Arrange
+	userId := uuid.MustParse("244f9f9a-f730-4860-b5aa-479c19320fa5")
+	referrerId := uuid.MustParse("07d87607-1f06-4599-8af5-07229525c106")
+	referrer := &usersDomain.User{Id: referrerId, Email: "referrer@gmail.com", ReferralCode: "A1B2C3D4"}
+	paramsCreateUser := usersDomain.ParamsCreateUser{
+		Name:         "User 123",
+		Email:        "1.2.3+new@Gmail.com",
+		Password:     "password123",
+		ReferralCode: "A1B2C3D4",
+	}
+
+	var tests = []struct {
+		name           string
+		args           usersDomain.ParamsCreateUser
+		referrer       *usersDomain.User
+		referrerErr    error
+		createErr      error
+		wantReferrerId *uuid.UUID
+		err            error
+	}{
+		{
+			name:           "success/referred user",
+			args:           paramsCreateUser,
+			referrer:       referrer,
+			wantReferrerId: &referrerId,
+			err:            nil,
+		},
+		{
+			name: "success/without referral code",
+			args: usersDomain.ParamsCreateUser{
+				Name:     paramsCreateUser.Name,
+				Email:    paramsCreateUser.Email,
+				Password: paramsCreateUser.Password,
+			},
+			wantReferrerId: nil,
+			err:            nil,
+		},
+		{
+			name: "fail/empty name",
+			args: usersDomain.ParamsCreateUser{Name: " ", Email: paramsCreateUser.Email, Password: paramsCreateUser.Password},
+			err:  terr.BadRequest("INVALID_NAME", "name is empty"),
+		},
+		{
+			name: "fail/invalid email",
+			args: usersDomain.ParamsCreateUser{Name: paramsCreateUser.Name, Email: "User <123@gmail.com>", Password: paramsCreateUser.Password},
+			err:  terr.BadRequest("INVALID_EMAIL", "invalid email User <123@gmail.com>"),
+		},
+		{
+			name: "fail/short password",
+			args: usersDomain.ParamsCreateUser{Name: paramsCreateUser.Name, Email: paramsCreateUser.Email, Password: "pass"},
+			err:  terr.BadRequest("INVALID_PASSWORD", "password is shorter than 8 characters"),
+		},
+		{
+			name:        "fail/invalid referral code",
+			args:        paramsCreateUser,
+			referrerErr: terr.NotFound("not found user with referral code A1B2C3D4"),
+			err:         terr.BadRequest("INVALID_REFERRAL_CODE", "not found referral code A1B2C3D4"),
+		},
+		{
+			name:     "fail/self referral",
+			args:     paramsCreateUser,
+			referrer: &usersDomain.User{Id: referrerId, Email: "123@gmail.com", ReferralCode: "A1B2C3D4"},
+			err:      terr.BadRequest("SELF_REFERRAL", "user cannot be referred by own referral code"),
+		},
+		{
+			name:      "fail/user already exists",
+			args:      paramsCreateUser,
+			referrer:  referrer,
+			createErr: terr.Conflict("USER_ALREADY_EXISTS", "user with email 1.2.3+new@Gmail.com already exists"),
+			err:       terr.Conflict("USER_ALREADY_EXISTS", "user with email 1.2.3+new@Gmail.com already exists"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			args := tt.args
+
+			usersStorage := mockUsersService.NewMockUsersStorage(ctrl)
+			if tt.referrer != nil || tt.referrerErr != nil {
+				usersStorage.EXPECT().GetUserByReferralCode(ctx, args.ReferralCode).Return(tt.referrer, tt.referrerErr)
+			}
+			var gotParams usersDomain.ParamsCreateUser
+			if tt.err == nil || tt.createErr != nil {
+				usersStorage.EXPECT().
+					CreateUser(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, paramsCreateUser *usersDomain.ParamsCreateUser) (uuid.UUID, error) {
+						gotParams = *paramsCreateUser
+						if tt.createErr != nil {
+							return uuid.Nil, tt.createErr
+						}
+						return userId, nil
+					})
+			}
+			usersService := NewUsersService(usersStorage)
+
+			// Act
+			got, err := usersService.CreateUser(ctx, &args)
+
+			// Assert
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, userId, got)
+			assert.Equal(t, "123@gmail.com", gotParams.EmailNormalized)
+			assert.Equal(t, tt.wantReferrerId, gotParams.ReferrerId)
+			assert.Len(t, gotParams.UserReferralCode, referralCodeLength)
+			assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(gotParams.PasswordHash), []byte(tt.args.Password)))
+		})
+	}
+}
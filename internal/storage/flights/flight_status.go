@@ -0,0 +1,66 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/util/terr"
+)
+
+// обновление операционного статуса рейса: статус заменяется целиком.
+// статус отмененного рейса не меняется - условие проверяется повторно при обновлении,
+// поэтому отмена рейса не перезаписывается параллельным обновлением
+func (s storage) UpdateFlightStatus(ctx context.Context, paramsUpdateFlightStatus *flightsDomain.ParamsUpdateFlightStatus) error {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	commandTag, err := conn.Exec(ctx,
+		`INSERT INTO flights_status (
+				flight_id,
+				state,
+				estimated_departure,
+				actual_departure,
+				estimated_arrival,
+				actual_arrival,
+				terminal,
+				gate,
+				boarding_time,
+				updated_timestamp
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			ON CONFLICT (flight_id) DO UPDATE
+				SET state = EXCLUDED.state,
+					estimated_departure = EXCLUDED.estimated_departure,
+					actual_departure = EXCLUDED.actual_departure,
+					estimated_arrival = EXCLUDED.estimated_arrival,
+					actual_arrival = EXCLUDED.actual_arrival,
+					terminal = EXCLUDED.terminal,
+					gate = EXCLUDED.gate,
+					boarding_time = EXCLUDED.boarding_time,
+					updated_timestamp = EXCLUDED.updated_timestamp
+				WHERE flights_status.state <> $11 OR EXCLUDED.state = $11`,
+		paramsUpdateFlightStatus.FlightId,
+		paramsUpdateFlightStatus.State,
+		paramsUpdateFlightStatus.EstimatedDeparture,
+		paramsUpdateFlightStatus.ActualDeparture,
+		paramsUpdateFlightStatus.EstimatedArrival,
+		paramsUpdateFlightStatus.ActualArrival,
+		paramsUpdateFlightStatus.Terminal,
+		paramsUpdateFlightStatus.Gate,
+		paramsUpdateFlightStatus.BoardingTime,
+		paramsUpdateFlightStatus.Timestamp,
+		flightsDomain.FlightStateCancelled,
+	)
+	if err != nil {
+		return terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return terr.Conflict("FLIGHT_ALREADY_CANCELLED", fmt.Sprintf("flight (id %s) is cancelled", paramsUpdateFlightStatus.FlightId))
+	}
+	return nil
+}
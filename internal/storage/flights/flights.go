@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4/pgxpool"
 	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/storage/inventory"
 )
 
 type FlightsStorage interface {
@@ -18,6 +19,18 @@ type FlightsStorage interface {
 	GetFlightById(ctx context.Context, flightId uuid.UUID) (*flightsDomain.Flight, error)
 	GetFlightVacantSeats(ctx context.Context, flightId uuid.UUID) ([]flightsDomain.VacantSeats, error)
 	GetFlightVacantSeatsByClassId(ctx context.Context, flightId uuid.UUID, classSeatsId uuid.UUID) (*flightsDomain.VacantSeats, error)
+	GetSeatsByClassId(ctx context.Context, classSeatsId uuid.UUID) ([]flightsDomain.Seat, error)
+	GetFlightAncillaries(ctx context.Context, flightId uuid.UUID) ([]flightsDomain.FlightAncillary, error)
+	GetFlightAncillaryById(ctx context.Context, flightAncillaryId uuid.UUID) (*flightsDomain.FlightAncillary, error)
+	GetOversoldFlights(ctx context.Context, paramsGetOversoldFlights *flightsDomain.ParamsGetOversoldFlights) ([]flightsDomain.OversoldClassSeats, error)
+	GetSeatHoldById(ctx context.Context, seatHoldId uuid.UUID) (*flightsDomain.SeatHold, error)
+	GetCountUserSeatHolds(ctx context.Context, flightId uuid.UUID, userId uuid.UUID, timestamp time.Time) (int, error)
+	CreateSeatHold(ctx context.Context, paramsCreateSeatHold *flightsDomain.ParamsCreateSeatHold) (*flightsDomain.SeatHold, error)
+	DeleteExpiredSeatHolds(ctx context.Context, timestamp time.Time) (int64, error)
+	ReconcileFlightInventory(ctx context.Context, timestamp time.Time) (int64, error)
+	UpdateFlightStatus(ctx context.Context, paramsUpdateFlightStatus *flightsDomain.ParamsUpdateFlightStatus) error
+	SearchLocations(ctx context.Context, paramsSearchLocations *flightsDomain.ParamsSearchLocations) ([]flightsDomain.Location, error)
+	GetLocationByCode(ctx context.Context, code string) (*flightsDomain.Location, error)
 }
 
 type storage struct {
@@ -37,22 +50,37 @@ func getSqlQueryFlights(sqlQueryCondition string) string {
 					
 					airport_departure.id,
 					airport_departure.name,
+					airport_departure.time_zone,
      		       		city_departure.id,
      		       		city_departure.name,
      		       	
      		       	airport_arrival.id,
-     		       	airport_arrival.name,    		          		       
+     		       	airport_arrival.name,
+     		       	airport_arrival.time_zone,
      		       		city_arrival.id,
      		       		city_arrival.name,  
      		        
 					flight.departure_date,
      		        flight.duration,
      		       
+					flight.currency,
 					flight.price_additional_baggage,
      		        flight.price_seat_selection,
      		        flight.is_international,
      		        flight.baggage_included,
-     		        flight.pet_allowed
+     		        flight.pet_allowed,
+     		        flight.max_infants,
+     		        flight.closed_timestamp,
+
+     		        COALESCE(flight_status.state, 'on_time'),
+     		        flight_status.estimated_departure,
+     		        flight_status.actual_departure,
+     		        flight_status.estimated_arrival,
+     		        flight_status.actual_arrival,
+     		        COALESCE(flight_status.terminal, ''),
+     		        COALESCE(flight_status.gate, ''),
+     		        flight_status.boarding_time,
+     		        flight_status.updated_timestamp
      		FROM flights flight
       			INNER JOIN aircrafts aircraft
      				ON flight.aircraft_id = aircraft.id
@@ -68,6 +96,9 @@ func getSqlQueryFlights(sqlQueryCondition string) string {
      				ON flight.arrival_airport_id = airport_arrival.id
      				INNER JOIN cities city_arrival
      					ON airport_arrival.city_id = city_arrival.id
+
+    			LEFT JOIN flights_status flight_status
+    				ON flight.id = flight_status.flight_id
 			WHERE ` + sqlQueryCondition
 }
 
@@ -93,22 +124,37 @@ func scanFlight(row pgx.Row) (flightsDomain.Flight, error) {
 
 		&airportDeparture.Id,
 		&airportDeparture.Name,
+		&airportDeparture.TimeZone,
 		&cityDeparture.Id,
 		&cityDeparture.Name,
 
 		&airportArrival.Id,
 		&airportArrival.Name,
+		&airportArrival.TimeZone,
 		&cityArrival.Id,
 		&cityArrival.Name,
 
 		&flight.DepartureDate,
 		&durationMin,
 
-		&flight.PriceAdditionalBaggage,
-		&flight.PriceSeatSelection,
+		&flight.Currency,
+		&flight.PriceAdditionalBaggage.Amount,
+		&flight.PriceSeatSelection.Amount,
 		&flight.IsInternational,
 		&flight.BaggageIncluded,
 		&flight.PetAllowed,
+		&flight.MaxInfants,
+		&flight.ClosedTimestamp,
+
+		&flight.Status.State,
+		&flight.Status.EstimatedDeparture,
+		&flight.Status.ActualDeparture,
+		&flight.Status.EstimatedArrival,
+		&flight.Status.ActualArrival,
+		&flight.Status.Terminal,
+		&flight.Status.Gate,
+		&flight.Status.BoardingTime,
+		&flight.Status.UpdatedTimestamp,
 	)
 
 	if err != nil {
@@ -123,6 +169,8 @@ func scanFlight(row pgx.Row) (flightsDomain.Flight, error) {
 	flight.ArrivalAirport = airportArrival
 
 	flight.Duration = time.Duration(durationMin) * time.Minute
+	flight.PriceAdditionalBaggage.Currency = flight.Currency
+	flight.PriceSeatSelection.Currency = flight.Currency
 	return flight, nil
 }
 
@@ -138,7 +186,13 @@ func (s storage) getFlightPrices(ctx context.Context, SqlQueryCondition string,
 		`WITH selected_flights AS (SELECT 
 				flights_prices.flight_id flight_id,
 				flights_prices.class_seats_id class_seats_id,   			
-				flights_prices.price_ticket price_ticket
+				flights_prices.price_ticket price_ticket,
+				flight.currency currency,
+				flights_prices.child_discount_percent child_discount_percent,
+				flights_prices.infant_discount_percent infant_discount_percent,
+				flights_prices.overbooking_percent overbooking_percent,
+				flights_prices.price_hold_max_hours price_hold_max_hours,
+				flights_prices.price_hold_fee price_hold_fee
 			FROM flights_prices
       			INNER JOIN flights flight
      				ON flights_prices.flight_id = flight.id
@@ -160,9 +214,15 @@ func (s storage) getFlightPrices(ctx context.Context, SqlQueryCondition string,
    		       		airline.id,
    		       		airline.name,
     				selected_flights.price_ticket,
-					class_seats.count_seats - CASE
-							WHEN busy_class_seats.count_busy IS NOT NULL
-								THEN busy_class_seats.count_busy
+    				selected_flights.currency,
+    				selected_flights.child_discount_percent,
+    				selected_flights.infant_discount_percent,
+    				selected_flights.overbooking_percent,
+    				selected_flights.price_hold_max_hours,
+    				selected_flights.price_hold_fee,
+					class_seats.count_seats + class_seats.count_seats * selected_flights.overbooking_percent / 100 - CASE
+							WHEN inventory.flight_id IS NOT NULL
+								THEN inventory.count_sold + inventory.count_held
 							ELSE 0
 						END AS count_vacant
 					
@@ -174,20 +234,9 @@ func (s storage) getFlightPrices(ctx context.Context, SqlQueryCondition string,
 						INNER JOIN airlines airline
 							ON aircraft.airline_id = airline.id
 
-        		LEFT JOIN (SELECT
-        		                tickets.flight_id,
-        		                tickets.class_seats_id,
-        		                SUM(1) AS count_busy
-        		            FROM tickets
-        		            	INNER JOIN selected_flights
-									ON selected_flights.flight_id = tickets.flight_id
-       								AND selected_flights.class_seats_id = tickets.class_seats_id
-          		            WHERE tickets.status_id <> 3 and tickets.status_id <> 4
-       		            	GROUP BY
-        		                tickets.flight_id,
-        		                tickets.class_seats_id) busy_class_seats
- 	   				ON selected_flights.flight_id = busy_class_seats.flight_id
-	   					AND selected_flights.class_seats_id = busy_class_seats.class_seats_id`,
+        		LEFT JOIN flight_inventory inventory
+ 	   				ON selected_flights.flight_id = inventory.flight_id
+	   					AND selected_flights.class_seats_id = inventory.class_seats_id`,
 		paramsQuery...)
 	if err != nil {
 		return nil, terr.SQLDatabaseError(err)
@@ -215,7 +264,13 @@ func (s storage) getFlightPrices(ctx context.Context, SqlQueryCondition string,
 			&aircraft.Name,
 			&airline.Id,
 			&airline.Name,
-			&flightPrice.PriceTicket,
+			&flightPrice.PriceTicket.Amount,
+			&flightPrice.PriceTicket.Currency,
+			&flightPrice.ChildDiscountPercent,
+			&flightPrice.InfantDiscountPercent,
+			&flightPrice.OverbookingPercent,
+			&flightPrice.PriceHoldMaxHours,
+			&flightPrice.PriceHoldFee.Amount,
 			&flightPrice.CountVacantSeats,
 		)
 
@@ -226,6 +281,7 @@ func (s storage) getFlightPrices(ctx context.Context, SqlQueryCondition string,
 		aircraft.Airline = airline
 		classSeats.Aircraft = aircraft
 		flightPrice.ClassSeats = classSeats
+		flightPrice.PriceHoldFee.Currency = flightPrice.PriceTicket.Currency
 
 		flightPrices := mapFlightsPrices[flightId]
 		flightPrices = append(flightPrices, flightPrice)
@@ -234,6 +290,69 @@ func (s storage) getFlightPrices(ctx context.Context, SqlQueryCondition string,
 	return mapFlightsPrices, nil
 }
 
+// получение сборов рейсов в разрезе id рейсов.
+// сбор применяется к рейсу, если совпадают все заполненные условия сбора: аэропорт вылета, аэропорт прилета, страна вылета.
+// суммы сборов - в валюте сбора
+
+func (s storage) getFlightTaxesFees(ctx context.Context, SqlQueryCondition string, paramsQuery []interface{}) (map[uuid.UUID][]flightsDomain.FlightTaxFee, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx,
+		`SELECT 	flight.id,
+					tax_fee.id,
+					tax_fee.fee_type,
+					tax_fee.name,
+					tax_fee.amount,
+					tax_fee.currency
+			FROM flights flight
+				INNER JOIN airports airport_departure
+					ON flight.departure_airport_id = airport_departure.id
+					INNER JOIN cities city_departure
+						ON airport_departure.city_id = city_departure.id
+				INNER JOIN airports airport_arrival
+					ON flight.arrival_airport_id = airport_arrival.id
+				INNER JOIN taxes_fees tax_fee
+					ON (tax_fee.departure_airport_id IS NULL OR tax_fee.departure_airport_id = flight.departure_airport_id)
+						AND (tax_fee.arrival_airport_id IS NULL OR tax_fee.arrival_airport_id = flight.arrival_airport_id)
+						AND (tax_fee.country_code IS NULL OR tax_fee.country_code = city_departure.country_code)
+			WHERE `+SqlQueryCondition+`
+			ORDER BY tax_fee.fee_type, tax_fee.name`,
+		paramsQuery...)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	mapFlightsTaxesFees := make(map[uuid.UUID][]flightsDomain.FlightTaxFee)
+	for rows.Next() {
+
+		var flightId uuid.UUID
+		var taxFee flightsDomain.FlightTaxFee
+
+		err = rows.Scan(
+			&flightId,
+			&taxFee.Id,
+			&taxFee.Type,
+			&taxFee.Name,
+			&taxFee.Price.Amount,
+			&taxFee.Price.Currency,
+		)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+
+		flightTaxesFees := mapFlightsTaxesFees[flightId]
+		flightTaxesFees = append(flightTaxesFees, taxFee)
+		mapFlightsTaxesFees[flightId] = flightTaxesFees
+	}
+	return mapFlightsTaxesFees, nil
+}
+
 func (s storage) GetCityById(ctx context.Context, cityId uuid.UUID) (*flightsDomain.City, error) {
 
 	conn, err := s.db.Acquire(ctx)
@@ -278,18 +397,34 @@ func (s storage) GetFlights(ctx context.Context, paramsGetFlights *flightsDomain
 	paramsQuery := []interface{}{
 		paramsGetFlights.DepartureCityId.String(),
 		paramsGetFlights.ArrivalCityId.String(),
-		paramsGetFlights.DepartureDate,
+		paramsGetFlights.DepartureDate.Format("2006-01-02"),
 	}
 
+	// дата вылета сравнивается с местной датой вылета в часовом поясе аэропорта вылета
 	sqlQueryCondition := `airport_departure.city_id = $1 
 							AND airport_arrival.city_id = $2
-							AND flight.departure_date::date = $3`
+							AND (flight.departure_date AT TIME ZONE airport_departure.time_zone)::date = $3::date`
+
+	// отбор по аэропортам, если вместо города передан код аэропорта
+	if paramsGetFlights.DepartureAirportId != nil {
+		paramsQuery = append(paramsQuery, paramsGetFlights.DepartureAirportId.String())
+		sqlQueryCondition += fmt.Sprintf(" AND flight.departure_airport_id = $%d", len(paramsQuery))
+	}
+	if paramsGetFlights.ArrivalAirportId != nil {
+		paramsQuery = append(paramsQuery, paramsGetFlights.ArrivalAirportId.String())
+		sqlQueryCondition += fmt.Sprintf(" AND flight.arrival_airport_id = $%d", len(paramsQuery))
+	}
 
 	mapFlightsPrices, err := s.getFlightPrices(ctx, sqlQueryCondition, paramsQuery)
 	if err != nil {
 		return nil, err
 	}
 
+	mapFlightsTaxesFees, err := s.getFlightTaxesFees(ctx, sqlQueryCondition, paramsQuery)
+	if err != nil {
+		return nil, err
+	}
+
 	sqlQuery := getSqlQueryFlights(sqlQueryCondition)
 	rows, err := conn.Query(ctx, sqlQuery, paramsQuery...)
 	if err != nil {
@@ -305,6 +440,7 @@ func (s storage) GetFlights(ctx context.Context, paramsGetFlights *flightsDomain
 			return nil, terr.SQLDatabaseError(err)
 		}
 		flight.PricesTickets = mapFlightsPrices[flight.Id]
+		flight.TaxesFees = mapFlightsTaxesFees[flight.Id]
 
 		flights = append(flights, flight)
 	}
@@ -342,44 +478,52 @@ func (s storage) GetFlightById(ctx context.Context, flightId uuid.UUID) (*flight
 	}
 	flight.PricesTickets = mapFlightsPrices[flight.Id]
 
+	mapFlightsTaxesFees, err := s.getFlightTaxesFees(ctx, sqlQueryCondition, paramsQuery)
+	if err != nil {
+		return nil, err
+	}
+	flight.TaxesFees = mapFlightsTaxesFees[flight.Id]
+
 	return &flight, err
 }
 
-// получение свободных мест рейса в разрезе классов
+// получение свободных мест рейса в разрезе классов.
+// количество занятых мест берется из остатков мест рейса (flight_inventory): проданные и удержанные места.
+// младенцы без отдельного места (passenger_type = 'infant') места не занимают,
+// удержанные места считаются занятыми до удаления истекших удержаний (DeleteExpiredSeatHolds).
+// количество свободных мест считается с учетом овербукинга класса мест рейса (flights_prices.overbooking_percent),
+// список свободных мест - только по физическим местам
 
 func getSqlQueryVacantSeats(sqlQueryCondition string) string {
 	return `WITH selected_classes_seats AS (SELECT 
 				flight.id flight_id,
 				class_seats.id class_seats_id,   			
 				class_seats.name class_seats_name,   			
-				class_seats.count_seats class_seats_count   			
+				class_seats.count_seats class_seats_count,
+				class_seats.count_seats + class_seats.count_seats * CASE
+						WHEN flights_prices.overbooking_percent IS NOT NULL
+							THEN flights_prices.overbooking_percent
+						ELSE 0
+					END / 100 class_seats_count_sale
         	FROM classes_seats class_seats      	    
         	    INNER JOIN flights flight
        				ON class_seats.aircraft_id = flight.aircraft_id
+				LEFT JOIN flights_prices
+					ON flights_prices.flight_id = flight.id
+					AND flights_prices.class_seats_id = class_seats.id
 			WHERE ` + sqlQueryCondition + `)
 			SELECT 
      		    selected_classes_seats.class_seats_id,
      		    selected_classes_seats.class_seats_name,
-				selected_classes_seats.class_seats_count - CASE
-						WHEN busy_class_seats.count_busy IS NOT NULL
-							THEN busy_class_seats.count_busy
+				selected_classes_seats.class_seats_count_sale - CASE
+						WHEN inventory.flight_id IS NOT NULL
+							THEN inventory.count_sold + inventory.count_held
 						ELSE 0
 					END AS count_vacant
         	FROM selected_classes_seats
-        		LEFT JOIN (SELECT
-        		                ticket.flight_id,
-        		                ticket.class_seats_id,
-        		                SUM(1) AS count_busy
-        		            FROM tickets ticket
-        	    				INNER JOIN selected_classes_seats
-       								ON ticket.flight_id = selected_classes_seats.flight_id
-       								AND ticket.class_seats_id = selected_classes_seats.class_seats_id
-         		            WHERE ticket.status_id <> 3 and ticket.status_id <> 4
-       		            	GROUP BY
-        		                ticket.flight_id,
-        		                ticket.class_seats_id) busy_class_seats
- 	   				ON selected_classes_seats.flight_id = busy_class_seats.flight_id
-	   					AND selected_classes_seats.class_seats_id = busy_class_seats.class_seats_id`
+        		LEFT JOIN flight_inventory inventory
+ 	   				ON selected_classes_seats.flight_id = inventory.flight_id
+	   					AND selected_classes_seats.class_seats_id = inventory.class_seats_id`
 }
 
 func scanVacantSeats(row pgx.Row) (flightsDomain.VacantSeats, error) {
@@ -408,6 +552,7 @@ func (s storage) getMapVacantSeats(ctx context.Context, SqlQueryCondition string
 		`SELECT 	
 					seat.id,
 					seat.number,	
+					seat.is_exit_row,
      				seat.class_seats_id
 				FROM seats seat
 					INNER JOIN classes_seats class_seats
@@ -417,7 +562,11 @@ func (s storage) getMapVacantSeats(ctx context.Context, SqlQueryCondition string
 					LEFT JOIN tickets ticket
 						ON ticket.flight_id = flight.id
 						AND ticket.seat_id = seat.id
-			WHERE ticket.id IS NULL AND `+SqlQueryCondition,
+					LEFT JOIN seat_holds hold
+						ON hold.flight_id = flight.id
+						AND hold.seat_id = seat.id
+						AND hold.expires_at > now()
+			WHERE ticket.id IS NULL AND hold.id IS NULL AND `+SqlQueryCondition,
 		paramsQuery...)
 
 	if err != nil {
@@ -434,6 +583,7 @@ func (s storage) getMapVacantSeats(ctx context.Context, SqlQueryCondition string
 		err = rows.Scan(
 			&seat.Id,
 			&seat.Number,
+			&seat.IsExitRow,
 			&classSeatsId,
 		)
 
@@ -521,6 +671,455 @@ func (s storage) GetFlightVacantSeatsByClassId(ctx context.Context, flightId uui
 	return &vacantSeats, err
 }
 
+// получение всех мест класса мест (схема салона для назначения мест при регистрации)
+func (s storage) GetSeatsByClassId(ctx context.Context, classSeatsId uuid.UUID) ([]flightsDomain.Seat, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx,
+		`SELECT seat.id,
+				seat.number,
+				seat.is_exit_row
+			FROM seats seat
+			WHERE seat.class_seats_id = $1`,
+		classSeatsId.String())
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	var seats []flightsDomain.Seat
+	for rows.Next() {
+
+		var seat flightsDomain.Seat
+		err = rows.Scan(
+			&seat.Id,
+			&seat.Number,
+			&seat.IsExitRow,
+		)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		seats = append(seats, seat)
+	}
+	return seats, nil
+}
+
+// получение дополнительных услуг рейса.
+// количество проданных услуг считается по действующим (не отмененным и не возвращенным) билетам
+
+func getSqlQueryFlightAncillaries(sqlQueryCondition string) string {
+	return `SELECT 	ancillary.id,
+					ancillary.flight_id,
+					ancillary.ancillary_type,
+					ancillary.name,
+					ancillary.price,
+					flight.currency,
+					ancillary.max_count,
+					CASE
+						WHEN sold_ancillaries.count_sold IS NOT NULL
+							THEN sold_ancillaries.count_sold
+						ELSE 0
+					END AS count_sold
+			FROM flights_ancillaries ancillary
+				INNER JOIN flights flight
+					ON ancillary.flight_id = flight.id
+				LEFT JOIN (SELECT
+								item.flight_ancillary_id,
+								SUM(item.quantity) AS count_sold
+							FROM tickets_items item
+								INNER JOIN tickets ticket
+									ON item.ticket_id = ticket.id
+							WHERE item.flight_ancillary_id IS NOT NULL
+								AND ticket.status_id <> 3 AND ticket.status_id <> 4
+							GROUP BY
+								item.flight_ancillary_id) sold_ancillaries
+					ON ancillary.id = sold_ancillaries.flight_ancillary_id
+			WHERE ` + sqlQueryCondition
+}
+
+func scanFlightAncillary(row pgx.Row) (flightsDomain.FlightAncillary, error) {
+
+	var flightAncillary flightsDomain.FlightAncillary
+	err := row.Scan(
+		&flightAncillary.Id,
+		&flightAncillary.FlightId,
+		&flightAncillary.Type,
+		&flightAncillary.Name,
+		&flightAncillary.Price.Amount,
+		&flightAncillary.Price.Currency,
+		&flightAncillary.MaxCount,
+		&flightAncillary.CountSold,
+	)
+	if err != nil {
+		return flightAncillary, err
+	}
+	return flightAncillary, nil
+}
+
+func (s storage) GetFlightAncillaries(ctx context.Context, flightId uuid.UUID) ([]flightsDomain.FlightAncillary, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	sqlQuery := getSqlQueryFlightAncillaries("ancillary.flight_id = $1 ORDER BY ancillary.ancillary_type, ancillary.name")
+	rows, err := conn.Query(ctx, sqlQuery, flightId.String())
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	var flightAncillaries []flightsDomain.FlightAncillary
+	for rows.Next() {
+
+		flightAncillary, err := scanFlightAncillary(rows)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		flightAncillaries = append(flightAncillaries, flightAncillary)
+	}
+	return flightAncillaries, nil
+}
+
+func (s storage) GetFlightAncillaryById(ctx context.Context, flightAncillaryId uuid.UUID) (*flightsDomain.FlightAncillary, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	sqlQuery := getSqlQueryFlightAncillaries("ancillary.id = $1")
+	row := conn.QueryRow(ctx, sqlQuery, flightAncillaryId.String())
+
+	flightAncillary, err := scanFlightAncillary(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, terr.NotFound(fmt.Sprintf("not found flight ancillary (id %s)", flightAncillaryId))
+		} else {
+			return nil, terr.SQLDatabaseError(err)
+		}
+	}
+	return &flightAncillary, nil
+}
+
+// получение классов мест предстоящих рейсов, на которые продано билетов больше, чем мест в классе.
+// количество проданных билетов (кроме младенцев без отдельного места) берется из остатков мест рейса
+func (s storage) GetOversoldFlights(ctx context.Context, paramsGetOversoldFlights *flightsDomain.ParamsGetOversoldFlights) ([]flightsDomain.OversoldClassSeats, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	paramsQuery := []interface{}{
+		paramsGetOversoldFlights.Timestamp,
+	}
+
+	sqlQueryCondition := "flight.departure_date > $1"
+	if paramsGetOversoldFlights.DepartureDateFrom != nil {
+		paramsQuery = append(paramsQuery, *paramsGetOversoldFlights.DepartureDateFrom)
+		sqlQueryCondition += fmt.Sprintf(" AND flight.departure_date::date >= $%d", len(paramsQuery))
+	}
+	if paramsGetOversoldFlights.DepartureDateTo != nil {
+		paramsQuery = append(paramsQuery, *paramsGetOversoldFlights.DepartureDateTo)
+		sqlQueryCondition += fmt.Sprintf(" AND flight.departure_date::date <= $%d", len(paramsQuery))
+	}
+
+	rows, err := conn.Query(ctx,
+		`SELECT 	flight.id,
+					flight.name,
+					flight.departure_date,
+					class_seats.id,
+					class_seats.name,
+					class_seats.count_seats,
+					CASE
+						WHEN flights_prices.overbooking_percent IS NOT NULL
+							THEN flights_prices.overbooking_percent
+						ELSE 0
+					END AS overbooking_percent,
+					inventory.count_sold
+			FROM flight_inventory inventory
+				INNER JOIN flights flight
+					ON inventory.flight_id = flight.id
+				INNER JOIN classes_seats class_seats
+					ON inventory.class_seats_id = class_seats.id
+				LEFT JOIN flights_prices
+					ON flights_prices.flight_id = flight.id
+					AND flights_prices.class_seats_id = class_seats.id
+			WHERE inventory.count_sold > class_seats.count_seats
+				AND `+sqlQueryCondition+`
+			ORDER BY flight.departure_date, flight.name, class_seats.name`,
+		paramsQuery...)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	var arrOversoldClassSeats []flightsDomain.OversoldClassSeats
+	for rows.Next() {
+
+		var oversoldClassSeats flightsDomain.OversoldClassSeats
+		err = rows.Scan(
+			&oversoldClassSeats.FlightId,
+			&oversoldClassSeats.FlightName,
+			&oversoldClassSeats.DepartureDate,
+			&oversoldClassSeats.ClassSeatsId,
+			&oversoldClassSeats.ClassSeatsName,
+			&oversoldClassSeats.CountSeats,
+			&oversoldClassSeats.OverbookingPercent,
+			&oversoldClassSeats.CountTickets,
+		)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		oversoldClassSeats.CountOversold = oversoldClassSeats.CountTickets - oversoldClassSeats.CountSeats
+
+		arrOversoldClassSeats = append(arrOversoldClassSeats, oversoldClassSeats)
+	}
+	return arrOversoldClassSeats, nil
+}
+
+// удержания мест рейсов
+
+func (s storage) GetSeatHoldById(ctx context.Context, seatHoldId uuid.UUID) (*flightsDomain.SeatHold, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	row := conn.QueryRow(ctx,
+		`SELECT id,
+				flight_id,
+				class_seats_id,
+				seat_id,
+				user_id,
+				hold_timestamp,
+				expires_at
+			FROM seat_holds
+			WHERE id = $1`,
+		seatHoldId.String())
+
+	var seatHold flightsDomain.SeatHold
+	err = row.Scan(
+		&seatHold.Id,
+		&seatHold.FlightId,
+		&seatHold.ClassSeatsId,
+		&seatHold.SeatId,
+		&seatHold.UserId,
+		&seatHold.Timestamp,
+		&seatHold.ExpiresAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, terr.NotFound(fmt.Sprintf("not found seat hold (id %s)", seatHoldId))
+		} else {
+			return nil, terr.SQLDatabaseError(err)
+		}
+	}
+	return &seatHold, nil
+}
+
+// количество действующих удержаний мест пользователя на рейсе
+func (s storage) GetCountUserSeatHolds(ctx context.Context, flightId uuid.UUID, userId uuid.UUID, timestamp time.Time) (int, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	var countSeatHolds int
+	err = conn.QueryRow(ctx,
+		`SELECT COUNT(*)
+			FROM seat_holds
+			WHERE flight_id = $1
+				AND user_id = $2
+				AND expires_at > $3`,
+		flightId.String(),
+		userId.String(),
+		timestamp).Scan(&countSeatHolds)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+	return countSeatHolds, nil
+}
+
+func (s storage) CreateSeatHold(ctx context.Context, paramsCreateSeatHold *flightsDomain.ParamsCreateSeatHold) (*flightsDomain.SeatHold, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	// начало транзакции
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Блокировка места (seats) до конца транзакции: параллельное удержание того же места
+	// ожидает завершения этой транзакции и затем видит добавленное удержание
+	if paramsCreateSeatHold.SeatId != nil {
+		_, err = tx.Exec(ctx, `SELECT id FROM seats WHERE id = $1 FOR UPDATE`, paramsCreateSeatHold.SeatId.String())
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+	}
+
+	// 2. Добавление удержания места (seat_holds).
+	// Место добавляется в удержание, только если оно не занято билетом и не удержано другим действующим удержанием
+	seatHoldId := uuid.New()
+	commandTag, err := tx.Exec(ctx,
+		`INSERT INTO seat_holds (
+						id,
+						flight_id,
+						class_seats_id,
+						seat_id,
+						user_id,
+						hold_timestamp,
+						expires_at
+					)
+					SELECT $1, $2, $3, $4, $5, $6, $7
+					WHERE $4::uuid IS NULL
+						OR (NOT EXISTS (SELECT 1
+											FROM seat_holds hold
+											WHERE hold.flight_id = $2
+												AND hold.seat_id = $4
+												AND hold.expires_at > $6)
+							AND NOT EXISTS (SELECT 1
+											FROM tickets ticket
+											WHERE ticket.flight_id = $2
+												AND ticket.seat_id = $4))`,
+		seatHoldId.String(),
+		paramsCreateSeatHold.FlightId.String(),
+		paramsCreateSeatHold.ClassSeatsId.String(),
+		paramsCreateSeatHold.SeatId,
+		paramsCreateSeatHold.UserId.String(),
+		paramsCreateSeatHold.Timestamp,
+		paramsCreateSeatHold.ExpiresAt)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return nil, terr.Conflict("SEAT_ALREADY_HELD", fmt.Sprintf("seat (id %s) is already held or taken", paramsCreateSeatHold.SeatId))
+	}
+
+	// 3. Увеличение количества удержанных мест в остатках мест рейса (flight_inventory).
+	// Если свободных мест класса не осталось (их заняли параллельно), то удержание не создается
+	err = inventory.AddCounts(ctx, tx, paramsCreateSeatHold.FlightId, paramsCreateSeatHold.ClassSeatsId, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	// подтверждение транзакции
+	if err = tx.Commit(ctx); err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+
+	return &flightsDomain.SeatHold{
+		Id:           seatHoldId,
+		FlightId:     paramsCreateSeatHold.FlightId,
+		ClassSeatsId: paramsCreateSeatHold.ClassSeatsId,
+		SeatId:       paramsCreateSeatHold.SeatId,
+		UserId:       paramsCreateSeatHold.UserId,
+		Timestamp:    paramsCreateSeatHold.Timestamp,
+		ExpiresAt:    paramsCreateSeatHold.ExpiresAt,
+	}, nil
+}
+
+// удаление истекших удержаний мест.
+// удержанные места освобождаются в остатках мест рейса в том же запросе, что и удаление удержаний
+func (s storage) DeleteExpiredSeatHolds(ctx context.Context, timestamp time.Time) (int64, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	var countDeleted int64
+	err = conn.QueryRow(ctx,
+		`WITH deleted_holds AS (DELETE FROM seat_holds
+									WHERE expires_at <= $1
+									RETURNING flight_id, class_seats_id),
+			updated_inventory AS (UPDATE flight_inventory inventory
+									SET count_held = inventory.count_held - deleted_class_seats.count_held
+									FROM (SELECT
+												flight_id,
+												class_seats_id,
+												COUNT(*) AS count_held
+											FROM deleted_holds
+											GROUP BY
+												flight_id,
+												class_seats_id) deleted_class_seats
+									WHERE inventory.flight_id = deleted_class_seats.flight_id
+										AND inventory.class_seats_id = deleted_class_seats.class_seats_id)
+		SELECT COUNT(*) FROM deleted_holds`,
+		timestamp).Scan(&countDeleted)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+	return countDeleted, nil
+}
+
+// пересчет остатков мест рейсов (flight_inventory) по билетам и удержаниям мест.
+// возвращает количество исправленных строк остатков
+func (s storage) ReconcileFlightInventory(ctx context.Context, timestamp time.Time) (int64, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	// начало транзакции
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Блокировка остатков на время пересчета.
+	// Транзакции изменения билетов и удержаний ожидают окончания пересчета,
+	// поэтому их изменения не теряются
+	_, err = tx.Exec(ctx, `LOCK TABLE flight_inventory IN EXCLUSIVE MODE`)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+
+	// 2. Удаление истекших удержаний мест, чтобы они не попали в удержанные места
+	_, err = tx.Exec(ctx, `DELETE FROM seat_holds WHERE expires_at <= $1`, timestamp)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+
+	// 3. Пересчет остатков
+	commandTag, err := tx.Exec(ctx, inventory.SqlQueryReconcile)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+
+	// подтверждение транзакции
+	if err = tx.Commit(ctx); err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+
+	return commandTag.RowsAffected(), nil
+}
+
 func NewFlightsStorage(db *pgxpool.Pool) FlightsStorage {
 	return &storage{db: db}
 }
@@ -0,0 +1,155 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+
+	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/util/terr"
+)
+
+// получение мест поиска рейсов: городов и аэропортов.
+// search_name - строка поиска: для аэропорта наименование аэропорта и города
+func getSqlQueryLocations(sqlQueryCondition string, sqlQueryOrder string) string {
+	return `WITH locations AS (
+				SELECT 	'` + flightsDomain.LocationTypeCity + `' AS type,
+						city.id,
+						city.name,
+						COALESCE(city.iata_code, '') AS iata_code,
+						'' AS icao_code,
+						city.id AS city_id,
+						city.name AS city_name,
+						COALESCE(city.country_code, '') AS country_code,
+						city.latitude,
+						city.longitude,
+						lower(city.name) AS search_name
+					FROM cities city
+				UNION ALL
+				SELECT 	'` + flightsDomain.LocationTypeAirport + `' AS type,
+						airport.id,
+						airport.name,
+						COALESCE(airport.iata_code, ''),
+						COALESCE(airport.icao_code, ''),
+						city.id,
+						city.name,
+						COALESCE(city.country_code, ''),
+						airport.latitude,
+						airport.longitude,
+						lower(airport.name || ' ' || city.name)
+					FROM airports airport
+						INNER JOIN cities city
+							ON airport.city_id = city.id)
+			SELECT 	location.type,
+					location.id,
+					location.name,
+					location.iata_code,
+					location.icao_code,
+					location.city_id,
+					location.city_name,
+					location.country_code,
+					location.latitude,
+					location.longitude
+			FROM locations location
+			WHERE ` + sqlQueryCondition + `
+			ORDER BY ` + sqlQueryOrder
+}
+
+func scanLocation(row pgx.Row) (flightsDomain.Location, error) {
+
+	var location flightsDomain.Location
+	err := row.Scan(
+		&location.Type,
+		&location.Id,
+		&location.Name,
+		&location.IataCode,
+		&location.IcaoCode,
+		&location.City.Id,
+		&location.City.Name,
+		&location.CountryCode,
+		&location.Latitude,
+		&location.Longitude,
+	)
+	return location, err
+}
+
+// поиск городов и аэропортов по наименованию и кодам IATA/ICAO. порядок результатов:
+// совпадение кода, наименование начинается со строки поиска, слово наименования начинается со строки поиска,
+// далее по сходству наименования (pg_trgm). при равенстве города выводятся раньше аэропортов
+func (s storage) SearchLocations(ctx context.Context, paramsSearchLocations *flightsDomain.ParamsSearchLocations) ([]flightsDomain.Location, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	query := strings.ToLower(paramsSearchLocations.Query)
+	queryLike := escapeLike(query)
+	paramsQuery := []interface{}{
+		strings.ToUpper(paramsSearchLocations.Query),
+		queryLike + "%",
+		"% " + queryLike + "%",
+		query,
+		paramsSearchLocations.Limit,
+	}
+
+	sqlQueryCondition := `location.iata_code = $1 OR location.icao_code = $1
+							OR location.search_name LIKE $2 OR location.search_name LIKE $3
+							OR location.search_name % $4`
+	sqlQueryOrder := `CASE
+						WHEN location.iata_code = $1 OR location.icao_code = $1 THEN 4
+						WHEN location.search_name LIKE $2 THEN 3
+						WHEN location.search_name LIKE $3 THEN 2
+						ELSE similarity(location.search_name, $4)
+					END DESC,
+					location.type = '` + flightsDomain.LocationTypeCity + `' DESC,
+					location.name
+				LIMIT $5`
+
+	rows, err := conn.Query(ctx, getSqlQueryLocations(sqlQueryCondition, sqlQueryOrder), paramsQuery...)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	var locations []flightsDomain.Location
+	for rows.Next() {
+		location, err := scanLocation(rows)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		locations = append(locations, location)
+	}
+	return locations, nil
+}
+
+// получение города или аэропорта по коду IATA/ICAO. если код есть и у города, и у аэропорта, то возвращается город
+func (s storage) GetLocationByCode(ctx context.Context, code string) (*flightsDomain.Location, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	sqlQueryCondition := "location.iata_code = $1 OR location.icao_code = $1"
+	sqlQueryOrder := "location.type = '" + flightsDomain.LocationTypeCity + "' DESC LIMIT 1"
+
+	row := conn.QueryRow(ctx, getSqlQueryLocations(sqlQueryCondition, sqlQueryOrder), strings.ToUpper(code))
+	location, err := scanLocation(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, terr.NotFound(fmt.Sprintf("not found city or airport (code %s)", code))
+		}
+		return nil, terr.SQLDatabaseError(err)
+	}
+	return &location, nil
+}
+
+// escapeLike экранирует специальные символы шаблона LIKE в строке поиска
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
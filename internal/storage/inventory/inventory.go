@@ -0,0 +1,135 @@
+// Package inventory содержит запросы ведения таблицы flight_inventory - остатков мест рейсов в разрезе классов мест.
+//
+// По каждому классу мест рейса хранятся количество мест класса capacity,
+// количество проданных мест count_sold (действующие билеты, кроме младенцев без отдельного места)
+// и количество удержанных мест count_held (записи seat_holds).
+// Счетчики изменяются в той же транзакции, что и билеты и удержания мест,
+// поэтому поиск рейсов и свободных мест не пересчитывает билеты при каждом запросе.
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+
+	"homework/internal/util/terr"
+)
+
+// AddCounts изменяет в транзакции счетчики проданных и удержанных мест класса рейса.
+// если строки остатков еще нет, то она создается с количеством мест класса.
+// остатки - источник истины о свободных местах: места добавляются, только если проданные и удержанные места
+// не превысят количество мест класса с учетом овербукинга, иначе возвращается ошибка NO_VACANT_SEAT.
+// строка остатков блокируется изменением до конца транзакции, поэтому параллельные оформления не продают лишних мест
+func AddCounts(ctx context.Context, tx pgx.Tx, flightId uuid.UUID, classSeatsId uuid.UUID, countSold int, countHeld int) error {
+
+	_, err := tx.Exec(ctx,
+		`INSERT INTO flight_inventory (
+						flight_id,
+						class_seats_id,
+						capacity,
+						count_sold,
+						count_held
+					)
+					SELECT $1, class_seats.id, class_seats.count_seats, 0, 0
+						FROM classes_seats class_seats
+						WHERE class_seats.id = $2
+				ON CONFLICT (flight_id, class_seats_id) DO NOTHING`,
+		flightId.String(),
+		classSeatsId.String())
+	if err != nil {
+		return terr.SQLDatabaseError(err)
+	}
+
+	commandTag, err := tx.Exec(ctx,
+		`UPDATE flight_inventory inventory
+			SET count_sold = inventory.count_sold + $3,
+				count_held = inventory.count_held + $4
+			WHERE inventory.flight_id = $1
+				AND inventory.class_seats_id = $2
+				AND ($3 + $4 <= 0
+					OR inventory.count_sold + inventory.count_held + $3 + $4 <= inventory.capacity + inventory.capacity * COALESCE(
+						(SELECT flights_prices.overbooking_percent
+							FROM flights_prices
+							WHERE flights_prices.flight_id = $1
+								AND flights_prices.class_seats_id = $2), 0) / 100)`,
+		flightId.String(),
+		classSeatsId.String(),
+		countSold,
+		countHeld)
+	if err != nil {
+		return terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return terr.Conflict("NO_VACANT_SEAT", fmt.Sprintf("no vacant seats with class seat (id %s)", classSeatsId))
+	}
+	return nil
+}
+
+// QueueReleaseTicketSeat добавляет в пакет освобождение места билета (отмена, возврат).
+// билет младенца без отдельного места место не занимает
+func QueueReleaseTicketSeat(batch *pgx.Batch, ticketId uuid.UUID) {
+	batch.Queue(`UPDATE flight_inventory inventory
+					SET count_sold = inventory.count_sold - 1
+					FROM tickets ticket
+					WHERE ticket.id = $1
+						AND ticket.passenger_type <> 'infant'
+						AND inventory.flight_id = ticket.flight_id
+						AND inventory.class_seats_id = ticket.class_seats_id`,
+		ticketId.String())
+}
+
+// SqlQueryReconcile пересчитывает остатки мест всех рейсов по билетам и удержаниям мест.
+// изменяются только расходящиеся строки, поэтому количество измененных строк - количество исправленных остатков
+const SqlQueryReconcile = `INSERT INTO flight_inventory (
+								flight_id,
+								class_seats_id,
+								capacity,
+								count_sold,
+								count_held
+							)
+							SELECT	flight.id,
+									class_seats.id,
+									class_seats.count_seats,
+									CASE
+										WHEN sold_class_seats.count_sold IS NOT NULL
+											THEN sold_class_seats.count_sold
+										ELSE 0
+									END,
+									CASE
+										WHEN held_class_seats.count_held IS NOT NULL
+											THEN held_class_seats.count_held
+										ELSE 0
+									END
+							FROM flights flight
+								INNER JOIN classes_seats class_seats
+									ON class_seats.aircraft_id = flight.aircraft_id
+								LEFT JOIN (SELECT
+												ticket.flight_id,
+												ticket.class_seats_id,
+												COUNT(*) AS count_sold
+											FROM tickets ticket
+											WHERE ticket.status_id <> 3 AND ticket.status_id <> 4
+												AND ticket.passenger_type <> 'infant'
+											GROUP BY
+												ticket.flight_id,
+												ticket.class_seats_id) sold_class_seats
+									ON sold_class_seats.flight_id = flight.id
+									AND sold_class_seats.class_seats_id = class_seats.id
+								LEFT JOIN (SELECT
+												hold.flight_id,
+												hold.class_seats_id,
+												COUNT(*) AS count_held
+											FROM seat_holds hold
+											GROUP BY
+												hold.flight_id,
+												hold.class_seats_id) held_class_seats
+									ON held_class_seats.flight_id = flight.id
+									AND held_class_seats.class_seats_id = class_seats.id
+						ON CONFLICT (flight_id, class_seats_id) DO UPDATE
+							SET capacity = EXCLUDED.capacity,
+								count_sold = EXCLUDED.count_sold,
+								count_held = EXCLUDED.count_held
+							WHERE (flight_inventory.capacity, flight_inventory.count_sold, flight_inventory.count_held)
+								IS DISTINCT FROM (EXCLUDED.capacity, EXCLUDED.count_sold, EXCLUDED.count_held)`
@@ -0,0 +1,78 @@
+// Package rates содержит запросы к таблице exchange_rates - истории курсов валют.
+//
+// Курс хранится для пары валют и действует с момента rate_timestamp до установки следующего курса пары.
+// Курсы загружаются в таблицу из внешнего источника, поэтому сервисы получают курсы через интерфейс
+// и источник курсов может быть заменен без изменения сервисов.
+package rates
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"homework/internal/domain/money"
+	"homework/internal/util/terr"
+)
+
+type RatesStorage interface {
+	GetExchangeRate(ctx context.Context, currencyFrom string, currencyTo string, timestamp time.Time) (*money.ExchangeRate, error)
+}
+
+type storage struct {
+	db *pgxpool.Pool
+}
+
+// получение курса валюты currencyFrom к валюте currencyTo, действующего на момент timestamp.
+// если курс пары не задан, то используется обратный курс. курс валюты к самой себе равен 1
+func (s storage) GetExchangeRate(ctx context.Context, currencyFrom string, currencyTo string, timestamp time.Time) (*money.ExchangeRate, error) {
+
+	if currencyFrom == currencyTo {
+		exchangeRate := money.NewIdentityRate(currencyFrom, timestamp)
+		return &exchangeRate, nil
+	}
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	row := conn.QueryRow(ctx,
+		`SELECT rates.rate,
+				rates.rate_timestamp
+			FROM (SELECT 	rate,
+							rate_timestamp
+					FROM exchange_rates
+					WHERE currency_from = $1 AND currency_to = $2 AND rate_timestamp <= $3
+				UNION ALL
+				SELECT 	1 / rate,
+						rate_timestamp
+					FROM exchange_rates
+					WHERE currency_from = $2 AND currency_to = $1 AND rate_timestamp <= $3) rates
+			ORDER BY rates.rate_timestamp DESC
+			LIMIT 1`,
+		currencyFrom, currencyTo, timestamp)
+
+	exchangeRate := money.ExchangeRate{
+		From: currencyFrom,
+		To:   currencyTo,
+	}
+	err = row.Scan(
+		&exchangeRate.Rate,
+		&exchangeRate.Timestamp,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, terr.NotFound(fmt.Sprintf("not found exchange rate %s/%s", currencyFrom, currencyTo))
+		}
+		return nil, terr.SQLDatabaseError(err)
+	}
+	return &exchangeRate, nil
+}
+
+func NewRatesStorage(db *pgxpool.Pool) RatesStorage {
+	return &storage{db: db}
+}
@@ -5,6 +5,7 @@ import (
 
 	"homework/internal/config"
 	flightsStorage "homework/internal/storage/flights"
+	ratesStorage "homework/internal/storage/rates"
 	ticketsStorage "homework/internal/storage/tickets"
 	usersStorage "homework/internal/storage/users"
 )
@@ -13,6 +14,7 @@ type Storages struct {
 	Flight flightsStorage.FlightsStorage
 	Ticket ticketsStorage.TicketsStorage
 	User   usersStorage.UsersStorage
+	Rate   ratesStorage.RatesStorage
 }
 
 func NewStorageRegistry(cfg *config.Config, db *pgxpool.Pool) *Storages {
@@ -20,10 +22,12 @@ func NewStorageRegistry(cfg *config.Config, db *pgxpool.Pool) *Storages {
 	flight := flightsStorage.NewFlightsStorage(db)
 	ticket := ticketsStorage.NewTicketsStorage(db)
 	user := usersStorage.NewUsersStorage(db)
+	rate := ratesStorage.NewRatesStorage(db)
 
 	return &Storages{
 		Flight: flight,
 		Ticket: ticket,
 		User:   user,
+		Rate:   rate,
 	}
 }
@@ -0,0 +1,139 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// посадка пассажира на рейс: билету устанавливается статус 7 (Boarded),
+// на баланс пользователя начисляются бонусы за билет, если они не были начислены ранее (is_bonuses_released)
+func (s storage) BoardTicket(ctx context.Context, paramsBoardTicket *ticketsDomain.ParamsBoardTicket) (uuid.UUID, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	// статус билета изменяется, только если билет все еще зарегистрирован (status_id = 5):
+	// повторное сканирование и закрытие рейса не приводят к повторному начислению бонусов
+	var countBoarded int
+	err = conn.QueryRow(ctx,
+		`WITH registered_ticket AS (SELECT
+											id,
+											user_id,
+											accrued_bonuses,
+											is_bonuses_released
+										FROM tickets
+										WHERE id = $1 AND status_id = 5
+										FOR UPDATE),
+			boarded_ticket AS (UPDATE tickets ticket
+									SET status_id = 7,
+										status_timestamp = $2,
+										is_bonuses_released = true
+									FROM registered_ticket
+									WHERE ticket.id = registered_ticket.id
+									RETURNING registered_ticket.user_id, registered_ticket.accrued_bonuses, registered_ticket.is_bonuses_released),
+			updated_balance AS (UPDATE users_balance balance
+									SET sum_bonuses = balance.sum_bonuses + boarded_ticket.accrued_bonuses
+									FROM boarded_ticket
+									WHERE balance.user_id = boarded_ticket.user_id
+										AND NOT boarded_ticket.is_bonuses_released)
+		SELECT COUNT(*) FROM boarded_ticket`,
+		paramsBoardTicket.TicketId.String(),
+		paramsBoardTicket.StatusTimestamp).Scan(&countBoarded)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	if countBoarded == 0 {
+		return uuid.UUID{}, terr.Conflict("INVALID_STATUS_TICKET", fmt.Sprintf("ticket (id %s) isn't registered anymore", paramsBoardTicket.TicketId))
+	}
+
+	ticketId := paramsBoardTicket.TicketId
+	return ticketId, nil
+}
+
+// закрытие рейса: рейсу устанавливается время закрытия closed_timestamp,
+// зарегистрированные билеты без посадки получают статус 8 (NoShow), оплаченные незарегистрированные - статус 6 (Closed)
+func (s storage) CloseFlight(ctx context.Context, paramsCloseFlight *ticketsDomain.ParamsCloseFlight) (*ticketsDomain.ClosedFlight, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	// начало транзакции
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Закрытие рейса (flights)
+	commandTag, err := tx.Exec(ctx,
+		`UPDATE flights
+			SET closed_timestamp = $2
+			WHERE id = $1 AND closed_timestamp IS NULL`,
+		paramsCloseFlight.FlightId.String(),
+		paramsCloseFlight.Timestamp)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return nil, terr.Conflict("FLIGHT_ALREADY_CLOSED", fmt.Sprintf("flight (id %s) is already closed", paramsCloseFlight.FlightId))
+	}
+
+	closedFlight := ticketsDomain.ClosedFlight{
+		FlightId:        paramsCloseFlight.FlightId,
+		ClosedTimestamp: paramsCloseFlight.Timestamp,
+	}
+
+	// 2. Зарегистрированные билеты без посадки: статус 8 (NoShow)
+	commandTag, err = tx.Exec(ctx,
+		`UPDATE tickets
+			SET status_id = 8,
+				status_timestamp = $2
+			WHERE flight_id = $1 AND status_id = 5`,
+		paramsCloseFlight.FlightId.String(),
+		paramsCloseFlight.Timestamp)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	closedFlight.CountNoShow = int(commandTag.RowsAffected())
+
+	// 3. Оплаченные незарегистрированные билеты: статус 6 (Closed)
+	commandTag, err = tx.Exec(ctx,
+		`UPDATE tickets
+			SET status_id = 6,
+				status_timestamp = $2
+			WHERE flight_id = $1 AND status_id = 2`,
+		paramsCloseFlight.FlightId.String(),
+		paramsCloseFlight.Timestamp)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	closedFlight.CountClosed = int(commandTag.RowsAffected())
+
+	// 4. Количество билетов, прошедших посадку: статус 7 (Boarded)
+	err = tx.QueryRow(ctx,
+		`SELECT COUNT(*)
+			FROM tickets
+			WHERE flight_id = $1 AND status_id = 7`,
+		paramsCloseFlight.FlightId.String()).Scan(&closedFlight.CountBoarded)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+
+	// подтверждение транзакции
+	if err = tx.Commit(ctx); err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+
+	return &closedFlight, nil
+}
@@ -0,0 +1,305 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+
+	"homework/internal/domain/money"
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/storage/inventory"
+	"homework/internal/util/terr"
+)
+
+// GetCompanyById получает компанию с пользователями компании и правилами поездок
+func (s storage) GetCompanyById(ctx context.Context, companyId uuid.UUID) (*ticketsDomain.Company, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	var company ticketsDomain.Company
+	var isPolicyExists bool
+	var policy ticketsDomain.TravelPolicy
+	var maxPrice *int64
+	var currency string
+	err = conn.QueryRow(ctx,
+		`SELECT 	company.id,
+					company.name,
+					CASE
+						WHEN policy.company_id IS NOT NULL
+							THEN true
+						ELSE false
+					END is_policy_exists,
+					policy.max_class_seats_name,
+					policy.max_price,
+					COALESCE(policy.currency, ''),
+					COALESCE(policy.approval_required, false)
+			FROM companies company
+				LEFT JOIN companies_travel_policies policy
+					ON company.id = policy.company_id
+			WHERE company.id = $1`,
+		companyId.String()).Scan(
+		&company.Id,
+		&company.Name,
+		&isPolicyExists,
+		&policy.MaxClassSeatsName,
+		&maxPrice,
+		&currency,
+		&policy.ApprovalRequired,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, terr.NotFound(fmt.Sprintf("not found company (id %s)", companyId))
+		}
+		return nil, terr.SQLDatabaseError(err)
+	}
+
+	if isPolicyExists {
+		if maxPrice != nil {
+			price := money.New(*maxPrice, currency)
+			policy.MaxPrice = &price
+		}
+		company.TravelPolicy = &policy
+	}
+
+	rows, err := conn.Query(ctx,
+		`SELECT 	users.id,
+					users.name,
+					users.email,
+					array_agg(member.role ORDER BY member.role)
+			FROM companies_users member
+				INNER JOIN users
+					ON member.user_id = users.id
+			WHERE member.company_id = $1
+			GROUP BY users.id, users.name, users.email
+			ORDER BY users.name, users.id`,
+		companyId.String())
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	company.Members = make([]ticketsDomain.CompanyMember, 0)
+	for rows.Next() {
+		var member ticketsDomain.CompanyMember
+		err = rows.Scan(
+			&member.User.Id,
+			&member.User.Name,
+			&member.User.Email,
+			&member.Roles,
+		)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		company.Members = append(company.Members, member)
+	}
+	return &company, nil
+}
+
+// GetCompanyTickets получает билеты компании с датой вылета в периоде
+func (s storage) GetCompanyTickets(ctx context.Context, paramsGetCompanyReport *ticketsDomain.ParamsGetCompanyReport) ([]ticketsDomain.Ticket, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	sqlQuery := getSqlQueryTickets(`ticket.company_id = $1
+				AND ($2::date IS NULL OR flight.departure_date::date >= $2::date)
+				AND ($3::date IS NULL OR flight.departure_date::date <= $3::date)
+			ORDER BY flight.departure_date, ticket.id`)
+	rows, err := conn.Query(ctx, sqlQuery,
+		paramsGetCompanyReport.CompanyId.String(),
+		paramsGetCompanyReport.DepartureDateFrom,
+		paramsGetCompanyReport.DepartureDateTo)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	tickets := make([]ticketsDomain.Ticket, 0)
+	for rows.Next() {
+
+		ticket, err := scanTicket(rows)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		tickets = append(tickets, ticket)
+	}
+	return tickets, nil
+}
+
+// ApproveTicket согласует или отклоняет билет компании, ожидающий согласования.
+// согласованный билет остается в статусе 1(Created), время статуса обновляется: срок оплаты отсчитывается от согласования.
+// отклоненный билет отменяется (статус 3(Canceled)) и освобождает место
+func (s storage) ApproveTicket(ctx context.Context, paramsApproveTicket *ticketsDomain.ParamsApproveTicket) (uuid.UUID, error) {
+
+	// начало транзакции
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	approvalStatus := ticketsDomain.ApprovalStatusRejected
+	statusId := 3
+	if paramsApproveTicket.Approved {
+		approvalStatus = ticketsDomain.ApprovalStatusApproved
+		statusId = 1
+	}
+
+	// 1. Изменение билета (tickets): только билет, еще ожидающий согласования,
+	// поэтому одновременные согласование и отклонение не применятся оба
+	commandTag, err := tx.Exec(ctx,
+		`UPDATE tickets
+				SET approval_status = $2,
+					approver_id = $3,
+					approval_timestamp = $4,
+					status_id = $5,
+					status_timestamp = $4
+				WHERE id = $1
+					AND status_id = 1
+					AND approval_status = 'pending'`,
+		paramsApproveTicket.TicketId.String(),
+		approvalStatus,
+		paramsApproveTicket.UserId.String(),
+		paramsApproveTicket.StatusTimestamp,
+		statusId)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return uuid.UUID{}, terr.Conflict("TICKET_NOT_PENDING_APPROVAL", fmt.Sprintf("ticket (id %s) isn't pending approval", paramsApproveTicket.TicketId))
+	}
+
+	// 2. Освобождение места отклоненного билета в остатках мест рейса (flight_inventory)
+	if !paramsApproveTicket.Approved {
+		batch := new(pgx.Batch)
+		inventory.QueueReleaseTicketSeat(batch, paramsApproveTicket.TicketId)
+
+		res := tx.SendBatch(ctx, batch)
+		if err = res.Close(); err != nil {
+			return uuid.UUID{}, terr.SQLDatabaseError(err)
+		}
+	}
+
+	// подтверждение транзакции
+	if err = tx.Commit(ctx); err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+
+	return paramsApproveTicket.TicketId, nil
+}
+
+// условия оплат по счету компании, включаемых в счет за период (оплаты до конца периода, еще не включенные в счет
+// и не возвращенные), и возвратов, уменьшающих счет (возвраты до конца периода оплат, включенных в предыдущие счета)
+const (
+	sqlConditionInvoicePayments = `payment.payment_method = 'invoice'
+						AND payment.invoice_id IS NULL
+						AND payment.refund_timestamp IS NULL
+						AND payment.payment_timestamp < $2
+						AND payment.ticket_id IN (SELECT id FROM tickets WHERE company_id = $1)`
+	sqlConditionInvoiceRefunds = `payment.payment_method = 'invoice'
+						AND payment.invoice_id IS NOT NULL
+						AND payment.refund_invoice_id IS NULL
+						AND payment.refund_timestamp < $2
+						AND payment.ticket_id IN (SELECT id FROM tickets WHERE company_id = $1)`
+)
+
+// CreateCompanyInvoice выставляет счет компании за период: сумма оплат билетов по счету за вычетом возвратов.
+// оплаты и возвраты отмечаются счетом, поэтому в следующие счета они не попадают. счет за период выставляется один раз
+func (s storage) CreateCompanyInvoice(ctx context.Context, paramsCreateCompanyInvoice *ticketsDomain.ParamsCreateCompanyInvoice) (*ticketsDomain.CompanyInvoice, error) {
+
+	// начало транзакции
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	invoice := ticketsDomain.CompanyInvoice{
+		Id:             uuid.New(),
+		CompanyId:      paramsCreateCompanyInvoice.CompanyId,
+		PeriodFrom:     paramsCreateCompanyInvoice.PeriodFrom,
+		PeriodTo:       paramsCreateCompanyInvoice.PeriodTo,
+		Amount:         money.New(0, money.BaseCurrency),
+		IssueTimestamp: paramsCreateCompanyInvoice.Timestamp,
+	}
+
+	// 1. Создание счета (companies_invoices)
+	err = tx.QueryRow(ctx,
+		`WITH payments AS (
+				SELECT 	COALESCE(SUM(payment.amount), 0) AS amount,
+						COUNT(*) AS count_payments
+				FROM tickets_payments payment
+				WHERE `+sqlConditionInvoicePayments+`
+			),
+			refunds AS (
+				SELECT 	COALESCE(SUM(payment.amount), 0) AS amount,
+						COUNT(*) AS count_refunds
+				FROM tickets_payments payment
+				WHERE `+sqlConditionInvoiceRefunds+`
+			)
+			INSERT INTO companies_invoices (id, company_id, period_from, period_to, amount, currency, count_payments, count_refunds, issue_timestamp)
+			SELECT 	$3, $1, $4, $2,
+					payments.amount - refunds.amount, $5,
+					payments.count_payments, refunds.count_refunds, $6
+			FROM payments, refunds
+			ON CONFLICT (company_id, period_from) DO NOTHING
+			RETURNING amount, count_payments, count_refunds`,
+		paramsCreateCompanyInvoice.CompanyId.String(),
+		paramsCreateCompanyInvoice.PeriodTo,
+		invoice.Id.String(),
+		paramsCreateCompanyInvoice.PeriodFrom,
+		invoice.Amount.Currency,
+		paramsCreateCompanyInvoice.Timestamp).Scan(
+		&invoice.Amount.Amount,
+		&invoice.CountPayments,
+		&invoice.CountRefunds,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, terr.Conflict("INVOICE_ALREADY_EXISTS", fmt.Sprintf("invoice of company (id %s) for period %s already exists",
+				paramsCreateCompanyInvoice.CompanyId, paramsCreateCompanyInvoice.PeriodFrom.Format("2006-01")))
+		}
+		return nil, terr.SQLDatabaseError(err)
+	}
+
+	// пакетный запрос
+	batch := new(pgx.Batch)
+
+	// 2. Отметка оплат и возвратов счетом (tickets_payments)
+	batch.Queue(`UPDATE tickets_payments payment
+					SET invoice_id = $3
+					WHERE `+sqlConditionInvoicePayments,
+		paramsCreateCompanyInvoice.CompanyId.String(),
+		paramsCreateCompanyInvoice.PeriodTo,
+		invoice.Id.String())
+	batch.Queue(`UPDATE tickets_payments payment
+					SET refund_invoice_id = $3
+					WHERE `+sqlConditionInvoiceRefunds,
+		paramsCreateCompanyInvoice.CompanyId.String(),
+		paramsCreateCompanyInvoice.PeriodTo,
+		invoice.Id.String())
+
+	// отправка пакета в БД
+	res := tx.SendBatch(ctx, batch)
+
+	// операция закрытия соединения
+	if err = res.Close(); err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+
+	// подтверждение транзакции
+	if err = tx.Commit(ctx); err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+
+	return &invoice, nil
+}
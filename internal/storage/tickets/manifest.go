@@ -0,0 +1,95 @@
+package tickets
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// получение пассажиров манифеста рейса: билеты рейса, кроме неоплаченных, отмененных и возвращенных (статусы 1, 3, 4).
+// документ берется из данных APIS (tickets_apis), если они собраны при регистрации, иначе - из билета
+func (s storage) GetFlightManifest(ctx context.Context, flightId uuid.UUID) ([]ticketsDomain.ManifestPassenger, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx,
+		`SELECT ticket.id,
+				ticket.name_passenger,
+				ticket.passenger_type,
+				class_seats.name,
+				COALESCE(seat.number, ''),
+				status.name,
+				ticket.count_additional_baggage,
+				COALESCE(apis.document_type, ticket.document_type, ''),
+				COALESCE(apis.document_number, ticket.document_number, ''),
+				COALESCE(apis.document_issuing_country, ticket.document_issuing_country, ''),
+				COALESCE(apis.nationality, ticket.nationality, ''),
+				COALESCE(apis.birth_date, ticket.birth_date),
+				CASE
+					WHEN apis.ticket_id IS NOT NULL
+						THEN apis.document_expiry_date
+					ELSE ticket.document_expiry_date
+				END,
+				COALESCE(apis.destination_country, ''),
+				COALESCE(apis.destination_city, ''),
+				COALESCE(apis.destination_address, '')
+			FROM tickets ticket
+				INNER JOIN statuses status
+					ON ticket.status_id = status.id
+				INNER JOIN classes_seats class_seats
+					ON ticket.class_seats_id = class_seats.id
+				LEFT JOIN seats seat
+					ON ticket.seat_id = seat.id
+				LEFT JOIN tickets_apis apis
+					ON ticket.id = apis.ticket_id
+			WHERE ticket.flight_id = $1
+				AND ticket.status_id NOT IN (1, 3, 4)
+			ORDER BY
+				class_seats.name,
+				seat.number NULLS LAST,
+				ticket.name_passenger`,
+		flightId.String())
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	var passengers []ticketsDomain.ManifestPassenger
+	for rows.Next() {
+
+		var passenger ticketsDomain.ManifestPassenger
+		err = rows.Scan(
+			&passenger.TicketId,
+			&passenger.NamePassenger,
+			&passenger.PassengerType,
+			&passenger.ClassSeatsName,
+			&passenger.SeatNumber,
+			&passenger.StatusName,
+			&passenger.CountAdditionalBaggage,
+			&passenger.DocumentType,
+			&passenger.DocumentNumber,
+			&passenger.DocumentIssuingCountry,
+			&passenger.Nationality,
+			&passenger.BirthDate,
+			&passenger.DocumentExpiryDate,
+			&passenger.DestinationCountry,
+			&passenger.DestinationCity,
+			&passenger.DestinationAddress,
+		)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		passengers = append(passengers, passenger)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	return passengers, nil
+}
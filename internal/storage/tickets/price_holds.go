@@ -0,0 +1,264 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/storage/inventory"
+	"homework/internal/util/terr"
+)
+
+// CreatePriceHold создает удержание цены билета, ожидающего оплаты.
+// строка билета блокируется до конца транзакции, поэтому билет не отменяется фоновой обработкой одновременно с удержанием
+func (s storage) CreatePriceHold(ctx context.Context, paramsCreatePriceHold *ticketsDomain.ParamsCreatePriceHold) (*ticketsDomain.PriceHold, error) {
+
+	// начало транзакции
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Блокировка билета (tickets): удерживается только билет со статусом 1(Created)
+	commandTag, err := tx.Exec(ctx,
+		`SELECT id FROM tickets WHERE id = $1 AND status_id = 1 FOR UPDATE`,
+		paramsCreatePriceHold.TicketId.String())
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return nil, terr.Conflict("INVALID_STATUS_TICKET", fmt.Sprintf("ticket (id %s) isn't awaiting payment", paramsCreatePriceHold.TicketId))
+	}
+
+	priceHold := ticketsDomain.PriceHold{
+		Id:              uuid.New(),
+		TicketId:        paramsCreatePriceHold.TicketId,
+		UserId:          paramsCreatePriceHold.UserId,
+		Fee:             paramsCreatePriceHold.Fee,
+		Status:          ticketsDomain.PriceHoldStatusActive,
+		HoldTimestamp:   paramsCreatePriceHold.Timestamp,
+		ExpiryTimestamp: paramsCreatePriceHold.ExpiryTimestamp,
+	}
+
+	// 2. Создание удержания (price_holds). Билет удерживается один раз
+	commandTag, err = tx.Exec(ctx,
+		`INSERT INTO price_holds (
+						id,
+						ticket_id,
+						user_id,
+						fee,
+						currency,
+						status,
+						hold_timestamp,
+						expiry_timestamp,
+						status_timestamp
+					)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $7)
+					ON CONFLICT (ticket_id) DO NOTHING`,
+		priceHold.Id.String(),
+		priceHold.TicketId.String(),
+		priceHold.UserId.String(),
+		priceHold.Fee.Amount,
+		priceHold.Fee.Currency,
+		priceHold.Status,
+		priceHold.HoldTimestamp,
+		priceHold.ExpiryTimestamp)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return nil, terr.Conflict("PRICE_HOLD_ALREADY_EXISTS", fmt.Sprintf("price of ticket (id %s) is already held", paramsCreatePriceHold.TicketId))
+	}
+
+	// подтверждение транзакции
+	if err = tx.Commit(ctx); err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+
+	return &priceHold, nil
+}
+
+// GetActivePriceHolds получает действующие удержания цены, истекающие до expiresBefore
+func (s storage) GetActivePriceHolds(ctx context.Context, expiresBefore time.Time) ([]ticketsDomain.PriceHold, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx,
+		`SELECT 	id,
+					ticket_id,
+					user_id,
+					fee,
+					currency,
+					status,
+					hold_timestamp,
+					expiry_timestamp,
+					reminder_timestamp
+			FROM price_holds
+			WHERE status = $1 AND expiry_timestamp < $2
+			ORDER BY expiry_timestamp`,
+		ticketsDomain.PriceHoldStatusActive,
+		expiresBefore)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	priceHolds := make([]ticketsDomain.PriceHold, 0)
+	for rows.Next() {
+		var priceHold ticketsDomain.PriceHold
+		err = rows.Scan(
+			&priceHold.Id,
+			&priceHold.TicketId,
+			&priceHold.UserId,
+			&priceHold.Fee.Amount,
+			&priceHold.Fee.Currency,
+			&priceHold.Status,
+			&priceHold.HoldTimestamp,
+			&priceHold.ExpiryTimestamp,
+			&priceHold.ReminderTimestamp,
+		)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		priceHolds = append(priceHolds, priceHold)
+	}
+	return priceHolds, nil
+}
+
+// RemindPriceHold отмечает отправку напоминания об окончании удержания цены и уведомляет пользователя.
+// напоминание по удержанию отправляется один раз
+func (s storage) RemindPriceHold(ctx context.Context, paramsProcessPriceHold *ticketsDomain.ParamsProcessPriceHold) (uuid.UUID, error) {
+
+	// начало транзакции
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Отметка напоминания (price_holds)
+	commandTag, err := tx.Exec(ctx,
+		`UPDATE price_holds
+			SET reminder_timestamp = $2
+			WHERE id = $1 AND status = $3 AND reminder_timestamp IS NULL`,
+		paramsProcessPriceHold.PriceHoldId.String(),
+		paramsProcessPriceHold.Timestamp,
+		ticketsDomain.PriceHoldStatusActive)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return uuid.UUID{}, terr.Conflict("PRICE_HOLD_ALREADY_PROCESSED", fmt.Sprintf("price hold (id %s) is already processed", paramsProcessPriceHold.PriceHoldId))
+	}
+
+	// 2. Уведомление пользователя (notifications)
+	batch := new(pgx.Batch)
+	queueCreateNotification(batch, &paramsProcessPriceHold.Notification, paramsProcessPriceHold.Timestamp)
+
+	res := tx.SendBatch(ctx, batch)
+	if err = res.Close(); err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+
+	// подтверждение транзакции
+	if err = tx.Commit(ctx); err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+
+	return paramsProcessPriceHold.PriceHoldId, nil
+}
+
+// ExpirePriceHold завершает истекшее удержание цены: неоплаченный билет отменяется (статус 3(Canceled)),
+// место освобождается в остатках мест рейса, пользователь уведомляется
+func (s storage) ExpirePriceHold(ctx context.Context, paramsProcessPriceHold *ticketsDomain.ParamsProcessPriceHold) (uuid.UUID, error) {
+
+	// начало транзакции
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Изменение статуса удержания (price_holds): только действующее удержание,
+	// поэтому одновременные оплата и истечение не применятся оба
+	commandTag, err := tx.Exec(ctx,
+		`UPDATE price_holds
+			SET status = $2,
+				status_timestamp = $3
+			WHERE id = $1 AND status = $4`,
+		paramsProcessPriceHold.PriceHoldId.String(),
+		ticketsDomain.PriceHoldStatusExpired,
+		paramsProcessPriceHold.Timestamp,
+		ticketsDomain.PriceHoldStatusActive)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return uuid.UUID{}, terr.Conflict("PRICE_HOLD_ALREADY_PROCESSED", fmt.Sprintf("price hold (id %s) is already processed", paramsProcessPriceHold.PriceHoldId))
+	}
+
+	// 2. Отмена неоплаченного билета (tickets)
+	commandTag, err = tx.Exec(ctx,
+		`UPDATE tickets
+			SET status_id = 3,
+				status_timestamp = $2
+			WHERE id = $1 AND status_id = 1`,
+		paramsProcessPriceHold.TicketId.String(),
+		paramsProcessPriceHold.Timestamp)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+
+	// пакетный запрос
+	batch := new(pgx.Batch)
+
+	// 3. Освобождение места отмененного билета в остатках мест рейса (flight_inventory)
+	if commandTag.RowsAffected() > 0 {
+		inventory.QueueReleaseTicketSeat(batch, paramsProcessPriceHold.TicketId)
+	}
+
+	// 4. Уведомление пользователя (notifications)
+	queueCreateNotification(batch, &paramsProcessPriceHold.Notification, paramsProcessPriceHold.Timestamp)
+
+	res := tx.SendBatch(ctx, batch)
+	if err = res.Close(); err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+
+	// подтверждение транзакции
+	if err = tx.Commit(ctx); err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+
+	return paramsProcessPriceHold.PriceHoldId, nil
+}
+
+// payPriceHold завершает удержание цены оплатой билета. удержание, истекшее одновременно с оплатой, не оплачивается
+func payPriceHold(ctx context.Context, tx pgx.Tx, paramsPayForTicket *ticketsDomain.ParamsPayForTicket) error {
+
+	commandTag, err := tx.Exec(ctx,
+		`UPDATE price_holds
+			SET status = $2,
+				status_timestamp = $3
+			WHERE id = $1 AND status = $4 AND expiry_timestamp > $3`,
+		paramsPayForTicket.PriceHoldId.String(),
+		ticketsDomain.PriceHoldStatusPaid,
+		paramsPayForTicket.StatusTimestamp,
+		ticketsDomain.PriceHoldStatusActive)
+	if err != nil {
+		return terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return terr.Conflict("PRICE_HOLD_EXPIRED", fmt.Sprintf("price hold of ticket (id %s) has expired", paramsPayForTicket.TicketId))
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// получение промокодов
+
+func getSqlQueryPromoCodes(sqlQueryCondition string) string {
+	return `SELECT 	promo_code.id,
+					promo_code.code,
+					promo_code.discount_type,
+					promo_code.discount_percent,
+					promo_code.discount_amount,
+					promo_code.min_spend,
+					promo_code.currency,
+					promo_code.departure_airport_id,
+					promo_code.arrival_airport_id,
+					promo_code.class_seats_name,
+					promo_code.valid_from,
+					promo_code.valid_to,
+					promo_code.max_uses,
+					promo_code.max_uses_per_user
+			FROM promo_codes promo_code
+			WHERE ` + sqlQueryCondition
+}
+
+func scanPromoCode(row pgx.Row) (ticketsDomain.PromoCode, error) {
+
+	var promoCode ticketsDomain.PromoCode
+	var currency string
+	err := row.Scan(
+		&promoCode.Id,
+		&promoCode.Code,
+		&promoCode.DiscountType,
+		&promoCode.DiscountPercent,
+		&promoCode.DiscountAmount.Amount,
+		&promoCode.MinSpend.Amount,
+		&currency,
+		&promoCode.DepartureAirportId,
+		&promoCode.ArrivalAirportId,
+		&promoCode.ClassSeatsName,
+		&promoCode.ValidFrom,
+		&promoCode.ValidTo,
+		&promoCode.MaxUses,
+		&promoCode.MaxUsesPerUser,
+	)
+	promoCode.DiscountAmount.Currency = currency
+	promoCode.MinSpend.Currency = currency
+	return promoCode, err
+}
+
+// GetPromoCodeByCode получает промокод по коду без учета регистра
+func (s storage) GetPromoCodeByCode(ctx context.Context, code string) (*ticketsDomain.PromoCode, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	row := conn.QueryRow(ctx, getSqlQueryPromoCodes("upper(promo_code.code) = upper($1)"), code)
+	promoCode, err := scanPromoCode(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, terr.NotFound(fmt.Sprintf("not found promo code %s", code))
+		}
+		return nil, terr.SQLDatabaseError(err)
+	}
+	return &promoCode, nil
+}
+
+func (s storage) GetPromoCodeById(ctx context.Context, promoCodeId uuid.UUID) (*ticketsDomain.PromoCode, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	row := conn.QueryRow(ctx, getSqlQueryPromoCodes("promo_code.id = $1"), promoCodeId.String())
+	promoCode, err := scanPromoCode(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, terr.NotFound(fmt.Sprintf("not found promo code (id %s)", promoCodeId))
+		}
+		return nil, terr.SQLDatabaseError(err)
+	}
+	return &promoCode, nil
+}
+
+// количество неотмененных использований промокода: всего и данным пользователем
+
+const sqlQueryPromoCodeUsage = `SELECT 	count(*),
+										count(*) FILTER (WHERE redemption.user_id = $2)
+			FROM promo_codes_redemptions redemption
+			WHERE redemption.promo_code_id = $1
+				AND redemption.reversed_timestamp IS NULL`
+
+func (s storage) GetPromoCodeUsage(ctx context.Context, promoCodeId uuid.UUID, userId uuid.UUID) (*ticketsDomain.PromoCodeUsage, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	var usage ticketsDomain.PromoCodeUsage
+	err = conn.QueryRow(ctx, sqlQueryPromoCodeUsage, promoCodeId.String(), userId.String()).Scan(
+		&usage.CountUses,
+		&usage.CountUserUses,
+	)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	return &usage, nil
+}
+
+// redeemPromoCode фиксирует использование промокода при оплате билета в транзакции оплаты.
+// строка промокода блокируется до конца транзакции, чтобы одновременные оплаты не превысили ограничения количества использований.
+// если ограничение уже достигнуто, использование не добавляется и оплата отменяется
+func redeemPromoCode(ctx context.Context, tx pgx.Tx, paramsPayForTicket *ticketsDomain.ParamsPayForTicket) error {
+
+	promoCode := paramsPayForTicket.PromoCode
+
+	_, err := tx.Exec(ctx, `SELECT id FROM promo_codes WHERE id = $1 FOR UPDATE`, promoCode.Id.String())
+	if err != nil {
+		return terr.SQLDatabaseError(err)
+	}
+
+	commandTag, err := tx.Exec(ctx,
+		`INSERT INTO promo_codes_redemptions (
+						id,
+						promo_code_id,
+						ticket_id,
+						user_id,
+						discount,
+						currency,
+						redemption_timestamp
+					)
+					SELECT 	$3,
+							promo_code.id,
+							$4,
+							$2,
+							$5,
+							$6,
+							$7
+					FROM promo_codes promo_code
+						CROSS JOIN (`+sqlQueryPromoCodeUsage+`) AS usage (count_uses, count_user_uses)
+					WHERE promo_code.id = $1
+						AND (promo_code.max_uses IS NULL OR usage.count_uses < promo_code.max_uses)
+						AND (promo_code.max_uses_per_user IS NULL OR usage.count_user_uses < promo_code.max_uses_per_user)`,
+		promoCode.Id.String(),
+		paramsPayForTicket.UserId.String(),
+		uuid.New().String(),
+		paramsPayForTicket.TicketId.String(),
+		paramsPayForTicket.PromoDiscount.Amount,
+		paramsPayForTicket.PromoDiscount.Currency,
+		paramsPayForTicket.StatusTimestamp)
+	if err != nil {
+		return terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return terr.Conflict("PROMO_CODE_LIMIT_EXCEEDED", fmt.Sprintf("promo code %s has reached the limit of uses", promoCode.Code))
+	}
+	return nil
+}
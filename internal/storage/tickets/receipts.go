@@ -0,0 +1,89 @@
+package tickets
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// GetTicketDocuments получает документы билета (чек оплаты и кредит-ноту возврата).
+// кредит-ноте заполняется код кредита на перелет, выпущенного при возврате билета вместо возврата денег
+func (s storage) GetTicketDocuments(ctx context.Context, ticketId uuid.UUID) ([]ticketsDomain.TicketDocument, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx,
+		`SELECT 	document.id,
+					document.document_type,
+					document.number,
+					document.document_timestamp,
+					COALESCE(credit.code, '')
+			FROM tickets_documents document
+					LEFT JOIN vouchers credit
+						ON document.document_type = $2
+							AND credit.ticket_id = document.ticket_id
+							AND credit.voucher_type = $3
+			WHERE document.ticket_id = $1
+			ORDER BY document.document_timestamp`,
+		ticketId.String(),
+		ticketsDomain.TicketDocumentTypeCreditNote,
+		ticketsDomain.VoucherTypeTravelCredit)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	documents := make([]ticketsDomain.TicketDocument, 0)
+	for rows.Next() {
+		var document ticketsDomain.TicketDocument
+		err = rows.Scan(
+			&document.Id,
+			&document.Type,
+			&document.Number,
+			&document.Timestamp,
+			&document.TravelCreditCode,
+		)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// добавление в пакет выдачи документа билета (tickets_documents) со следующим номером по типу документа.
+// счетчик номеров изменяется в транзакции оплаты (возврата) билета, поэтому номера идут без пропусков.
+// документ выдается билету один раз: если он уже выдан, номер не расходуется
+func queueCreateTicketDocument(batch *pgx.Batch, ticketId uuid.UUID, documentType string, timestamp time.Time) {
+
+	batch.Queue(`WITH counter AS (
+						UPDATE tickets_documents_counters
+							SET last_number = last_number + 1
+							WHERE document_type = $3
+								AND NOT EXISTS (SELECT 1 FROM tickets_documents
+												WHERE ticket_id = $2 AND document_type = $3)
+							RETURNING last_number
+					)
+					INSERT INTO tickets_documents (
+						id,
+						ticket_id,
+						document_type,
+						number,
+						document_timestamp
+					)
+					SELECT $1, $2, $3, last_number, $4
+						FROM counter`,
+		uuid.New().String(),
+		ticketId.String(),
+		documentType,
+		timestamp)
+}
@@ -0,0 +1,70 @@
+package tickets
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+
+	ticketsDomain "homework/internal/domain/tickets"
+	usersDomain "homework/internal/domain/users"
+)
+
+// добавление в пакет задания начисления бонусов реферальной программы при регистрации билета на рейс.
+// бонусы начисляются пригласившему и приглашенному пользователю (users_balance), если:
+// - пользователь билета зарегистрирован по реферальному коду,
+// - это первый зарегистрированный билет пользователя (других билетов в статусах Registered, Closed, Boarded нет),
+// - бонусы по приглашенному пользователю еще не начислялись (referral_rewards.referee_id уникален),
+// - документ пассажира билета не встречается в билетах и пассажирах пригласившего пользователя
+// (повторный аккаунт того же человека).
+// если баланс пользователя еще не заполнен, то добавляется запись баланса с нулевой суммой покупок
+func queueReferralReward(batch *pgx.Batch, paramsRegisterTicket *ticketsDomain.ParamsRegisterTicket) {
+
+	arrParams := []interface{}{
+		paramsRegisterTicket.TicketId.String(),
+		uuid.New().String(),
+		usersDomain.ReferrerBonus.Amount,
+		usersDomain.RefereeBonus.Amount,
+		paramsRegisterTicket.StatusTimestamp,
+		uuid.New().String(),
+		uuid.New().String(),
+	}
+	sqlQuery := `WITH reward AS (
+					INSERT INTO referral_rewards (id, referrer_id, referee_id, ticket_id, referrer_bonus, referee_bonus, reward_timestamp)
+					SELECT $2, referee.referrer_id, referee.id, ticket.id, $3, $4, $5
+					FROM tickets ticket
+						INNER JOIN users referee
+							ON ticket.user_id = referee.id
+					WHERE ticket.id = $1
+						AND referee.referrer_id IS NOT NULL
+						AND NOT EXISTS (
+							SELECT 1
+							FROM tickets registered_ticket
+							WHERE registered_ticket.user_id = referee.id
+								AND registered_ticket.id <> ticket.id
+								AND registered_ticket.status_id IN (5, 6, 7))
+						AND NOT EXISTS (
+							SELECT 1
+							FROM tickets referrer_ticket
+							WHERE referrer_ticket.user_id = referee.referrer_id
+								AND referrer_ticket.document_number = ticket.document_number)
+						AND NOT EXISTS (
+							SELECT 1
+							FROM passengers referrer_passenger
+							WHERE referrer_passenger.user_id = referee.referrer_id
+								AND referrer_passenger.document_number = ticket.document_number)
+					ON CONFLICT (referee_id) DO NOTHING
+					RETURNING referrer_id, referee_id, referrer_bonus, referee_bonus
+				),
+				bonuses AS (
+					SELECT $6::uuid AS id, reward.referrer_id AS user_id, reward.referrer_bonus AS bonus
+					FROM reward
+					UNION ALL
+					SELECT $7::uuid, reward.referee_id, reward.referee_bonus
+					FROM reward
+				)
+				INSERT INTO users_balance (id, user_id, sum_purchases, sum_bonuses)
+				SELECT id, user_id, 0, bonus
+				FROM bonuses
+				ON CONFLICT (user_id) DO UPDATE
+					SET sum_bonuses = users_balance.sum_bonuses + EXCLUDED.sum_bonuses;`
+	batch.Queue(sqlQuery, arrParams...)
+}
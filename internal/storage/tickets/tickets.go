@@ -2,31 +2,138 @@ package tickets
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	flightsDomain "homework/internal/domain/flights"
+	"homework/internal/domain/money"
 	usersDomain "homework/internal/domain/users"
 	"time"
 
 	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/storage/inventory"
 	"homework/internal/util/terr"
 )
 
 type TicketsStorage interface {
 	GetPassengerById(ctx context.Context, passengerId uuid.UUID) (*ticketsDomain.Passenger, error)
+	GetUserPassengers(ctx context.Context, userId uuid.UUID) ([]ticketsDomain.Passenger, error)
+	GetCountPassengerActiveTickets(ctx context.Context, passengerId uuid.UUID, timestamp time.Time) (int, error)
+	CreatePassenger(ctx context.Context, paramsCreatePassenger *ticketsDomain.ParamsCreatePassenger) (uuid.UUID, error)
+	UpdatePassenger(ctx context.Context, paramsUpdatePassenger *ticketsDomain.ParamsUpdatePassenger) (uuid.UUID, error)
+	DeletePassenger(ctx context.Context, paramsDeletePassenger *ticketsDomain.ParamsDeletePassenger) (uuid.UUID, error)
 	GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticketsDomain.Ticket, error)
+	GetUserTickets(ctx context.Context, paramsGetUserTickets *ticketsDomain.ParamsGetUserTickets) (*ticketsDomain.TicketsPage, error)
+	GetAccompaniedTickets(ctx context.Context, ticketId uuid.UUID) ([]ticketsDomain.Ticket, error)
+	GetUserFlightTickets(ctx context.Context, userId uuid.UUID, flightId uuid.UUID) ([]ticketsDomain.Ticket, error)
+	GetCountFlightInfants(ctx context.Context, flightId uuid.UUID) (int, error)
 	CreateTicket(ctx context.Context, paramsCreateTicket *ticketsDomain.ParamsCreateTicket) (uuid.UUID, error)
 	PayForTicket(ctx context.Context, paramsPayForTicket *ticketsDomain.ParamsPayForTicket) (uuid.UUID, error)
 	RefundTicket(ctx context.Context, paramsRefundTicket *ticketsDomain.ParamsRefundTicket) (uuid.UUID, error)
 	RegisterTicket(ctx context.Context, paramsRegisterTicket *ticketsDomain.ParamsRegisterTicket) (uuid.UUID, error)
+	RegisterTickets(ctx context.Context, paramsRegisterTickets []ticketsDomain.ParamsRegisterTicket) ([]uuid.UUID, error)
+	BoardTicket(ctx context.Context, paramsBoardTicket *ticketsDomain.ParamsBoardTicket) (uuid.UUID, error)
+	CloseFlight(ctx context.Context, paramsCloseFlight *ticketsDomain.ParamsCloseFlight) (*ticketsDomain.ClosedFlight, error)
+	GetFlightManifest(ctx context.Context, flightId uuid.UUID) ([]ticketsDomain.ManifestPassenger, error)
+	AddTicketAncillary(ctx context.Context, paramsAddTicketAncillary *ticketsDomain.ParamsAddTicketAncillary) (uuid.UUID, error)
+	GetCountPassengerWaitlistEntries(ctx context.Context, passengerId uuid.UUID, flightId uuid.UUID) (int, error)
+	GetWaitlistClassesSeats(ctx context.Context) ([]ticketsDomain.WaitlistClassSeats, error)
+	GetWaitlistEntries(ctx context.Context, flightId uuid.UUID, classSeatsId uuid.UUID, limit int) ([]ticketsDomain.WaitlistEntry, error)
+	GetExpiredWaitlistEntries(ctx context.Context, timestamp time.Time) ([]ticketsDomain.WaitlistEntry, error)
+	CreateWaitlistEntry(ctx context.Context, paramsJoinWaitlist *ticketsDomain.ParamsJoinWaitlist) (uuid.UUID, error)
+	CloseWaitlistEntry(ctx context.Context, paramsCloseWaitlistEntry *ticketsDomain.ParamsCloseWaitlistEntry) (uuid.UUID, error)
+	CancelUnpaidTickets(ctx context.Context, timestamp time.Time, createdBefore time.Time, pendingApprovalBefore time.Time) (int64, error)
+	GetPromoCodeByCode(ctx context.Context, code string) (*ticketsDomain.PromoCode, error)
+	GetPromoCodeById(ctx context.Context, promoCodeId uuid.UUID) (*ticketsDomain.PromoCode, error)
+	GetPromoCodeUsage(ctx context.Context, promoCodeId uuid.UUID, userId uuid.UUID) (*ticketsDomain.PromoCodeUsage, error)
+	GetVoucherByCode(ctx context.Context, code string) (*ticketsDomain.Voucher, error)
+	GetCompanyById(ctx context.Context, companyId uuid.UUID) (*ticketsDomain.Company, error)
+	GetCompanyTickets(ctx context.Context, paramsGetCompanyReport *ticketsDomain.ParamsGetCompanyReport) ([]ticketsDomain.Ticket, error)
+	ApproveTicket(ctx context.Context, paramsApproveTicket *ticketsDomain.ParamsApproveTicket) (uuid.UUID, error)
+	CreateCompanyInvoice(ctx context.Context, paramsCreateCompanyInvoice *ticketsDomain.ParamsCreateCompanyInvoice) (*ticketsDomain.CompanyInvoice, error)
+	CreatePriceHold(ctx context.Context, paramsCreatePriceHold *ticketsDomain.ParamsCreatePriceHold) (*ticketsDomain.PriceHold, error)
+	GetActivePriceHolds(ctx context.Context, expiresBefore time.Time) ([]ticketsDomain.PriceHold, error)
+	RemindPriceHold(ctx context.Context, paramsProcessPriceHold *ticketsDomain.ParamsProcessPriceHold) (uuid.UUID, error)
+	ExpirePriceHold(ctx context.Context, paramsProcessPriceHold *ticketsDomain.ParamsProcessPriceHold) (uuid.UUID, error)
+	GetTicketDocuments(ctx context.Context, ticketId uuid.UUID) ([]ticketsDomain.TicketDocument, error)
 }
 
 type storage struct {
 	db *pgxpool.Pool
 }
 
+// документ пассажира в том виде, в котором он хранится в БД.
+// у пассажиров, созданных до появления структурированных документов, поля документа не заполнены
+type documentRow struct {
+	Type           *string
+	Number         *string
+	IssuingCountry *string
+	Nationality    *string
+	BirthDate      *time.Time
+	ExpiryDate     *time.Time
+}
+
+func (d documentRow) toDomain() *ticketsDomain.IdentityDocument {
+
+	if d.Type == nil || d.Number == nil || d.IssuingCountry == nil || d.Nationality == nil || d.BirthDate == nil {
+		return nil
+	}
+
+	return &ticketsDomain.IdentityDocument{
+		Type:           *d.Type,
+		Number:         *d.Number,
+		IssuingCountry: *d.IssuingCountry,
+		Nationality:    *d.Nationality,
+		BirthDate:      *d.BirthDate,
+		ExpiryDate:     d.ExpiryDate,
+	}
+}
+
+// добавление пассажира (passengers)
+
+const sqlQueryInsertPassenger = `INSERT INTO passengers (
+	 		            	id,
+	 		                user_id,
+	 		                name_passenger,
+	 		                identity_data_passenger,
+							document_type,
+							document_number,
+							document_issuing_country,
+							nationality,
+							birth_date,
+							document_expiry_date
+	 					)
+	 					VALUES (
+	 						$1,
+	 				        $2,
+	 				        $3,
+	 				        $4,
+	 				        $5,
+	 				        $6,
+	 				        $7,
+	 				        $8,
+	 				        $9,
+	 				        $10
+	 					);`
+
+func getParamsInsertPassenger(passengerId uuid.UUID, paramsCreatePassenger *ticketsDomain.ParamsCreatePassenger) []interface{} {
+	return []interface{}{
+		passengerId.String(),
+		paramsCreatePassenger.UserId.String(),
+		paramsCreatePassenger.NamePassenger,
+		paramsCreatePassenger.IdentityDataPassenger,
+		paramsCreatePassenger.Document.Type,
+		paramsCreatePassenger.Document.Number,
+		paramsCreatePassenger.Document.IssuingCountry,
+		paramsCreatePassenger.Document.Nationality,
+		paramsCreatePassenger.Document.BirthDate,
+		paramsCreatePassenger.Document.ExpiryDate,
+	}
+}
+
 func (s storage) GetPassengerById(ctx context.Context, passengerId uuid.UUID) (*ticketsDomain.Passenger, error) {
 
 	conn, err := s.db.Acquire(ctx)
@@ -44,17 +151,24 @@ func (s storage) GetPassengerById(ctx context.Context, passengerId uuid.UUID) (*
 					users.email,
      		       	
 					passenger.name_passenger,
-     		       	passenger.identity_data_passenger
+     		       	passenger.identity_data_passenger,
+					passenger.document_type,
+					passenger.document_number,
+					passenger.document_issuing_country,
+					passenger.nationality,
+					passenger.birth_date,
+					passenger.document_expiry_date
         		FROM passengers passenger
 
        			INNER JOIN users
      				ON passenger.user_id = users.id
 
-			WHERE passenger.id = $1`,
+			WHERE passenger.id = $1 AND NOT passenger.is_deleted`,
 		passengerId.String())
 
 	var user usersDomain.User
 	var passenger ticketsDomain.Passenger
+	var document documentRow
 	err = row.Scan(
 		&passenger.Id,
 
@@ -64,6 +178,12 @@ func (s storage) GetPassengerById(ctx context.Context, passengerId uuid.UUID) (*
 
 		&passenger.NamePassenger,
 		&passenger.IdentityDataPassenger,
+		&document.Type,
+		&document.Number,
+		&document.IssuingCountry,
+		&document.Nationality,
+		&document.BirthDate,
+		&document.ExpiryDate,
 	)
 
 	if err != nil {
@@ -75,10 +195,11 @@ func (s storage) GetPassengerById(ctx context.Context, passengerId uuid.UUID) (*
 	}
 
 	passenger.User = user
+	passenger.Document = document.toDomain()
 	return &passenger, nil
 }
 
-func (s storage) GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticketsDomain.Ticket, error) {
+func (s storage) GetUserPassengers(ctx context.Context, userId uuid.UUID) ([]ticketsDomain.Passenger, error) {
 
 	conn, err := s.db.Acquire(ctx)
 	if err != nil {
@@ -86,9 +207,179 @@ func (s storage) GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticket
 	}
 	defer conn.Release()
 
-	row := conn.QueryRow(ctx,
+	rows, err := conn.Query(ctx,
 		`
-     		SELECT 	ticket.id, 
+     		SELECT 	passenger.id, 
+					passenger.name_passenger,
+     		       	passenger.identity_data_passenger,
+					passenger.document_type,
+					passenger.document_number,
+					passenger.document_issuing_country,
+					passenger.nationality,
+					passenger.birth_date,
+					passenger.document_expiry_date
+        		FROM passengers passenger
+			WHERE passenger.user_id = $1 AND NOT passenger.is_deleted
+			ORDER BY passenger.name_passenger`,
+		userId.String())
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	var passengers []ticketsDomain.Passenger
+	for rows.Next() {
+
+		var passenger ticketsDomain.Passenger
+		var document documentRow
+		err = rows.Scan(
+			&passenger.Id,
+			&passenger.NamePassenger,
+			&passenger.IdentityDataPassenger,
+			&document.Type,
+			&document.Number,
+			&document.IssuingCountry,
+			&document.Nationality,
+			&document.BirthDate,
+			&document.ExpiryDate,
+		)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+
+		passenger.User.Id = userId
+		passenger.Document = document.toDomain()
+		passengers = append(passengers, passenger)
+	}
+	return passengers, nil
+}
+
+func (s storage) GetCountPassengerActiveTickets(ctx context.Context, passengerId uuid.UUID, timestamp time.Time) (int, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	// действующие билеты - билеты со статусами 1(Created), 2(Paid), 5(Registered) на рейсы, которые еще не вылетели
+	row := conn.QueryRow(ctx,
+		`SELECT COUNT(ticket.id)
+			FROM tickets ticket
+				INNER JOIN flights flight
+					ON ticket.flight_id = flight.id
+			WHERE ticket.passenger_id = $1
+				AND ticket.status_id IN (1, 2, 5)
+				AND flight.departure_date > $2`,
+		passengerId.String(),
+		timestamp)
+
+	var countTickets int
+	err = row.Scan(
+		&countTickets,
+	)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+	return countTickets, nil
+}
+
+func (s storage) CreatePassenger(ctx context.Context, paramsCreatePassenger *ticketsDomain.ParamsCreatePassenger) (uuid.UUID, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	passengerId := uuid.New()
+	_, err = conn.Exec(ctx, sqlQueryInsertPassenger, getParamsInsertPassenger(passengerId, paramsCreatePassenger)...)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+
+	return passengerId, nil
+}
+
+func (s storage) UpdatePassenger(ctx context.Context, paramsUpdatePassenger *ticketsDomain.ParamsUpdatePassenger) (uuid.UUID, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	// изменяются только данные пассажира (passengers).
+	// в уже оформленных билетах хранится копия данных пассажира на момент оформления, она не изменяется
+	_, err = conn.Exec(ctx,
+		`UPDATE passengers
+					SET name_passenger = $2, 
+						identity_data_passenger = $3,
+						document_type = $4,
+						document_number = $5,
+						document_issuing_country = $6,
+						nationality = $7,
+						birth_date = $8,
+						document_expiry_date = $9
+					WHERE id = $1`,
+		paramsUpdatePassenger.PassengerId.String(),
+		paramsUpdatePassenger.NamePassenger,
+		paramsUpdatePassenger.IdentityDataPassenger,
+		paramsUpdatePassenger.Document.Type,
+		paramsUpdatePassenger.Document.Number,
+		paramsUpdatePassenger.Document.IssuingCountry,
+		paramsUpdatePassenger.Document.Nationality,
+		paramsUpdatePassenger.Document.BirthDate,
+		paramsUpdatePassenger.Document.ExpiryDate,
+	)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+
+	return paramsUpdatePassenger.PassengerId, nil
+}
+
+func (s storage) DeletePassenger(ctx context.Context, paramsDeletePassenger *ticketsDomain.ParamsDeletePassenger) (uuid.UUID, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	// пассажир помечается на удаление, т.к. на него ссылаются ранее оформленные билеты.
+	// проверка действующих билетов (как в GetCountPassengerActiveTickets) выполняется в том же запросе,
+	// чтобы билет, оформленный параллельно с удалением, не остался у удаленного пассажира
+	commandTag, err := conn.Exec(ctx,
+		`UPDATE passengers
+					SET is_deleted = true
+					WHERE id = $1
+						AND NOT EXISTS (
+							SELECT 1
+								FROM tickets ticket
+									INNER JOIN flights flight
+										ON ticket.flight_id = flight.id
+								WHERE ticket.passenger_id = $1
+									AND ticket.status_id IN (1, 2, 5)
+									AND flight.departure_date > $2
+						)`,
+		paramsDeletePassenger.PassengerId.String(),
+		paramsDeletePassenger.Timestamp,
+	)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return uuid.UUID{}, terr.Conflict("PASSENGER_HAS_ACTIVE_TICKETS", fmt.Sprintf("passenger (id %s) has active tickets", paramsDeletePassenger.PassengerId))
+	}
+
+	return paramsDeletePassenger.PassengerId, nil
+}
+
+// получение данных билетов
+
+func getSqlQueryTickets(sqlQueryCondition string) string {
+	return `SELECT 	ticket.id, 
 						
 					status.id,
                    	status.name,
@@ -102,27 +393,47 @@ func (s storage) GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticket
      		       			airline.name,
 						airport_departure.id,
 						airport_departure.name,
+						airport_departure.time_zone,
      		       			city_departure.id,
      		       			city_departure.name,
      		       		airport_arrival.id,
-     		       		airport_arrival.name,    		          		       
+     		       		airport_arrival.name,
+     		       		airport_arrival.time_zone,
      		       			city_arrival.id,
      		       			city_arrival.name,  
 					flight.departure_date,
      		        flight.duration, 
+					flight.currency,
 					flight.price_additional_baggage,
      		        flight.price_seat_selection,
      		        flight.is_international,
      		        flight.baggage_included,
      		        flight.pet_allowed,
+					COALESCE(flight_status.state, 'on_time'),
+					flight_status.estimated_departure,
+					flight_status.actual_departure,
+					flight_status.estimated_arrival,
+					flight_status.actual_arrival,
+					COALESCE(flight_status.terminal, ''),
+					COALESCE(flight_status.gate, ''),
+					flight_status.boarding_time,
+					flight_status.updated_timestamp,
 
 					users.id,
 					users.name,
 					users.email,
 
-     		 		passenger.id, 
-     		       	passenger.name_passenger,
-     		       	passenger.identity_data_passenger,
+     		 		ticket.passenger_id, 
+     		       	ticket.name_passenger,
+     		       	ticket.identity_data_passenger,
+					ticket.document_type,
+					ticket.document_number,
+					ticket.document_issuing_country,
+					ticket.nationality,
+					ticket.birth_date,
+					ticket.document_expiry_date,
+					ticket.passenger_type,
+					ticket.accompanying_ticket_id,
 
      				class_seats.id,
    	 				class_seats.name,
@@ -149,8 +460,27 @@ func (s storage) GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticket
 
 					ticket.count_additional_baggage,
 					ticket.price,
+					ticket.currency,
+					ticket.exchange_rate,
+					ticket.exchange_rate_timestamp,
 					ticket.paid_with_bonuses,
-					ticket.accrued_bonuses
+					ticket.accrued_bonuses,
+					ticket.promo_code_id,
+					COALESCE(promo_code.code, ''),
+					ticket.company_id,
+					COALESCE(company.name, ''),
+					ticket.booker_id,
+					COALESCE(ticket.approval_status, ''),
+					ticket.policy_violations,
+					ticket.approver_id,
+					ticket.approval_timestamp,
+					price_hold.id,
+					COALESCE(price_hold.fee, 0),
+					COALESCE(price_hold.currency, ''),
+					COALESCE(price_hold.status, ''),
+					price_hold.hold_timestamp,
+					price_hold.expiry_timestamp,
+					price_hold.reminder_timestamp
 
        		FROM tickets ticket
 
@@ -171,21 +501,31 @@ func (s storage) GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticket
      					ON flight.arrival_airport_id = airport_arrival.id
      					INNER JOIN cities city_arrival
      						ON airport_arrival.city_id = city_arrival.id
+    				LEFT JOIN flights_status flight_status
+    					ON flight.id = flight_status.flight_id
 
       			INNER JOIN users
      				ON ticket.user_id = users.id
 
-      			INNER JOIN passengers passenger
-     				ON ticket.passenger_id = passenger.id
-
       			INNER JOIN classes_seats class_seats
      				ON ticket.class_seats_id = class_seats.id
 
       			LEFT JOIN seats seat
      				ON ticket.seat_id = seat.id
 
- 			WHERE ticket.id = $1`,
-		ticketId.String())
+      			LEFT JOIN promo_codes promo_code
+     				ON ticket.promo_code_id = promo_code.id
+
+      			LEFT JOIN companies company
+     				ON ticket.company_id = company.id
+
+      			LEFT JOIN price_holds price_hold
+     				ON ticket.id = price_hold.ticket_id
+
+ 			WHERE ` + sqlQueryCondition
+}
+
+func scanTicket(row pgx.Row) (ticketsDomain.Ticket, error) {
 
 	var status ticketsDomain.Status
 	var aircraft flightsDomain.Aircraft
@@ -198,12 +538,18 @@ func (s storage) GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticket
 	var flight flightsDomain.Flight
 	var user usersDomain.User
 	var passenger ticketsDomain.Passenger
+	var document documentRow
 	var classSeats flightsDomain.ClassSeats
 	var isSeatAssigned bool
 	var seat flightsDomain.Seat
+	var companyId *uuid.UUID
+	var ticketCompany ticketsDomain.TicketCompany
+	var priceHoldId *uuid.UUID
+	var priceHold ticketsDomain.PriceHold
+	var holdTimestamp, expiryTimestamp *time.Time
 	var ticket ticketsDomain.Ticket
 
-	err = row.Scan(
+	err := row.Scan(
 		&ticket.Id,
 
 		&status.Id,
@@ -218,20 +564,32 @@ func (s storage) GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticket
 		&airline.Name,
 		&airportDeparture.Id,
 		&airportDeparture.Name,
+		&airportDeparture.TimeZone,
 		&cityDeparture.Id,
 		&cityDeparture.Name,
 		&airportArrival.Id,
 		&airportArrival.Name,
+		&airportArrival.TimeZone,
 		&cityArrival.Id,
 		&cityArrival.Name,
 
 		&flight.DepartureDate,
 		&durationMin,
-		&flight.PriceAdditionalBaggage,
-		&flight.PriceSeatSelection,
+		&flight.Currency,
+		&flight.PriceAdditionalBaggage.Amount,
+		&flight.PriceSeatSelection.Amount,
 		&flight.IsInternational,
 		&flight.BaggageIncluded,
 		&flight.PetAllowed,
+		&flight.Status.State,
+		&flight.Status.EstimatedDeparture,
+		&flight.Status.ActualDeparture,
+		&flight.Status.EstimatedArrival,
+		&flight.Status.ActualArrival,
+		&flight.Status.Terminal,
+		&flight.Status.Gate,
+		&flight.Status.BoardingTime,
+		&flight.Status.UpdatedTimestamp,
 
 		&user.Id,
 		&user.Name,
@@ -240,6 +598,14 @@ func (s storage) GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticket
 		&passenger.Id,
 		&passenger.NamePassenger,
 		&passenger.IdentityDataPassenger,
+		&document.Type,
+		&document.Number,
+		&document.IssuingCountry,
+		&document.Nationality,
+		&document.BirthDate,
+		&document.ExpiryDate,
+		&ticket.PassengerType,
+		&ticket.AccompanyingTicketId,
 
 		&classSeats.Id,
 		&classSeats.Name,
@@ -253,18 +619,32 @@ func (s storage) GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticket
 		&seat.Number,
 
 		&ticket.CountAdditionalBaggage,
-		&ticket.Price,
-		&ticket.PaidWithBonuses,
-		&ticket.AccruedBonuses,
+		&ticket.Price.Amount,
+		&ticket.Price.Currency,
+		&ticket.ExchangeRate.Rate,
+		&ticket.ExchangeRate.Timestamp,
+		&ticket.PaidWithBonuses.Amount,
+		&ticket.AccruedBonuses.Amount,
+		&ticket.PromoCodeId,
+		&ticket.PromoCode,
+		&companyId,
+		&ticketCompany.CompanyName,
+		&ticketCompany.BookerId,
+		&ticketCompany.ApprovalStatus,
+		&ticketCompany.PolicyViolations,
+		&ticketCompany.ApproverId,
+		&ticketCompany.ApprovalTimestamp,
+		&priceHoldId,
+		&priceHold.Fee.Amount,
+		&priceHold.Fee.Currency,
+		&priceHold.Status,
+		&holdTimestamp,
+		&expiryTimestamp,
+		&priceHold.ReminderTimestamp,
 	)
 
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, terr.NotFound(fmt.Sprintf("not found ticket (id %s)", ticketId))
-
-		} else {
-			return nil, terr.SQLDatabaseError(err)
-		}
+		return ticket, err
 	}
 
 	ticket.Status = status
@@ -276,11 +656,20 @@ func (s storage) GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticket
 	flight.DepartureAirport = airportDeparture
 	flight.ArrivalAirport = airportArrival
 	flight.Duration = time.Duration(durationMin) * time.Minute
+	flight.PriceAdditionalBaggage.Currency = flight.Currency
+	flight.PriceSeatSelection.Currency = flight.Currency
 	ticket.Flight = flight
 
+	// бонусы - в валюте учета, курс билета - курс валюты билета к валюте учета
+	ticket.PaidWithBonuses.Currency = money.BaseCurrency
+	ticket.AccruedBonuses.Currency = money.BaseCurrency
+	ticket.ExchangeRate.From = ticket.Price.Currency
+	ticket.ExchangeRate.To = money.BaseCurrency
+
 	ticket.User = user
 
 	passenger.User = user
+	passenger.Document = document.toDomain()
 	ticket.Passenger = passenger
 
 	classSeats.Aircraft = aircraft
@@ -291,199 +680,643 @@ func (s storage) GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticket
 		ticket.Seat = &seat
 	}
 
-	return &ticket, nil
+	if companyId != nil {
+		ticketCompany.CompanyId = *companyId
+		ticket.Company = &ticketCompany
+	}
+
+	if priceHoldId != nil {
+		priceHold.Id = *priceHoldId
+		priceHold.TicketId = ticket.Id
+		priceHold.UserId = user.Id
+		priceHold.HoldTimestamp = *holdTimestamp
+		priceHold.ExpiryTimestamp = *expiryTimestamp
+		ticket.PriceHold = &priceHold
+	}
+
+	return ticket, nil
 }
 
-func (s storage) CreateTicket(ctx context.Context, paramsCreateTicket *ticketsDomain.ParamsCreateTicket) (uuid.UUID, error) {
+// получение состава стоимости билетов в разрезе id билетов
+
+func (s storage) getMapTicketsItems(ctx context.Context, ticketsIds []string) (map[uuid.UUID][]ticketsDomain.TicketItem, error) {
 
 	conn, err := s.db.Acquire(ctx)
 	if err != nil {
-		return uuid.UUID{}, terr.SQLDatabaseError(err)
+		return nil, terr.SQLDatabaseError(err)
 	}
 	defer conn.Release()
 
-	// начало транзакции
-	tx, err := s.db.Begin(ctx)
+	rows, err := conn.Query(ctx,
+		`SELECT 	item.id,
+					item.ticket_id,
+					item.item_type,
+					item.name,
+					item.tax_fee_id,
+					item.flight_ancillary_id,
+					item.quantity,
+					item.price,
+					ticket.currency,
+					item.item_timestamp
+			FROM tickets_items item
+				INNER JOIN tickets ticket
+					ON item.ticket_id = ticket.id
+			WHERE item.ticket_id = ANY($1)
+			ORDER BY item.item_timestamp, item.item_type`,
+		ticketsIds)
 	if err != nil {
-		return uuid.UUID{}, terr.SQLDatabaseError(err)
+		return nil, terr.SQLDatabaseError(err)
 	}
-	defer tx.Rollback(ctx)
-
-	// пакетный запрос
-	batch := new(pgx.Batch)
-
-	// добавление заданий в пакет
-	var arrParams []interface{}
-	var sqlQuery string
-
-	// 1. Создание пассажира (passengers)
-	var passengerId uuid.UUID
-	if paramsCreateTicket.PassengerId == nil {
-		passengerId = uuid.New()
-		arrParams = []interface{}{
-			passengerId.String(),
-			paramsCreateTicket.UserId.String(),
-			paramsCreateTicket.ParamsCreatePassenger.NamePassenger,
-			paramsCreateTicket.ParamsCreatePassenger.IdentityDataPassenger,
+	defer rows.Close()
+
+	mapTicketsItems := make(map[uuid.UUID][]ticketsDomain.TicketItem)
+	for rows.Next() {
+
+		var ticketId uuid.UUID
+		var ticketItem ticketsDomain.TicketItem
+
+		err = rows.Scan(
+			&ticketItem.Id,
+			&ticketId,
+			&ticketItem.Type,
+			&ticketItem.Name,
+			&ticketItem.TaxFeeId,
+			&ticketItem.FlightAncillaryId,
+			&ticketItem.Quantity,
+			&ticketItem.Price.Amount,
+			&ticketItem.Price.Currency,
+			&ticketItem.Timestamp,
+		)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
 		}
-		sqlQuery = `INSERT INTO passengers (
-	 		            	id,
-	 		                user_id,
-	 		                name_passenger,
-	 		                identity_data_passenger
-	 					)
-	 					VALUES (
-	 						$1,
-	 				        $2,
-	 				        $3,
-	 				        $4
-	 					);`
-		batch.Queue(sqlQuery, arrParams...)
-	} else {
-		passengerId = *paramsCreateTicket.PassengerId
-	}
 
-	// 2. Создание билета (tickets)
-	ticketId := uuid.New()
-	arrParams = []interface{}{
-		ticketId.String(),
-		paramsCreateTicket.StatusTimestamp,
-		paramsCreateTicket.FlightId.String(),
-		paramsCreateTicket.UserId.String(),
-		passengerId.String(),
-		paramsCreateTicket.ClassSeatsId.String(),
-		paramsCreateTicket.CountAdditionalBaggage,
-		paramsCreateTicket.Price,
+		ticketItems := mapTicketsItems[ticketId]
+		ticketItems = append(ticketItems, ticketItem)
+		mapTicketsItems[ticketId] = ticketItems
 	}
+	return mapTicketsItems, nil
+}
 
-	if paramsCreateTicket.SeatId != nil {
-		// создание билета с выбранным местом
-		arrParams = append(arrParams,
-			paramsCreateTicket.SeatId.String(),
-		)
-		sqlQuery = `
-	 		INSERT INTO tickets (
+// добавление позиции состава стоимости билета (tickets_items)
+
+const sqlQueryInsertTicketItem = `INSERT INTO tickets_items (
 	 		            	id,
-							status_id,
-							status_timestamp,
-	 		                flight_id,
-	 		                user_id,
-	 		            	passenger_id,
-	 		                class_seats_id,
-	 		                count_additional_baggage,
+	 		                ticket_id,
+	 		                item_type,
+	 		                name,
+	 		                tax_fee_id,
+	 		                flight_ancillary_id,
+	 		                quantity,
 	 		                price,
-	 		                paid_with_bonuses,
-	 		                accrued_bonuses,
-							seat_id
-	 				)
-	 				VALUES (
+	 		                item_timestamp
+	 					)
+	 					VALUES (
 	 						$1,
-							1,
 	 				        $2,
 	 				        $3,
 	 				        $4,
 	 				        $5,
 	 				        $6,
 	 				        $7,
-							$8,
-							0,
-							0,
+	 				        $8,
 	 				        $9
-	 				);`
-	} else {
-		// создание билета без выбранного места
-		sqlQuery = `
-	 		INSERT INTO tickets (
-	 		            	id,
-							status_id,
-							status_timestamp,
-	 		                flight_id,
-	 		                user_id,
-	 		            	passenger_id,
-	 		                class_seats_id,
-	 		                count_additional_baggage,
-	 		                price,
-	 		                paid_with_bonuses,
-	 		                accrued_bonuses
-	 				)
-	 				VALUES (
-	 						$1,
-							1,
-	 				        $2,
-	 				        $3,
-	 				        $4,
-	 				        $5,
-	 				        $6,
-	 				        $7,
-							$8,
-							0,
-							0
-					);`
+	 					);`
+
+func getParamsInsertTicketItem(itemId uuid.UUID, ticketId uuid.UUID, ticketItem *ticketsDomain.TicketItem) []interface{} {
+	return []interface{}{
+		itemId.String(),
+		ticketId.String(),
+		ticketItem.Type,
+		ticketItem.Name,
+		ticketItem.TaxFeeId,
+		ticketItem.FlightAncillaryId,
+		ticketItem.Quantity,
+		ticketItem.Price.Amount,
+		ticketItem.Timestamp,
 	}
-	batch.Queue(sqlQuery, arrParams...)
+}
 
-	// отправка пакета в БД
-	res := tx.SendBatch(ctx, batch)
+func (s storage) GetTicketById(ctx context.Context, ticketId uuid.UUID) (*ticketsDomain.Ticket, error) {
 
-	// операция закрытия соединения
-	if err = res.Close(); err != nil {
-		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
 	}
+	defer conn.Release()
 
-	// подтверждение транзакции
-	if err = tx.Commit(ctx); err != nil {
-		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	sqlQuery := getSqlQueryTickets("ticket.id = $1")
+	row := conn.QueryRow(ctx, sqlQuery, ticketId.String())
+
+	ticket, err := scanTicket(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, terr.NotFound(fmt.Sprintf("not found ticket (id %s)", ticketId))
+
+		} else {
+			return nil, terr.SQLDatabaseError(err)
+		}
 	}
 
-	return ticketId, nil
+	mapTicketsItems, err := s.getMapTicketsItems(ctx, []string{ticket.Id.String()})
+	if err != nil {
+		return nil, err
+	}
+	ticket.Items = mapTicketsItems[ticket.Id]
+
+	mapTicketsPayments, err := s.getMapTicketsPayments(ctx, []string{ticket.Id.String()})
+	if err != nil {
+		return nil, err
+	}
+	ticket.Payments = mapTicketsPayments[ticket.Id]
+
+	return &ticket, nil
 }
 
-func (s storage) PayForTicket(ctx context.Context, paramsPayForTicket *ticketsDomain.ParamsPayForTicket) (uuid.UUID, error) {
+func (s storage) GetUserTickets(ctx context.Context, paramsGetUserTickets *ticketsDomain.ParamsGetUserTickets) (*ticketsDomain.TicketsPage, error) {
 
 	conn, err := s.db.Acquire(ctx)
 	if err != nil {
-		return uuid.UUID{}, terr.SQLDatabaseError(err)
+		return nil, terr.SQLDatabaseError(err)
 	}
 	defer conn.Release()
 
-	// начало транзакции
-	tx, err := s.db.Begin(ctx)
-	if err != nil {
-		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	// отбор билетов пользователя формируется по переданным фильтрам,
+	// все билеты получаются одним запросом с теми же соединениями, что и в GetTicketById
+	paramsQuery := []interface{}{
+		paramsGetUserTickets.UserId.String(),
 	}
-	defer tx.Rollback(ctx)
+	sqlQueryCondition := "ticket.user_id = $1"
 
-	// пакетный запрос
+	if len(paramsGetUserTickets.StatusesNames) > 0 {
+		paramsQuery = append(paramsQuery, paramsGetUserTickets.StatusesNames)
+		sqlQueryCondition += fmt.Sprintf(" AND status.name = ANY($%d)", len(paramsQuery))
+	}
+
+	switch paramsGetUserTickets.Period {
+	case ticketsDomain.TicketsPeriodUpcoming:
+		paramsQuery = append(paramsQuery, paramsGetUserTickets.Timestamp)
+		sqlQueryCondition += fmt.Sprintf(" AND flight.departure_date >= $%d", len(paramsQuery))
+	case ticketsDomain.TicketsPeriodPast:
+		paramsQuery = append(paramsQuery, paramsGetUserTickets.Timestamp)
+		sqlQueryCondition += fmt.Sprintf(" AND flight.departure_date < $%d", len(paramsQuery))
+	}
+
+	if paramsGetUserTickets.DepartureDateFrom != nil {
+		paramsQuery = append(paramsQuery, *paramsGetUserTickets.DepartureDateFrom)
+		sqlQueryCondition += fmt.Sprintf(" AND flight.departure_date::date >= $%d", len(paramsQuery))
+	}
+	if paramsGetUserTickets.DepartureDateTo != nil {
+		paramsQuery = append(paramsQuery, *paramsGetUserTickets.DepartureDateTo)
+		sqlQueryCondition += fmt.Sprintf(" AND flight.departure_date::date <= $%d", len(paramsQuery))
+	}
+
+	// предстоящие рейсы выводятся от ближайшего к дальнему, остальные - от последнего к первому.
+	// курсор - пара (дата вылета, id билета) последнего билета предыдущей страницы
+	sortAscending := paramsGetUserTickets.Period == ticketsDomain.TicketsPeriodUpcoming
+	compareOperator, sortDirection := "<", "DESC"
+	if sortAscending {
+		compareOperator, sortDirection = ">", "ASC"
+	}
+
+	if paramsGetUserTickets.Cursor != nil {
+		paramsQuery = append(paramsQuery,
+			paramsGetUserTickets.Cursor.DepartureDate,
+			paramsGetUserTickets.Cursor.TicketId.String(),
+		)
+		sqlQueryCondition += fmt.Sprintf(" AND (flight.departure_date, ticket.id) %s ($%d, $%d)",
+			compareOperator, len(paramsQuery)-1, len(paramsQuery))
+	}
+
+	// запрашиваем на один билет больше, чтобы определить наличие следующей страницы
+	paramsQuery = append(paramsQuery, paramsGetUserTickets.Limit+1)
+	sqlQueryCondition += fmt.Sprintf(" ORDER BY flight.departure_date %s, ticket.id %s LIMIT $%d",
+		sortDirection, sortDirection, len(paramsQuery))
+
+	sqlQuery := getSqlQueryTickets(sqlQueryCondition)
+	rows, err := conn.Query(ctx, sqlQuery, paramsQuery...)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	var ticketsPage ticketsDomain.TicketsPage
+	for rows.Next() {
+
+		ticket, err := scanTicket(rows)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		ticketsPage.Tickets = append(ticketsPage.Tickets, ticket)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+
+	if len(ticketsPage.Tickets) > paramsGetUserTickets.Limit {
+		ticketsPage.Tickets = ticketsPage.Tickets[:paramsGetUserTickets.Limit]
+		lastTicket := ticketsPage.Tickets[len(ticketsPage.Tickets)-1]
+		ticketsPage.NextCursor = &ticketsDomain.TicketsCursor{
+			DepartureDate: lastTicket.Flight.DepartureDate,
+			TicketId:      lastTicket.Id,
+		}
+	}
+
+	// состав стоимости и оплаты билетов страницы получаются одним запросом каждый, как и в GetTicketById
+	ticketsIds := make([]string, len(ticketsPage.Tickets))
+	for i, ticket := range ticketsPage.Tickets {
+		ticketsIds[i] = ticket.Id.String()
+	}
+	mapTicketsItems, err := s.getMapTicketsItems(ctx, ticketsIds)
+	if err != nil {
+		return nil, err
+	}
+	mapTicketsPayments, err := s.getMapTicketsPayments(ctx, ticketsIds)
+	if err != nil {
+		return nil, err
+	}
+	for i, ticket := range ticketsPage.Tickets {
+		ticketsPage.Tickets[i].Items = mapTicketsItems[ticket.Id]
+		ticketsPage.Tickets[i].Payments = mapTicketsPayments[ticket.Id]
+	}
+
+	return &ticketsPage, nil
+}
+
+// получение действующих (не отмененных и не возвращенных) билетов детей и младенцев,
+// оформленных с сопровождением по билету взрослого ticketId
+func (s storage) GetAccompaniedTickets(ctx context.Context, ticketId uuid.UUID) ([]ticketsDomain.Ticket, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	sqlQuery := getSqlQueryTickets("ticket.accompanying_ticket_id = $1 AND ticket.status_id <> 3 AND ticket.status_id <> 4")
+	rows, err := conn.Query(ctx, sqlQuery, ticketId.String())
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	var tickets []ticketsDomain.Ticket
+	for rows.Next() {
+
+		ticket, err := scanTicket(rows)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		tickets = append(tickets, ticket)
+	}
+	return tickets, nil
+}
+
+// получение действующих (не отмененных и не возвращенных) билетов пользователя на рейс
+func (s storage) GetUserFlightTickets(ctx context.Context, userId uuid.UUID, flightId uuid.UUID) ([]ticketsDomain.Ticket, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	sqlQuery := getSqlQueryTickets("ticket.user_id = $1 AND ticket.flight_id = $2 AND ticket.status_id <> 3 AND ticket.status_id <> 4")
+	rows, err := conn.Query(ctx, sqlQuery, userId.String(), flightId.String())
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	var tickets []ticketsDomain.Ticket
+	for rows.Next() {
+
+		ticket, err := scanTicket(rows)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		tickets = append(tickets, ticket)
+	}
+	return tickets, nil
+}
+
+// получение количества действующих (не отмененных и не возвращенных) билетов младенцев на рейс
+func (s storage) GetCountFlightInfants(ctx context.Context, flightId uuid.UUID) (int, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	row := conn.QueryRow(ctx,
+		`SELECT COUNT(ticket.id)
+			FROM tickets ticket
+			WHERE ticket.flight_id = $1
+				AND ticket.passenger_type = 'infant'
+				AND ticket.status_id <> 3 AND ticket.status_id <> 4`,
+		flightId.String())
+
+	var countInfants int
+	err = row.Scan(
+		&countInfants,
+	)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+	return countInfants, nil
+}
+
+// оформление билета в транзакции: создание пассажира (если он не существует) и билета, изменение остатков мест рейса,
+// добавление в пакет заданий создания состава стоимости билета
+func createTicket(ctx context.Context, tx pgx.Tx, batch *pgx.Batch, paramsCreateTicket *ticketsDomain.ParamsCreateTicket) (uuid.UUID, error) {
+
+	var arrParams []interface{}
+	var sqlQuery string
+
+	// 1. Создание пассажира (passengers)
+	var passengerId uuid.UUID
+	if paramsCreateTicket.PassengerId == nil {
+		passengerId = uuid.New()
+		arrParams = getParamsInsertPassenger(passengerId, paramsCreateTicket.ParamsCreatePassenger)
+		sqlQuery = sqlQueryInsertPassenger
+		if _, err := tx.Exec(ctx, sqlQuery, arrParams...); err != nil {
+			return uuid.UUID{}, terr.SQLDatabaseError(err)
+		}
+	} else {
+		passengerId = *paramsCreateTicket.PassengerId
+	}
+
+	// 2. Создание билета (tickets).
+	// В билет копируются данные пассажира на момент оформления,
+	// чтобы последующее изменение пассажира не меняло уже оформленные билеты.
+	// Если пассажир не найден (например, удален), то билет не создается.
+	// Место seat_id заполняется, если место выбрано при оформлении билета.
+	// Для ребенка и младенца заполняется билет сопровождающего взрослого accompanying_ticket_id.
+	// Для билета компании заполняются компания, кто оформил билет, статус согласования и нарушения правил поездок.
+	ticketId := uuid.New()
+	var approvalStatus *string
+	if paramsCreateTicket.ApprovalStatus != "" {
+		approvalStatus = &paramsCreateTicket.ApprovalStatus
+	}
+	arrParams = []interface{}{
+		ticketId.String(),
+		paramsCreateTicket.StatusTimestamp,
+		paramsCreateTicket.FlightId.String(),
+		paramsCreateTicket.UserId.String(),
+		passengerId.String(),
+		paramsCreateTicket.ClassSeatsId.String(),
+		paramsCreateTicket.CountAdditionalBaggage,
+		paramsCreateTicket.Price.Amount,
+		paramsCreateTicket.SeatId,
+		paramsCreateTicket.PassengerType,
+		paramsCreateTicket.AccompanyingTicketId,
+		paramsCreateTicket.Price.Currency,
+		paramsCreateTicket.ExchangeRate.Rate,
+		paramsCreateTicket.ExchangeRate.Timestamp,
+		paramsCreateTicket.PromoCodeId,
+		paramsCreateTicket.CompanyId,
+		paramsCreateTicket.BookerId,
+		approvalStatus,
+		paramsCreateTicket.PolicyViolations,
+	}
+	sqlQuery = `
+	 		INSERT INTO tickets (
+	 		            	id,
+							status_id,
+							status_timestamp,
+	 		                flight_id,
+	 		                user_id,
+	 		            	passenger_id,
+	 		                class_seats_id,
+	 		                count_additional_baggage,
+	 		                price,
+	 		                currency,
+	 		                exchange_rate,
+	 		                exchange_rate_timestamp,
+	 		                promo_code_id,
+	 		                company_id,
+	 		                booker_id,
+	 		                approval_status,
+	 		                policy_violations,
+	 		                paid_with_bonuses,
+	 		                accrued_bonuses,
+							seat_id,
+							passenger_type,
+							accompanying_ticket_id,
+							name_passenger,
+							identity_data_passenger,
+							document_type,
+							document_number,
+							document_issuing_country,
+							nationality,
+							birth_date,
+							document_expiry_date
+	 				)
+	 				SELECT 
+	 						$1,
+							1,
+	 				        $2,
+	 				        $3,
+	 				        $4,
+	 				        passenger.id,
+	 				        $6,
+	 				        $7,
+							$8,
+							$12,
+							$13,
+							$14,
+							$15,
+							$16,
+							$17,
+							$18,
+							$19,
+							0,
+							0,
+	 				        $9,
+	 				        $10,
+	 				        $11,
+							passenger.name_passenger,
+							passenger.identity_data_passenger,
+							passenger.document_type,
+							passenger.document_number,
+							passenger.document_issuing_country,
+							passenger.nationality,
+							passenger.birth_date,
+							passenger.document_expiry_date
+						FROM passengers passenger
+						WHERE passenger.id = $5 AND NOT passenger.is_deleted;`
+	commandTag, err := tx.Exec(ctx, sqlQuery, arrParams...)
+	if isSeatTakenError(err) {
+		return uuid.UUID{}, terr.Conflict("SEAT_ALREADY_TAKEN", fmt.Sprintf("seat (id %s) is already taken", paramsCreateTicket.SeatId))
+	}
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return uuid.UUID{}, terr.NotFound(fmt.Sprintf("passenger (id %s) not found", passengerId))
+	}
+
+	// 3. Создание состава стоимости билета (tickets_items): тариф, сборы, дополнительный багаж, выбор места
+	for i := range paramsCreateTicket.Items {
+		arrParams = getParamsInsertTicketItem(uuid.New(), ticketId, &paramsCreateTicket.Items[i])
+		batch.Queue(sqlQueryInsertTicketItem, arrParams...)
+	}
+
+	// 4. Увеличение количества проданных мест в остатках мест рейса (flight_inventory).
+	// Младенец без отдельного места место не занимает.
+	// Удержанное место переходит в проданные: уменьшается количество удержанных мест.
+	// Если свободных мест класса не осталось (их заняли параллельно), то билет не создается
+	var countSold, countHeld int
+	if paramsCreateTicket.PassengerType != ticketsDomain.PassengerTypeInfant {
+		countSold = 1
+	}
+	if paramsCreateTicket.SeatHoldId != nil {
+		countHeld = -1
+	}
+	if countSold != 0 || countHeld != 0 {
+		err = inventory.AddCounts(ctx, tx, paramsCreateTicket.FlightId, paramsCreateTicket.ClassSeatsId, countSold, countHeld)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+	}
+
+	return ticketId, nil
+}
+
+func (s storage) CreateTicket(ctx context.Context, paramsCreateTicket *ticketsDomain.ParamsCreateTicket) (uuid.UUID, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	// начало транзакции
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Удержание места, по которому оформляется билет, удаляется (переходит в билет).
+	// Если удержание уже истекло или было использовано, то билет не создается
+	if paramsCreateTicket.SeatHoldId != nil {
+		commandTag, err := tx.Exec(ctx,
+			`DELETE FROM seat_holds WHERE id = $1 AND expires_at > $2`,
+			paramsCreateTicket.SeatHoldId.String(),
+			paramsCreateTicket.StatusTimestamp)
+		if err != nil {
+			return uuid.UUID{}, terr.SQLDatabaseError(err)
+		}
+		if commandTag.RowsAffected() == 0 {
+			return uuid.UUID{}, terr.Conflict("SEAT_HOLD_EXPIRED", fmt.Sprintf("seat hold (id %s) has expired", paramsCreateTicket.SeatHoldId))
+		}
+	}
+
+	// пакетный запрос
 	batch := new(pgx.Batch)
 
-	// добавление заданий в пакет
+	// создание билета и добавление заданий в пакет
+	ticketId, err := createTicket(ctx, tx, batch, paramsCreateTicket)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// отправка пакета в БД
+	res := tx.SendBatch(ctx, batch)
+
+	// операция закрытия соединения
+	if err = res.Close(); err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+
+	// подтверждение транзакции
+	if err = tx.Commit(ctx); err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+
+	return ticketId, nil
+}
+
+func (s storage) PayForTicket(ctx context.Context, paramsPayForTicket *ticketsDomain.ParamsPayForTicket) (uuid.UUID, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	// начало транзакции
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
 
 	// 1. Изменение билета (tickets). Билету  устанавливаются:
 	// - статус status_id = 2(Paid) и время изменения статуса status_timestamp
 	// - сумма начисляемых бонусных баллов accrued_bonuses
 	// - сумма бонусов, использованных для оплаты билета paid_with_bonuses
-	arrParams := []interface{}{
+	// Оплачивается только билет со статусом 1(Created): билет мог быть отменен фоновой обработкой
+	// с освобождением места после проверок сервиса или уже оплачен параллельным запросом.
+	// Переход статуса выполняется до списания сертификатов, кредитов и бонусов,
+	// поэтому повторная оплата ничего не списывает
+	commandTag, err := tx.Exec(ctx,
+		`UPDATE tickets
+			SET status_id = 2,
+				status_timestamp = $2,
+				paid_with_bonuses = $3,
+				accrued_bonuses = $4
+			WHERE id = $1 AND status_id = 1`,
 		paramsPayForTicket.TicketId.String(),
 		paramsPayForTicket.StatusTimestamp,
-		paramsPayForTicket.PaidWithBonuses,
-		paramsPayForTicket.AccruedBonuses,
-	}
-	sqlQuery := `UPDATE tickets
-					SET status_id = 2, 
-						status_timestamp = $2, 
-						paid_with_bonuses = $3, 
-						accrued_bonuses = $4 
-					WHERE id = $1`
-	batch.Queue(sqlQuery, arrParams...)
+		paramsPayForTicket.PaidWithBonuses.Amount,
+		paramsPayForTicket.AccruedBonuses.Amount)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return uuid.UUID{}, terr.Conflict("INVALID_STATUS_TICKET", fmt.Sprintf("ticket (id %s) isn't awaiting payment", paramsPayForTicket.TicketId))
+	}
+
+	// Использование промокода, примененного при оформлении билета, фиксируется вместе с оплатой
+	if paramsPayForTicket.PromoCode != nil {
+		err = redeemPromoCode(ctx, tx, paramsPayForTicket)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+	}
+
+	// Списание оплаты с остатков сертификатов и кредитов
+	err = debitVouchers(ctx, tx, paramsPayForTicket)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	// Удержание цены билета завершается оплатой, если оно еще не истекло
+	if paramsPayForTicket.PriceHoldId != nil {
+		err = payPriceHold(ctx, tx, paramsPayForTicket)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+	}
+
+	// пакетный запрос
+	batch := new(pgx.Batch)
+
+	// добавление заданий в пакет
 
 	// 2. Изменения баланса пользователя (users_balance).
+	var arrParams []interface{}
+	var sqlQuery string
 	if !paramsPayForTicket.UserBalanceInit {
 		// Если для пользователя еще не заполнен баланс, то добавляется запись в таблицу users_balance.
 		// Сумма покупок sum_purchases устанавливается равной стоимости билета.
 		arrParams = []interface{}{
 			uuid.New().String(),
 			paramsPayForTicket.UserId.String(),
-			paramsPayForTicket.Price,
+			paramsPayForTicket.Price.Amount,
 		}
 		sqlQuery = `INSERT INTO users_balance (
 		            	id,
@@ -504,8 +1337,8 @@ func (s storage) PayForTicket(ctx context.Context, paramsPayForTicket *ticketsDo
 		// - по пользователю уменьшается общая сумма бонусов sum_bonuses на сумму бонусов, использованную при покупке билета.
 		arrParams = []interface{}{
 			paramsPayForTicket.UserId.String(),
-			paramsPayForTicket.Price,
-			paramsPayForTicket.PaidWithBonuses,
+			paramsPayForTicket.Price.Amount,
+			paramsPayForTicket.PaidWithBonuses.Amount,
 		}
 		sqlQuery = `UPDATE users_balance
 						SET sum_purchases = sum_purchases + $2, 
@@ -514,6 +1347,12 @@ func (s storage) PayForTicket(ctx context.Context, paramsPayForTicket *ticketsDo
 	}
 	batch.Queue(sqlQuery, arrParams...)
 
+	// 3. Оплаты билета по способам оплаты (tickets_payments)
+	queueInsertTicketPayments(batch, paramsPayForTicket.TicketId, paramsPayForTicket.Payments)
+
+	// 4. Чек оплаты билета со следующим номером (tickets_documents)
+	queueCreateTicketDocument(batch, paramsPayForTicket.TicketId, ticketsDomain.TicketDocumentTypeReceipt, paramsPayForTicket.StatusTimestamp)
+
 	// отправка пакета в БД
 	res := tx.SendBatch(ctx, batch)
 
@@ -546,39 +1385,72 @@ func (s storage) RefundTicket(ctx context.Context, paramsRefundTicket *ticketsDo
 	}
 	defer tx.Rollback(ctx)
 
+	// 1. Изменение билета (tickets). Билету  устанавливаются:
+	// - статус status_id = 4(Refunded) и время изменения статуса status_timestamp
+	// Возвращается только оплаченный или зарегистрированный билет: при повторном или параллельном возврате
+	// место, оплаты и кредит не возвращаются второй раз
+	commandTag, err := tx.Exec(ctx,
+		`UPDATE tickets
+			SET status_id = 4,
+				status_timestamp = $2
+			WHERE id = $1 AND status_id IN (2, 5)`,
+		paramsRefundTicket.TicketId.String(),
+		paramsRefundTicket.StatusTimestamp)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return uuid.UUID{}, terr.Conflict("INVALID_STATUS_TICKET", fmt.Sprintf("ticket (id %s) is already refunded or can't be refunded", paramsRefundTicket.TicketId))
+	}
+
 	// пакетный запрос
 	batch := new(pgx.Batch)
 
 	// добавление заданий в пакет
 
-	// 1. Изменение билета (tickets). Билету  устанавливаются:
-	// - статус status_id = 4(Refunded) и время изменения статуса status_timestamp
-	arrParams := []interface{}{
-		paramsRefundTicket.TicketId.String(),
-		paramsRefundTicket.StatusTimestamp,
-	}
-	sqlQuery := `UPDATE tickets
-					SET status_id = 4, 
-						status_timestamp = $2
-   					WHERE id = $1;`
-	batch.Queue(sqlQuery, arrParams...)
+	// освобождение места билета в остатках мест рейса (flight_inventory)
+	inventory.QueueReleaseTicketSeat(batch, paramsRefundTicket.TicketId)
 
 	// 2. Изменения баланса пользователя (users_balance):
 	// - по пользователю уменьшается общая сумма покупок на стоимость билета.
-	// - по пользователю увеличивается общая сумма бонусов на стоимость билета.
+	// - по пользователю увеличивается общая сумма бонусов на сумму возврата в бонусы RefundToBonuses.
 	// Таким образом, возвращаются на баланс пользователя
 	// и сумма бонусов, использованная при покупке билета paid_with_bonuses,
-	// и сумма оплаченных денег за билет ticket.Price-PaidWithBonuses.
-	arrParams = []interface{}{
+	// и сумма оплаченных денег за билет, если она не возвращается кредитом.
+	arrParams := []interface{}{
 		paramsRefundTicket.UserId.String(),
-		paramsRefundTicket.Price,
+		paramsRefundTicket.Price.Amount,
+		paramsRefundTicket.RefundToBonuses.Amount,
 	}
-	sqlQuery = `UPDATE users_balance
+	sqlQuery := `UPDATE users_balance
 					SET sum_purchases = sum_purchases - $2, 
-						sum_bonuses = sum_bonuses + $2 
+						sum_bonuses = sum_bonuses + $3 
 					WHERE user_id = $1;`
 	batch.Queue(sqlQuery, arrParams...)
 
+	// 3. Отмена использования промокода билета (promo_codes_redemptions):
+	// отмененное использование не учитывается в ограничениях количества использований промокода
+	arrParams = []interface{}{
+		paramsRefundTicket.TicketId.String(),
+		paramsRefundTicket.StatusTimestamp,
+	}
+	sqlQuery = `UPDATE promo_codes_redemptions
+					SET reversed_timestamp = $2
+					WHERE ticket_id = $1
+						AND reversed_timestamp IS NULL;`
+	batch.Queue(sqlQuery, arrParams...)
+
+	// 4. Возврат оплат билета (tickets_payments): оплаченное сертификатами и кредитами возвращается на их остаток
+	queueRefundTicketPayments(batch, paramsRefundTicket.TicketId, paramsRefundTicket.Payments, paramsRefundTicket.StatusTimestamp)
+
+	// 5. Выпуск кредита на перелет вместо возврата денег (vouchers)
+	if paramsRefundTicket.TravelCredit != nil {
+		queueInsertVoucher(batch, paramsRefundTicket.TravelCredit)
+	}
+
+	// 6. Кредит-нота возврата билета со следующим номером (tickets_documents)
+	queueCreateTicketDocument(batch, paramsRefundTicket.TicketId, ticketsDomain.TicketDocumentTypeCreditNote, paramsRefundTicket.StatusTimestamp)
+
 	// отправка пакета в БД
 	res := tx.SendBatch(ctx, batch)
 
@@ -598,27 +1470,137 @@ func (s storage) RefundTicket(ctx context.Context, paramsRefundTicket *ticketsDo
 
 func (s storage) RegisterTicket(ctx context.Context, paramsRegisterTicket *ticketsDomain.ParamsRegisterTicket) (uuid.UUID, error) {
 
+	ticketsIds, err := s.RegisterTickets(ctx, []ticketsDomain.ParamsRegisterTicket{*paramsRegisterTicket})
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	return ticketsIds[0], nil
+}
+
+// регистрация на рейс нескольких билетов в одной транзакции
+func (s storage) RegisterTickets(ctx context.Context, paramsRegisterTickets []ticketsDomain.ParamsRegisterTicket) ([]uuid.UUID, error) {
+
 	conn, err := s.db.Acquire(ctx)
 	if err != nil {
-		return uuid.UUID{}, terr.SQLDatabaseError(err)
+		return nil, terr.SQLDatabaseError(err)
 	}
 	defer conn.Release()
 
 	// начало транзакции
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
-		return uuid.UUID{}, terr.SQLDatabaseError(err)
+		return nil, terr.SQLDatabaseError(err)
 	}
 	defer tx.Rollback(ctx)
 
-	// пакетный запрос
-	batch := new(pgx.Batch)
+	// блокировка назначаемых мест до конца транзакции
+	seatsIds := make([]string, 0, len(paramsRegisterTickets))
+	for i := range paramsRegisterTickets {
+		if paramsRegisterTickets[i].SeatId != nil {
+			seatsIds = append(seatsIds, paramsRegisterTickets[i].SeatId.String())
+		}
+	}
+	err = lockSeats(ctx, tx, seatsIds)
+	if err != nil {
+		return nil, err
+	}
 
-	// добавление заданий в пакет
+	// регистрация билетов по очереди: бонусы реферальной программы начисляются
+	// только за первый зарегистрированный билет приглашенного пользователя
+	ticketsIds := make([]uuid.UUID, 0, len(paramsRegisterTickets))
+	for i := range paramsRegisterTickets {
+		err = registerTicket(ctx, tx, &paramsRegisterTickets[i])
+		if err != nil {
+			return nil, err
+		}
+		ticketsIds = append(ticketsIds, paramsRegisterTickets[i].TicketId)
+	}
+
+	// подтверждение транзакции
+	if err = tx.Commit(ctx); err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+
+	return ticketsIds, nil
+}
+
+// уникальный индекс мест действующих билетов рейса (см. миграцию 000038)
+const constraintTicketsFlightSeat = "idx_tickets_flight_seat"
+
+// isSeatTakenError проверяет, что ошибка - нарушение уникальности места действующих билетов рейса
+func isSeatTakenError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == constraintTicketsFlightSeat
+}
+
+// lockSeats блокирует места (seats) до конца транзакции: параллельное назначение тех же мест
+// ожидает завершения транзакции и затем видит назначенные места.
+// места блокируются в порядке id, чтобы параллельные транзакции не ожидали друг друга взаимно
+func lockSeats(ctx context.Context, tx pgx.Tx, seatsIds []string) error {
+
+	if len(seatsIds) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(ctx, `SELECT id FROM seats WHERE id = ANY($1) ORDER BY id FOR UPDATE`, seatsIds)
+	if err != nil {
+		return terr.SQLDatabaseError(err)
+	}
+	return nil
+}
+
+// checkSeatVacant проверяет в транзакции после блокировки места, что место рейса билета
+// не занято другим билетом и не удержано действующим удержанием
+func checkSeatVacant(ctx context.Context, tx pgx.Tx, ticketId uuid.UUID, seatId uuid.UUID, timestamp time.Time) error {
+
+	row := tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1
+							FROM tickets ticket
+								INNER JOIN tickets other_ticket
+									ON other_ticket.flight_id = ticket.flight_id
+							WHERE ticket.id = $1
+								AND other_ticket.id <> ticket.id
+								AND other_ticket.seat_id = $2)
+				OR EXISTS (SELECT 1
+							FROM tickets ticket
+								INNER JOIN seat_holds hold
+									ON hold.flight_id = ticket.flight_id
+							WHERE ticket.id = $1
+								AND hold.seat_id = $2
+								AND hold.expires_at > $3)`,
+		ticketId.String(),
+		seatId.String(),
+		timestamp)
+
+	var isSeatTaken bool
+	err := row.Scan(
+		&isSeatTaken,
+	)
+	if err != nil {
+		return terr.SQLDatabaseError(err)
+	}
+	if isSeatTaken {
+		return terr.Conflict("SEAT_ALREADY_TAKEN", fmt.Sprintf("seat (id %s) is already taken", seatId))
+	}
+	return nil
+}
+
+// регистрация билета на рейс в транзакции: изменение билета, сохранение данных APIS
+// и начисление бонусов реферальной программы
+func registerTicket(ctx context.Context, tx pgx.Tx, paramsRegisterTicket *ticketsDomain.ParamsRegisterTicket) error {
 
 	// 1. Изменение билета (tickets). Билету устанавливаются:
 	// - статус status_id = 5(Registered) и время изменения статуса status_timestamp
 	// - место seat_id, если при покупке билета место не было назначено
+	// Регистрируется только оплаченный билет со статусом 2(Paid): билет мог быть возвращен,
+	// отклонен компанией или закрыт с рейсом параллельно.
+	// Назначаемое место заблокировано (см. lockSeats) и проверяется повторно: его могли занять параллельно
+	if paramsRegisterTicket.SeatId != nil {
+		err := checkSeatVacant(ctx, tx, paramsRegisterTicket.TicketId, *paramsRegisterTicket.SeatId, paramsRegisterTicket.StatusTimestamp)
+		if err != nil {
+			return err
+		}
+	}
+
 	var sqlQuery string
 
 	arrParams := []interface{}{
@@ -633,24 +1615,174 @@ func (s storage) RegisterTicket(ctx context.Context, paramsRegisterTicket *ticke
 						SET status_id = 5, 
 							status_timestamp = $2,
 				    		seat_id = $3
-   					WHERE id = $1;`
+   					WHERE id = $1 AND status_id = 2;`
 	} else {
 		sqlQuery = `UPDATE tickets
 						SET status_id = 5, 
 							status_timestamp = $2
-   					WHERE id = $1;`
+   					WHERE id = $1 AND status_id = 2;`
+	}
+	commandTag, err := tx.Exec(ctx, sqlQuery, arrParams...)
+	if isSeatTakenError(err) {
+		return terr.Conflict("SEAT_ALREADY_TAKEN", fmt.Sprintf("seat (id %s) is already taken", paramsRegisterTicket.SeatId))
+	}
+	if err != nil {
+		return terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return terr.Conflict("INVALID_STATUS_TICKET", fmt.Sprintf("ticket (id %s) isn't paid", paramsRegisterTicket.TicketId))
 	}
-	batch.Queue(sqlQuery, arrParams...)
 
-	// 2. Изменения баланса пользователя (users_balance):
-	// - по пользователю увеличивается общая сумма бонусов sum_bonuses на сумму начисленных за билет бонусов accrued_bonuses
+	// пакетный запрос
+	batch := new(pgx.Batch)
+
+	// 2. Сохранение данных APIS пассажира (tickets_apis) для международного рейса
+	if paramsRegisterTicket.Apis != nil && paramsRegisterTicket.Apis.Document != nil {
+		apis := paramsRegisterTicket.Apis
+		var postalCode *string
+		if apis.DestinationAddress.PostalCode != "" {
+			postalCode = &apis.DestinationAddress.PostalCode
+		}
+		arrParams = []interface{}{
+			paramsRegisterTicket.TicketId.String(),
+			apis.Document.Type,
+			apis.Document.Number,
+			apis.Document.IssuingCountry,
+			apis.Document.Nationality,
+			apis.Document.BirthDate,
+			apis.Document.ExpiryDate,
+			apis.DestinationAddress.Country,
+			apis.DestinationAddress.City,
+			apis.DestinationAddress.Address,
+			postalCode,
+			paramsRegisterTicket.StatusTimestamp,
+		}
+		sqlQuery = `INSERT INTO tickets_apis (ticket_id, document_type, document_number, document_issuing_country, nationality, birth_date, document_expiry_date,
+						destination_country, destination_city, destination_address, destination_postal_code, apis_timestamp)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+					ON CONFLICT (ticket_id) DO UPDATE
+						SET document_type = EXCLUDED.document_type,
+							document_number = EXCLUDED.document_number,
+							document_issuing_country = EXCLUDED.document_issuing_country,
+							nationality = EXCLUDED.nationality,
+							birth_date = EXCLUDED.birth_date,
+							document_expiry_date = EXCLUDED.document_expiry_date,
+							destination_country = EXCLUDED.destination_country,
+							destination_city = EXCLUDED.destination_city,
+							destination_address = EXCLUDED.destination_address,
+							destination_postal_code = EXCLUDED.destination_postal_code,
+							apis_timestamp = EXCLUDED.apis_timestamp;`
+		batch.Queue(sqlQuery, arrParams...)
+	}
+
+	// 3. Начисление бонусов реферальной программы, если это первый зарегистрированный билет приглашенного пользователя
+	queueReferralReward(batch, paramsRegisterTicket)
+
+	// отправка пакета в БД
+	res := tx.SendBatch(ctx, batch)
+
+	// операция закрытия соединения
+	if err = res.Close(); err != nil {
+		return terr.SQLDatabaseError(err)
+	}
+	return nil
+}
+
+func (s storage) AddTicketAncillary(ctx context.Context, paramsAddTicketAncillary *ticketsDomain.ParamsAddTicketAncillary) (uuid.UUID, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	// начало транзакции
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Блокировка выбранного места (seats) до конца транзакции и повторная проверка, что место свободно:
+	// место могли занять оформлением, регистрацией или покупкой выбора места параллельно
+	var seatId *uuid.UUID
+	if paramsAddTicketAncillary.Type == flightsDomain.AncillaryTypeSeatSelection {
+		seatId = paramsAddTicketAncillary.SeatId
+	}
+	if seatId != nil {
+		err = lockSeats(ctx, tx, []string{seatId.String()})
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+		err = checkSeatVacant(ctx, tx, paramsAddTicketAncillary.TicketId, *seatId, paramsAddTicketAncillary.Timestamp)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+	}
+
+	// 2. Изменение билета (tickets). Билету устанавливаются:
+	// - стоимость билета price увеличивается на стоимость услуги, чтобы при возврате билета возвращалась и стоимость услуг
+	// - количество мест дополнительного багажа count_additional_baggage, если покупается дополнительный багаж
+	// - место seat_id, если покупается выбор места
+	// - сумма начисляемых бонусных баллов accrued_bonuses увеличивается на бонусы за услугу
+	// Услуга добавляется только к оплаченному билету со статусом 2(Paid): билет мог быть возвращен параллельно
+	var countAdditionalBaggage int
+	if paramsAddTicketAncillary.Type == flightsDomain.AncillaryTypeExtraBaggage {
+		countAdditionalBaggage = paramsAddTicketAncillary.Quantity
+	}
+	commandTag, err := tx.Exec(ctx,
+		`UPDATE tickets
+			SET price = price + $2,
+				count_additional_baggage = count_additional_baggage + $3,
+				seat_id = COALESCE($4, seat_id),
+				accrued_bonuses = accrued_bonuses + $5
+			WHERE id = $1 AND status_id = 2`,
+		paramsAddTicketAncillary.TicketId.String(),
+		paramsAddTicketAncillary.Item.Price.Amount,
+		countAdditionalBaggage,
+		seatId,
+		paramsAddTicketAncillary.AccruedBonuses.Amount)
+	if isSeatTakenError(err) {
+		return uuid.UUID{}, terr.Conflict("SEAT_ALREADY_TAKEN", fmt.Sprintf("seat (id %s) is already taken", seatId))
+	}
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return uuid.UUID{}, terr.Conflict("INVALID_STATUS_TICKET", fmt.Sprintf("ticket (id %s) isn't paid", paramsAddTicketAncillary.TicketId))
+	}
+
+	// 3. Проверка ограничения количества услуги из каталога рейса (flights_ancillaries)
+	if paramsAddTicketAncillary.Item.FlightAncillaryId != nil {
+		err = checkFlightAncillaryMaxCount(ctx, tx, *paramsAddTicketAncillary.Item.FlightAncillaryId, paramsAddTicketAncillary.Quantity)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+	}
+
+	// пакетный запрос
+	batch := new(pgx.Batch)
+
+	// добавление заданий в пакет
+
+	// 4. Добавление позиции в состав стоимости билета (tickets_items)
+	itemId := uuid.New()
+	arrParams := getParamsInsertTicketItem(itemId, paramsAddTicketAncillary.TicketId, &paramsAddTicketAncillary.Item)
+	batch.Queue(sqlQueryInsertTicketItem, arrParams...)
+
+	// 5. Оплата услуги (tickets_payments)
+	queueInsertTicketPayments(batch, paramsAddTicketAncillary.TicketId, []ticketsDomain.TicketPayment{paramsAddTicketAncillary.Payment})
+
+	// 6. Изменение баланса пользователя (users_balance).
+	// Услуга оплачивается отдельно от билета: по пользователю увеличивается общая сумма покупок sum_purchases
+	// на стоимость услуги в валюте учета.
 	arrParams = []interface{}{
-		paramsRegisterTicket.UserId.String(),
-		paramsRegisterTicket.AccruedBonuses,
+		paramsAddTicketAncillary.UserId.String(),
+		paramsAddTicketAncillary.BasePrice.Amount,
 	}
-	sqlQuery = `UPDATE users_balance
-					SET sum_bonuses = sum_bonuses + $2 
-					WHERE user_id = $1;`
+	sqlQuery := `UPDATE users_balance
+					SET sum_purchases = sum_purchases + $2 
+				WHERE user_id = $1;`
 	batch.Queue(sqlQuery, arrParams...)
 
 	// отправка пакета в БД
@@ -666,8 +1798,41 @@ func (s storage) RegisterTicket(ctx context.Context, paramsRegisterTicket *ticke
 		return uuid.UUID{}, terr.SQLDatabaseError(err)
 	}
 
-	ticketId := paramsRegisterTicket.TicketId
-	return ticketId, nil
+	return itemId, nil
+}
+
+// checkFlightAncillaryMaxCount проверяет, что с учетом покупки quantity количество проданных услуг не превысит max_count.
+// услуга блокируется до конца транзакции, поэтому параллельные покупки проверяются последовательно
+func checkFlightAncillaryMaxCount(ctx context.Context, tx pgx.Tx, flightAncillaryId uuid.UUID, quantity int) error {
+
+	var maxCount *int
+	err := tx.QueryRow(ctx,
+		`SELECT max_count FROM flights_ancillaries WHERE id = $1 FOR UPDATE`,
+		flightAncillaryId.String()).Scan(&maxCount)
+	if err != nil {
+		return terr.SQLDatabaseError(err)
+	}
+	if maxCount == nil {
+		return nil
+	}
+
+	// количество проданных услуг по действующим (не отмененным и не возвращенным) билетам
+	var countSold int
+	err = tx.QueryRow(ctx,
+		`SELECT COALESCE(SUM(item.quantity), 0)
+			FROM tickets_items item
+				INNER JOIN tickets ticket
+					ON item.ticket_id = ticket.id
+			WHERE item.flight_ancillary_id = $1
+				AND ticket.status_id <> 3 AND ticket.status_id <> 4`,
+		flightAncillaryId.String()).Scan(&countSold)
+	if err != nil {
+		return terr.SQLDatabaseError(err)
+	}
+	if countSold+quantity > *maxCount {
+		return terr.Conflict("ANCILLARY_SOLD_OUT", fmt.Sprintf("flight ancillary (id %s) is sold out", flightAncillaryId))
+	}
+	return nil
 }
 
 func NewTicketsStorage(db *pgxpool.Pool) TicketsStorage {
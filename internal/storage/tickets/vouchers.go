@@ -0,0 +1,229 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+
+	ticketsDomain "homework/internal/domain/tickets"
+	"homework/internal/util/terr"
+)
+
+// получение сертификатов и кредитов
+
+func getSqlQueryVouchers(sqlQueryCondition string) string {
+	return `SELECT 	voucher.id,
+					voucher.voucher_type,
+					voucher.code,
+					voucher.user_id,
+					voucher.ticket_id,
+					voucher.amount,
+					voucher.balance,
+					voucher.currency,
+					voucher.issue_timestamp,
+					voucher.expiry_timestamp
+			FROM vouchers voucher
+			WHERE ` + sqlQueryCondition
+}
+
+func scanVoucher(row pgx.Row) (ticketsDomain.Voucher, error) {
+
+	var voucher ticketsDomain.Voucher
+	var currency string
+	err := row.Scan(
+		&voucher.Id,
+		&voucher.Type,
+		&voucher.Code,
+		&voucher.UserId,
+		&voucher.TicketId,
+		&voucher.Amount.Amount,
+		&voucher.Balance.Amount,
+		&currency,
+		&voucher.IssueTimestamp,
+		&voucher.ExpiryTimestamp,
+	)
+	voucher.Amount.Currency = currency
+	voucher.Balance.Currency = currency
+	return voucher, err
+}
+
+// GetVoucherByCode получает сертификат или кредит по коду без учета регистра
+func (s storage) GetVoucherByCode(ctx context.Context, code string) (*ticketsDomain.Voucher, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	row := conn.QueryRow(ctx, getSqlQueryVouchers("upper(voucher.code) = upper($1)"), code)
+	voucher, err := scanVoucher(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, terr.NotFound(fmt.Sprintf("not found voucher %s", code))
+		}
+		return nil, terr.SQLDatabaseError(err)
+	}
+	return &voucher, nil
+}
+
+// получение оплат билетов в разрезе id билетов
+
+func (s storage) getMapTicketsPayments(ctx context.Context, ticketsIds []string) (map[uuid.UUID][]ticketsDomain.TicketPayment, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx,
+		`SELECT 	payment.id,
+					payment.ticket_id,
+					payment.payment_method,
+					payment.voucher_id,
+					COALESCE(voucher.code, ''),
+					payment.invoice_id,
+					payment.amount,
+					payment.currency,
+					payment.payment_timestamp,
+					payment.refund_timestamp
+			FROM tickets_payments payment
+				LEFT JOIN vouchers voucher
+					ON payment.voucher_id = voucher.id
+			WHERE payment.ticket_id = ANY($1)
+			ORDER BY payment.payment_timestamp, payment.payment_method`,
+		ticketsIds)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	mapTicketsPayments := make(map[uuid.UUID][]ticketsDomain.TicketPayment)
+	for rows.Next() {
+
+		var ticketId uuid.UUID
+		var payment ticketsDomain.TicketPayment
+
+		err = rows.Scan(
+			&payment.Id,
+			&ticketId,
+			&payment.Method,
+			&payment.VoucherId,
+			&payment.VoucherCode,
+			&payment.InvoiceId,
+			&payment.Amount.Amount,
+			&payment.Amount.Currency,
+			&payment.Timestamp,
+			&payment.RefundTimestamp,
+		)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+
+		mapTicketsPayments[ticketId] = append(mapTicketsPayments[ticketId], payment)
+	}
+	return mapTicketsPayments, nil
+}
+
+// debitVouchers списывает оплату билета с остатков сертификатов и кредитов в транзакции оплаты.
+// списание выполняется только при достаточном остатке действующего сертификата,
+// поэтому одновременные оплаты одним сертификатом не уведут остаток в минус
+func debitVouchers(ctx context.Context, tx pgx.Tx, paramsPayForTicket *ticketsDomain.ParamsPayForTicket) error {
+
+	for _, voucherPayment := range paramsPayForTicket.Vouchers {
+		commandTag, err := tx.Exec(ctx,
+			`UPDATE vouchers
+					SET balance = balance - $2
+					WHERE id = $1
+						AND balance >= $2
+						AND expiry_timestamp > $3`,
+			voucherPayment.Voucher.Id.String(),
+			voucherPayment.Amount.Amount,
+			paramsPayForTicket.StatusTimestamp)
+		if err != nil {
+			return terr.SQLDatabaseError(err)
+		}
+		if commandTag.RowsAffected() == 0 {
+			return terr.Conflict("INSUFFICIENT_VOUCHER_BALANCE", fmt.Sprintf("voucher %s doesn't have enough balance", voucherPayment.Voucher.Code))
+		}
+	}
+	return nil
+}
+
+// добавление в пакет оплат билета (tickets_payments)
+func queueInsertTicketPayments(batch *pgx.Batch, ticketId uuid.UUID, payments []ticketsDomain.TicketPayment) {
+
+	for _, payment := range payments {
+		batch.Queue(`INSERT INTO tickets_payments (
+						id,
+						ticket_id,
+						payment_method,
+						voucher_id,
+						amount,
+						currency,
+						payment_timestamp
+					)
+					VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			uuid.New().String(),
+			ticketId.String(),
+			payment.Method,
+			payment.VoucherId,
+			payment.Amount.Amount,
+			payment.Amount.Currency,
+			payment.Timestamp)
+	}
+}
+
+// добавление в пакет возврата оплат билета: сертификатам и кредитам восстанавливается остаток,
+// оплатам устанавливается время возврата
+func queueRefundTicketPayments(batch *pgx.Batch, ticketId uuid.UUID, payments []ticketsDomain.TicketPayment, timestamp time.Time) {
+
+	for _, payment := range payments {
+		if payment.VoucherId == nil || payment.RefundTimestamp != nil {
+			continue
+		}
+		batch.Queue(`UPDATE vouchers
+						SET balance = balance + $2
+						WHERE id = $1`,
+			payment.VoucherId.String(),
+			payment.Amount.Amount)
+	}
+
+	batch.Queue(`UPDATE tickets_payments
+					SET refund_timestamp = $2
+					WHERE ticket_id = $1
+						AND refund_timestamp IS NULL`,
+		ticketId.String(),
+		timestamp)
+}
+
+// добавление в пакет выпуска кредита на перелет (vouchers)
+func queueInsertVoucher(batch *pgx.Batch, voucher *ticketsDomain.Voucher) {
+
+	batch.Queue(`INSERT INTO vouchers (
+					id,
+					voucher_type,
+					code,
+					user_id,
+					ticket_id,
+					amount,
+					balance,
+					currency,
+					issue_timestamp,
+					expiry_timestamp
+				)
+				VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $8, $9)`,
+		voucher.Id.String(),
+		voucher.Type,
+		voucher.Code,
+		voucher.UserId,
+		voucher.TicketId,
+		voucher.Amount.Amount,
+		voucher.Amount.Currency,
+		voucher.IssueTimestamp,
+		voucher.ExpiryTimestamp)
+}
@@ -0,0 +1,333 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+
+	ticketsDomain "homework/internal/domain/tickets"
+	usersDomain "homework/internal/domain/users"
+	"homework/internal/util/terr"
+)
+
+// получение записей листа ожидания
+
+func getSqlQueryWaitlistEntries(sqlQueryCondition string) string {
+	return `SELECT 	waitlist.id,
+					waitlist.flight_id,
+					waitlist.class_seats_id,
+					waitlist.user_id,
+					waitlist.passenger_id,
+					waitlist.count_additional_baggage,
+					waitlist.status,
+					waitlist.entry_timestamp,
+					waitlist.status_timestamp,
+					waitlist.ticket_id
+			FROM waitlist
+			WHERE ` + sqlQueryCondition
+}
+
+func scanWaitlistEntry(row pgx.Row) (ticketsDomain.WaitlistEntry, error) {
+
+	var waitlistEntry ticketsDomain.WaitlistEntry
+	err := row.Scan(
+		&waitlistEntry.Id,
+		&waitlistEntry.FlightId,
+		&waitlistEntry.ClassSeatsId,
+		&waitlistEntry.UserId,
+		&waitlistEntry.PassengerId,
+		&waitlistEntry.CountAdditionalBaggage,
+		&waitlistEntry.Status,
+		&waitlistEntry.Timestamp,
+		&waitlistEntry.StatusTimestamp,
+		&waitlistEntry.TicketId,
+	)
+	return waitlistEntry, err
+}
+
+func (s storage) getWaitlistEntries(ctx context.Context, sqlQueryCondition string, paramsQuery []interface{}) ([]ticketsDomain.WaitlistEntry, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, getSqlQueryWaitlistEntries(sqlQueryCondition), paramsQuery...)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	var waitlistEntries []ticketsDomain.WaitlistEntry
+	for rows.Next() {
+		waitlistEntry, err := scanWaitlistEntry(rows)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		waitlistEntries = append(waitlistEntries, waitlistEntry)
+	}
+	return waitlistEntries, nil
+}
+
+// количество ожидающих записей пассажира в листе ожидания рейса
+func (s storage) GetCountPassengerWaitlistEntries(ctx context.Context, passengerId uuid.UUID, flightId uuid.UUID) (int, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	var countWaitlistEntries int
+	err = conn.QueryRow(ctx,
+		`SELECT COUNT(*)
+			FROM waitlist
+			WHERE passenger_id = $1
+				AND flight_id = $2
+				AND status = $3`,
+		passengerId.String(),
+		flightId.String(),
+		ticketsDomain.WaitlistStatusWaiting).Scan(&countWaitlistEntries)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+	return countWaitlistEntries, nil
+}
+
+// классы мест рейсов, по которым есть ожидающие записи в листе ожидания
+func (s storage) GetWaitlistClassesSeats(ctx context.Context) ([]ticketsDomain.WaitlistClassSeats, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx,
+		`SELECT DISTINCT
+				flight_id,
+				class_seats_id
+			FROM waitlist
+			WHERE status = $1`,
+		ticketsDomain.WaitlistStatusWaiting)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	var waitlistClassesSeats []ticketsDomain.WaitlistClassSeats
+	for rows.Next() {
+		var waitlistClassSeats ticketsDomain.WaitlistClassSeats
+		err = rows.Scan(
+			&waitlistClassSeats.FlightId,
+			&waitlistClassSeats.ClassSeatsId,
+		)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		waitlistClassesSeats = append(waitlistClassesSeats, waitlistClassSeats)
+	}
+	return waitlistClassesSeats, nil
+}
+
+// ожидающие записи листа ожидания по классу мест рейса в порядке очереди
+func (s storage) GetWaitlistEntries(ctx context.Context, flightId uuid.UUID, classSeatsId uuid.UUID, limit int) ([]ticketsDomain.WaitlistEntry, error) {
+
+	paramsQuery := []interface{}{
+		flightId.String(),
+		classSeatsId.String(),
+		ticketsDomain.WaitlistStatusWaiting,
+		limit,
+	}
+	sqlQueryCondition := `waitlist.flight_id = $1
+				AND waitlist.class_seats_id = $2
+				AND waitlist.status = $3
+			ORDER BY waitlist.entry_timestamp, waitlist.id
+			LIMIT $4`
+	return s.getWaitlistEntries(ctx, sqlQueryCondition, paramsQuery)
+}
+
+// ожидающие записи листа ожидания рейсов, продажа билетов на которые уже закрыта (менее 2 часов до вылета)
+func (s storage) GetExpiredWaitlistEntries(ctx context.Context, timestamp time.Time) ([]ticketsDomain.WaitlistEntry, error) {
+
+	paramsQuery := []interface{}{
+		ticketsDomain.WaitlistStatusWaiting,
+		timestamp.Add(2 * time.Hour),
+	}
+	sqlQueryCondition := `waitlist.status = $1
+				AND waitlist.flight_id IN (SELECT flight.id FROM flights flight WHERE flight.departure_date <= $2)`
+	return s.getWaitlistEntries(ctx, sqlQueryCondition, paramsQuery)
+}
+
+func (s storage) CreateWaitlistEntry(ctx context.Context, paramsJoinWaitlist *ticketsDomain.ParamsJoinWaitlist) (uuid.UUID, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	waitlistEntryId := uuid.New()
+	_, err = conn.Exec(ctx,
+		`INSERT INTO waitlist (
+						id,
+						flight_id,
+						class_seats_id,
+						user_id,
+						passenger_id,
+						count_additional_baggage,
+						status,
+						entry_timestamp,
+						status_timestamp
+					)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)`,
+		waitlistEntryId.String(),
+		paramsJoinWaitlist.FlightId.String(),
+		paramsJoinWaitlist.ClassSeatsId.String(),
+		paramsJoinWaitlist.UserId.String(),
+		paramsJoinWaitlist.PassengerId.String(),
+		paramsJoinWaitlist.CountAdditionalBaggage,
+		ticketsDomain.WaitlistStatusWaiting,
+		paramsJoinWaitlist.Timestamp)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	return waitlistEntryId, nil
+}
+
+func (s storage) CloseWaitlistEntry(ctx context.Context, paramsCloseWaitlistEntry *ticketsDomain.ParamsCloseWaitlistEntry) (uuid.UUID, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	// начало транзакции
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Изменение статуса записи листа ожидания (waitlist).
+	// Изменяется только ожидающая запись, чтобы по одной записи не было оформлено несколько билетов
+	commandTag, err := tx.Exec(ctx,
+		`UPDATE waitlist
+			SET status = $2,
+				status_timestamp = $3
+			WHERE id = $1 AND status = $4`,
+		paramsCloseWaitlistEntry.WaitlistEntryId.String(),
+		paramsCloseWaitlistEntry.Status,
+		paramsCloseWaitlistEntry.StatusTimestamp,
+		ticketsDomain.WaitlistStatusWaiting)
+	if err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return uuid.UUID{}, terr.Conflict("WAITLIST_ENTRY_ALREADY_CLOSED", fmt.Sprintf("waitlist entry (id %s) is already closed", paramsCloseWaitlistEntry.WaitlistEntryId))
+	}
+
+	// пакетный запрос
+	batch := new(pgx.Batch)
+
+	// 2. Оформление билета по записи листа ожидания
+	notification := paramsCloseWaitlistEntry.Notification
+	if paramsCloseWaitlistEntry.ParamsCreateTicket != nil {
+		ticketId, err := createTicket(ctx, tx, batch, paramsCloseWaitlistEntry.ParamsCreateTicket)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+		batch.Queue(`UPDATE waitlist SET ticket_id = $2 WHERE id = $1`,
+			paramsCloseWaitlistEntry.WaitlistEntryId.String(),
+			ticketId.String())
+		notification.TicketId = &ticketId
+	}
+
+	// 3. Уведомление пользователя (notifications)
+	queueCreateNotification(batch, &notification, paramsCloseWaitlistEntry.StatusTimestamp)
+
+	// отправка пакета в БД
+	res := tx.SendBatch(ctx, batch)
+
+	// операция закрытия соединения
+	if err = res.Close(); err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+
+	// подтверждение транзакции
+	if err = tx.Commit(ctx); err != nil {
+		return uuid.UUID{}, terr.SQLDatabaseError(err)
+	}
+
+	return paramsCloseWaitlistEntry.WaitlistEntryId, nil
+}
+
+// queueCreateNotification добавляет в пакет уведомление пользователя
+func queueCreateNotification(batch *pgx.Batch, notification *usersDomain.Notification, timestamp time.Time) {
+	batch.Queue(`INSERT INTO notifications (
+						id,
+						user_id,
+						notification_type,
+						message,
+						ticket_id,
+						notification_timestamp
+					)
+					VALUES ($1, $2, $3, $4, $5, $6)`,
+		uuid.New().String(),
+		notification.UserId.String(),
+		notification.Type,
+		notification.Message,
+		notification.TicketId,
+		timestamp)
+}
+
+// отмена билетов со статусом 1(Created), не оплаченных до окончания срока оплаты.
+// билеты компании, ожидающие согласования, отменяются по окончании срока согласования (pendingApprovalBefore).
+// билеты с действующим удержанием цены не отменяются: истекшие удержания завершаются отдельно (ExpirePriceHold).
+// отмененные билеты освобождают места для листа ожидания: места освобождаются в остатках мест рейса
+// в том же запросе, что и отмена билетов
+func (s storage) CancelUnpaidTickets(ctx context.Context, timestamp time.Time, createdBefore time.Time, pendingApprovalBefore time.Time) (int64, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	var countCanceled int64
+	err = conn.QueryRow(ctx,
+		`WITH canceled_tickets AS (UPDATE tickets
+										SET status_id = 3,
+											status_timestamp = $1
+										WHERE status_id = 1 AND status_timestamp < $2
+											AND (approval_status IS DISTINCT FROM 'pending' OR status_timestamp < $3)
+											AND NOT EXISTS (SELECT 1 FROM price_holds price_hold
+															WHERE price_hold.ticket_id = tickets.id AND price_hold.status = 'active')
+										RETURNING flight_id, class_seats_id, passenger_type),
+			updated_inventory AS (UPDATE flight_inventory inventory
+									SET count_sold = inventory.count_sold - canceled_class_seats.count_sold
+									FROM (SELECT
+												flight_id,
+												class_seats_id,
+												COUNT(*) AS count_sold
+											FROM canceled_tickets
+											WHERE passenger_type <> 'infant'
+											GROUP BY
+												flight_id,
+												class_seats_id) canceled_class_seats
+									WHERE inventory.flight_id = canceled_class_seats.flight_id
+										AND inventory.class_seats_id = canceled_class_seats.class_seats_id)
+		SELECT COUNT(*) FROM canceled_tickets`,
+		timestamp,
+		createdBefore,
+		pendingApprovalBefore).Scan(&countCanceled)
+	if err != nil {
+		return 0, terr.SQLDatabaseError(err)
+	}
+	return countCanceled, nil
+}
@@ -8,24 +8,78 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 
+	"homework/internal/domain/money"
 	usersDomain "homework/internal/domain/users"
 	terr "homework/internal/util/terr"
 )
 
 type UsersStorage interface {
-	GetAccruedBonuses(ctx context.Context, userId uuid.UUID, ticketPrice int) (int, error)
+	CreateUser(ctx context.Context, paramsCreateUser *usersDomain.ParamsCreateUser) (uuid.UUID, error)
+	GetAccruedBonuses(ctx context.Context, paramsLoyalty *usersDomain.ParamsLoyalty) (money.Money, error)
+	GetBonusRedemptionLimit(ctx context.Context, paramsLoyalty *usersDomain.ParamsLoyalty) (money.Money, error)
 	GetUserById(ctx context.Context, userId uuid.UUID) (*usersDomain.User, error)
+	GetUserByReferralCode(ctx context.Context, referralCode string) (*usersDomain.User, error)
+	GetUserNotifications(ctx context.Context, userId uuid.UUID) ([]usersDomain.Notification, error)
 }
 
 type storage struct {
 	db *pgxpool.Pool
 }
 
-func (s storage) GetAccruedBonuses(ctx context.Context, userId uuid.UUID, ticketPrice int) (int, error) {
+// getLoyaltyRules получает правила программы лояльности, применимые к билету, в порядке убывания приоритета
+func getLoyaltyRules(ctx context.Context, conn *pgxpool.Conn, paramsLoyalty *usersDomain.ParamsLoyalty) ([]usersDomain.LoyaltyRule, error) {
+
+	rows, err := conn.Query(ctx,
+		`SELECT 	rule.id,
+					rule.name,
+					rule.priority,
+					rule.max_redemption_percent,
+					rule.accrual_percent,
+					rule.ancillaries_accrual
+			FROM loyalty_rules rule
+			WHERE (rule.departure_airport_id IS NULL OR rule.departure_airport_id = $1)
+				AND (rule.arrival_airport_id IS NULL OR rule.arrival_airport_id = $2)
+				AND (rule.class_seats_name IS NULL OR rule.class_seats_name = $3)
+				AND (rule.valid_from IS NULL OR rule.valid_from <= $4)
+				AND (rule.valid_to IS NULL OR rule.valid_to > $4)
+			ORDER BY rule.priority DESC, rule.name`,
+		paramsLoyalty.DepartureAirportId.String(),
+		paramsLoyalty.ArrivalAirportId.String(),
+		paramsLoyalty.ClassSeatsName,
+		paramsLoyalty.Timestamp)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	rules := make([]usersDomain.LoyaltyRule, 0)
+	for rows.Next() {
+		var rule usersDomain.LoyaltyRule
+		err = rows.Scan(
+			&rule.Id,
+			&rule.Name,
+			&rule.Priority,
+			&rule.MaxRedemptionPercent,
+			&rule.AccrualPercent,
+			&rule.AncillariesAccrual,
+		)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// расчет бонусов за билет: процент шкалы начисления бонусов по общей сумме покупок пользователя
+// с учетом применимых к билету правил программы лояльности (см. usersDomain.CalcAccruedBonuses).
+// верхняя граница шкалы 0 означает отсутствие ограничения.
+// стоимость билета, сумма покупок и бонусы - в валюте учета
+func (s storage) GetAccruedBonuses(ctx context.Context, paramsLoyalty *usersDomain.ParamsLoyalty) (money.Money, error) {
 
 	conn, err := s.db.Acquire(ctx)
 	if err != nil {
-		return 0, terr.SQLDatabaseError(err)
+		return money.Money{}, terr.SQLDatabaseError(err)
 	}
 	defer conn.Release()
 
@@ -34,9 +88,10 @@ func (s storage) GetAccruedBonuses(ctx context.Context, userId uuid.UUID, ticket
 				bonus_calc_scale.percent
      		FROM users_balance
       			INNER JOIN bonus_calc_scale
-     				ON users_balance.sum_purchases BETWEEN bonus_calc_scale.sum_purchases_to AND bonus_calc_scale.sum_purchases_from
+     				ON users_balance.sum_purchases >= bonus_calc_scale.sum_purchases_to
+     					AND (bonus_calc_scale.sum_purchases_from = 0 OR users_balance.sum_purchases <= bonus_calc_scale.sum_purchases_from)
 			WHERE users_balance.user_id = $1;`,
-		userId.String())
+		paramsLoyalty.UserId.String())
 
 	var percent int
 	err = row.Scan(
@@ -44,13 +99,35 @@ func (s storage) GetAccruedBonuses(ctx context.Context, userId uuid.UUID, ticket
 	)
 
 	if err != nil && err != pgx.ErrNoRows {
-		return 0, terr.SQLDatabaseError(err)
+		return money.Money{}, terr.SQLDatabaseError(err)
 	}
 
-	accruedBonuses := int((percent * ticketPrice) / 100)
+	rules, err := getLoyaltyRules(ctx, conn, paramsLoyalty)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	accruedBonuses := usersDomain.CalcAccruedBonuses(paramsLoyalty, percent, rules)
 	return accruedBonuses, nil
 }
 
+// максимальная сумма бонусов для оплаты билета: доля стоимости билета по применимым правилам программы лояльности
+func (s storage) GetBonusRedemptionLimit(ctx context.Context, paramsLoyalty *usersDomain.ParamsLoyalty) (money.Money, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return money.Money{}, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	rules, err := getLoyaltyRules(ctx, conn, paramsLoyalty)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return paramsLoyalty.Price.Percent(usersDomain.GetMaxRedemptionPercent(rules)), nil
+}
+
 func (s storage) GetUserById(ctx context.Context, userId uuid.UUID) (*usersDomain.User, error) {
 
 	conn, err := s.db.Acquire(ctx)
@@ -65,6 +142,7 @@ func (s storage) GetUserById(ctx context.Context, userId uuid.UUID) (*usersDomai
 				users.id,
 				users.name,
 				users.email,
+				users.referral_code,
 				CASE
 					WHEN users_balance.user_id IS NOT NULL
 						THEN true
@@ -88,14 +166,18 @@ func (s storage) GetUserById(ctx context.Context, userId uuid.UUID) (*usersDomai
 
 	var user usersDomain.User
 	var userBalanceExists bool
-	var balance usersDomain.UserBalance
+	balance := usersDomain.UserBalance{
+		SumPurchases: money.New(0, money.BaseCurrency),
+		SumBonuses:   money.New(0, money.BaseCurrency),
+	}
 	err = row.Scan(
 		&user.Id,
 		&user.Name,
 		&user.Email,
+		&user.ReferralCode,
 		&userBalanceExists,
-		&balance.SumPurchases,
-		&balance.SumBonuses,
+		&balance.SumPurchases.Amount,
+		&balance.SumBonuses.Amount,
 	)
 
 	if err != nil {
@@ -113,6 +195,118 @@ func (s storage) GetUserById(ctx context.Context, userId uuid.UUID) (*usersDomai
 	return &user, nil
 }
 
+// пользователь по реферальному коду (без учета регистра). баланс пользователя не заполняется
+func (s storage) GetUserByReferralCode(ctx context.Context, referralCode string) (*usersDomain.User, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	row := conn.QueryRow(ctx,
+		`SELECT id,
+				name,
+				email,
+				referral_code
+			FROM users
+			WHERE referral_code = upper($1)`,
+		referralCode)
+
+	var user usersDomain.User
+	err = row.Scan(
+		&user.Id,
+		&user.Name,
+		&user.Email,
+		&user.ReferralCode,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, terr.NotFound(fmt.Sprintf("not found user (referral code %s)", referralCode))
+
+		} else {
+			return nil, terr.SQLDatabaseError(err)
+		}
+	}
+	return &user, nil
+}
+
+// регистрация пользователя. повторный аккаунт с тем же нормализованным адресом электронной почты не создается
+func (s storage) CreateUser(ctx context.Context, paramsCreateUser *usersDomain.ParamsCreateUser) (uuid.UUID, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return uuid.Nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	userId := uuid.New()
+	commandTag, err := conn.Exec(ctx,
+		`INSERT INTO users (id, name, email, password, email_normalized, referral_code, referrer_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (email_normalized) DO NOTHING`,
+		userId.String(),
+		paramsCreateUser.Name,
+		paramsCreateUser.Email,
+		paramsCreateUser.PasswordHash,
+		paramsCreateUser.EmailNormalized,
+		paramsCreateUser.UserReferralCode,
+		paramsCreateUser.ReferrerId)
+	if err != nil {
+		return uuid.Nil, terr.SQLDatabaseError(err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return uuid.Nil, terr.Conflict("USER_ALREADY_EXISTS",
+			fmt.Sprintf("user with email %s already exists", paramsCreateUser.Email))
+	}
+	return userId, nil
+}
+
+// уведомления пользователя от последнего к первому
+func (s storage) GetUserNotifications(ctx context.Context, userId uuid.UUID) ([]usersDomain.Notification, error) {
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx,
+		`SELECT id,
+				user_id,
+				notification_type,
+				message,
+				ticket_id,
+				notification_timestamp
+			FROM notifications
+			WHERE user_id = $1
+			ORDER BY notification_timestamp DESC, id`,
+		userId.String())
+	if err != nil {
+		return nil, terr.SQLDatabaseError(err)
+	}
+	defer rows.Close()
+
+	notifications := make([]usersDomain.Notification, 0)
+	for rows.Next() {
+		var notification usersDomain.Notification
+		err = rows.Scan(
+			&notification.Id,
+			&notification.UserId,
+			&notification.Type,
+			&notification.Message,
+			&notification.TicketId,
+			&notification.Timestamp,
+		)
+		if err != nil {
+			return nil, terr.SQLDatabaseError(err)
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, nil
+}
+
 func NewUsersStorage(db *pgxpool.Pool) UsersStorage {
 	return &storage{db: db}
 }
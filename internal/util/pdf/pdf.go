@@ -0,0 +1,160 @@
+// Package pdf формирует простые текстовые PDF-документы: строки моноширинным шрифтом Courier на страницах формата A4.
+// кириллица транслитерируется латиницей (как в загранпаспорте), т.к. стандартные шрифты PDF не содержат кириллических символов
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// размеры страницы A4 и поля, пункты
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+	pageMargin = 36
+)
+
+// размер шрифта по умолчанию, пункты
+const defaultFontSize = 9
+
+// Document - текстовый документ: заголовок выводится на каждой странице вместе с номером страницы
+type Document struct {
+	Title     string
+	Landscape bool
+	FontSize  float64
+	Lines     []string
+}
+
+// Write записывает документ в формате PDF в w
+func Write(w io.Writer, document *Document) error {
+
+	width, height := pageWidth, pageHeight
+	if document.Landscape {
+		width, height = pageHeight, pageWidth
+	}
+	fontSize := document.FontSize
+	if fontSize <= 0 {
+		fontSize = defaultFontSize
+	}
+	leading := fontSize * 1.25
+
+	// строки по страницам: на каждой странице резервируются две строки под заголовок
+	countLinesPerPage := int((height-2*pageMargin)/leading) - 2
+	if countLinesPerPage < 1 {
+		countLinesPerPage = 1
+	}
+	var pages [][]string
+	for start := 0; start < len(document.Lines); start += countLinesPerPage {
+		end := start + countLinesPerPage
+		if end > len(document.Lines) {
+			end = len(document.Lines)
+		}
+		pages = append(pages, document.Lines[start:end])
+	}
+	if len(pages) == 0 {
+		pages = append(pages, nil)
+	}
+
+	var buf bytes.Buffer
+	var offsets []int
+	writeObject := func(content string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), content)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// объекты: 1 - каталог, 2 - дерево страниц, 3 - шрифт, 4 - сведения о документе,
+	// далее по два объекта на страницу: страница и ее содержимое
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	writeObject(fmt.Sprintf("<< /Title (%s) >>", encodeText(document.Title)))
+
+	for i, lines := range pages {
+
+		var content strings.Builder
+		fmt.Fprintf(&content, "BT\n/F1 %.2f Tf\n%.2f TL\n%d %.2f Td\n", fontSize, leading, pageMargin, height-pageMargin-fontSize)
+		fmt.Fprintf(&content, "(%s) Tj T* T*\n", encodeText(fmt.Sprintf("%s    %d/%d", document.Title, i+1, len(pages))))
+		for _, line := range lines {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", encodeText(line))
+		}
+		content.WriteString("ET")
+
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			width, height, 6+2*i))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	// таблица смещений объектов
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 4 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xrefOffset)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// транслитерация кириллицы (ICAO Doc 9303)
+var transliteration = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i", 'й': "i",
+	'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f",
+	'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "ie", 'ы': "y", 'ь': "", 'э': "e", 'ю': "iu", 'я': "ia",
+}
+
+// Transliterate приводит строку к символам ASCII, которые можно вывести шрифтом документа:
+// кириллица транслитерируется, остальные символы вне ASCII заменяются на '?'.
+// позволяет выравнивать колонки по длине строки до записи документа
+func Transliterate(text string) string {
+
+	runes := []rune(text)
+	var transliterated strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == '\t':
+			transliterated.WriteRune(' ')
+		case r >= ' ' && r < unicode.MaxASCII:
+			transliterated.WriteRune(r)
+		default:
+			latin, ok := transliteration[unicode.ToLower(r)]
+			if !ok {
+				transliterated.WriteRune('?')
+				continue
+			}
+			// заглавная буква: в слове заглавными буквами - все заглавные (ZHUK), иначе только первая (Zhuk, Zh.)
+			if unicode.IsUpper(r) {
+				if i+1 < len(runes) && unicode.IsUpper(runes[i+1]) || latin == "" {
+					latin = strings.ToUpper(latin)
+				} else {
+					latin = strings.ToUpper(latin[:1]) + latin[1:]
+				}
+			}
+			transliterated.WriteString(latin)
+		}
+	}
+	return transliterated.String()
+}
+
+// encodeText приводит строку к виду, допустимому в строке PDF:
+// строка транслитерируется, скобки и обратная косая черта экранируются
+func encodeText(text string) string {
+
+	var encoded strings.Builder
+	for _, r := range Transliterate(text) {
+		if r == '(' || r == ')' || r == '\\' {
+			encoded.WriteRune('\\')
+		}
+		encoded.WriteRune(r)
+	}
+	return encoded.String()
+}
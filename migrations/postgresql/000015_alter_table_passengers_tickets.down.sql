@@ -0,0 +1,6 @@
+ALTER TABLE tickets
+    DROP COLUMN name_passenger,
+    DROP COLUMN identity_data_passenger;
+
+ALTER TABLE passengers
+    DROP COLUMN is_deleted;
@@ -0,0 +1,16 @@
+ALTER TABLE passengers
+    ADD COLUMN is_deleted bool not null default false;
+
+ALTER TABLE tickets
+    ADD COLUMN name_passenger           varchar (200),
+    ADD COLUMN identity_data_passenger  varchar (500);
+
+UPDATE tickets
+    SET name_passenger = passengers.name_passenger,
+        identity_data_passenger = passengers.identity_data_passenger
+    FROM passengers
+    WHERE tickets.passenger_id = passengers.id;
+
+ALTER TABLE tickets
+    ALTER COLUMN name_passenger SET not null,
+    ALTER COLUMN identity_data_passenger SET not null;
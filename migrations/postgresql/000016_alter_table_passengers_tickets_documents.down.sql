@@ -0,0 +1,15 @@
+ALTER TABLE tickets
+    DROP COLUMN document_type,
+    DROP COLUMN document_number,
+    DROP COLUMN document_issuing_country,
+    DROP COLUMN nationality,
+    DROP COLUMN birth_date,
+    DROP COLUMN document_expiry_date;
+
+ALTER TABLE passengers
+    DROP COLUMN document_type,
+    DROP COLUMN document_number,
+    DROP COLUMN document_issuing_country,
+    DROP COLUMN nationality,
+    DROP COLUMN birth_date,
+    DROP COLUMN document_expiry_date;
@@ -0,0 +1,15 @@
+ALTER TABLE passengers
+    ADD COLUMN document_type            varchar (30),
+    ADD COLUMN document_number          varchar (30),
+    ADD COLUMN document_issuing_country char (2),
+    ADD COLUMN nationality              char (2),
+    ADD COLUMN birth_date               date,
+    ADD COLUMN document_expiry_date     date;
+
+ALTER TABLE tickets
+    ADD COLUMN document_type            varchar (30),
+    ADD COLUMN document_number          varchar (30),
+    ADD COLUMN document_issuing_country char (2),
+    ADD COLUMN nationality              char (2),
+    ADD COLUMN birth_date               date,
+    ADD COLUMN document_expiry_date     date;
@@ -0,0 +1,12 @@
+DROP INDEX idx_tickets_accompanying_ticket;
+
+ALTER TABLE tickets
+    DROP COLUMN accompanying_ticket_id,
+    DROP COLUMN passenger_type;
+
+ALTER TABLE flights
+    DROP COLUMN max_infants;
+
+ALTER TABLE flights_prices
+    DROP COLUMN infant_discount_percent,
+    DROP COLUMN child_discount_percent;
@@ -0,0 +1,13 @@
+ALTER TABLE flights_prices
+    ADD COLUMN child_discount_percent   int not null default 0 CHECK (child_discount_percent BETWEEN 0 AND 100),
+    ADD COLUMN infant_discount_percent  int not null default 0 CHECK (infant_discount_percent BETWEEN 0 AND 100);
+
+ALTER TABLE flights
+    ADD COLUMN max_infants  int not null default 10 CHECK (max_infants >= 0);
+
+ALTER TABLE tickets
+    ADD COLUMN passenger_type           varchar (10) not null default 'adult',
+    ADD COLUMN accompanying_ticket_id   uuid,
+    ADD FOREIGN KEY (accompanying_ticket_id) REFERENCES tickets (id) ON DELETE CASCADE;
+
+CREATE INDEX idx_tickets_accompanying_ticket ON tickets(accompanying_ticket_id);
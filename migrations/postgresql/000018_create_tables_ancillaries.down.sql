@@ -0,0 +1,3 @@
+DROP TABLE tickets_items;
+
+DROP TABLE flights_ancillaries;
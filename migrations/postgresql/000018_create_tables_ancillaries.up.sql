@@ -0,0 +1,44 @@
+CREATE TABLE flights_ancillaries(
+    id              uuid PRIMARY KEY,
+    flight_id       uuid not null,
+    ancillary_type  varchar (30) not null,
+    name            varchar (100) not null,
+    price           int not null CHECK (price >= 0),
+    max_count       int CHECK (max_count >= 0),
+    FOREIGN KEY (flight_id) REFERENCES flights (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX idx_flights_ancillaries_flight ON flights_ancillaries(flight_id);
+
+CREATE TABLE tickets_items(
+    id                      uuid PRIMARY KEY,
+    ticket_id               uuid not null,
+    item_type               varchar (30) not null,
+    name                    varchar (100) not null,
+    flight_ancillary_id     uuid,
+    quantity                int not null,
+    price                   int not null,
+    item_timestamp          timestamptz not null,
+    FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE CASCADE,
+    FOREIGN KEY (flight_ancillary_id) REFERENCES flights_ancillaries (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX idx_tickets_items_ticket ON tickets_items(ticket_id);
+CREATE INDEX idx_tickets_items_flight_ancillary ON tickets_items(flight_ancillary_id);
+
+-- состав стоимости ранее оформленных билетов: дополнительный багаж и тариф.
+-- выбор места при оформлении по данным билета не восстанавливается и входит в тариф
+INSERT INTO tickets_items (id, ticket_id, item_type, name, flight_ancillary_id, quantity, price, item_timestamp)
+    SELECT gen_random_uuid(), ticket.id, 'extra_baggage', 'Extra baggage', NULL,
+           ticket.count_additional_baggage, ticket.count_additional_baggage * flight.price_additional_baggage, ticket.status_timestamp
+        FROM tickets ticket
+            INNER JOIN flights flight
+                ON ticket.flight_id = flight.id
+        WHERE ticket.count_additional_baggage > 0;
+
+INSERT INTO tickets_items (id, ticket_id, item_type, name, flight_ancillary_id, quantity, price, item_timestamp)
+    SELECT gen_random_uuid(), ticket.id, 'fare', 'Fare', NULL,
+           1, ticket.price - ticket.count_additional_baggage * flight.price_additional_baggage, ticket.status_timestamp
+        FROM tickets ticket
+            INNER JOIN flights flight
+                ON ticket.flight_id = flight.id;
@@ -0,0 +1 @@
+DROP TABLE seat_holds;
@@ -0,0 +1,16 @@
+CREATE TABLE seat_holds(
+    id                  uuid PRIMARY KEY,
+    flight_id           uuid not null,
+    class_seats_id      uuid not null,
+    seat_id             uuid,
+    user_id             uuid not null,
+    hold_timestamp      timestamptz not null,
+    expires_at          timestamptz not null,
+    FOREIGN KEY (flight_id) REFERENCES flights (id) ON DELETE CASCADE,
+    FOREIGN KEY (class_seats_id) REFERENCES classes_seats (id) ON DELETE CASCADE,
+    FOREIGN KEY (seat_id) REFERENCES seats (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX idx_seat_holds_flight ON seat_holds(flight_id, class_seats_id, expires_at);
+CREATE INDEX idx_seat_holds_expires_at ON seat_holds(expires_at);
@@ -0,0 +1,33 @@
+CREATE TABLE waitlist(
+    id                          uuid PRIMARY KEY,
+    flight_id                   uuid not null,
+    class_seats_id              uuid not null,
+    user_id                     uuid not null,
+    passenger_id                uuid not null,
+    count_additional_baggage    int not null DEFAULT 0,
+    status                      varchar (20) not null,
+    entry_timestamp             timestamptz not null,
+    status_timestamp            timestamptz not null,
+    ticket_id                   uuid,
+    FOREIGN KEY (flight_id) REFERENCES flights (id) ON DELETE CASCADE,
+    FOREIGN KEY (class_seats_id) REFERENCES classes_seats (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (passenger_id) REFERENCES passengers (id) ON DELETE CASCADE,
+    FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE SET NULL
+    );
+
+CREATE INDEX idx_waitlist_flight_class ON waitlist(flight_id, class_seats_id, status, entry_timestamp);
+CREATE INDEX idx_waitlist_passenger ON waitlist(passenger_id, flight_id, status);
+
+CREATE TABLE notifications(
+    id                          uuid PRIMARY KEY,
+    user_id                     uuid not null,
+    notification_type           varchar (30) not null,
+    message                     varchar (500) not null,
+    ticket_id                   uuid,
+    notification_timestamp      timestamptz not null,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE SET NULL
+    );
+
+CREATE INDEX idx_notifications_user ON notifications(user_id, notification_timestamp);
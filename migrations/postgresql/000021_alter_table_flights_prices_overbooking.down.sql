@@ -0,0 +1,2 @@
+ALTER TABLE flights_prices
+    DROP COLUMN overbooking_percent;
@@ -0,0 +1,2 @@
+ALTER TABLE flights_prices
+    ADD COLUMN overbooking_percent  int not null default 0 CHECK (overbooking_percent BETWEEN 0 AND 100);
@@ -0,0 +1 @@
+DROP TABLE flight_inventory;
@@ -0,0 +1,34 @@
+CREATE TABLE flight_inventory(
+    flight_id           uuid not null,
+    class_seats_id      uuid not null,
+    capacity            int not null,
+    count_sold          int not null default 0,
+    count_held          int not null default 0,
+    PRIMARY KEY (flight_id, class_seats_id),
+    FOREIGN KEY (flight_id) REFERENCES flights (id) ON DELETE CASCADE,
+    FOREIGN KEY (class_seats_id) REFERENCES classes_seats (id) ON DELETE CASCADE
+    );
+
+INSERT INTO flight_inventory (
+    flight_id,
+    class_seats_id,
+    capacity,
+    count_sold,
+    count_held
+    )
+SELECT  flight.id,
+        class_seats.id,
+        class_seats.count_seats,
+        (SELECT COUNT(*)
+            FROM tickets ticket
+            WHERE ticket.flight_id = flight.id
+                AND ticket.class_seats_id = class_seats.id
+                AND ticket.status_id <> 3 AND ticket.status_id <> 4
+                AND ticket.passenger_type <> 'infant'),
+        (SELECT COUNT(*)
+            FROM seat_holds hold
+            WHERE hold.flight_id = flight.id
+                AND hold.class_seats_id = class_seats.id)
+FROM flights flight
+    INNER JOIN classes_seats class_seats
+        ON class_seats.aircraft_id = flight.aircraft_id;
@@ -0,0 +1,2 @@
+ALTER TABLE seats
+    DROP COLUMN is_exit_row;
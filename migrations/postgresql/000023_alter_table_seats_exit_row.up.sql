@@ -0,0 +1,2 @@
+ALTER TABLE seats
+    ADD COLUMN is_exit_row  boolean not null default false;
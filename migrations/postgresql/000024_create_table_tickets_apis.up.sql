@@ -0,0 +1,15 @@
+CREATE TABLE tickets_apis(
+    ticket_id                   uuid PRIMARY KEY,
+    document_type               varchar (30) not null,
+    document_number             varchar (30) not null,
+    document_issuing_country    char (2) not null,
+    nationality                 char (2) not null,
+    birth_date                  date not null,
+    document_expiry_date        date,
+    destination_country         char (2) not null,
+    destination_city            varchar (100) not null,
+    destination_address         varchar (200) not null,
+    destination_postal_code     varchar (20),
+    apis_timestamp              timestamptz not null,
+    FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE CASCADE
+    );
@@ -0,0 +1,11 @@
+ALTER TABLE flights
+    DROP COLUMN closed_timestamp;
+
+ALTER TABLE tickets
+    DROP COLUMN is_bonuses_released;
+
+UPDATE tickets
+    SET status_id = 5
+    WHERE status_id IN (7, 8);
+
+DELETE FROM statuses WHERE id IN (7, 8);
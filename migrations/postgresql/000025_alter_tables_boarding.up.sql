@@ -0,0 +1,13 @@
+INSERT INTO statuses(id, name) VALUES (7, 'Boarded'), (8, 'NoShow');
+SELECT setval('statuses_id_seq', (SELECT MAX(id) FROM statuses));
+
+ALTER TABLE tickets
+    ADD COLUMN is_bonuses_released  boolean not null default false;
+
+-- бонусы билетов, зарегистрированных до перехода на начисление бонусов при посадке, уже начислены
+UPDATE tickets
+    SET is_bonuses_released = true
+    WHERE status_id = 5;
+
+ALTER TABLE flights
+    ADD COLUMN closed_timestamp     timestamptz;
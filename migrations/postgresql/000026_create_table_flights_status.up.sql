@@ -0,0 +1,13 @@
+CREATE TABLE flights_status(
+    flight_id                   uuid PRIMARY KEY,
+    state                       varchar (20) not null,
+    estimated_departure         timestamptz,
+    actual_departure            timestamptz,
+    estimated_arrival           timestamptz,
+    actual_arrival              timestamptz,
+    terminal                    varchar (10) not null default '',
+    gate                        varchar (10) not null default '',
+    boarding_time               timestamptz,
+    updated_timestamp           timestamptz not null,
+    FOREIGN KEY (flight_id) REFERENCES flights (id) ON DELETE CASCADE
+    );
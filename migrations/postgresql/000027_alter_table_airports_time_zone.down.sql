@@ -0,0 +1,2 @@
+ALTER TABLE airports
+    DROP COLUMN time_zone;
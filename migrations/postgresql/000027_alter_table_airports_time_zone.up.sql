@@ -0,0 +1,3 @@
+-- часовой пояс аэропорта (IANA), например Europe/Moscow
+ALTER TABLE airports
+    ADD COLUMN time_zone        varchar (64) not null default 'UTC';
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_airports_icao_code;
+DROP INDEX IF EXISTS idx_airports_iata_code;
+DROP INDEX IF EXISTS idx_cities_iata_code;
+
+ALTER TABLE airports
+    DROP COLUMN iata_code,
+    DROP COLUMN icao_code,
+    DROP COLUMN latitude,
+    DROP COLUMN longitude;
+
+ALTER TABLE cities
+    DROP COLUMN iata_code,
+    DROP COLUMN country_code,
+    DROP COLUMN latitude,
+    DROP COLUMN longitude;
@@ -0,0 +1,20 @@
+-- нечеткий поиск городов и аэропортов по наименованию (сходство по триграммам)
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- код города IATA (например, MOW - все аэропорты Москвы), страна (код ISO 3166-1 alpha-2) и координаты
+ALTER TABLE cities
+    ADD COLUMN iata_code        char (3),
+    ADD COLUMN country_code     char (2),
+    ADD COLUMN latitude         double precision,
+    ADD COLUMN longitude        double precision;
+
+-- коды аэропорта IATA (SVO) и ICAO (UUEE) и координаты
+ALTER TABLE airports
+    ADD COLUMN iata_code        char (3),
+    ADD COLUMN icao_code        char (4),
+    ADD COLUMN latitude         double precision,
+    ADD COLUMN longitude        double precision;
+
+CREATE UNIQUE INDEX idx_cities_iata_code ON cities(iata_code);
+CREATE UNIQUE INDEX idx_airports_iata_code ON airports(iata_code);
+CREATE UNIQUE INDEX idx_airports_icao_code ON airports(icao_code);
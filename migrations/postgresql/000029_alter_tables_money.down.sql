@@ -0,0 +1,35 @@
+DROP TABLE IF EXISTS exchange_rates;
+
+ALTER TABLE tickets
+    DROP COLUMN currency,
+    DROP COLUMN exchange_rate,
+    DROP COLUMN exchange_rate_timestamp;
+
+ALTER TABLE flights
+    DROP COLUMN currency;
+
+ALTER TABLE bonus_calc_scale
+    ALTER COLUMN sum_purchases_to           TYPE int USING sum_purchases_to / 100,
+    ALTER COLUMN sum_purchases_from         TYPE int USING sum_purchases_from / 100;
+
+ALTER TABLE users_balance
+    ALTER COLUMN sum_purchases              TYPE int USING sum_purchases / 100,
+    ALTER COLUMN sum_bonuses                TYPE int USING sum_bonuses / 100;
+
+ALTER TABLE tickets_items
+    ALTER COLUMN price                      TYPE int USING price / 100;
+
+ALTER TABLE tickets
+    ALTER COLUMN price                      TYPE int USING price / 100,
+    ALTER COLUMN paid_with_bonuses          TYPE int USING paid_with_bonuses / 100,
+    ALTER COLUMN accrued_bonuses            TYPE int USING accrued_bonuses / 100;
+
+ALTER TABLE flights_ancillaries
+    ALTER COLUMN price                      TYPE int USING price / 100;
+
+ALTER TABLE flights_prices
+    ALTER COLUMN price_ticket               TYPE int USING price_ticket / 100;
+
+ALTER TABLE flights
+    ALTER COLUMN price_additional_baggage   TYPE int USING price_additional_baggage / 100,
+    ALTER COLUMN price_seat_selection       TYPE int USING price_seat_selection / 100;
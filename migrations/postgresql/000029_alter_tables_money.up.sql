@@ -0,0 +1,57 @@
+-- суммы хранятся в минимальных единицах валюты (копейках): существующие суммы в рублях умножаются на 100
+ALTER TABLE flights
+    ALTER COLUMN price_additional_baggage   TYPE bigint USING price_additional_baggage * 100,
+    ALTER COLUMN price_seat_selection       TYPE bigint USING price_seat_selection * 100;
+
+ALTER TABLE flights_prices
+    ALTER COLUMN price_ticket               TYPE bigint USING price_ticket * 100;
+
+ALTER TABLE flights_ancillaries
+    ALTER COLUMN price                      TYPE bigint USING price * 100;
+
+ALTER TABLE tickets
+    ALTER COLUMN price                      TYPE bigint USING price * 100,
+    ALTER COLUMN paid_with_bonuses          TYPE bigint USING paid_with_bonuses * 100,
+    ALTER COLUMN accrued_bonuses            TYPE bigint USING accrued_bonuses * 100;
+
+ALTER TABLE tickets_items
+    ALTER COLUMN price                      TYPE bigint USING price * 100;
+
+-- баланс пользователя и шкала начисления бонусов - в валюте учета (RUB).
+-- верхняя граница диапазона шкалы включает копейки последнего рубля диапазона, 0 - диапазон без верхней границы
+ALTER TABLE users_balance
+    ALTER COLUMN sum_purchases              TYPE bigint USING sum_purchases * 100,
+    ALTER COLUMN sum_bonuses                TYPE bigint USING sum_bonuses * 100;
+
+ALTER TABLE bonus_calc_scale
+    ALTER COLUMN sum_purchases_to           TYPE bigint USING sum_purchases_to * 100,
+    ALTER COLUMN sum_purchases_from         TYPE bigint USING CASE
+                                                                WHEN sum_purchases_from = 0 THEN 0
+                                                                ELSE sum_purchases_from * 100 + 99
+                                                            END;
+
+-- валюта цен рейса (ISO 4217): цены билетов, дополнительного багажа, выбора места и дополнительных услуг
+ALTER TABLE flights
+    ADD COLUMN currency                 char (3) not null default 'RUB';
+
+-- валюта билета (валюта цен рейса) и курс валюты билета к валюте учета, зафиксированный при оформлении билета
+ALTER TABLE tickets
+    ADD COLUMN currency                 char (3) not null default 'RUB',
+    ADD COLUMN exchange_rate            numeric (20, 10) not null default 1 CHECK (exchange_rate > 0),
+    ADD COLUMN exchange_rate_timestamp  timestamptz;
+
+UPDATE tickets
+    SET exchange_rate_timestamp = status_timestamp;
+
+ALTER TABLE tickets
+    ALTER COLUMN exchange_rate_timestamp SET NOT NULL;
+
+-- история курсов валют: курс currency_from к currency_to (количество единиц currency_to за единицу currency_from),
+-- действующий с rate_timestamp до следующего курса пары
+CREATE TABLE exchange_rates(
+    currency_from       char (3) not null,
+    currency_to         char (3) not null,
+    rate                numeric (20, 10) not null CHECK (rate > 0),
+    rate_timestamp      timestamptz not null,
+    PRIMARY KEY (currency_from, currency_to, rate_timestamp)
+    );
@@ -0,0 +1,4 @@
+ALTER TABLE tickets_items
+    DROP COLUMN IF EXISTS tax_fee_id;
+
+DROP TABLE IF EXISTS taxes_fees;
@@ -0,0 +1,25 @@
+-- сборы и таксы, включаемые в стоимость билета отдельными позициями:
+-- аэропортовые сборы (airport_tax), государственные сборы страны вылета (country_tax),
+-- топливный сбор (fuel_surcharge), сервисные сборы (service_fee).
+-- сбор применяется к рейсу, если совпадают все заполненные условия: аэропорт вылета, аэропорт прилета, страна вылета.
+-- сбор без условий применяется ко всем рейсам. сумма - за билет в минимальных единицах валюты сбора
+CREATE TABLE taxes_fees(
+    id                      uuid PRIMARY KEY,
+    fee_type                varchar (30) not null,
+    name                    varchar (100) not null,
+    departure_airport_id    uuid,
+    arrival_airport_id      uuid,
+    country_code            char (2),
+    amount                  bigint not null CHECK (amount >= 0),
+    currency                char (3) not null default 'RUB',
+    FOREIGN KEY (departure_airport_id) REFERENCES airports (id) ON DELETE CASCADE,
+    FOREIGN KEY (arrival_airport_id) REFERENCES airports (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX idx_taxes_fees_departure_airport ON taxes_fees(departure_airport_id);
+CREATE INDEX idx_taxes_fees_arrival_airport ON taxes_fees(arrival_airport_id);
+
+-- сбор, по которому сформирована позиция состава стоимости билета
+ALTER TABLE tickets_items
+    ADD COLUMN tax_fee_id   uuid,
+    ADD FOREIGN KEY (tax_fee_id) REFERENCES taxes_fees (id) ON DELETE SET NULL;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS promo_codes_redemptions;
+
+ALTER TABLE tickets
+    DROP COLUMN IF EXISTS promo_code_id;
+
+DROP TABLE IF EXISTS promo_codes;
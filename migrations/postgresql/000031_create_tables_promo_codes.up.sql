@@ -0,0 +1,47 @@
+-- промокоды. скидка - процент от тарифа (percent) или фиксированная сумма в валюте промокода (fixed), но не больше тарифа.
+-- условия применения (заполненные): минимальная стоимость билета min_spend в валюте промокода, аэропорт вылета,
+-- аэропорт прилета, наименование класса мест, период действия, количество использований всего и одним пользователем
+CREATE TABLE promo_codes(
+    id                      uuid PRIMARY KEY,
+    code                    varchar (30) not null,
+    discount_type           varchar (20) not null,
+    discount_percent        int not null default 0 CHECK (discount_percent >= 0 AND discount_percent <= 100),
+    discount_amount         bigint not null default 0 CHECK (discount_amount >= 0),
+    currency                char (3) not null default 'RUB',
+    min_spend               bigint not null default 0 CHECK (min_spend >= 0),
+    departure_airport_id    uuid,
+    arrival_airport_id      uuid,
+    class_seats_name        varchar (100),
+    valid_from              timestamptz,
+    valid_to                timestamptz,
+    max_uses                int CHECK (max_uses > 0),
+    max_uses_per_user       int CHECK (max_uses_per_user > 0),
+    FOREIGN KEY (departure_airport_id) REFERENCES airports (id) ON DELETE CASCADE,
+    FOREIGN KEY (arrival_airport_id) REFERENCES airports (id) ON DELETE CASCADE
+    );
+
+CREATE UNIQUE INDEX idx_promo_codes_code ON promo_codes(upper(code));
+
+-- промокод, примененный при оформлении билета
+ALTER TABLE tickets
+    ADD COLUMN promo_code_id    uuid,
+    ADD FOREIGN KEY (promo_code_id) REFERENCES promo_codes (id) ON DELETE SET NULL;
+
+-- использования промокодов. использование фиксируется при оплате билета и отменяется (reversed_timestamp) при возврате билета.
+-- в ограничениях количества использований учитываются только неотмененные использования
+CREATE TABLE promo_codes_redemptions(
+    id                      uuid PRIMARY KEY,
+    promo_code_id           uuid not null,
+    ticket_id               uuid not null,
+    user_id                 uuid not null,
+    discount                bigint not null,
+    currency                char (3) not null,
+    redemption_timestamp    timestamptz not null,
+    reversed_timestamp      timestamptz,
+    FOREIGN KEY (promo_code_id) REFERENCES promo_codes (id) ON DELETE CASCADE,
+    FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+    );
+
+CREATE UNIQUE INDEX idx_promo_codes_redemptions_ticket ON promo_codes_redemptions(ticket_id);
+CREATE INDEX idx_promo_codes_redemptions_promo_code ON promo_codes_redemptions(promo_code_id, user_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tickets_payments;
+DROP TABLE IF EXISTS vouchers;
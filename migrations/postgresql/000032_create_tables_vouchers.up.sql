@@ -0,0 +1,37 @@
+-- сертификаты и кредиты на перелет - средства оплаты билетов с кодом, остатком и сроком действия:
+-- подарочные сертификаты gift_certificate (на предъявителя) и кредиты travel_credit (принадлежат пользователю,
+-- выпускаются вместо возврата денег за билет). суммы - в валюте учета
+CREATE TABLE vouchers(
+    id                      uuid PRIMARY KEY,
+    voucher_type            varchar (20) not null,
+    code                    varchar (30) not null,
+    user_id                 uuid,
+    ticket_id               uuid,
+    amount                  bigint not null CHECK (amount > 0),
+    balance                 bigint not null CHECK (balance >= 0 AND balance <= amount),
+    currency                char (3) not null default 'RUB',
+    issue_timestamp         timestamptz not null,
+    expiry_timestamp        timestamptz not null,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE SET NULL
+    );
+
+CREATE UNIQUE INDEX idx_vouchers_code ON vouchers(upper(code));
+CREATE INDEX idx_vouchers_user ON vouchers(user_id);
+
+-- оплаты билета по способам оплаты (раздельная оплата): бонусы bonuses, сертификат gift_certificate,
+-- кредит travel_credit, банковская карта card. при возврате билета оплатам устанавливается refund_timestamp
+CREATE TABLE tickets_payments(
+    id                      uuid PRIMARY KEY,
+    ticket_id               uuid not null,
+    payment_method          varchar (20) not null,
+    voucher_id              uuid,
+    amount                  bigint not null CHECK (amount > 0),
+    currency                char (3) not null,
+    payment_timestamp       timestamptz not null,
+    refund_timestamp        timestamptz,
+    FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE CASCADE,
+    FOREIGN KEY (voucher_id) REFERENCES vouchers (id) ON DELETE SET NULL
+    );
+
+CREATE INDEX idx_tickets_payments_ticket ON tickets_payments(ticket_id);
@@ -0,0 +1,22 @@
+-- правила программы лояльности. условия применения (проверяются только заполненные): аэропорт вылета,
+-- аэропорт прилета, наименование класса мест, период действия. действия правила (незаполненные не меняют расчет):
+-- max_redemption_percent - максимальная доля стоимости билета, оплачиваемая бонусами (по умолчанию 50%),
+-- accrual_percent - процент начисления бонусов от начисления по шкале bonus_calc_scale (200 - двойные бонусы),
+-- ancillaries_accrual - начисляются ли бонусы за дополнительные услуги (по умолчанию начисляются).
+-- доля оплаты бонусами и начисление за услуги берутся из применимого правила с наибольшим приоритетом,
+-- проценты начисления всех применимых правил перемножаются
+CREATE TABLE loyalty_rules(
+    id                      uuid PRIMARY KEY,
+    name                    varchar (100) not null,
+    priority                int not null default 0,
+    departure_airport_id    uuid,
+    arrival_airport_id      uuid,
+    class_seats_name        varchar (100),
+    valid_from              timestamptz,
+    valid_to                timestamptz,
+    max_redemption_percent  int CHECK (max_redemption_percent >= 0 AND max_redemption_percent <= 100),
+    accrual_percent         int CHECK (accrual_percent >= 0),
+    ancillaries_accrual     boolean,
+    FOREIGN KEY (departure_airport_id) REFERENCES airports (id) ON DELETE CASCADE,
+    FOREIGN KEY (arrival_airport_id) REFERENCES airports (id) ON DELETE CASCADE
+    );
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS referral_rewards;
+
+DROP INDEX IF EXISTS users_balance_user_id_idx;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS referrer_id,
+    DROP COLUMN IF EXISTS referral_code,
+    DROP COLUMN IF EXISTS email_normalized;
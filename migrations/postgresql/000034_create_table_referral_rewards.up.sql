@@ -0,0 +1,69 @@
+-- реферальная программа: у каждого пользователя свой реферальный код, при регистрации нового пользователя
+-- по коду сохраняется пригласивший пользователь referrer_id.
+-- email_normalized - адрес электронной почты без учета регистра, подадреса (+tag) и точек в адресах gmail.com,
+-- уникальность нормализованного адреса не дает завести повторные аккаунты.
+-- для существующих пользователей реферальный код формируется по идентификатору
+ALTER TABLE users
+    ADD COLUMN email_normalized varchar (300),
+    ADD COLUMN referral_code    varchar (20),
+    ADD COLUMN referrer_id      uuid,
+    ADD FOREIGN KEY (referrer_id) REFERENCES users (id) ON DELETE SET NULL,
+    ADD CHECK (referrer_id <> id);
+
+-- нормализация адреса повторяет usersDomain.NormalizeEmail: нижний регистр, без подадреса (+tag),
+-- googlemail.com как gmail.com и без точек в адресах gmail.com.
+-- если у существующих пользователей нормализованные адреса совпадают, то нормализованный адрес получает
+-- пользователь, чей адрес уже нормализован (иначе с меньшим id), остальным сохраняется адрес в нижнем регистре
+WITH email_parts AS (
+    SELECT id,
+           lower(trim(email)) AS email,
+           split_part(regexp_replace(lower(trim(email)), '@[^@]*$', ''), '+', 1) AS local,
+           regexp_replace(substring(lower(trim(email)) from '@([^@]*)$'), '^googlemail\.com$', 'gmail.com') AS domain
+        FROM users
+), emails AS (
+    SELECT id,
+           email,
+           CASE
+               WHEN domain IS NULL THEN email
+               WHEN domain = 'gmail.com' THEN replace(local, '.', '') || '@gmail.com'
+               ELSE local || '@' || domain
+           END AS normalized
+        FROM email_parts
+), emails_normalized AS (
+    SELECT id,
+           CASE
+               WHEN row_number() OVER (PARTITION BY normalized ORDER BY email = normalized DESC, id) = 1 THEN normalized
+               ELSE email
+           END AS email_normalized
+        FROM emails
+)
+UPDATE users
+    SET email_normalized = emails_normalized.email_normalized,
+        referral_code = upper(substr(md5(users.id::text), 1, 8))
+    FROM emails_normalized
+    WHERE users.id = emails_normalized.id;
+
+ALTER TABLE users
+    ALTER COLUMN email_normalized SET NOT NULL,
+    ALTER COLUMN referral_code SET NOT NULL;
+
+CREATE UNIQUE INDEX users_email_normalized_idx ON users (email_normalized);
+CREATE UNIQUE INDEX users_referral_code_idx ON users (referral_code);
+
+-- у пользователя одна запись баланса: бонусы реферальной программы начисляются и пользователю без покупок
+CREATE UNIQUE INDEX users_balance_user_id_idx ON users_balance (user_id);
+
+-- бонусы реферальной программы, начисленные при регистрации на рейс первого билета приглашенного пользователя.
+-- бонусы по приглашенному пользователю начисляются один раз
+CREATE TABLE referral_rewards(
+    id                      uuid PRIMARY KEY,
+    referrer_id             uuid not null,
+    referee_id              uuid not null UNIQUE,
+    ticket_id               uuid not null,
+    referrer_bonus          bigint not null,
+    referee_bonus           bigint not null,
+    reward_timestamp        timestamptz not null,
+    FOREIGN KEY (referrer_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (referee_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE CASCADE
+    );
@@ -0,0 +1,19 @@
+ALTER TABLE tickets_payments
+    DROP COLUMN IF EXISTS refund_invoice_id,
+    DROP COLUMN IF EXISTS invoice_id;
+
+DROP TABLE IF EXISTS companies_invoices;
+
+DROP INDEX IF EXISTS tickets_company_id_idx;
+
+ALTER TABLE tickets
+    DROP COLUMN IF EXISTS approval_timestamp,
+    DROP COLUMN IF EXISTS approver_id,
+    DROP COLUMN IF EXISTS policy_violations,
+    DROP COLUMN IF EXISTS approval_status,
+    DROP COLUMN IF EXISTS booker_id,
+    DROP COLUMN IF EXISTS company_id;
+
+DROP TABLE IF EXISTS companies_travel_policies;
+DROP TABLE IF EXISTS companies_users;
+DROP TABLE IF EXISTS companies;
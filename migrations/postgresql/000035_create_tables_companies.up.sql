@@ -0,0 +1,65 @@
+-- корпоративные клиенты: компании, пользователи компании с ролями и правила поездок (travel policy).
+-- роли пользователя компании: traveler - путешественник (пользователь билета), booker - оформляет билеты
+-- за путешественников, approver - согласует билеты. у пользователя может быть несколько ролей
+CREATE TABLE companies(
+    id                      uuid PRIMARY KEY,
+    name                    varchar (300) not null
+    );
+
+CREATE TABLE companies_users(
+    company_id              uuid not null,
+    user_id                 uuid not null,
+    role                    varchar (20) not null CHECK (role IN ('traveler', 'booker', 'approver')),
+    PRIMARY KEY (company_id, user_id, role),
+    FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+    );
+
+-- правила поездок компании (проверяются только заполненные): максимальный класс мест max_class_seats_name
+-- (Economy, Premium Economy, Business, First) и максимальная стоимость билета max_price в валюте учета.
+-- билет с нарушением правил оформляется только после согласования, approval_required - согласование всех билетов
+CREATE TABLE companies_travel_policies(
+    company_id              uuid PRIMARY KEY,
+    max_class_seats_name    varchar (100),
+    max_price               bigint CHECK (max_price >= 0),
+    currency                char (3) not null default 'RUB',
+    approval_required       boolean not null default false,
+    FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE
+    );
+
+-- билет компании: кто оформил билет booker_id, статус согласования approval_status (pending, approved, rejected),
+-- нарушения правил поездок policy_violations, кто и когда согласовал или отклонил билет
+ALTER TABLE tickets
+    ADD COLUMN company_id           uuid,
+    ADD COLUMN booker_id            uuid,
+    ADD COLUMN approval_status      varchar (20) CHECK (approval_status IN ('pending', 'approved', 'rejected')),
+    ADD COLUMN policy_violations    text[],
+    ADD COLUMN approver_id          uuid,
+    ADD COLUMN approval_timestamp   timestamptz,
+    ADD FOREIGN KEY (company_id) REFERENCES companies (id),
+    ADD FOREIGN KEY (booker_id) REFERENCES users (id),
+    ADD FOREIGN KEY (approver_id) REFERENCES users (id);
+
+CREATE INDEX tickets_company_id_idx ON tickets (company_id) WHERE company_id IS NOT NULL;
+
+-- ежемесячные счета компании. билеты компании оплачиваются способом оплаты invoice и включаются в счет
+-- за месяц (period_from - первый день месяца). возвраты оплат, уже включенных в счет, уменьшают следующий счет
+CREATE TABLE companies_invoices(
+    id                      uuid PRIMARY KEY,
+    company_id              uuid not null,
+    period_from             date not null,
+    period_to               date not null,
+    amount                  bigint not null,
+    currency                char (3) not null,
+    count_payments          int not null,
+    count_refunds           int not null,
+    issue_timestamp         timestamptz not null,
+    UNIQUE (company_id, period_from),
+    FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE
+    );
+
+ALTER TABLE tickets_payments
+    ADD COLUMN invoice_id           uuid,
+    ADD COLUMN refund_invoice_id    uuid,
+    ADD FOREIGN KEY (invoice_id) REFERENCES companies_invoices (id),
+    ADD FOREIGN KEY (refund_invoice_id) REFERENCES companies_invoices (id);
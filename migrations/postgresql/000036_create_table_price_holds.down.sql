@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS price_holds;
+
+ALTER TABLE flights_prices
+    DROP COLUMN IF EXISTS price_hold_fee,
+    DROP COLUMN IF EXISTS price_hold_max_hours;
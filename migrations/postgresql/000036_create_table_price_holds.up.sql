@@ -0,0 +1,25 @@
+-- правила удержания цены тарифа: максимальный срок удержания в часах price_hold_max_hours (0 - удержание недоступно)
+-- и плата за удержание price_hold_fee в валюте цен рейса (0 - бесплатное удержание)
+ALTER TABLE flights_prices
+    ADD COLUMN price_hold_max_hours int not null default 72 CHECK (price_hold_max_hours = 0 OR price_hold_max_hours BETWEEN 24 AND 72),
+    ADD COLUMN price_hold_fee       bigint not null default 0 CHECK (price_hold_fee >= 0);
+
+-- удержание цены неоплаченного билета (book now, pay later): билет со статусом 1(Created) оплачивается
+-- до expiry_timestamp. статусы: active - действует, paid - билет оплачен, expired - истекло, билет отменен.
+-- reminder_timestamp - время отправки напоминания об окончании удержания
+CREATE TABLE price_holds(
+    id                          uuid PRIMARY KEY,
+    ticket_id                   uuid not null UNIQUE,
+    user_id                     uuid not null,
+    fee                         bigint not null CHECK (fee >= 0),
+    currency                    char (3) not null,
+    status                      varchar (20) not null CHECK (status IN ('active', 'paid', 'expired')),
+    hold_timestamp              timestamptz not null,
+    expiry_timestamp            timestamptz not null,
+    reminder_timestamp          timestamptz,
+    status_timestamp            timestamptz not null,
+    FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX idx_price_holds_active ON price_holds(expiry_timestamp) WHERE status = 'active';
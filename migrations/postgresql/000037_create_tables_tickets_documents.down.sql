@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tickets_documents;
+DROP TABLE IF EXISTS tickets_documents_counters;
@@ -0,0 +1,41 @@
+-- последние выданные номера документов билетов по типам документов. строка счетчика блокируется до конца
+-- транзакции оплаты (возврата) билета, поэтому номера выдаются по порядку и без пропусков
+CREATE TABLE tickets_documents_counters(
+    document_type           varchar (20) PRIMARY KEY,
+    last_number             bigint not null default 0
+    );
+
+INSERT INTO tickets_documents_counters (document_type) VALUES ('receipt'), ('credit_note');
+
+-- документы билета: чек оплаты receipt выдается при оплате билета, кредит-нота credit_note - при возврате билета.
+-- номер number - сквозной по типу документа
+CREATE TABLE tickets_documents(
+    id                      uuid PRIMARY KEY,
+    ticket_id               uuid not null,
+    document_type           varchar (20) not null REFERENCES tickets_documents_counters (document_type),
+    number                  bigint not null,
+    document_timestamp      timestamptz not null,
+    UNIQUE (ticket_id, document_type),
+    UNIQUE (document_type, number),
+    FOREIGN KEY (ticket_id) REFERENCES tickets (id) ON DELETE CASCADE
+    );
+
+-- документы уже оплаченных и возвращенных билетов нумеруются по времени оплаты (возврата)
+INSERT INTO tickets_documents (id, ticket_id, document_type, number, document_timestamp)
+SELECT gen_random_uuid(), paid.id, 'receipt', row_number() OVER (ORDER BY paid.payment_timestamp, paid.id), paid.payment_timestamp
+FROM (
+    SELECT  ticket.id,
+            COALESCE((SELECT min(payment.payment_timestamp) FROM tickets_payments payment WHERE payment.ticket_id = ticket.id),
+                     ticket.status_timestamp) AS payment_timestamp
+    FROM tickets ticket
+    WHERE ticket.status_id IN (2, 4, 5, 6, 7, 8)
+    ) paid;
+
+INSERT INTO tickets_documents (id, ticket_id, document_type, number, document_timestamp)
+SELECT gen_random_uuid(), ticket.id, 'credit_note', row_number() OVER (ORDER BY ticket.status_timestamp, ticket.id), ticket.status_timestamp
+FROM tickets ticket
+WHERE ticket.status_id = 4;
+
+UPDATE tickets_documents_counters counter
+    SET last_number = COALESCE((SELECT max(document.number) FROM tickets_documents document
+                                WHERE document.document_type = counter.document_type), 0);
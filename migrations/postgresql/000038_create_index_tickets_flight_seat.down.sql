@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_tickets_flight_seat;
@@ -0,0 +1,4 @@
+-- место рейса может быть назначено только одному действующему (не отмененному и не возвращенному) билету.
+-- индекс защищает от назначения одного места параллельными оформлением, регистрацией и покупкой выбора места
+CREATE UNIQUE INDEX idx_tickets_flight_seat ON tickets(flight_id, seat_id)
+    WHERE seat_id IS NOT NULL AND status_id <> 3 AND status_id <> 4;
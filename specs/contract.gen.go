@@ -13,6 +13,38 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
+// Defines values for GetFlightManifestParamsFormat.
+const (
+	GetFlightManifestParamsFormatCsv GetFlightManifestParamsFormat = "csv"
+
+	GetFlightManifestParamsFormatJson GetFlightManifestParamsFormat = "json"
+
+	GetFlightManifestParamsFormatPdf GetFlightManifestParamsFormat = "pdf"
+)
+
+// Defines values for GetUserTicketsParamsPeriod.
+const (
+	GetUserTicketsParamsPeriodPast GetUserTicketsParamsPeriod = "past"
+
+	GetUserTicketsParamsPeriodUpcoming GetUserTicketsParamsPeriod = "upcoming"
+)
+
+// Defines values for GetUserTicketsParamsView.
+const (
+	GetUserTicketsParamsViewFull GetUserTicketsParamsView = "full"
+
+	GetUserTicketsParamsViewSummary GetUserTicketsParamsView = "summary"
+)
+
+// Defines values for IdentityDocumentType.
+const (
+	IdentityDocumentTypeBirthCertificate IdentityDocumentType = "birth_certificate"
+
+	IdentityDocumentTypeNationalId IdentityDocumentType = "national_id"
+
+	IdentityDocumentTypePassport IdentityDocumentType = "passport"
+)
+
 // APIError defines model for APIError.
 type APIError struct {
 	// Код состояния HTTP
@@ -25,12 +57,186 @@ type APIError struct {
 	Message string `json:"message"`
 }
 
+// Предварительная информация о пассажире (APIS). Обязательна для международного рейса, для внутреннего рейса не сохраняется. Если документ не заполнен, используется документ, указанный в билете.
+type ApisData struct {
+	// Адрес пребывания пассажира в стране назначения.
+	DestinationAddress DestinationAddress `json:"destinationAddress"`
+
+	// Документ, удостоверяющий личность пассажира.
+	Document *IdentityDocument `json:"document,omitempty"`
+}
+
+// ClosedFlight defines model for ClosedFlight.
+type ClosedFlight struct {
+	// Дата и время закрытия рейса.
+	ClosedTimestamp time.Time `json:"closedTimestamp"`
+
+	// Количество билетов, прошедших посадку (Boarded).
+	CountBoarded int `json:"countBoarded"`
+
+	// Количество оплаченных незарегистрированных билетов (Closed).
+	CountClosed int `json:"countClosed"`
+
+	// Количество зарегистрированных билетов без посадки (NoShow).
+	CountNoShow int `json:"countNoShow"`
+
+	// Идентификатор рейса.
+	FlightId string `json:"flightId"`
+}
+
+// Company defines model for Company.
+type Company struct {
+	// Идентификатор компании.
+	Id string `json:"id"`
+
+	// Пользователи компании и их роли.
+	Members []CompanyMember `json:"members"`
+
+	// Наименование компании.
+	Name string `json:"name"`
+
+	// Правила поездок компании. Незаполненные правила не проверяются.
+	TravelPolicy *TravelPolicy `json:"travelPolicy,omitempty"`
+}
+
+// Счет компании за месяц.
+type CompanyInvoice struct {
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Amount Money `json:"amount"`
+
+	// Идентификатор компании.
+	CompanyId string `json:"companyId"`
+
+	// Количество оплат билетов, включенных в счет.
+	CountPayments int `json:"countPayments"`
+
+	// Количество возвратов оплат из предыдущих счетов, уменьшающих счет.
+	CountRefunds int `json:"countRefunds"`
+
+	// Идентификатор счета.
+	Id string `json:"id"`
+
+	// Дата и время выставления счета.
+	IssueTimestamp time.Time `json:"issueTimestamp"`
+
+	// Месяц счета (ГГГГ-ММ, UTC).
+	Period string `json:"period"`
+}
+
+// CompanyMember defines model for CompanyMember.
+type CompanyMember struct {
+	// Электронная почта пользователя.
+	Email string `json:"email"`
+
+	// Имя пользователя.
+	Name string `json:"name"`
+
+	// Роли пользователя в компании (traveler - путешественник, booker - оформляет билеты, approver - согласует билеты).
+	Roles []string `json:"roles"`
+
+	// Идентификатор пользователя.
+	UserId string `json:"userId"`
+}
+
+// CompanyReport defines model for CompanyReport.
+type CompanyReport struct {
+	// Идентификатор компании.
+	CompanyId string `json:"companyId"`
+
+	// Количество билетов и их стоимость в валюте учета (RUB). Итог и билеты с нарушением правил поездок - кроме отмененных и возвращенных.
+	OutOfPolicy CompanyReportRow `json:"outOfPolicy"`
+
+	// Количество билетов и их стоимость в валюте учета (RUB). Итог и билеты с нарушением правил поездок - кроме отмененных и возвращенных.
+	PendingApproval CompanyReportRow `json:"pendingApproval"`
+
+	// Билеты по статусам, в том числе отмененные и возвращенные.
+	Statuses []CompanyReportStatus `json:"statuses"`
+
+	// Количество билетов и их стоимость в валюте учета (RUB). Итог и билеты с нарушением правил поездок - кроме отмененных и возвращенных.
+	Total CompanyReportRow `json:"total"`
+
+	// Билеты по путешественникам, кроме отмененных и возвращенных.
+	Travelers []CompanyReportTraveler `json:"travelers"`
+}
+
+// Количество билетов и их стоимость в валюте учета (RUB). Итог и билеты с нарушением правил поездок - кроме отмененных и возвращенных.
+type CompanyReportRow struct {
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Amount Money `json:"amount"`
+
+	// Количество билетов.
+	CountTickets int `json:"countTickets"`
+}
+
+// CompanyReportStatus defines model for CompanyReportStatus.
+type CompanyReportStatus struct {
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Amount Money `json:"amount"`
+
+	// Количество билетов.
+	CountTickets int `json:"countTickets"`
+
+	// Наименование статуса билета.
+	StatusName string `json:"statusName"`
+}
+
+// CompanyReportTraveler defines model for CompanyReportTraveler.
+type CompanyReportTraveler struct {
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Amount Money `json:"amount"`
+
+	// Количество билетов.
+	CountTickets int `json:"countTickets"`
+
+	// Имя пользователя.
+	Name string `json:"name"`
+
+	// Идентификатор пользователя.
+	UserId string `json:"userId"`
+}
+
 // CreatedItem defines model for CreatedItem.
 type CreatedItem struct {
 	// ID созданного объекта
 	Id string `json:"id"`
 }
 
+// DeletedItem defines model for DeletedItem.
+type DeletedItem struct {
+	// ID удаленного объекта
+	Id string `json:"id"`
+}
+
+// Адрес пребывания пассажира в стране назначения.
+type DestinationAddress struct {
+	// Адрес (до 200 символов).
+	Address string `json:"address"`
+
+	// Город (до 100 символов).
+	City string `json:"city"`
+
+	// Страна назначения (код ISO 3166-1 alpha-2).
+	Country string `json:"country"`
+
+	// Почтовый индекс (до 20 символов).
+	PostalCode *string `json:"postalCode,omitempty"`
+}
+
+// Курс валюты.
+type ExchangeRate struct {
+	// Валюта, для которой задан курс (код ISO 4217).
+	From string `json:"from"`
+
+	// Количество единиц валюты курса за единицу валюты from.
+	Rate float64 `json:"rate"`
+
+	// Дата и время установки курса.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Валюта курса (код ISO 4217).
+	To string `json:"to"`
+}
+
 // Flight defines model for Flight.
 type Flight struct {
 	Airline struct {
@@ -58,17 +264,32 @@ type Flight struct {
 
 		// Наименование города прилета
 		CityName string `json:"cityName"`
+
+		// Часовой пояс аэропорта прилета (IANA)
+		TimeZone string `json:"timeZone"`
 	} `json:"arrival"`
 
 	// Признак наличия багажа
 	BaggageIncluded bool `json:"baggageIncluded"`
-	Date            struct {
-		// Дата и время прилета
+
+	// Дата и время закрытия рейса (окончания посадки). Не заполняется, пока рейс не закрыт
+	ClosedTimestamp *time.Time `json:"closedTimestamp,omitempty"`
+
+	// Валюта цен рейса (код ISO 4217).
+	Currency string `json:"currency"`
+	Date     struct {
+		// Дата и время прилета (UTC), время вылета + продолжительность полета
 		Arrival time.Time `json:"arrival"`
 
-		// Дата и время вылета
+		// Местные дата и время прилета со смещением часового пояса аэропорта прилета
+		ArrivalLocal time.Time `json:"arrivalLocal"`
+
+		// Дата и время вылета (UTC)
 		Departure time.Time `json:"departure"`
 
+		// Местные дата и время вылета со смещением часового пояса аэропорта вылета
+		DepartureLocal time.Time `json:"departureLocal"`
+
 		// Продолжительность полета в минутах
 		Duration int `json:"duration"`
 	} `json:"date"`
@@ -84,388 +305,2175 @@ type Flight struct {
 
 		// Наименование города вылета
 		CityName string `json:"cityName"`
+
+		// Часовой пояс аэропорта вылета (IANA)
+		TimeZone string `json:"timeZone"`
 	} `json:"departure"`
 
+	// Курс валюты.
+	DisplayExchangeRate *ExchangeRate `json:"displayExchangeRate,omitempty"`
+
 	// Идентификатор рейса
 	Id string `json:"id"`
 
 	// Признак международного рейса
 	IsInternational bool `json:"isInternational"`
 
+	// Максимальное количество младенцев без места на рейсе
+	MaxInfants int `json:"maxInfants"`
+
 	// Название рейса
 	Name string `json:"name"`
 
 	// Признак возможности перевоза животных
 	PetAllowed bool `json:"petAllowed"`
 
-	// Стоимость дополнительного багажа.
-	PriceAdditionalBaggage int `json:"priceAdditionalBaggage"`
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	PriceAdditionalBaggage Money `json:"priceAdditionalBaggage"`
 
-	// Стоимость выбора места.
-	PriceSeatSelection int `json:"priceSeatSelection"`
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	PriceSeatSelection Money `json:"priceSeatSelection"`
 
 	// Цены билетов в зависимости от класса места.
 	PricesTickets []FlightPrice `json:"pricesTickets"`
+	Status        *FlightStatus `json:"status,omitempty"`
+
+	// Сборы рейса, включаемые в стоимость каждого билета, в валюте цен рейса.
+	TaxesFees []FlightTaxFee `json:"taxesFees"`
+}
+
+// FlightAncillary defines model for FlightAncillary.
+type FlightAncillary struct {
+	// Количество проданных услуг.
+	CountSold int `json:"countSold"`
+
+	// Идентификатор услуги.
+	Id string `json:"id"`
+
+	// Максимальное количество услуг на рейсе. Не заполняется, если количество не ограничено.
+	MaxCount *int `json:"maxCount,omitempty"`
+
+	// Наименование услуги.
+	Name string `json:"name"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Price Money `json:"price"`
+
+	// Тип услуги (pet_in_cabin - животное в салоне, priority_boarding - приоритетная посадка, meal - питание).
+	Type string `json:"type"`
+}
+
+// FlightManifest defines model for FlightManifest.
+type FlightManifest struct {
+	// Дата и время вылета.
+	DepartureDate time.Time `json:"departureDate"`
+
+	// Идентификатор рейса.
+	FlightId string `json:"flightId"`
+
+	// Наименование рейса.
+	FlightName string `json:"flightName"`
+
+	// Персональные данные пассажиров маскированы.
+	IsMasked bool `json:"isMasked"`
+
+	// Пассажиры рейса.
+	Passengers []ManifestPassenger `json:"passengers"`
+
+	// Дата и время формирования манифеста.
+	Timestamp time.Time `json:"timestamp"`
 }
 
 // FlightPrice defines model for FlightPrice.
 type FlightPrice struct {
+	// Скидка на билет ребенка (от 2 до 12 лет), %.
+	ChildDiscountPercent int `json:"childDiscountPercent"`
+
 	// Идентификатор класса места.
 	ClassSeatsId string `json:"classSeatsId"`
 
 	// Наименование класса места
 	ClassSeatsName string `json:"classSeatsName"`
 
-	// Количество свободных мест.
+	// Количество мест, доступных для продажи (с учетом овербукинга).
 	CountVacantSeats int `json:"countVacantSeats"`
 
-	// Стоимость билета.
-	PriceTicket int `json:"priceTicket"`
+	// Скидка на билет младенца без места (до 2 лет), %.
+	InfantDiscountPercent int `json:"infantDiscountPercent"`
+
+	// Допустимая продажа билетов без места сверх количества мест класса (овербукинг), %.
+	OverbookingPercent int `json:"overbookingPercent"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	PriceHoldFee Money `json:"priceHoldFee"`
+
+	// Максимальный срок удержания цены билета, часов (0 - удержание цены недоступно).
+	PriceHoldMaxHours int `json:"priceHoldMaxHours"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	PriceTicket Money `json:"priceTicket"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	PriceTotal Money `json:"priceTotal"`
 }
 
-// ParamsCreateTicket defines model for ParamsCreateTicket.
-type ParamsCreateTicket struct {
-	// Идентификатор класса места.
-	ClassSeatsId string `json:"classSeatsId"`
+// FlightStatus defines model for FlightStatus.
+type FlightStatus struct {
+	// Фактическое время прилета.
+	ActualArrival *time.Time `json:"actualArrival,omitempty"`
 
-	// Количество мест дополнительного багажа.
-	CountAdditionalBaggage int `json:"countAdditionalBaggage"`
+	// Фактическое время вылета.
+	ActualDeparture *time.Time `json:"actualDeparture,omitempty"`
+
+	// Время начала посадки.
+	BoardingTime *time.Time `json:"boardingTime,omitempty"`
+
+	// Расчетное время прилета.
+	EstimatedArrival *time.Time `json:"estimatedArrival,omitempty"`
+
+	// Расчетное время вылета.
+	EstimatedDeparture *time.Time `json:"estimatedDeparture,omitempty"`
 
 	// Идентификатор рейса.
 	FlightId string `json:"flightId"`
 
-	// Паспортные данные пассажира. Заполняется, если будет создаваться пассажир, а не выбираться существующий.
-	IdentityDataPassenger *string `json:"identityDataPassenger,omitempty"`
-
-	// ФИО пассажира. Заполняется, если будет создаваться пассажир, а не выбираться существующий.
-	NamePassenger *string `json:"namePassenger,omitempty"`
+	// Наименование рейса.
+	FlightName string `json:"flightName"`
 
-	// Идентификатор пассажира. Заполняется, если выбран существующий пассажир, а не создается новый.
-	PassengerId *string `json:"passengerId,omitempty"`
+	// Выход на посадку.
+	Gate *string `json:"gate,omitempty"`
 
-	// Идентификатор места в самолете. Заполняется, если при оформлении билета сразу покупается определенное место.
-	SeatId *string `json:"seatId,omitempty"`
+	// Время прилета по расписанию.
+	ScheduledArrival time.Time `json:"scheduledArrival"`
 
-	// Идентификатор пользователя, выполняющего оформление билета.
-	UserId string `json:"userId"`
-}
+	// Время вылета по расписанию.
+	ScheduledDeparture time.Time `json:"scheduledDeparture"`
 
-// ParamsPayForTicket defines model for ParamsPayForTicket.
-type ParamsPayForTicket struct {
-	// Сумма бонусов для оплаты.
-	PaidWithBonuses int `json:"paidWithBonuses"`
+	// Состояние рейса (on_time - по расписанию, delayed - задержан, boarding - посадка, departed - вылетел, cancelled - отменен).
+	State string `json:"state"`
 
-	// Идентификатор билета для оплаты.
-	TicketId string `json:"ticketId"`
+	// Терминал вылета.
+	Terminal *string `json:"terminal,omitempty"`
 
-	// Идентификатор пользователя, выполняющего оплату билета.
-	UserId string `json:"userId"`
+	// Дата и время последнего обновления статуса. Не заполняется, если статус не обновлялся.
+	UpdatedTimestamp *time.Time `json:"updatedTimestamp,omitempty"`
 }
 
-// ParamsRefundTicket defines model for ParamsRefundTicket.
-type ParamsRefundTicket struct {
-	// Идентификатор билета для оплаты.
-	TicketId string `json:"ticketId"`
+// FlightTaxFee defines model for FlightTaxFee.
+type FlightTaxFee struct {
+	// Идентификатор сбора.
+	Id string `json:"id"`
 
-	// Идентификатор пользователя, выполняющего возврат билета.
-	UserId string `json:"userId"`
+	// Наименование сбора.
+	Name string `json:"name"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Price Money `json:"price"`
+
+	// Тип сбора (airport_tax - аэропортовый сбор, country_tax - государственный сбор, fuel_surcharge - топливный сбор, service_fee - сервисный сбор).
+	Type string `json:"type"`
 }
 
-// ParamsRegisterTicket defines model for ParamsRegisterTicket.
-type ParamsRegisterTicket struct {
-	// Идентификатор места в самолете. Заполняется, если ранее при покупке билета не было выбрано определенное место.
-	SeatId *string `json:"seatId,omitempty"`
+// IdentityDocument defines model for IdentityDocument.
+type IdentityDocument struct {
+	// Дата рождения пассажира.
+	BirthDate openapi_types.Date `json:"birthDate"`
 
-	// Идентификатор билета.
-	TicketId string `json:"ticketId"`
+	// Дата окончания срока действия документа. Обязательна для паспорта.
+	ExpiryDate *openapi_types.Date `json:"expiryDate,omitempty"`
 
-	// Идентификатор пользователя, выполняющего регистрацию на рейс.
-	UserId string `json:"userId"`
-}
+	// Страна выдачи документа (код ISO 3166-1 alpha-2).
+	IssuingCountry string `json:"issuingCountry"`
 
-// Seat defines model for Seat.
-type Seat struct {
-	// Идентификатор места в самолете
-	Id string `json:"id"`
+	// Гражданство пассажира (код ISO 3166-1 alpha-2).
+	Nationality string `json:"nationality"`
 
-	// Номер места в самолете
+	// Номер документа (от 4 до 20 символов A-Z, 0-9, '-').
 	Number string `json:"number"`
+
+	// Тип документа (passport - паспорт, national_id - удостоверение личности, birth_certificate - свидетельство о рождении).
+	Type IdentityDocumentType `json:"type"`
 }
 
-// Ticket defines model for Ticket.
-type Ticket struct {
-	// Сумма бонусов, начисленных за билет.
-	AccruedBonuses int `json:"accruedBonuses"`
-	Flight         struct {
-		// Наименование самолета
-		Aircraft string `json:"aircraft"`
+// Тип документа (passport - паспорт, national_id - удостоверение личности, birth_certificate - свидетельство о рождении).
+type IdentityDocumentType string
 
-		// Название авиакомпании
-		Airline string `json:"airline"`
+// Location defines model for Location.
+type Location struct {
+	// Идентификатор города (для аэропорта - города аэропорта).
+	CityId string `json:"cityId"`
 
-		// Наименование аэропорта прилета
-		ArrivalAirport string `json:"arrivalAirport"`
+	// Наименование города.
+	CityName string `json:"cityName"`
 
-		// Наименование города прилета
-		ArrivalCity string `json:"arrivalCity"`
+	// Страна (код ISO 3166-1 alpha-2).
+	CountryCode *string `json:"countryCode,omitempty"`
 
-		// Дата и время прилета
-		ArrivalDate time.Time `json:"arrivalDate"`
+	// Код IATA.
+	IataCode *string `json:"iataCode,omitempty"`
 
-		// Наименование аэропорта вылета
-		DepartureAirport string `json:"departureAirport"`
+	// Код ICAO аэропорта.
+	IcaoCode *string `json:"icaoCode,omitempty"`
 
-		// Наименование города вылета
-		DepartureCity string `json:"departureCity"`
+	// Идентификатор города или аэропорта.
+	Id string `json:"id"`
 
-		// Дата и время вылета
-		DepartureDate time.Time `json:"departureDate"`
+	// Широта.
+	Latitude *float64 `json:"latitude,omitempty"`
 
-		// Продолжительность полета в минутах
-		Duration int `json:"duration"`
+	// Долгота.
+	Longitude *float64 `json:"longitude,omitempty"`
 
-		// Идентификатор рейса
-		Id string `json:"id"`
+	// Наименование города или аэропорта.
+	Name string `json:"name"`
 
-		// Название рейса
-		Name string `json:"name"`
-	} `json:"flight"`
+	// Тип места (city - город, airport - аэропорт).
+	Type string `json:"type"`
+}
 
-	// Идентификатор билета.
-	Id string `json:"id"`
+// ManifestPassenger defines model for ManifestPassenger.
+type ManifestPassenger struct {
+	// Дата рождения пассажира. Не выводится при маскировании.
+	BirthDate *openapi_types.Date `json:"birthDate,omitempty"`
 
-	// Сумма бонусов, использованных для оплаты билета.
-	PaidWithBonuses int `json:"paidWithBonuses"`
-	Passenger       struct {
-		// Идентификатор пассажира.
-		Id string `json:"id"`
+	// Наименование класса мест.
+	ClassSeatsName string `json:"classSeatsName"`
 
-		// Паспортные данные пассажира.
-		IdentityData string `json:"identityData"`
+	// Количество дополнительного багажа.
+	CountAdditionalBaggage int `json:"countAdditionalBaggage"`
 
-		// ФИО пассажира.
-		Name string `json:"name"`
-	} `json:"passenger"`
+	// Адрес пребывания из данных APIS. Не выводится при маскировании.
+	DestinationAddress *string `json:"destinationAddress,omitempty"`
 
-	// Цена билета в рублях.
-	Price int `json:"price"`
-	Seat  struct {
-		// Идентификатор класса места
-		ClassSeatsId string `json:"classSeatsId"`
+	// Город пребывания из данных APIS.
+	DestinationCity *string `json:"destinationCity,omitempty"`
 
-		// Наименование класса места
-		ClassSeatsName string `json:"classSeatsName"`
+	// Страна назначения из данных APIS (код ISO 3166-1 alpha-2).
+	DestinationCountry *string `json:"destinationCountry,omitempty"`
 
-		// Идентификатор места в самолете
-		SeatId *string `json:"seatId,omitempty"`
+	// Дата окончания срока действия документа. Не выводится при маскировании.
+	DocumentExpiryDate *openapi_types.Date `json:"documentExpiryDate,omitempty"`
 
-		// Номер места в самолете
-		SeatNumber *string `json:"seatNumber,omitempty"`
-	} `json:"seat"`
-	Status struct {
-		// Наименование статуса
-		Name string `json:"name"`
+	// Страна выдачи документа (код ISO 3166-1 alpha-2).
+	DocumentIssuingCountry *string `json:"documentIssuingCountry,omitempty"`
 
-		// Дата и время установки статуса
-		Timestamp time.Time `json:"timestamp"`
-	} `json:"status"`
-	User struct {
-		// Идентификатор пользователя
-		Id string `json:"id"`
+	// Номер документа. При маскировании выводятся последние 4 символа.
+	DocumentNumber *string `json:"documentNumber,omitempty"`
 
-		// Имя пользователя
-		Name string `json:"name"`
-	} `json:"user"`
+	// Тип документа.
+	DocumentType *string `json:"documentType,omitempty"`
 
-	// Количество мест дополнительного багажа.
-	СountAdditionalBaggage int `json:"сountAdditionalBaggage"`
-}
+	// ФИО пассажира. При маскировании - фамилия и инициалы.
+	NamePassenger string `json:"namePassenger"`
 
-// UpdatedItem defines model for UpdatedItem.
-type UpdatedItem struct {
-	// ID обновленного объекта
-	Id string `json:"id"`
+	// Гражданство пассажира (код ISO 3166-1 alpha-2).
+	Nationality *string `json:"nationality,omitempty"`
+
+	// Тип пассажира (adult - взрослый, child - ребенок, infant - младенец без места).
+	PassengerType string `json:"passengerType"`
+
+	// Номер места. Не заполняется, если место не назначено.
+	SeatNumber *string `json:"seatNumber,omitempty"`
+
+	// Наименование статуса билета.
+	Status string `json:"status"`
+
+	// Идентификатор билета.
+	TicketId string `json:"ticketId"`
 }
 
-// User defines model for User.
-type User struct {
-	Balance struct {
-		// Сумма бонусов.
-		SumBonuses int `json:"sumBonuses"`
+// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+type Money struct {
+	// Сумма в минимальных единицах валюты (копейках, центах).
+	Amount int64 `json:"amount"`
 
-		// Сумма покупок.
-		SumPurchases int `json:"sumPurchases"`
-	} `json:"balance"`
+	// Валюта (код ISO 4217).
+	Currency string `json:"currency"`
 
-	// Электронная почта пользователя
-	Email string `json:"email"`
+	// Сумма в валюте просмотра в минимальных единицах валюты. Для рейса - по текущему курсу, для билета - по курсу валюты учета, зафиксированному при оформлении билета.
+	DisplayAmount *int64 `json:"displayAmount,omitempty"`
 
-	// Идентификатор пользователя
+	// Валюта просмотра (код ISO 4217).
+	DisplayCurrency *string `json:"displayCurrency,omitempty"`
+}
+
+// Notification defines model for Notification.
+type Notification struct {
+	// Идентификатор уведомления.
 	Id string `json:"id"`
 
-	// Имя пользователя
-	Name string `json:"name"`
+	// Текст уведомления.
+	Message string `json:"message"`
+
+	// Идентификатор билета, к которому относится уведомление.
+	TicketId *string `json:"ticketId,omitempty"`
+
+	// Дата и время уведомления.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Тип уведомления (waitlist_ticket_created - билет оформлен по листу ожидания, waitlist_expired - запись листа ожидания истекла, waitlist_canceled - билет по листу ожидания не может быть оформлен).
+	Type string `json:"type"`
 }
 
-// VacantSeats defines model for VacantSeats.
-type VacantSeats struct {
+// OversoldClassSeats defines model for OversoldClassSeats.
+type OversoldClassSeats struct {
 	// Идентификатор класса места.
 	ClassSeatsId string `json:"classSeatsId"`
 
 	// Наименование класса места
 	ClassSeatsName string `json:"classSeatsName"`
 
-	// Количество свободных мест.
-	CountVacantSeats int `json:"countVacantSeats"`
+	// Количество билетов сверх мест класса, т.е. пассажиров, которым может быть отказано в посадке.
+	CountOversold int `json:"countOversold"`
 
-	// Свободные места на рейсе.
+	// Количество мест класса.
+	CountSeats int `json:"countSeats"`
+
+	// Количество проданных билетов (без младенцев без места).
+	CountTickets int `json:"countTickets"`
+
+	// Дата и время вылета.
+	DepartureDate time.Time `json:"departureDate"`
+
+	// Идентификатор рейса.
+	FlightId string `json:"flightId"`
+
+	// Наименование рейса.
+	FlightName string `json:"flightName"`
+
+	// Допустимая продажа билетов сверх количества мест класса (овербукинг), %.
+	OverbookingPercent int `json:"overbookingPercent"`
+}
+
+// ParamsAddTicketAncillary defines model for ParamsAddTicketAncillary.
+type ParamsAddTicketAncillary struct {
+	// Идентификатор услуги из каталога рейса. Заполняется для услуг pet_in_cabin, priority_boarding, meal.
+	FlightAncillaryId *string `json:"flightAncillaryId,omitempty"`
+
+	// Количество. По умолчанию 1.
+	Quantity *int `json:"quantity,omitempty"`
+
+	// Идентификатор места в самолете. Заполняется для услуги seat_selection.
+	SeatId *string `json:"seatId,omitempty"`
+
+	// Идентификатор билета.
+	TicketId string `json:"ticketId"`
+
+	// Тип услуги (extra_baggage - дополнительный багаж, seat_selection - выбор места, pet_in_cabin - животное в салоне, priority_boarding - приоритетная посадка, meal - питание).
+	Type string `json:"type"`
+
+	// Идентификатор пользователя, выполняющего покупку услуги.
+	UserId string `json:"userId"`
+}
+
+// ParamsApproveTicket defines model for ParamsApproveTicket.
+type ParamsApproveTicket struct {
+	// Билет согласован (true) или отклонен (false).
+	Approved bool `json:"approved"`
+
+	// Идентификатор билета для согласования.
+	TicketId string `json:"ticketId"`
+
+	// Идентификатор пользователя компании с ролью approver.
+	UserId string `json:"userId"`
+}
+
+// ParamsBoardTicket defines model for ParamsBoardTicket.
+type ParamsBoardTicket struct {
+	// Отсканированный код посадочного талона или id билета.
+	Code string `json:"code"`
+}
+
+// ParamsCreateCompanyInvoice defines model for ParamsCreateCompanyInvoice.
+type ParamsCreateCompanyInvoice struct {
+	// Закончившийся месяц счета (ГГГГ-ММ, UTC).
+	Period string `json:"period"`
+}
+
+// ParamsCreatePriceHold defines model for ParamsCreatePriceHold.
+type ParamsCreatePriceHold struct {
+	// Идентификатор неоплаченного билета.
+	TicketId string `json:"ticketId"`
+
+	// Идентификатор пользователя билета.
+	UserId string `json:"userId"`
+}
+
+// ParamsCreateSeatHold defines model for ParamsCreateSeatHold.
+type ParamsCreateSeatHold struct {
+	// Идентификатор класса места.
+	ClassSeatsId string `json:"classSeatsId"`
+
+	// Идентификатор места в самолете. Если не заполнено, то удерживается одно место класса без выбора конкретного места.
+	SeatId *string `json:"seatId,omitempty"`
+
+	// Идентификатор пользователя, выполняющего удержание места.
+	UserId string `json:"userId"`
+}
+
+// ParamsCreateTicket defines model for ParamsCreateTicket.
+type ParamsCreateTicket struct {
+	// Идентификатор билета сопровождающего взрослого на этот же рейс. Обязателен, если пассажир на дату вылета ребенок или младенец.
+	AccompanyingTicketId *string `json:"accompanyingTicketId,omitempty"`
+
+	// Идентификатор пользователя компании с ролью booker, оформляющего билет. Заполняется, если билет компании оформляется не самим путешественником.
+	BookerId *string `json:"bookerId,omitempty"`
+
+	// Идентификатор класса места.
+	ClassSeatsId string `json:"classSeatsId"`
+
+	// Идентификатор компании. Заполняется, если билет оформляется на компанию и оплачивается по счету компании.
+	CompanyId *string `json:"companyId,omitempty"`
+
+	// Количество мест дополнительного багажа.
+	CountAdditionalBaggage int `json:"countAdditionalBaggage"`
+
+	// Документ, удостоверяющий личность пассажира.
+	DocumentPassenger *IdentityDocument `json:"documentPassenger,omitempty"`
+
+	// Идентификатор рейса.
+	FlightId string `json:"flightId"`
+
+	// ФИО пассажира. Заполняется, если будет создаваться пассажир, а не выбираться существующий.
+	NamePassenger *string `json:"namePassenger,omitempty"`
+
+	// Идентификатор пассажира. Заполняется, если выбран существующий пассажир, а не создается новый.
+	PassengerId *string `json:"passengerId,omitempty"`
+
+	// Промокод на скидку от тарифа. Регистр не учитывается.
+	PromoCode *string `json:"promoCode,omitempty"`
+
+	// Идентификатор удержания места. Заполняется, если билет оформляется по ранее удержанному месту.
+	SeatHoldId *string `json:"seatHoldId,omitempty"`
+
+	// Идентификатор места в самолете. Заполняется, если при оформлении билета сразу покупается определенное место.
+	SeatId *string `json:"seatId,omitempty"`
+
+	// Идентификатор пользователя, выполняющего оформление билета.
+	UserId string `json:"userId"`
+}
+
+// ParamsCreateUser defines model for ParamsCreateUser.
+type ParamsCreateUser struct {
+	// Электронная почта пользователя.
+	Email string `json:"email"`
+
+	// Имя пользователя.
+	Name string `json:"name"`
+
+	// Пароль пользователя (не короче 8 символов).
+	Password string `json:"password"`
+
+	// Реферальный код пригласившего пользователя. Бонусы начисляются обоим пользователям после регистрации на рейс первого билета приглашенного пользователя.
+	ReferralCode *string `json:"referralCode,omitempty"`
+}
+
+// ParamsJoinWaitlist defines model for ParamsJoinWaitlist.
+type ParamsJoinWaitlist struct {
+	// Идентификатор класса места.
+	ClassSeatsId string `json:"classSeatsId"`
+
+	// Количество мест дополнительного багажа в оформляемом билете.
+	CountAdditionalBaggage *int `json:"countAdditionalBaggage,omitempty"`
+
+	// Идентификатор рейса.
+	FlightId string `json:"flightId"`
+
+	// Идентификатор сохраненного пассажира пользователя.
+	PassengerId string `json:"passengerId"`
+
+	// Идентификатор пользователя, выполняющего постановку в лист ожидания.
+	UserId string `json:"userId"`
+}
+
+// ParamsPayForTicket defines model for ParamsPayForTicket.
+type ParamsPayForTicket struct {
+	// Сумма бонусов для оплаты в минимальных единицах валюты учета (копейках).
+	PaidWithBonuses int64 `json:"paidWithBonuses"`
+
+	// Идентификатор билета для оплаты.
+	TicketId string `json:"ticketId"`
+
+	// Идентификатор пользователя, выполняющего оплату билета.
+	UserId string `json:"userId"`
+
+	// Оплата подарочными сертификатами и кредитами на перелет. Остаток стоимости билета оплачивается картой.
+	Vouchers *[]ParamsVoucherPayment `json:"vouchers,omitempty"`
+}
+
+// ParamsRefundTicket defines model for ParamsRefundTicket.
+type ParamsRefundTicket struct {
+	// Вернуть оплаченное картой кредитом на перелет вместо возврата в бонусы.
+	RefundAsCredit *bool `json:"refundAsCredit,omitempty"`
+
+	// Идентификатор билета для оплаты.
+	TicketId string `json:"ticketId"`
+
+	// Идентификатор пользователя, выполняющего возврат билета.
+	UserId string `json:"userId"`
+}
+
+// ParamsRegisterFlightTicket defines model for ParamsRegisterFlightTicket.
+type ParamsRegisterFlightTicket struct {
+	// Предварительная информация о пассажире (APIS). Обязательна для международного рейса, для внутреннего рейса не сохраняется. Если документ не заполнен, используется документ, указанный в билете.
+	Apis *ApisData `json:"apis,omitempty"`
+
+	// Идентификатор места в самолете. Заполняется, если ранее при покупке билета не было выбрано определенное место. Если не заполнено, то место назначается автоматически рядом с другими пассажирами пользователя.
+	SeatId *string `json:"seatId,omitempty"`
+
+	// Идентификатор регистрируемого билета.
+	TicketId string `json:"ticketId"`
+}
+
+// ParamsRegisterFlightTickets defines model for ParamsRegisterFlightTickets.
+type ParamsRegisterFlightTickets struct {
+	// Идентификатор рейса.
+	FlightId string `json:"flightId"`
+
+	// Параметры регистрации отдельных билетов. Билеты, которые не переданы, регистрируются с автоматическим назначением места.
+	Tickets *[]ParamsRegisterFlightTicket `json:"tickets,omitempty"`
+
+	// Идентификатор пользователя, выполняющего регистрацию на рейс.
+	UserId string `json:"userId"`
+}
+
+// ParamsRegisterTicket defines model for ParamsRegisterTicket.
+type ParamsRegisterTicket struct {
+	// Предварительная информация о пассажире (APIS). Обязательна для международного рейса, для внутреннего рейса не сохраняется. Если документ не заполнен, используется документ, указанный в билете.
+	Apis *ApisData `json:"apis,omitempty"`
+
+	// Идентификатор места в самолете. Заполняется, если ранее при покупке билета не было выбрано определенное место. Если не заполнено, то место назначается автоматически.
+	SeatId *string `json:"seatId,omitempty"`
+
+	// Идентификатор билета.
+	TicketId string `json:"ticketId"`
+
+	// Идентификатор пользователя, выполняющего регистрацию на рейс.
+	UserId string `json:"userId"`
+}
+
+// ParamsSavePassenger defines model for ParamsSavePassenger.
+type ParamsSavePassenger struct {
+	// Документ, удостоверяющий личность пассажира.
+	DocumentPassenger IdentityDocument `json:"documentPassenger"`
+
+	// ФИО пассажира.
+	NamePassenger string `json:"namePassenger"`
+}
+
+// ParamsUpdateFlightStatus defines model for ParamsUpdateFlightStatus.
+type ParamsUpdateFlightStatus struct {
+	// Фактическое время прилета.
+	ActualArrival *time.Time `json:"actualArrival,omitempty"`
+
+	// Фактическое время вылета. Обязательно для вылетевшего рейса.
+	ActualDeparture *time.Time `json:"actualDeparture,omitempty"`
+
+	// Время начала посадки.
+	BoardingTime *time.Time `json:"boardingTime,omitempty"`
+
+	// Расчетное время прилета. По умолчанию - расчетное время вылета + длительность полета.
+	EstimatedArrival *time.Time `json:"estimatedArrival,omitempty"`
+
+	// Расчетное время вылета. Обязательно для задержанного рейса, позже времени вылета по расписанию.
+	EstimatedDeparture *time.Time `json:"estimatedDeparture,omitempty"`
+
+	// Выход на посадку (до 10 символов).
+	Gate *string `json:"gate,omitempty"`
+
+	// Состояние рейса (on_time - по расписанию, delayed - задержан, boarding - посадка, departed - вылетел, cancelled - отменен).
+	State string `json:"state"`
+
+	// Терминал вылета (до 10 символов).
+	Terminal *string `json:"terminal,omitempty"`
+}
+
+// ParamsVoucherPayment defines model for ParamsVoucherPayment.
+type ParamsVoucherPayment struct {
+	// Сумма оплаты сертификатом или кредитом в минимальных единицах валюты учета (копейках).
+	Amount int64 `json:"amount"`
+
+	// Код сертификата или кредита.
+	Code string `json:"code"`
+}
+
+// Passenger defines model for Passenger.
+type Passenger struct {
+	// Документ, удостоверяющий личность пассажира.
+	Document *IdentityDocument `json:"document,omitempty"`
+
+	// Идентификатор пассажира.
+	Id string `json:"id"`
+
+	// Паспортные данные пассажира.
+	IdentityData string `json:"identityData"`
+
+	// ФИО пассажира.
+	Name string `json:"name"`
+}
+
+// Итоги состава стоимости билета по видам позиций. Сумма итогов равна цене билета.
+type PriceBreakdown struct {
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Ancillaries Money `json:"ancillaries"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Discounts Money `json:"discounts"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Fare Money `json:"fare"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Fees Money `json:"fees"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Taxes Money `json:"taxes"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Total Money `json:"total"`
+}
+
+// Удержание цены билета. Билет с действующим удержанием можно оплатить до окончания удержания.
+type PriceHold struct {
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Fee Money `json:"fee"`
+
+	// Дата и время окончания удержания.
+	ExpiryTimestamp time.Time `json:"expiryTimestamp"`
+
+	// Дата и время создания удержания.
+	HoldTimestamp time.Time `json:"holdTimestamp"`
+
+	// Идентификатор удержания цены.
+	Id string `json:"id"`
+
+	// Дата и время напоминания об окончании удержания.
+	ReminderTimestamp *time.Time `json:"reminderTimestamp,omitempty"`
+
+	// Статус удержания (active - действует, paid - билет оплачен, expired - истекло, билет отменен).
+	Status string `json:"status"`
+
+	// Идентификатор билета.
+	TicketId string `json:"ticketId"`
+
+	// Идентификатор пользователя.
+	UserId string `json:"userId"`
+}
+
+// Seat defines model for Seat.
+type Seat struct {
+	// Идентификатор места в самолете
+	Id string `json:"id"`
+
+	// Место в ряду у аварийного выхода (не назначается детям и взрослым с младенцами)
+	IsExitRow bool `json:"isExitRow"`
+
+	// Номер места в самолете
+	Number string `json:"number"`
+}
+
+// SeatHold defines model for SeatHold.
+type SeatHold struct {
+	// Идентификатор класса места.
+	ClassSeatsId string `json:"classSeatsId"`
+
+	// Дата и время истечения удержания.
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Идентификатор рейса.
+	FlightId string `json:"flightId"`
+
+	// Идентификатор удержания.
+	Id string `json:"id"`
+
+	// Идентификатор удержанного места.
+	SeatId *string `json:"seatId,omitempty"`
+
+	// Дата и время создания удержания.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Идентификатор пользователя.
+	UserId string `json:"userId"`
+}
+
+// Ticket defines model for Ticket.
+type Ticket struct {
+	// Идентификатор билета сопровождающего взрослого. Заполняется для билетов детей и младенцев.
+	AccompanyingTicketId *string `json:"accompanyingTicketId,omitempty"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	AccruedBonuses Money `json:"accruedBonuses"`
+
+	// Код посадочного талона (рейс/место/id билета). Заполняется для зарегистрированного билета и билета, прошедшего посадку.
+	BoardingPassCode *string `json:"boardingPassCode,omitempty"`
+
+	// Билет компании. Заполняется для билета, оформленного на компанию.
+	Company *TicketCompany `json:"company,omitempty"`
+
+	// Курс валюты.
+	ExchangeRate ExchangeRate `json:"exchangeRate"`
+	Flight       struct {
+		// Наименование самолета
+		Aircraft string `json:"aircraft"`
+
+		// Название авиакомпании
+		Airline string `json:"airline"`
+
+		// Наименование аэропорта прилета
+		ArrivalAirport string `json:"arrivalAirport"`
+
+		// Наименование города прилета
+		ArrivalCity string `json:"arrivalCity"`
+
+		// Дата и время прилета (UTC)
+		ArrivalDate time.Time `json:"arrivalDate"`
+
+		// Местные дата и время прилета со смещением часового пояса аэропорта прилета
+		ArrivalDateLocal time.Time `json:"arrivalDateLocal"`
+
+		// Наименование аэропорта вылета
+		DepartureAirport string `json:"departureAirport"`
+
+		// Наименование города вылета
+		DepartureCity string `json:"departureCity"`
+
+		// Дата и время вылета (UTC)
+		DepartureDate time.Time `json:"departureDate"`
+
+		// Местные дата и время вылета со смещением часового пояса аэропорта вылета
+		DepartureDateLocal time.Time `json:"departureDateLocal"`
+
+		// Продолжительность полета в минутах
+		Duration int `json:"duration"`
+
+		// Идентификатор рейса
+		Id string `json:"id"`
+
+		// Название рейса
+		Name string `json:"name"`
+	} `json:"flight"`
+
+	// Идентификатор билета.
+	Id string `json:"id"`
+
+	// Состав стоимости билета. Сумма позиций равна цене билета.
+	Items []TicketItem `json:"items"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	PaidWithBonuses Money `json:"paidWithBonuses"`
+	Passenger       struct {
+		// Документ, удостоверяющий личность пассажира.
+		Document *IdentityDocument `json:"document,omitempty"`
+
+		// Идентификатор пассажира.
+		Id string `json:"id"`
+
+		// Паспортные данные пассажира.
+		IdentityData string `json:"identityData"`
+
+		// ФИО пассажира.
+		Name string `json:"name"`
+	} `json:"passenger"`
+
+	// Тип пассажира по возрасту на дату вылета (adult - взрослый, child - ребенок от 2 до 12 лет, infant - младенец до 2 лет без места).
+	PassengerType string `json:"passengerType"`
+
+	// Оплаты билета по способам оплаты в валюте учета. Заполняется для оплаченного билета.
+	Payments *[]TicketPayment `json:"payments,omitempty"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Price Money `json:"price"`
+
+	// Итоги состава стоимости билета по видам позиций. Сумма итогов равна цене билета.
+	PriceBreakdown PriceBreakdown `json:"priceBreakdown"`
+
+	// Удержание цены билета. Билет с действующим удержанием можно оплатить до окончания удержания.
+	PriceHold *PriceHold `json:"priceHold,omitempty"`
+
+	// Промокод, примененный при оформлении билета.
+	PromoCode *string `json:"promoCode,omitempty"`
+	Seat      struct {
+		// Идентификатор класса места
+		ClassSeatsId string `json:"classSeatsId"`
+
+		// Наименование класса места
+		ClassSeatsName string `json:"classSeatsName"`
+
+		// Идентификатор места в самолете
+		SeatId *string `json:"seatId,omitempty"`
+
+		// Номер места в самолете
+		SeatNumber *string `json:"seatNumber,omitempty"`
+	} `json:"seat"`
+	Status struct {
+		// Наименование статуса
+		Name string `json:"name"`
+
+		// Дата и время установки статуса
+		Timestamp time.Time `json:"timestamp"`
+	} `json:"status"`
+	User struct {
+		// Идентификатор пользователя
+		Id string `json:"id"`
+
+		// Имя пользователя
+		Name string `json:"name"`
+	} `json:"user"`
+
+	// Количество мест дополнительного багажа.
+	СountAdditionalBaggage int `json:"сountAdditionalBaggage"`
+}
+
+// Билет компании. Заполняется для билета, оформленного на компанию.
+type TicketCompany struct {
+	// Статус согласования билета (pending - ожидает согласования, approved - согласован, rejected - отклонен).
+	ApprovalStatus string `json:"approvalStatus"`
+
+	// Дата и время согласования или отклонения билета.
+	ApprovalTimestamp *time.Time `json:"approvalTimestamp,omitempty"`
+
+	// Идентификатор пользователя компании, согласовавшего или отклонившего билет.
+	ApproverId *string `json:"approverId,omitempty"`
+
+	// Идентификатор пользователя компании, оформившего билет. Заполняется, если билет оформлен не самим путешественником.
+	BookerId *string `json:"bookerId,omitempty"`
+
+	// Идентификатор компании.
+	CompanyId string `json:"companyId"`
+
+	// Наименование компании.
+	CompanyName string `json:"companyName"`
+
+	// Нарушения правил поездок компании.
+	PolicyViolations *[]string `json:"policyViolations,omitempty"`
+}
+
+// TicketItem defines model for TicketItem.
+type TicketItem struct {
+	// Идентификатор услуги из каталога рейса.
+	FlightAncillaryId *string `json:"flightAncillaryId,omitempty"`
+
+	// Идентификатор позиции.
+	Id string `json:"id"`
+
+	// Наименование позиции.
+	Name string `json:"name"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Price Money `json:"price"`
+
+	// Количество.
+	Quantity int `json:"quantity"`
+
+	// Идентификатор сбора рейса. Заполняется для позиций сборов.
+	TaxFeeId *string `json:"taxFeeId,omitempty"`
+
+	// Дата и время добавления позиции.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Тип позиции (fare - тариф, airport_tax - аэропортовый сбор, country_tax - государственный сбор, fuel_surcharge - топливный сбор, service_fee - сервисный сбор, extra_baggage - дополнительный багаж, seat_selection - выбор места, pet_in_cabin - животное в салоне, priority_boarding - приоритетная посадка, meal - питание, promo_discount - скидка по промокоду с отрицательной суммой).
+	Type string `json:"type"`
+}
+
+// Оплата билета одним способом оплаты.
+type TicketPayment struct {
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Amount Money `json:"amount"`
+
+	// Идентификатор оплаты.
+	Id string `json:"id"`
+
+	// Идентификатор счета компании. Заполняется для оплаты по счету компании, включенной в счет.
+	InvoiceId *string `json:"invoiceId,omitempty"`
+
+	// Способ оплаты (bonuses - бонусы, gift_certificate - подарочный сертификат, travel_credit - кредит на перелет, card - банковская карта, invoice - счет компании).
+	Method string `json:"method"`
+
+	// Дата и время возврата оплаты. Заполняется для возвращенного билета.
+	RefundTimestamp *time.Time `json:"refundTimestamp,omitempty"`
+
+	// Дата и время оплаты.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Код сертификата или кредита. Заполняется для оплаты сертификатом или кредитом.
+	VoucherCode *string `json:"voucherCode,omitempty"`
+}
+
+// TicketSummary defines model for TicketSummary.
+type TicketSummary struct {
+	// Наименование города прилета
+	ArrivalCity string `json:"arrivalCity"`
+
+	// Наименование класса места
+	ClassSeatsName string `json:"classSeatsName"`
+
+	// Наименование города вылета
+	DepartureCity string `json:"departureCity"`
+
+	// Дата и время вылета
+	DepartureDate time.Time `json:"departureDate"`
+
+	// Название рейса
+	FlightName string `json:"flightName"`
+
+	// Идентификатор билета.
+	Id string `json:"id"`
+
+	// ФИО пассажира.
+	PassengerName string `json:"passengerName"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Price Money `json:"price"`
+
+	// Номер места в самолете
+	SeatNumber *string `json:"seatNumber,omitempty"`
+
+	// Наименование статуса
+	Status string `json:"status"`
+}
+
+// TicketsPage defines model for TicketsPage.
+type TicketsPage struct {
+	// Курсор следующей страницы. Не заполняется на последней странице.
+	NextCursor *string `json:"nextCursor,omitempty"`
+
+	// Билеты пользователя. Заполняется при полном виде вывода.
+	Tickets *[]Ticket `json:"tickets,omitempty"`
+
+	// Краткие данные билетов пользователя. Заполняется при кратком виде вывода.
+	TicketsSummary *[]TicketSummary `json:"ticketsSummary,omitempty"`
+}
+
+// Правила поездок компании. Незаполненные правила не проверяются.
+type TravelPolicy struct {
+	// Согласование всех билетов компании, в том числе без нарушений правил поездок.
+	ApprovalRequired bool `json:"approvalRequired"`
+
+	// Максимальный класс места (Economy, Comfort, Business, First).
+	MaxClassSeatsName *string `json:"maxClassSeatsName,omitempty"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	MaxPrice *Money `json:"maxPrice,omitempty"`
+}
+
+// UpdatedItem defines model for UpdatedItem.
+type UpdatedItem struct {
+	// ID обновленного объекта
+	Id string `json:"id"`
+}
+
+// User defines model for User.
+type User struct {
+	Balance struct {
+		// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+		SumBonuses Money `json:"sumBonuses"`
+
+		// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+		SumPurchases Money `json:"sumPurchases"`
+	} `json:"balance"`
+
+	// Электронная почта пользователя
+	Email string `json:"email"`
+
+	// Идентификатор пользователя
+	Id string `json:"id"`
+
+	// Имя пользователя
+	Name string `json:"name"`
+
+	// Реферальный код пользователя для приглашения новых пользователей
+	ReferralCode string `json:"referralCode"`
+}
+
+// VacantSeats defines model for VacantSeats.
+type VacantSeats struct {
+	// Идентификатор класса места.
+	ClassSeatsId string `json:"classSeatsId"`
+
+	// Наименование класса места
+	ClassSeatsName string `json:"classSeatsName"`
+
+	// Количество мест, доступных для продажи (с учетом овербукинга). Может быть больше количества свободных мест.
+	CountVacantSeats int `json:"countVacantSeats"`
+
+	// Свободные места на рейсе.
 	Seats []Seat `json:"seats"`
 }
 
-// UUIDPathObjectID defines model for UUIDPathObjectID.
-type UUIDPathObjectID string
+// Подарочный сертификат или кредит на перелет.
+type Voucher struct {
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Amount Money `json:"amount"`
+
+	// Денежная сумма. Суммы цен рейса и билета - в валюте цен рейса, баланс пользователя и бонусы - в валюте учета (RUB).
+	Balance Money `json:"balance"`
+
+	// Код сертификата или кредита.
+	Code string `json:"code"`
+
+	// Дата и время окончания срока действия.
+	ExpiryTimestamp time.Time `json:"expiryTimestamp"`
+
+	// Дата и время выпуска.
+	IssueTimestamp time.Time `json:"issueTimestamp"`
+
+	// Тип (gift_certificate - подарочный сертификат, travel_credit - кредит на перелет).
+	Type string `json:"type"`
+}
+
+// UUIDPathObjectID defines model for UUIDPathObjectID.
+type UUIDPathObjectID string
+
+// GetFlightManifestParams defines parameters for GetFlightManifest.
+type GetFlightManifestParams struct {
+	// Формат выгрузки (json - по умолчанию, csv, pdf)
+	Format *GetFlightManifestParamsFormat `json:"format,omitempty"`
+
+	// Роль сотрудника, передаваемая шлюзом (admin - полные данные, agent - маскированные данные)
+	XRole *string `json:"X-Role,omitempty"`
+}
+
+// GetFlightManifestParamsFormat defines parameters for GetFlightManifest.
+type GetFlightManifestParamsFormat string
+
+// UpdateFlightStatusJSONBody defines parameters for UpdateFlightStatus.
+type UpdateFlightStatusJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsUpdateFlightStatus)
+	ParamsUpdateFlightStatus `yaml:",inline"`
+}
+
+// UpdateFlightStatusParams defines parameters for UpdateFlightStatus.
+type UpdateFlightStatusParams struct {
+	// Роль сотрудника, передаваемая шлюзом (admin, agent)
+	XRole *string `json:"X-Role,omitempty"`
+}
+
+// CreateCompanyInvoiceJSONBody defines parameters for CreateCompanyInvoice.
+type CreateCompanyInvoiceJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsCreateCompanyInvoice)
+	ParamsCreateCompanyInvoice `yaml:",inline"`
+}
+
+// GetCompanyReportParams defines parameters for GetCompanyReport.
+type GetCompanyReportParams struct {
+	// Начало периода дат вылета
+	DepartureDateFrom *openapi_types.Date `json:"departureDateFrom,omitempty"`
+
+	// Окончание периода дат вылета
+	DepartureDateTo *openapi_types.Date `json:"departureDateTo,omitempty"`
+}
+
+// GetFlightsParams defines parameters for GetFlights.
+type GetFlightsParams struct {
+	// Идентификатор города вылета или код IATA/ICAO города или аэропорта вылета
+	DepartureCityId string `json:"departureCityId"`
+
+	// Идентификатор города прилета или код IATA/ICAO города или аэропорта прилета
+	ArrivalCityId string `json:"arrivalCityId"`
+
+	// Дата вылета (местная дата в часовом поясе аэропорта вылета)
+	DepartureDate openapi_types.Date `json:"departureDate"`
+
+	// Валюта просмотра цен (код ISO 4217). Цены дополнительно выводятся в этой валюте по текущему курсу
+	Currency *string `json:"currency,omitempty"`
+}
+
+// GetOversoldFlightsParams defines parameters for GetOversoldFlights.
+type GetOversoldFlightsParams struct {
+	// Начало периода дат вылета
+	DepartureDateFrom *openapi_types.Date `json:"departureDateFrom,omitempty"`
+
+	// Окончание периода дат вылета
+	DepartureDateTo *openapi_types.Date `json:"departureDateTo,omitempty"`
+}
+
+// GetFlightByIdParams defines parameters for GetFlightById.
+type GetFlightByIdParams struct {
+	// Валюта просмотра цен (код ISO 4217). Цены дополнительно выводятся в этой валюте по текущему курсу
+	Currency *string `json:"currency,omitempty"`
+}
+
+// BoardTicketJSONBody defines parameters for BoardTicket.
+type BoardTicketJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsBoardTicket)
+	ParamsBoardTicket `yaml:",inline"`
+}
+
+// CreateSeatHoldJSONBody defines parameters for CreateSeatHold.
+type CreateSeatHoldJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsCreateSeatHold)
+	ParamsCreateSeatHold `yaml:",inline"`
+}
+
+// SearchLocationsParams defines parameters for SearchLocations.
+type SearchLocationsParams struct {
+	// Строка поиска (от 2 до 100 символов)
+	Q string `json:"q"`
+
+	// Количество мест в результате (по умолчанию 10, не более 50)
+	Limit *int `json:"limit,omitempty"`
+}
+
+// CreateTicketJSONBody defines parameters for CreateTicket.
+type CreateTicketJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsCreateTicket)
+	ParamsCreateTicket `yaml:",inline"`
+}
+
+// AddTicketAncillaryJSONBody defines parameters for AddTicketAncillary.
+type AddTicketAncillaryJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsAddTicketAncillary)
+	ParamsAddTicketAncillary `yaml:",inline"`
+}
+
+// ApproveTicketJSONBody defines parameters for ApproveTicket.
+type ApproveTicketJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsApproveTicket)
+	ParamsApproveTicket `yaml:",inline"`
+}
+
+// PayForTicketJSONBody defines parameters for PayForTicket.
+type PayForTicketJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsPayForTicket)
+	ParamsPayForTicket `yaml:",inline"`
+}
+
+// CreatePriceHoldJSONBody defines parameters for CreatePriceHold.
+type CreatePriceHoldJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsCreatePriceHold)
+	ParamsCreatePriceHold `yaml:",inline"`
+}
+
+// RefundTicketJSONBody defines parameters for RefundTicket.
+type RefundTicketJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsRefundTicket)
+	ParamsRefundTicket `yaml:",inline"`
+}
+
+// RegisterTicketJSONBody defines parameters for RegisterTicket.
+type RegisterTicketJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsRegisterTicket)
+	ParamsRegisterTicket `yaml:",inline"`
+}
+
+// RegisterFlightTicketsJSONBody defines parameters for RegisterFlightTickets.
+type RegisterFlightTicketsJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsRegisterFlightTickets)
+	ParamsRegisterFlightTickets `yaml:",inline"`
+}
+
+// JoinWaitlistJSONBody defines parameters for JoinWaitlist.
+type JoinWaitlistJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsJoinWaitlist)
+	ParamsJoinWaitlist `yaml:",inline"`
+}
+
+// CreateUserJSONBody defines parameters for CreateUser.
+type CreateUserJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsCreateUser)
+	ParamsCreateUser `yaml:",inline"`
+}
+
+// CreatePassengerJSONBody defines parameters for CreatePassenger.
+type CreatePassengerJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsSavePassenger)
+	ParamsSavePassenger `yaml:",inline"`
+}
+
+// UpdatePassengerJSONBody defines parameters for UpdatePassenger.
+type UpdatePassengerJSONBody struct {
+	// Embedded struct due to allOf(#/components/schemas/ParamsSavePassenger)
+	ParamsSavePassenger `yaml:",inline"`
+}
+
+// GetUserTicketsParams defines parameters for GetUserTickets.
+type GetUserTicketsParams struct {
+	// Наименования статусов билетов
+	Status *[]string `json:"status,omitempty"`
+
+	// Период вылета рейсов относительно текущего момента (upcoming - предстоящие, past - прошедшие)
+	Period *GetUserTicketsParamsPeriod `json:"period,omitempty"`
+
+	// Начало периода дат вылета
+	DepartureDateFrom *openapi_types.Date `json:"departureDateFrom,omitempty"`
+
+	// Окончание периода дат вылета
+	DepartureDateTo *openapi_types.Date `json:"departureDateTo,omitempty"`
+
+	// Курсор следующей страницы, полученный в предыдущем ответе
+	Cursor *string `json:"cursor,omitempty"`
+
+	// Количество билетов на странице (по умолчанию 20, не более 100)
+	Limit *int `json:"limit,omitempty"`
+
+	// Вид вывода билетов (full - полные данные, summary - краткие данные)
+	View *GetUserTicketsParamsView `json:"view,omitempty"`
+}
+
+// GetUserTicketsParamsPeriod defines parameters for GetUserTickets.
+type GetUserTicketsParamsPeriod string
+
+// GetUserTicketsParamsView defines parameters for GetUserTickets.
+type GetUserTicketsParamsView string
+
+// UpdateFlightStatusJSONRequestBody defines body for UpdateFlightStatus for application/json ContentType.
+type UpdateFlightStatusJSONRequestBody UpdateFlightStatusJSONBody
+
+// CreateCompanyInvoiceJSONRequestBody defines body for CreateCompanyInvoice for application/json ContentType.
+type CreateCompanyInvoiceJSONRequestBody CreateCompanyInvoiceJSONBody
+
+// BoardTicketJSONRequestBody defines body for BoardTicket for application/json ContentType.
+type BoardTicketJSONRequestBody BoardTicketJSONBody
+
+// CreateSeatHoldJSONRequestBody defines body for CreateSeatHold for application/json ContentType.
+type CreateSeatHoldJSONRequestBody CreateSeatHoldJSONBody
+
+// CreateTicketJSONRequestBody defines body for CreateTicket for application/json ContentType.
+type CreateTicketJSONRequestBody CreateTicketJSONBody
+
+// AddTicketAncillaryJSONRequestBody defines body for AddTicketAncillary for application/json ContentType.
+type AddTicketAncillaryJSONRequestBody AddTicketAncillaryJSONBody
+
+// ApproveTicketJSONRequestBody defines body for ApproveTicket for application/json ContentType.
+type ApproveTicketJSONRequestBody ApproveTicketJSONBody
+
+// PayForTicketJSONRequestBody defines body for PayForTicket for application/json ContentType.
+type PayForTicketJSONRequestBody PayForTicketJSONBody
+
+// CreatePriceHoldJSONRequestBody defines body for CreatePriceHold for application/json ContentType.
+type CreatePriceHoldJSONRequestBody CreatePriceHoldJSONBody
+
+// RefundTicketJSONRequestBody defines body for RefundTicket for application/json ContentType.
+type RefundTicketJSONRequestBody RefundTicketJSONBody
+
+// RegisterTicketJSONRequestBody defines body for RegisterTicket for application/json ContentType.
+type RegisterTicketJSONRequestBody RegisterTicketJSONBody
+
+// RegisterFlightTicketsJSONRequestBody defines body for RegisterFlightTickets for application/json ContentType.
+type RegisterFlightTicketsJSONRequestBody RegisterFlightTicketsJSONBody
+
+// JoinWaitlistJSONRequestBody defines body for JoinWaitlist for application/json ContentType.
+type JoinWaitlistJSONRequestBody JoinWaitlistJSONBody
+
+// CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
+type CreateUserJSONRequestBody CreateUserJSONBody
+
+// CreatePassengerJSONRequestBody defines body for CreatePassenger for application/json ContentType.
+type CreatePassengerJSONRequestBody CreatePassengerJSONBody
+
+// UpdatePassengerJSONRequestBody defines body for UpdatePassenger for application/json ContentType.
+type UpdatePassengerJSONRequestBody UpdatePassengerJSONBody
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Манифест рейса.
+	// (GET /v1/admin/flights/{id}/manifest)
+	GetFlightManifest(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID, params GetFlightManifestParams)
+	// Обновление операционного статуса рейса.
+	// (PUT /v1/admin/flights/{id}/status)
+	UpdateFlightStatus(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID, params UpdateFlightStatusParams)
+	// Информация о компании.
+	// (GET /v1/companies/{id})
+	GetCompanyById(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+	// Выставление счета компании.
+	// (POST /v1/companies/{id}/invoices)
+	CreateCompanyInvoice(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+	// Отчет по расходам компании.
+	// (GET /v1/companies/{id}/report)
+	GetCompanyReport(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID, params GetCompanyReportParams)
+	// Получить список рейсов.
+	// (GET /v1/flights)
+	GetFlights(w http.ResponseWriter, r *http.Request, params GetFlightsParams)
+	// Каталог дополнительных услуг рейса.
+	// (GET /v1/flights/ancillaries/{id})
+	GetFlightAncillaries(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+	// Рейсы с проданными сверх мест билетами.
+	// (GET /v1/flights/oversold)
+	GetOversoldFlights(w http.ResponseWriter, r *http.Request, params GetOversoldFlightsParams)
+	// Информация о свободных местах рейса.
+	// (GET /v1/flights/vacant_seats/{id})
+	GetFlightVacantSeats(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+	// Информация о рейсе.
+	// (GET /v1/flights/{id})
+	GetFlightById(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID, params GetFlightByIdParams)
+	// Посадка пассажира на рейс.
+	// (PUT /v1/flights/{id}/boarding)
+	BoardTicket(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+	// Закрытие рейса.
+	// (PUT /v1/flights/{id}/close)
+	CloseFlight(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+	// Удержание места.
+	// (POST /v1/flights/{id}/seat-holds)
+	CreateSeatHold(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+	// Операционный статус рейса.
+	// (GET /v1/flights/{id}/status)
+	GetFlightStatus(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+	// Поиск городов и аэропортов.
+	// (GET /v1/locations)
+	SearchLocations(w http.ResponseWriter, r *http.Request, params SearchLocationsParams)
+	// Создание билета.
+	// (POST /v1/tickets)
+	CreateTicket(w http.ResponseWriter, r *http.Request)
+	// Покупка дополнительной услуги.
+	// (POST /v1/tickets/ancillaries)
+	AddTicketAncillary(w http.ResponseWriter, r *http.Request)
+	// Согласование билета компании.
+	// (PUT /v1/tickets/approve)
+	ApproveTicket(w http.ResponseWriter, r *http.Request)
+	// Оплата билета.
+	// (PUT /v1/tickets/pay)
+	PayForTicket(w http.ResponseWriter, r *http.Request)
+	// Удержание цены билета.
+	// (POST /v1/tickets/price-holds)
+	CreatePriceHold(w http.ResponseWriter, r *http.Request)
+	// Возврат билета.
+	// (PUT /v1/tickets/refund)
+	RefundTicket(w http.ResponseWriter, r *http.Request)
+	// Онлайн-регистрация билета.
+	// (PUT /v1/tickets/register)
+	RegisterTicket(w http.ResponseWriter, r *http.Request)
+	// Онлайн-регистрация всех билетов пользователя на рейс.
+	// (PUT /v1/tickets/register/flight)
+	RegisterFlightTickets(w http.ResponseWriter, r *http.Request)
+	// Постановка в лист ожидания.
+	// (POST /v1/tickets/waitlist)
+	JoinWaitlist(w http.ResponseWriter, r *http.Request)
+	// Информация о билете.
+	// (GET /v1/tickets/{id})
+	GetTicketById(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+	// Кредит-нота возврата билета.
+	// (GET /v1/tickets/{id}/credit-note)
+	GetTicketCreditNote(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+	// Чек оплаты билета.
+	// (GET /v1/tickets/{id}/receipt)
+	GetTicketReceipt(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+	// Регистрация пользователя.
+	// (POST /v1/users)
+	CreateUser(w http.ResponseWriter, r *http.Request)
+	// Информация о пользователе.
+	// (GET /v1/users/{id})
+	GetUserById(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+	// Уведомления пользователя.
+	// (GET /v1/users/{id}/notifications)
+	GetUserNotifications(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+	// Список пассажиров пользователя.
+	// (GET /v1/users/{id}/passengers)
+	GetUserPassengers(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+	// Создание пассажира.
+	// (POST /v1/users/{id}/passengers)
+	CreatePassenger(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+	// Удаление пассажира.
+	// (DELETE /v1/users/{id}/passengers/{passengerId})
+	DeletePassenger(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID, passengerId string)
+	// Изменение пассажира.
+	// (PUT /v1/users/{id}/passengers/{passengerId})
+	UpdatePassenger(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID, passengerId string)
+	// Список билетов пользователя.
+	// (GET /v1/users/{id}/tickets)
+	GetUserTickets(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID, params GetUserTicketsParams)
+	// Информация о сертификате или кредите.
+	// (GET /v1/vouchers/{code})
+	GetVoucherByCode(w http.ResponseWriter, r *http.Request, code string)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
+
+// GetFlightManifest operation middleware
+func (siw *ServerInterfaceWrapper) GetFlightManifest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFlightManifestParams
+
+	// ------------- Optional query parameter "format" -------------
+	if paramValue := r.URL.Query().Get("format"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Role" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Role")]; found {
+		var XRole string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Role", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Role", runtime.ParamLocationHeader, valueList[0], &XRole)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Role", Err: err})
+			return
+		}
+
+		params.XRole = &XRole
+
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFlightManifest(w, r, id, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// UpdateFlightStatus operation middleware
+func (siw *ServerInterfaceWrapper) UpdateFlightStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateFlightStatusParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Role" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Role")]; found {
+		var XRole string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Role", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Role", runtime.ParamLocationHeader, valueList[0], &XRole)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Role", Err: err})
+			return
+		}
+
+		params.XRole = &XRole
+
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateFlightStatus(w, r, id, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetCompanyById operation middleware
+func (siw *ServerInterfaceWrapper) GetCompanyById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCompanyById(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// CreateCompanyInvoice operation middleware
+func (siw *ServerInterfaceWrapper) CreateCompanyInvoice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateCompanyInvoice(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetCompanyReport operation middleware
+func (siw *ServerInterfaceWrapper) GetCompanyReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCompanyReportParams
+
+	// ------------- Optional query parameter "departureDateFrom" -------------
+	if paramValue := r.URL.Query().Get("departureDateFrom"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "departureDateFrom", r.URL.Query(), &params.DepartureDateFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "departureDateFrom", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "departureDateTo" -------------
+	if paramValue := r.URL.Query().Get("departureDateTo"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "departureDateTo", r.URL.Query(), &params.DepartureDateTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "departureDateTo", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCompanyReport(w, r, id, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetFlights operation middleware
+func (siw *ServerInterfaceWrapper) GetFlights(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFlightsParams
+
+	// ------------- Required query parameter "departureCityId" -------------
+	if paramValue := r.URL.Query().Get("departureCityId"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "departureCityId"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "departureCityId", r.URL.Query(), &params.DepartureCityId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "departureCityId", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "arrivalCityId" -------------
+	if paramValue := r.URL.Query().Get("arrivalCityId"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "arrivalCityId"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "arrivalCityId", r.URL.Query(), &params.ArrivalCityId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "arrivalCityId", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "departureDate" -------------
+	if paramValue := r.URL.Query().Get("departureDate"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "departureDate"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "departureDate", r.URL.Query(), &params.DepartureDate)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "departureDate", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+	if paramValue := r.URL.Query().Get("currency"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", r.URL.Query(), &params.Currency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFlights(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetFlightAncillaries operation middleware
+func (siw *ServerInterfaceWrapper) GetFlightAncillaries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFlightAncillaries(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetOversoldFlights operation middleware
+func (siw *ServerInterfaceWrapper) GetOversoldFlights(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOversoldFlightsParams
+
+	// ------------- Optional query parameter "departureDateFrom" -------------
+	if paramValue := r.URL.Query().Get("departureDateFrom"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "departureDateFrom", r.URL.Query(), &params.DepartureDateFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "departureDateFrom", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "departureDateTo" -------------
+	if paramValue := r.URL.Query().Get("departureDateTo"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "departureDateTo", r.URL.Query(), &params.DepartureDateTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "departureDateTo", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOversoldFlights(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetFlightVacantSeats operation middleware
+func (siw *ServerInterfaceWrapper) GetFlightVacantSeats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFlightVacantSeats(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetFlightById operation middleware
+func (siw *ServerInterfaceWrapper) GetFlightById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFlightByIdParams
+
+	// ------------- Optional query parameter "currency" -------------
+	if paramValue := r.URL.Query().Get("currency"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", r.URL.Query(), &params.Currency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFlightById(w, r, id, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// BoardTicket operation middleware
+func (siw *ServerInterfaceWrapper) BoardTicket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BoardTicket(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// CloseFlight operation middleware
+func (siw *ServerInterfaceWrapper) CloseFlight(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CloseFlight(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// CreateSeatHold operation middleware
+func (siw *ServerInterfaceWrapper) CreateSeatHold(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSeatHold(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetFlightStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetFlightStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// GetFlightsParams defines parameters for GetFlights.
-type GetFlightsParams struct {
-	// Идентификатор города вылета
-	DepartureCityId string `json:"departureCityId"`
+	var err error
 
-	// Идентификатор города прилета
-	ArrivalCityId string `json:"arrivalCityId"`
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
 
-	// Дата вылета
-	DepartureDate openapi_types.Date `json:"departureDate"`
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFlightStatus(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
 }
 
-// CreateTicketJSONBody defines parameters for CreateTicket.
-type CreateTicketJSONBody struct {
-	// Embedded struct due to allOf(#/components/schemas/ParamsCreateTicket)
-	ParamsCreateTicket `yaml:",inline"`
+// SearchLocations operation middleware
+func (siw *ServerInterfaceWrapper) SearchLocations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchLocationsParams
+
+	// ------------- Required query parameter "q" -------------
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := r.URL.Query().Get("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchLocations(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
 }
 
-// PayForTicketJSONBody defines parameters for PayForTicket.
-type PayForTicketJSONBody struct {
-	// Embedded struct due to allOf(#/components/schemas/ParamsPayForTicket)
-	ParamsPayForTicket `yaml:",inline"`
+// CreateTicket operation middleware
+func (siw *ServerInterfaceWrapper) CreateTicket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateTicket(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
 }
 
-// RefundTicketJSONBody defines parameters for RefundTicket.
-type RefundTicketJSONBody struct {
-	// Embedded struct due to allOf(#/components/schemas/ParamsRefundTicket)
-	ParamsRefundTicket `yaml:",inline"`
+// AddTicketAncillary operation middleware
+func (siw *ServerInterfaceWrapper) AddTicketAncillary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddTicketAncillary(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
 }
 
-// RegisterTicketJSONBody defines parameters for RegisterTicket.
-type RegisterTicketJSONBody struct {
-	// Embedded struct due to allOf(#/components/schemas/ParamsRegisterTicket)
-	ParamsRegisterTicket `yaml:",inline"`
+// ApproveTicket operation middleware
+func (siw *ServerInterfaceWrapper) ApproveTicket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApproveTicket(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
 }
 
-// CreateTicketJSONRequestBody defines body for CreateTicket for application/json ContentType.
-type CreateTicketJSONRequestBody CreateTicketJSONBody
+// PayForTicket operation middleware
+func (siw *ServerInterfaceWrapper) PayForTicket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// PayForTicketJSONRequestBody defines body for PayForTicket for application/json ContentType.
-type PayForTicketJSONRequestBody PayForTicketJSONBody
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PayForTicket(w, r)
+	}
 
-// RefundTicketJSONRequestBody defines body for RefundTicket for application/json ContentType.
-type RefundTicketJSONRequestBody RefundTicketJSONBody
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// RegisterTicketJSONRequestBody defines body for RegisterTicket for application/json ContentType.
-type RegisterTicketJSONRequestBody RegisterTicketJSONBody
+	handler(w, r.WithContext(ctx))
+}
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Получить список рейсов.
-	// (GET /v1/flights)
-	GetFlights(w http.ResponseWriter, r *http.Request, params GetFlightsParams)
-	// Информация о свободных местах рейса.
-	// (GET /v1/flights/vacant_seats/{id})
-	GetFlightVacantSeats(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
-	// Информация о рейсе.
-	// (GET /v1/flights/{id})
-	GetFlightById(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
-	// Создание билета.
-	// (POST /v1/tickets)
-	CreateTicket(w http.ResponseWriter, r *http.Request)
-	// Оплата билета.
-	// (PUT /v1/tickets/pay)
-	PayForTicket(w http.ResponseWriter, r *http.Request)
-	// Возврат билета.
-	// (PUT /v1/tickets/refund)
-	RefundTicket(w http.ResponseWriter, r *http.Request)
-	// Онлайн-регистрация билета.
-	// (PUT /v1/tickets/register)
-	RegisterTicket(w http.ResponseWriter, r *http.Request)
-	// Информация о билете.
-	// (GET /v1/tickets/{id})
-	GetTicketById(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
-	// Информация о пользователе.
-	// (GET /v1/users/{id})
-	GetUserById(w http.ResponseWriter, r *http.Request, id UUIDPathObjectID)
+// CreatePriceHold operation middleware
+func (siw *ServerInterfaceWrapper) CreatePriceHold(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePriceHold(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// RefundTicket operation middleware
+func (siw *ServerInterfaceWrapper) RefundTicket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RefundTicket(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// RegisterTicket operation middleware
+func (siw *ServerInterfaceWrapper) RegisterTicket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegisterTicket(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// RegisterFlightTickets operation middleware
+func (siw *ServerInterfaceWrapper) RegisterFlightTickets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegisterFlightTickets(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// JoinWaitlist operation middleware
+func (siw *ServerInterfaceWrapper) JoinWaitlist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.JoinWaitlist(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
 }
 
-type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
+// GetTicketById operation middleware
+func (siw *ServerInterfaceWrapper) GetTicketById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTicketById(w, r, id)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// GetFlights operation middleware
-func (siw *ServerInterfaceWrapper) GetFlights(w http.ResponseWriter, r *http.Request) {
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTicketCreditNote operation middleware
+func (siw *ServerInterfaceWrapper) GetTicketCreditNote(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetFlightsParams
-
-	// ------------- Required query parameter "departureCityId" -------------
-	if paramValue := r.URL.Query().Get("departureCityId"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "departureCityId"})
-		return
-	}
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
 
-	err = runtime.BindQueryParameter("form", true, true, "departureCityId", r.URL.Query(), &params.DepartureCityId)
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "departureCityId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
-	// ------------- Required query parameter "arrivalCityId" -------------
-	if paramValue := r.URL.Query().Get("arrivalCityId"); paramValue != "" {
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTicketCreditNote(w, r, id)
+	}
 
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "arrivalCityId"})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "arrivalCityId", r.URL.Query(), &params.ArrivalCityId)
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTicketReceipt operation middleware
+func (siw *ServerInterfaceWrapper) GetTicketReceipt(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "arrivalCityId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
-	// ------------- Required query parameter "departureDate" -------------
-	if paramValue := r.URL.Query().Get("departureDate"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "departureDate"})
-		return
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTicketReceipt(w, r, id)
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "departureDate", r.URL.Query(), &params.DepartureDate)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "departureDate", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler(w, r.WithContext(ctx))
+}
+
+// CreateUser operation middleware
+func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetFlights(w, r, params)
+		siw.Handler.CreateUser(w, r)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -475,8 +2483,8 @@ func (siw *ServerInterfaceWrapper) GetFlights(w http.ResponseWriter, r *http.Req
 	handler(w, r.WithContext(ctx))
 }
 
-// GetFlightVacantSeats operation middleware
-func (siw *ServerInterfaceWrapper) GetFlightVacantSeats(w http.ResponseWriter, r *http.Request) {
+// GetUserById operation middleware
+func (siw *ServerInterfaceWrapper) GetUserById(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -491,7 +2499,7 @@ func (siw *ServerInterfaceWrapper) GetFlightVacantSeats(w http.ResponseWriter, r
 	}
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetFlightVacantSeats(w, r, id)
+		siw.Handler.GetUserById(w, r, id)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -501,8 +2509,8 @@ func (siw *ServerInterfaceWrapper) GetFlightVacantSeats(w http.ResponseWriter, r
 	handler(w, r.WithContext(ctx))
 }
 
-// GetFlightById operation middleware
-func (siw *ServerInterfaceWrapper) GetFlightById(w http.ResponseWriter, r *http.Request) {
+// GetUserNotifications operation middleware
+func (siw *ServerInterfaceWrapper) GetUserNotifications(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -517,7 +2525,7 @@ func (siw *ServerInterfaceWrapper) GetFlightById(w http.ResponseWriter, r *http.
 	}
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetFlightById(w, r, id)
+		siw.Handler.GetUserNotifications(w, r, id)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -527,12 +2535,23 @@ func (siw *ServerInterfaceWrapper) GetFlightById(w http.ResponseWriter, r *http.
 	handler(w, r.WithContext(ctx))
 }
 
-// CreateTicket operation middleware
-func (siw *ServerInterfaceWrapper) CreateTicket(w http.ResponseWriter, r *http.Request) {
+// GetUserPassengers operation middleware
+func (siw *ServerInterfaceWrapper) GetUserPassengers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateTicket(w, r)
+		siw.Handler.GetUserPassengers(w, r, id)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -542,12 +2561,23 @@ func (siw *ServerInterfaceWrapper) CreateTicket(w http.ResponseWriter, r *http.R
 	handler(w, r.WithContext(ctx))
 }
 
-// PayForTicket operation middleware
-func (siw *ServerInterfaceWrapper) PayForTicket(w http.ResponseWriter, r *http.Request) {
+// CreatePassenger operation middleware
+func (siw *ServerInterfaceWrapper) CreatePassenger(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PayForTicket(w, r)
+		siw.Handler.CreatePassenger(w, r, id)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -557,12 +2587,32 @@ func (siw *ServerInterfaceWrapper) PayForTicket(w http.ResponseWriter, r *http.R
 	handler(w, r.WithContext(ctx))
 }
 
-// RefundTicket operation middleware
-func (siw *ServerInterfaceWrapper) RefundTicket(w http.ResponseWriter, r *http.Request) {
+// DeletePassenger operation middleware
+func (siw *ServerInterfaceWrapper) DeletePassenger(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "passengerId" -------------
+	var passengerId string
+
+	err = runtime.BindStyledParameter("simple", false, "passengerId", chi.URLParam(r, "passengerId"), &passengerId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "passengerId", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RefundTicket(w, r)
+		siw.Handler.DeletePassenger(w, r, id, passengerId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -572,12 +2622,32 @@ func (siw *ServerInterfaceWrapper) RefundTicket(w http.ResponseWriter, r *http.R
 	handler(w, r.WithContext(ctx))
 }
 
-// RegisterTicket operation middleware
-func (siw *ServerInterfaceWrapper) RegisterTicket(w http.ResponseWriter, r *http.Request) {
+// UpdatePassenger operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePassenger(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UUIDPathObjectID
+
+	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "passengerId" -------------
+	var passengerId string
+
+	err = runtime.BindStyledParameter("simple", false, "passengerId", chi.URLParam(r, "passengerId"), &passengerId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "passengerId", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RegisterTicket(w, r)
+		siw.Handler.UpdatePassenger(w, r, id, passengerId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -587,8 +2657,8 @@ func (siw *ServerInterfaceWrapper) RegisterTicket(w http.ResponseWriter, r *http
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTicketById operation middleware
-func (siw *ServerInterfaceWrapper) GetTicketById(w http.ResponseWriter, r *http.Request) {
+// GetUserTickets operation middleware
+func (siw *ServerInterfaceWrapper) GetUserTickets(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -602,8 +2672,88 @@ func (siw *ServerInterfaceWrapper) GetTicketById(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserTicketsParams
+
+	// ------------- Optional query parameter "status" -------------
+	if paramValue := r.URL.Query().Get("status"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "period" -------------
+	if paramValue := r.URL.Query().Get("period"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "period", r.URL.Query(), &params.Period)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "period", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "departureDateFrom" -------------
+	if paramValue := r.URL.Query().Get("departureDateFrom"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "departureDateFrom", r.URL.Query(), &params.DepartureDateFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "departureDateFrom", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "departureDateTo" -------------
+	if paramValue := r.URL.Query().Get("departureDateTo"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "departureDateTo", r.URL.Query(), &params.DepartureDateTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "departureDateTo", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := r.URL.Query().Get("cursor"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := r.URL.Query().Get("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "view" -------------
+	if paramValue := r.URL.Query().Get("view"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "view", r.URL.Query(), &params.View)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "view", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetTicketById(w, r, id)
+		siw.Handler.GetUserTickets(w, r, id, params)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -613,23 +2763,23 @@ func (siw *ServerInterfaceWrapper) GetTicketById(w http.ResponseWriter, r *http.
 	handler(w, r.WithContext(ctx))
 }
 
-// GetUserById operation middleware
-func (siw *ServerInterfaceWrapper) GetUserById(w http.ResponseWriter, r *http.Request) {
+// GetVoucherByCode operation middleware
+func (siw *ServerInterfaceWrapper) GetVoucherByCode(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id UUIDPathObjectID
+	// ------------- Path parameter "code" -------------
+	var code string
 
-	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
+	err = runtime.BindStyledParameter("simple", false, "code", chi.URLParam(r, "code"), &code)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
 		return
 	}
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUserById(w, r, id)
+		siw.Handler.GetVoucherByCode(w, r, code)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -752,33 +2902,114 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/admin/flights/{id}/manifest", wrapper.GetFlightManifest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/admin/flights/{id}/status", wrapper.UpdateFlightStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/companies/{id}", wrapper.GetCompanyById)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/companies/{id}/invoices", wrapper.CreateCompanyInvoice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/companies/{id}/report", wrapper.GetCompanyReport)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v1/flights", wrapper.GetFlights)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flights/ancillaries/{id}", wrapper.GetFlightAncillaries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flights/oversold", wrapper.GetOversoldFlights)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v1/flights/vacant_seats/{id}", wrapper.GetFlightVacantSeats)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v1/flights/{id}", wrapper.GetFlightById)
 	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/flights/{id}/boarding", wrapper.BoardTicket)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/flights/{id}/close", wrapper.CloseFlight)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/flights/{id}/seat-holds", wrapper.CreateSeatHold)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/flights/{id}/status", wrapper.GetFlightStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/locations", wrapper.SearchLocations)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/v1/tickets", wrapper.CreateTicket)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tickets/ancillaries", wrapper.AddTicketAncillary)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/tickets/approve", wrapper.ApproveTicket)
+	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/v1/tickets/pay", wrapper.PayForTicket)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tickets/price-holds", wrapper.CreatePriceHold)
+	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/v1/tickets/refund", wrapper.RefundTicket)
 	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/v1/tickets/register", wrapper.RegisterTicket)
 	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/tickets/register/flight", wrapper.RegisterFlightTickets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tickets/waitlist", wrapper.JoinWaitlist)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v1/tickets/{id}", wrapper.GetTicketById)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tickets/{id}/credit-note", wrapper.GetTicketCreditNote)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tickets/{id}/receipt", wrapper.GetTicketReceipt)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/users", wrapper.CreateUser)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v1/users/{id}", wrapper.GetUserById)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/users/{id}/notifications", wrapper.GetUserNotifications)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/users/{id}/passengers", wrapper.GetUserPassengers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/users/{id}/passengers", wrapper.CreatePassenger)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/users/{id}/passengers/{passengerId}", wrapper.DeletePassenger)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/users/{id}/passengers/{passengerId}", wrapper.UpdatePassenger)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/users/{id}/tickets", wrapper.GetUserTickets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/vouchers/{code}", wrapper.GetVoucherByCode)
+	})
 
 	return r
 }